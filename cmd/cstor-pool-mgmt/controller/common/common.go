@@ -71,6 +71,20 @@ const (
 	MessageResourceFailStatusSync EventReason = "Resource status sync failed"
 	// MessageResourceFailCapacitySync holds message for corresponding failed capacity sync of resource.
 	MessageResourceFailCapacitySync EventReason = "Resource capacity sync failed"
+	// SuccessResized holds status for corresponding resized resource.
+	SuccessResized EventReason = "Resized"
+	// MessageResourceResized holds message for corresponding resized resource.
+	MessageResourceResized EventReason = "Resource resized successfully"
+	// FailureResize holds status for corresponding failed resize resource.
+	FailureResize EventReason = "FailResize"
+	// MessageResourceFailResize holds message for corresponding failed resize resource.
+	MessageResourceFailResize EventReason = "Resource resize failed"
+	// SuccessPropertiesUpdated holds status for corresponding updated zfs properties of resource.
+	SuccessPropertiesUpdated EventReason = "PropertiesUpdated"
+	// FailurePropertiesUpdate holds status for corresponding failed update of zfs properties of resource.
+	FailurePropertiesUpdate EventReason = "FailPropertiesUpdate"
+	// FailureBlockSizeChange holds status for corresponding resource whose block size was changed after creation.
+	FailureBlockSizeChange EventReason = "FailBlockSizeChange"
 	// MessageResourceSyncSuccess holds message for corresponding successful sync of resource.
 	MessageResourceSyncSuccess EventReason = "Resource successfully synced"
 	// MessageResourceSyncFailure holds message for corresponding failed sync of resource.
@@ -97,6 +111,27 @@ const (
 	MessageResourceAlreadyPresent EventReason = "Resource already present"
 	// MessageImproperPoolStatus holds message for corresponding failed validate resource.
 	MessageImproperPoolStatus EventReason = "Improper pool status"
+
+	// ScrubStarted holds status for corresponding scrub started on schedule.
+	ScrubStarted EventReason = "ScrubStarted"
+	// ScrubPaused holds status for corresponding scrub paused due to resilver or rebuild.
+	ScrubPaused EventReason = "ScrubPaused"
+	// ScrubResumed holds status for corresponding scrub resumed after resilver or rebuild.
+	ScrubResumed EventReason = "ScrubResumed"
+	// ScrubFinished holds status for corresponding finished scrub.
+	ScrubFinished EventReason = "ScrubFinished"
+	// FailureScrub holds status for corresponding failed start, pause or resume of scrub.
+	FailureScrub EventReason = "FailScrub"
+	// VdevFailing holds status for corresponding block device of pool turning unhealthy or reporting errors.
+	VdevFailing EventReason = "VdevFailing"
+	// OverCommitted holds status for corresponding pool whose provisioned capacity crossed its over commit limit.
+	OverCommitted EventReason = "OverCommitted"
+	// PoolUsageHigh holds status for corresponding replica whose pool usage crossed a usage threshold.
+	PoolUsageHigh EventReason = "PoolUsageHigh"
+	// FailureProvisionedSync holds status for corresponding failed provisioned capacity sync of resource.
+	FailureProvisionedSync EventReason = "FailProvisionedSync"
+	// MessageResourceFailProvisionedSync holds message for corresponding failed provisioned capacity sync of resource.
+	MessageResourceFailProvisionedSync EventReason = "Resource provisioned capacity sync failed"
 )
 
 // Periodic interval duration.
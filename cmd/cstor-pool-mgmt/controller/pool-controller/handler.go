@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -30,10 +31,13 @@ import (
 	"github.com/openebs/maya/cmd/cstor-pool-mgmt/volumereplica"
 	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
 	zpool "github.com/openebs/maya/pkg/apis/openebs.io/zpool/v1alpha1"
+	cstorpool "github.com/openebs/maya/pkg/cstor/pool/v1alpha1"
 	lease "github.com/openebs/maya/pkg/lease/v1alpha1"
 	"github.com/openebs/maya/pkg/util"
+	zstatus "github.com/openebs/maya/pkg/zpool/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/cache"
@@ -88,7 +92,6 @@ func (c *CStorPoolController) syncHandler(key string, operation common.QueueOper
 	}
 	// Synchronize cstor pool used and free capacity fields on CSP object.
 	// Any kind of sync activity should be done from here.
-	// ToDo: Move status sync (of csp) here from cStorPoolEventHandler function.
 	// ToDo: Instead of having statusSync, capacitySync we can make it generic resource sync which syncs all the
 	// ToDo: requried fields on CSP ( Some code re-organization will be required)
 	c.syncCsp(cspObject)
@@ -153,9 +156,10 @@ func (c *CStorPoolController) cStorPoolEventHandler(operation common.QueueOperat
 			status, err := c.cStorPoolAddEventHandler(cStorPoolGot)
 			return status, err
 		}
+		// status of the pool is synced along with the other fields of
+		// csp by syncCsp so that `zpool status` is run once per sync
 		glog.V(4).Infof("Synchronizing cStor pool status for pool %s", cStorPoolGot.ObjectMeta.Name)
-		status, err := c.getPoolStatus(cStorPoolGot)
-		return status, err
+		return string(cStorPoolGot.Status.Phase), nil
 	}
 	glog.Errorf("ignored event '%s' for cstor pool '%s'", string(operation), string(cStorPoolGot.ObjectMeta.Name))
 	return string(apis.CStorPoolStatusInvalid), nil
@@ -322,17 +326,6 @@ func (c *CStorPoolController) cStorPoolDestroyEventHandler(cStorPoolGot *apis.CS
 
 }
 
-//  getPoolStatus is a wrapper that fetches the status of cstor pool.
-func (c *CStorPoolController) getPoolStatus(cStorPoolGot *apis.CStorPool) (string, error) {
-	poolStatus, err := pool.Status(string(pool.PoolPrefix) + string(cStorPoolGot.ObjectMeta.UID))
-	if err != nil {
-		// ToDO : Put error in event recorder
-		c.recorder.Event(cStorPoolGot, corev1.EventTypeWarning, string(common.FailureStatusSync), string(common.MessageResourceFailStatusSync))
-		return "", err
-	}
-	return poolStatus, nil
-}
-
 // getPoolResource returns object corresponding to the resource key
 func (c *CStorPoolController) getPoolResource(key string) (*apis.CStorPool, error) {
 	// Convert the key(namespace/name) string into a distinct name
@@ -458,14 +451,212 @@ func IsDeletionFailedBefore(cStorPool *apis.CStorPool) bool {
 
 // syncCsp updates field on CSP object after fetching the values from zpool utility.
 func (c *CStorPoolController) syncCsp(cStorPool *apis.CStorPool) {
+	poolName := string(pool.PoolPrefix) + string(cStorPool.ObjectMeta.UID)
+	prevCapacity := cStorPool.Status.Capacity
 	// Get capacity of the pool.
-	capacity, err := pool.Capacity(string(pool.PoolPrefix) + string(cStorPool.ObjectMeta.UID))
+	capacity, err := pool.Capacity(poolName)
 	if err != nil {
 		glog.Errorf("Unable to sync CSP capacity: %v", err)
 		c.recorder.Event(cStorPool, corev1.EventTypeWarning, string(common.FailureCapacitySync), string(common.MessageResourceFailCapacitySync))
 	} else {
 		cStorPool.Status.Capacity = *capacity
+		c.syncProvisioned(cStorPool, prevCapacity)
+		c.checkPoolUsage(cStorPool, prevCapacity)
+	}
+	// phase, vdevs & scrub of CSP are synced from a single run of
+	// `zpool status`
+	zpoolStatus, err := pool.ZpoolStatus(poolName)
+	if err != nil {
+		glog.Errorf("Unable to sync CSP status: %v", err)
+		c.recorder.Event(cStorPool, corev1.EventTypeWarning, string(common.FailureStatusSync), string(common.MessageResourceFailStatusSync))
+		return
+	}
+	c.syncPhase(cStorPool, zpoolStatus)
+	c.syncVdevs(cStorPool, zpoolStatus)
+	c.syncScrub(cStorPool, zpoolStatus)
+}
+
+// syncPhase updates the phase of CSP as per the state of the pool
+func (c *CStorPoolController) syncPhase(cStorPool *apis.CStorPool, zpoolStatus zstatus.PoolStatus) {
+	phase := apis.CStorPoolPhase(pool.Phase(zpoolStatus))
+	if cStorPool.Status.Phase != phase {
+		cStorPool.Status.LastTransitionTime = metav1.Now()
+		cStorPool.Status.Phase = phase
+	}
+}
+
+// syncProvisioned updates the capacity provisioned on CSP after
+// fetching it from zfs utility. An event is raised when the
+// provisioned capacity crosses the over commit limit of the pool.
+func (c *CStorPoolController) syncProvisioned(cStorPool *apis.CStorPool, prev apis.CStorPoolCapacityAttr) {
+	provisioned, err := volumereplica.ProvisionedCapacity(string(pool.PoolPrefix) + string(cStorPool.ObjectMeta.UID))
+	if err != nil {
+		glog.Errorf("Unable to sync CSP provisioned capacity: %v", err)
+		c.recorder.Event(cStorPool, corev1.EventTypeWarning, string(common.FailureProvisionedSync), string(common.MessageResourceFailProvisionedSync))
+		cStorPool.Status.Capacity.Provisioned = prev.Provisioned
+		return
+	}
+	cStorPool.Status.Capacity.Provisioned = resource.NewQuantity(provisioned, resource.BinarySI).String()
+
+	size, err := volumereplica.CapacityInBytes(cStorPool.Status.Capacity.Total)
+	if err != nil {
+		glog.Errorf("Unable to check over commit of CSP %s: %v", cStorPool.Name, err)
+		return
+	}
+	limit, err := cstorpool.OverCommitLimit(cStorPool.Spec.PoolSpec, size)
+	if err != nil {
+		glog.Errorf("Unable to check over commit of CSP %s: %v", cStorPool.Name, err)
+		c.recorder.Event(cStorPool, corev1.EventTypeWarning, string(common.FailureValidate), err.Error())
+		return
+	}
+	if limit == 0 || provisioned <= limit {
+		return
+	}
+	// event is raised only when the limit is crossed
+	if prevProvisioned, err := volumereplica.CapacityInBytes(prev.Provisioned); err == nil && prevProvisioned > limit {
+		return
+	}
+	c.recorder.Eventf(cStorPool, corev1.EventTypeWarning, string(common.OverCommitted),
+		"Provisioned capacity %s exceeds over commit limit %s of pool of size %s",
+		cStorPool.Status.Capacity.Provisioned,
+		resource.NewQuantity(limit, resource.BinarySI).String(),
+		cStorPool.Status.Capacity.Total)
+}
+
+// checkPoolUsage raises an event on the volume replicas of the pool
+// when the used capacity of pool crosses one of the usage thresholds.
+func (c *CStorPoolController) checkPoolUsage(cStorPool *apis.CStorPool, prev apis.CStorPoolCapacityAttr) {
+	size, err := volumereplica.CapacityInBytes(cStorPool.Status.Capacity.Total)
+	if err != nil {
+		return
+	}
+	used, err := volumereplica.CapacityInBytes(cStorPool.Status.Capacity.Used)
+	if err != nil {
+		return
+	}
+	// usage that was never synced is considered as zero
+	prevUsed, _ := volumereplica.CapacityInBytes(prev.Used)
+	threshold := pool.CrossedUsageThreshold(prevUsed, used, size)
+	if threshold == 0 {
+		return
+	}
+	cvrList, err := c.clientset.OpenebsV1alpha1().CStorVolumeReplicas(metav1.NamespaceAll).
+		List(metav1.ListOptions{LabelSelector: volumereplica.CStorPoolUIDKey + "=" + string(cStorPool.UID)})
+	if err != nil {
+		glog.Errorf("Unable to list replicas of CSP %s: %v", cStorPool.Name, err)
+		return
+	}
+	glog.Warningf("CSP %s crossed %d%% usage: used %s of %s", cStorPool.Name, threshold,
+		cStorPool.Status.Capacity.Used, cStorPool.Status.Capacity.Total)
+	for i := range cvrList.Items {
+		cvr := &cvrList.Items[i]
+		c.recorder.Eventf(cvr, corev1.EventTypeWarning, string(common.PoolUsageHigh),
+			"Pool %s of volume %s crossed %d%% usage: used %s of %s", cStorPool.Name,
+			cvr.Labels[volumereplica.PvNameKey], threshold,
+			cStorPool.Status.Capacity.Used, cStorPool.Status.Capacity.Total)
+	}
+}
+
+// syncVdevs updates the vdev tree of CSP as per the parsed output of zpool
+// utility. An event is raised for every block device that turned
+// unhealthy or reported more errors since the last sync.
+func (c *CStorPoolController) syncVdevs(cStorPool *apis.CStorPool, zpoolStatus zstatus.PoolStatus) {
+	vdevs := pool.VdevStatus(zpoolStatus)
+	for _, failure := range pool.VdevFailures(vdevs, cStorPool.Status.Vdevs) {
+		c.recorder.Event(cStorPool, corev1.EventTypeWarning, string(common.VdevFailing), failure)
+	}
+	cStorPool.Status.Vdevs = vdevs
+}
+
+// syncScrub updates the scrub status of CSP as per the parsed output
+// of zpool utility. It starts a scrub when one is due as per the scrub
+// interval of CSP & pauses the running scrub while the pool is
+// resilvering or its replicas are rebuilding.
+func (c *CStorPoolController) syncScrub(cStorPool *apis.CStorPool, zpoolStatus zstatus.PoolStatus) {
+	poolName := string(pool.PoolPrefix) + string(cStorPool.ObjectMeta.UID)
+	prev := cStorPool.Status.Scrub
+	cur := pool.ScrubStatus(zpoolStatus)
+	cur.LastScheduledTime = prev.LastScheduledTime
+	cur.AutoPaused = prev.AutoPaused && cur.State == apis.CStorPoolScanStatePaused
+	if cur.Function == prev.Function && cur.State == prev.State {
+		cur.Message = prev.Message
+	}
+	if cur.Function == apis.CStorPoolScanScrub && cur.State == apis.CStorPoolScanStateFinished &&
+		prev.State != apis.CStorPoolScanStateFinished && prev.State != "" {
+		eventType := corev1.EventTypeNormal
+		if cur.Errors != 0 {
+			eventType = corev1.EventTypeWarning
+		}
+		c.recorder.Eventf(cStorPool, eventType, string(common.ScrubFinished),
+			"Scrub finished: repaired %s with %d errors", cur.Repaired, cur.Errors)
+	}
+	defer func() { cStorPool.Status.Scrub = *cur }()
+
+	interval, err := pool.ScrubInterval(cStorPool)
+	if err != nil {
+		glog.Errorf("Unable to schedule scrub: %v", err)
+		c.recorder.Event(cStorPool, corev1.EventTypeWarning, string(common.FailureValidate), err.Error())
+	}
+	busy, reason := false, ""
+	if interval != 0 || cur.State == apis.CStorPoolScanStateInProgress || cur.State == apis.CStorPoolScanStatePaused {
+		busy, reason = c.isPoolBusy(poolName, cur)
+	}
+	now := time.Now()
+	action := pool.NextScrubAction(cur, &prev, interval, busy, cStorPool.CreationTimestamp.Time, now)
+	switch action {
+	case pool.ScrubActionStart:
+		err = pool.StartScrub(poolName)
+		if err == nil {
+			cur.Function = apis.CStorPoolScanScrub
+			cur.State = apis.CStorPoolScanStateInProgress
+			cur.Progress, cur.Repaired, cur.Errors = "", "", 0
+			cur.StartTime = metav1.NewTime(now)
+			cur.EndTime = metav1.Time{}
+			cur.LastScheduledTime = cur.StartTime
+			cur.Message = fmt.Sprintf("Scrub started as per scrub interval %s", interval)
+			c.recorder.Event(cStorPool, corev1.EventTypeNormal, string(common.ScrubStarted), cur.Message)
+		}
+	case pool.ScrubActionPause:
+		err = pool.PauseScrub(poolName)
+		if err == nil {
+			cur.State = apis.CStorPoolScanStatePaused
+			cur.AutoPaused = true
+			cur.Message = "Scrub paused as " + reason
+			c.recorder.Event(cStorPool, corev1.EventTypeNormal, string(common.ScrubPaused), cur.Message)
+		}
+	case pool.ScrubActionResume:
+		err = pool.StartScrub(poolName)
+		if err == nil {
+			cur.State = apis.CStorPoolScanStateInProgress
+			cur.AutoPaused = false
+			cur.Message = "Scrub resumed"
+			c.recorder.Event(cStorPool, corev1.EventTypeNormal, string(common.ScrubResumed), cur.Message)
+		}
+	}
+	if err != nil && action != pool.ScrubActionNone {
+		glog.Errorf("Unable to %s scrub of CSP %s: %v", strings.ToLower(string(action)), cStorPool.Name, err)
+		c.recorder.Eventf(cStorPool, corev1.EventTypeWarning, string(common.FailureScrub),
+			"Unable to %s scrub: %v", strings.ToLower(string(action)), err)
+	}
+}
+
+// isPoolBusy returns true along with the reason if the pool is
+// resilvering or any of its replicas is rebuilding.
+func (c *CStorPoolController) isPoolBusy(poolName string, scan *apis.CStorPoolScrubStatus) (bool, string) {
+	if scan.Function == apis.CStorPoolScanResilver && scan.State == apis.CStorPoolScanStateInProgress {
+		return true, "pool is resilvering"
+	}
+	volumes, err := volumereplica.RebuildingVolumes(poolName)
+	if err != nil {
+		// scrub is not started or resumed unless the replicas are
+		// known to be healthy
+		glog.Errorf("Unable to get rebuilding volumes of pool %s: %v", poolName, err)
+		return true, "rebuild status of replicas is unknown"
+	}
+	if len(volumes) != 0 {
+		return true, fmt.Sprintf("replicas %s are rebuilding", strings.Join(volumes, ", "))
 	}
+	return false, ""
 }
 
 func (c *CStorPoolController) getDeviceIDs(csp *apis.CStorPool) ([]string, error) {
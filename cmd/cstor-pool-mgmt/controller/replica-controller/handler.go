@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"strings"
 
 	"github.com/golang/glog"
 	"github.com/openebs/maya/cmd/cstor-pool-mgmt/controller/common"
@@ -30,6 +31,7 @@ import (
 	merrors "github.com/openebs/maya/pkg/errors/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/runtime"
@@ -322,7 +324,21 @@ func (c *CStorVolumeReplicaController) cVRAddEventHandler(
 
 	// IsEmptyStatus is to check if initial status of cVR object is empty.
 	if IsEmptyStatus(cVR) || IsInitStatus(cVR) || IsRecreateStatus(cVR) {
-		err := volumereplica.CreateVolumeReplica(cVR, fullVolName, quorum)
+		// zfs properties are validated only while creating the volume
+		// so that an invalid change to the properties of an existing
+		// volume fails their update instead of the volume
+		err := volumereplica.ValidateVolumeProperties(cVR)
+		if err != nil {
+			glog.Errorf("cVR validation failure: %v", err.Error())
+			c.recorder.Eventf(
+				cVR,
+				corev1.EventTypeWarning,
+				string(common.FailureValidate),
+				"%s: %v", common.MessageResourceFailValidate, err,
+			)
+			return string(apis.CVRStatusOffline), err
+		}
+		err = volumereplica.CreateVolumeReplica(cVR, fullVolName, quorum)
 		if err != nil {
 			glog.Errorf("cVR creation failure: %v", err.Error())
 			return string(apis.CVRStatusOffline), err
@@ -491,6 +507,34 @@ func (c *CStorVolumeReplicaController) getCVRStatus(
 	return poolStatus, nil
 }
 
+// resizeCvr resizes the zfs volume if the capacity on the cvr has been
+// increased i.e. the cstor volume is being expanded. It returns the
+// size of the zfs volume after the resize.
+func (c *CStorVolumeReplicaController) resizeCvr(
+	cvr *apis.CStorVolumeReplica, volumeName string, volSize int64,
+) int64 {
+	resizedSize, err := volumereplica.ResizeVolumeReplica(cvr, volumeName, volSize)
+	if err != nil {
+		glog.Errorf("Unable to resize CVR %s: %v", cvr.Name, err)
+		c.recorder.Event(
+			cvr,
+			corev1.EventTypeWarning,
+			string(common.FailureResize),
+			string(common.MessageResourceFailResize),
+		)
+		return volSize
+	}
+	if resizedSize != volSize {
+		c.recorder.Event(
+			cvr,
+			corev1.EventTypeNormal,
+			string(common.SuccessResized),
+			string(common.MessageResourceResized),
+		)
+	}
+	return resizedSize
+}
+
 // syncCvr updates field on CVR object after fetching the values from zfs utility.
 func (c *CStorVolumeReplicaController) syncCvr(cvr *apis.CStorVolumeReplica) {
 	// Get the zfs volume name corresponding to this cvr.
@@ -504,6 +548,42 @@ func (c *CStorVolumeReplicaController) syncCvr(cvr *apis.CStorVolumeReplica) {
 			string(common.MessageResourceFailCapacitySync),
 		)
 	}
+	// Get the present size of the volume which is used to resize
+	// the volume and to verify that the replica has been resized.
+	volSize, volSizeErr := volumereplica.VolSize(volumeName)
+	if volSizeErr != nil {
+		glog.Errorf("Unable to sync CVR volume size: %v", volSizeErr)
+	} else {
+		volSize = c.resizeCvr(cvr, volumeName, volSize)
+	}
+	// Set the zfs properties of the volume that have been changed on
+	// the cvr e.g. compression or sync.
+	changed, err := volumereplica.SetVolumeProperties(cvr, volumeName)
+	if volumereplica.IsBlockSizeChange(err) {
+		glog.Warningf("Ignoring block size change of CVR %s: %v", cvr.Name, err)
+		c.recorder.Eventf(
+			cvr,
+			corev1.EventTypeWarning,
+			string(common.FailureBlockSizeChange),
+			"Ignoring block size change: %v", err,
+		)
+	} else if err != nil {
+		glog.Errorf("Unable to update properties of CVR %s: %v", cvr.Name, err)
+		c.recorder.Eventf(
+			cvr,
+			corev1.EventTypeWarning,
+			string(common.FailurePropertiesUpdate),
+			"Unable to update zfs properties: %v", err,
+		)
+	}
+	if len(changed) != 0 {
+		c.recorder.Eventf(
+			cvr,
+			corev1.EventTypeNormal,
+			string(common.SuccessPropertiesUpdated),
+			"Updated zfs properties %s", strings.Join(changed, ", "),
+		)
+	}
 	// Get capacity of the volume.
 	capacity, err := volumereplica.Capacity(volumeName)
 	if err != nil {
@@ -514,7 +594,10 @@ func (c *CStorVolumeReplicaController) syncCvr(cvr *apis.CStorVolumeReplica) {
 			string(common.FailureCapacitySync),
 			string(common.MessageResourceFailCapacitySync),
 		)
-	} else {
-		cvr.Status.Capacity = *capacity
+		return
+	}
+	if volSizeErr == nil {
+		capacity.VolSize = resource.NewQuantity(volSize, resource.BinarySI).String()
 	}
+	cvr.Status.Capacity = *capacity
 }
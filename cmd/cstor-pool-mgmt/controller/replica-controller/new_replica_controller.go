@@ -215,10 +215,9 @@ func NewCStorVolumeReplicaController(
 				}
 
 				if newCVR.ResourceVersion != oldCVR.ResourceVersion {
-					// cvr modify is not implemented
-					// hence below is commented
-					// ql.Operation = common.QOpModify
-
+					// cvr modify is handled as a sync of the cvr
+					// e.g. resize of the zfs volume on change of
+					// capacity
 					controller.recorder.Event(
 						newCVR,
 						corev1.EventTypeNormal,
@@ -226,7 +225,9 @@ func NewCStorVolumeReplicaController(
 						string(common.MessageModifySynced),
 					)
 
-					// no further handling needed
+					// push this operation to workqueue
+					ql.Operation = common.QOpModify
+					controller.enqueueCStorReplica(newCVR, ql)
 					return
 				}
 
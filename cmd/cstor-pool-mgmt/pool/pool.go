@@ -25,6 +25,7 @@ import (
 	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
 	zpool "github.com/openebs/maya/pkg/apis/openebs.io/zpool/v1alpha1"
 	"github.com/openebs/maya/pkg/util"
+	zstatus "github.com/openebs/maya/pkg/zpool/v1alpha1"
 	"github.com/pkg/errors"
 )
 
@@ -233,28 +234,50 @@ func Capacity(poolName string) (*apis.CStorPoolCapacityAttr, error) {
 */
 // The output is then parsed by poolStatusOutputParser function to get the status of the pool
 func Status(poolName string) (string, error) {
-	var poolStatus string
-	statusPoolStr := []string{"status", poolName}
-	stdoutStderr, err := RunnerVar.RunCombinedOutput(zpool.PoolOperator, statusPoolStr...)
+	stdoutStderr, err := statusOutput(poolName)
 	if err != nil {
-		glog.Errorf("Unable to get pool status: %v", string(stdoutStderr))
 		return "", err
 	}
-	poolStatus = poolStatusOutputParser(string(stdoutStderr))
+	return toPhase(poolStatusOutputParser(string(stdoutStderr))), nil
+}
+
+// ZpoolStatus runs `zpool status <pool-name>` & returns its parsed
+// output. The parsed output is meant to be shared by Phase,
+// VdevStatus & ScrubStatus so that the command is run once per sync.
+func ZpoolStatus(poolName string) (zstatus.PoolStatus, error) {
+	stdoutStderr, err := statusOutput(poolName)
+	if err != nil {
+		return zstatus.PoolStatus{}, err
+	}
+	poolStatus, err := zstatus.StatusParser(stdoutStderr)
+	if err != nil {
+		return zstatus.PoolStatus{}, errors.Wrapf(err, "failed to parse status of pool %s", poolName)
+	}
+	return poolStatus, nil
+}
+
+// Phase returns the phase of csp as per the state of the pool in the
+// parsed output of `zpool status <pool-name>`.
+func Phase(poolStatus zstatus.PoolStatus) string {
+	return toPhase(string(poolStatus.Status))
+}
+
+// toPhase returns the phase of csp for the given state of the pool
+func toPhase(poolStatus string) string {
 	if poolStatus == ZpoolStatusDegraded {
-		return string(apis.CStorPoolStatusDegraded), nil
+		return string(apis.CStorPoolStatusDegraded)
 	} else if poolStatus == ZpoolStatusFaulted {
-		return string(apis.CStorPoolStatusOffline), nil
+		return string(apis.CStorPoolStatusOffline)
 	} else if poolStatus == ZpoolStatusOffline {
-		return string(apis.CStorPoolStatusOffline), nil
+		return string(apis.CStorPoolStatusOffline)
 	} else if poolStatus == ZpoolStatusOnline {
-		return string(apis.CStorPoolStatusOnline), nil
+		return string(apis.CStorPoolStatusOnline)
 	} else if poolStatus == ZpoolStatusRemoved {
-		return string(apis.CStorPoolStatusDegraded), nil
+		return string(apis.CStorPoolStatusDegraded)
 	} else if poolStatus == ZpoolStatusUnavail {
-		return string(apis.CStorPoolStatusOffline), nil
+		return string(apis.CStorPoolStatusOffline)
 	} else {
-		return string(apis.CStorPoolStatusError), nil
+		return string(apis.CStorPoolStatusError)
 	}
 }
 
@@ -280,11 +303,7 @@ func poolStatusOutputParser(output string) string {
 func capacityOutputParser(output string) *apis.CStorPoolCapacityAttr {
 	var outputStr []string
 	// Initialize capacity object.
-	capacity := &apis.CStorPoolCapacityAttr{
-		"",
-		"",
-		"",
-	}
+	capacity := &apis.CStorPoolCapacityAttr{}
 	if strings.TrimSpace(string(output)) != "" {
 		outputStr = strings.Split(string(output), "\n")
 		if !(len(outputStr) < 4) {
@@ -890,6 +890,37 @@ func TestPoolStatus(t *testing.T) {
 	}
 }
 
+// TestZpoolStatus tests that the phase of the parsed output of
+// ZpoolStatus is the same as the one returned by Status.
+func TestZpoolStatus(t *testing.T) {
+	tests := map[string]struct {
+		mockedOutputType string
+		expectedPhase    string
+	}{
+		"online pool":   {mockedOutputType: ZpoolStatusOnline, expectedPhase: "Healthy"},
+		"offline pool":  {mockedOutputType: ZpoolStatusOffline, expectedPhase: "Offline"},
+		"removed pool":  {mockedOutputType: ZpoolStatusRemoved, expectedPhase: "Degraded"},
+		"degraded pool": {mockedOutputType: ZpoolStatusDegraded, expectedPhase: "Degraded"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			os.Setenv("StatusType", test.mockedOutputType)
+			defer os.Unsetenv("StatusType")
+			RunnerVar = TestRunner{}
+			got, err := ZpoolStatus("cstor-530c9c4f-e0df-11e8-94a8-42010a80013b")
+			if err != nil {
+				t.Fatalf("Test %q failed: expected no error: got %v", name, err)
+			}
+			if phase := Phase(got); phase != test.expectedPhase {
+				t.Fatalf("Test %q failed: expected phase '%s' but got '%s'", name, test.expectedPhase, phase)
+			}
+			if got.Name != "cstor-530c9c4f-e0df-11e8-94a8-42010a80013b" {
+				t.Fatalf("Test %q failed: expected pool name in parsed status: got '%s'", name, got.Name)
+			}
+		})
+	}
+}
+
 // TestPoolCapacity tests Capacity function.
 func TestPoolCapacity(t *testing.T) {
 	testPoolResource := map[string]struct {
@@ -904,9 +935,9 @@ func TestPoolCapacity(t *testing.T) {
 		"#1 OnlinePoolStatus": {
 			poolName: "cstor-530c9c4f-e0df-11e8-94a8-42010a80013b",
 			expectedCapacity: &apis.CStorPoolCapacityAttr{
-				"9.94G",
-				"9.94G",
-				"202K",
+				Total: "9.94G",
+				Free:  "9.94G",
+				Used:  "202K",
 			},
 		},
 	}
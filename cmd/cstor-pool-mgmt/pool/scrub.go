@@ -0,0 +1,172 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"time"
+
+	"github.com/golang/glog"
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	zpool "github.com/openebs/maya/pkg/apis/openebs.io/zpool/v1alpha1"
+	zstatus "github.com/openebs/maya/pkg/zpool/v1alpha1"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScrubAction is a typed string for the action to be taken on the
+// scrub of a pool.
+type ScrubAction string
+
+const (
+	// ScrubActionNone signifies that the scrub is left as is.
+	ScrubActionNone ScrubAction = ""
+	// ScrubActionStart signifies that a scrub is due.
+	ScrubActionStart ScrubAction = "Start"
+	// ScrubActionPause signifies that the running scrub is paused.
+	ScrubActionPause ScrubAction = "Pause"
+	// ScrubActionResume signifies that the scrub paused earlier is
+	// resumed.
+	ScrubActionResume ScrubAction = "Resume"
+)
+
+// ScrubStatus returns the status of the last scrub or resilver of the
+// pool from the scan & errors sections of the parsed output of
+// `zpool status <pool-name>`.
+func ScrubStatus(poolStatus zstatus.PoolStatus) *apis.CStorPoolScrubStatus {
+	scan := poolStatus.Scan
+	status := &apis.CStorPoolScrubStatus{
+		State:      apis.CStorPoolScanState(scan.State),
+		Repaired:   scan.Repaired,
+		Errors:     scan.Errors,
+		DataErrors: poolStatus.DataErrors,
+		StartTime:  toMetaTime(scan.StartTime),
+		EndTime:    toMetaTime(scan.EndTime),
+	}
+	switch scan.Function {
+	case zstatus.ScanScrub:
+		status.Function = apis.CStorPoolScanScrub
+	case zstatus.ScanResilver:
+		status.Function = apis.CStorPoolScanResilver
+	}
+	if scan.PercentDone != "" {
+		status.Progress = scan.PercentDone + "%"
+	}
+	return status
+}
+
+// scanStatusOutputParser returns the status of the last scrub or
+// resilver from the output of `zpool status` command.
+func scanStatusOutputParser(output string) *apis.CStorPoolScrubStatus {
+	poolStatus, err := zstatus.StatusParser([]byte(output))
+	if err != nil {
+		glog.Warningf("Unable to parse pool status: %v", err)
+		return &apis.CStorPoolScrubStatus{State: apis.CStorPoolScanStateNone}
+	}
+	return ScrubStatus(poolStatus)
+}
+
+// toMetaTime returns the given time as metav1.Time, zero time is
+// left as is.
+func toMetaTime(t time.Time) metav1.Time {
+	if t.IsZero() {
+		return metav1.Time{}
+	}
+	return metav1.NewTime(t)
+}
+
+// StartScrub starts a scrub of the pool or resumes the paused scrub.
+func StartScrub(poolName string) error {
+	stdoutStderr, err := RunnerVar.RunCombinedOutput(zpool.PoolOperator, "scrub", poolName)
+	if err != nil {
+		glog.Errorf("Unable to start scrub: %v", string(stdoutStderr))
+		return errors.Wrapf(err, "failed to start scrub of pool %s: %s", poolName, string(stdoutStderr))
+	}
+	return nil
+}
+
+// PauseScrub pauses the running scrub of the pool.
+func PauseScrub(poolName string) error {
+	stdoutStderr, err := RunnerVar.RunCombinedOutput(zpool.PoolOperator, "scrub", "-p", poolName)
+	if err != nil {
+		glog.Errorf("Unable to pause scrub: %v", string(stdoutStderr))
+		return errors.Wrapf(err, "failed to pause scrub of pool %s: %s", poolName, string(stdoutStderr))
+	}
+	return nil
+}
+
+// ScrubInterval returns the interval at which the pool is scrubbed.
+// Zero is returned if the pool is not scrubbed on schedule.
+func ScrubInterval(cStorPool *apis.CStorPool) (time.Duration, error) {
+	interval := cStorPool.Spec.PoolSpec.ScrubInterval
+	if interval == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(interval)
+	if err != nil || d <= 0 {
+		return 0, errors.Errorf("invalid scrub interval '%s' of csp %s: expected a positive duration e.g. 168h",
+			interval, cStorPool.Name)
+	}
+	return d, nil
+}
+
+// NextScrubAction returns the action to be taken on the scrub of a
+// pool. cur is the status as reported by zpool while prev is the
+// status recorded earlier on csp. busy is true if the pool is
+// resilvering or its replicas are rebuilding; a running scrub is
+// paused in that case & resumed once the pool is no more busy. A
+// scrub is due if interval has elapsed since the last scrub or the
+// creation of pool if it was never scrubbed.
+func NextScrubAction(
+	cur, prev *apis.CStorPoolScrubStatus,
+	interval time.Duration,
+	busy bool,
+	created, now time.Time,
+) ScrubAction {
+	if cur.Function == apis.CStorPoolScanScrub {
+		switch cur.State {
+		case apis.CStorPoolScanStateInProgress:
+			if busy {
+				return ScrubActionPause
+			}
+			return ScrubActionNone
+		case apis.CStorPoolScanStatePaused:
+			if prev.AutoPaused && !busy {
+				return ScrubActionResume
+			}
+			return ScrubActionNone
+		}
+	}
+	if interval == 0 || busy {
+		return ScrubActionNone
+	}
+	last := created
+	if prev.LastScheduledTime.Time.After(last) {
+		last = prev.LastScheduledTime.Time
+	}
+	if cur.Function == apis.CStorPoolScanScrub {
+		if cur.StartTime.Time.After(last) {
+			last = cur.StartTime.Time
+		}
+		if cur.EndTime.Time.After(last) {
+			last = cur.EndTime.Time
+		}
+	}
+	if now.Sub(last) >= interval {
+		return ScrubActionStart
+	}
+	return ScrubActionNone
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const statusOutputTemplate = `  pool: cstor-530c9c4f-e0df-11e8-94a8-42010a80013b
+ state: ONLINE
+%s
+config:
+
+	NAME                                        STATE     READ WRITE CKSUM
+	cstor-530c9c4f-e0df-11e8-94a8-42010a80013b  ONLINE       0     0     0
+	  scsi-0Google_PersistentDisk_ashu-disk2    ONLINE       0     0     0
+
+errors: %s
+`
+
+func TestScanStatusOutputParser(t *testing.T) {
+	startTime := time.Date(2019, 6, 3, 10, 0, 0, 0, time.Local)
+	endTime := time.Date(2019, 6, 3, 10, 5, 0, 0, time.Local)
+	testCases := map[string]struct {
+		scan     string
+		errors   string
+		expected apis.CStorPoolScrubStatus
+	}{
+		"never scanned": {
+			scan:     "  scan: none requested",
+			errors:   "No known data errors",
+			expected: apis.CStorPoolScrubStatus{State: apis.CStorPoolScanStateNone},
+		},
+		"scrub in progress": {
+			scan: "  scan: scrub in progress since Mon Jun  3 10:00:00 2019\n" +
+				"\t1.50G scanned out of 10.0G at 100M/s, 0h1m to go\n" +
+				"\t0B repaired, 15.00% done",
+			errors: "No known data errors",
+			expected: apis.CStorPoolScrubStatus{
+				Function:  apis.CStorPoolScanScrub,
+				State:     apis.CStorPoolScanStateInProgress,
+				Progress:  "15.00%",
+				Repaired:  "0B",
+				StartTime: v1.NewTime(startTime),
+			},
+		},
+		"scrub paused": {
+			scan: "  scan: scrub paused since Mon Jun  3 10:05:00 2019\n" +
+				"\tscrub started on Mon Jun  3 10:00:00 2019\n" +
+				"\t1.50G scanned out of 10.0G, 15.00% done",
+			errors: "No known data errors",
+			expected: apis.CStorPoolScrubStatus{
+				Function:  apis.CStorPoolScanScrub,
+				State:     apis.CStorPoolScanStatePaused,
+				Progress:  "15.00%",
+				StartTime: v1.NewTime(startTime),
+			},
+		},
+		"scrub finished with errors": {
+			scan:   "  scan: scrub repaired 12K in 0h5m with 3 errors on Mon Jun  3 10:05:00 2019",
+			errors: "3 data errors, use '-v' for a list",
+			expected: apis.CStorPoolScrubStatus{
+				Function:   apis.CStorPoolScanScrub,
+				State:      apis.CStorPoolScanStateFinished,
+				Progress:   "100%",
+				Repaired:   "12K",
+				Errors:     3,
+				DataErrors: 3,
+				EndTime:    v1.NewTime(endTime),
+			},
+		},
+		"scrub canceled": {
+			scan:   "  scan: scrub canceled on Mon Jun  3 10:05:00 2019",
+			errors: "No known data errors",
+			expected: apis.CStorPoolScrubStatus{
+				Function: apis.CStorPoolScanScrub,
+				State:    apis.CStorPoolScanStateCanceled,
+				EndTime:  v1.NewTime(endTime),
+			},
+		},
+		"resilver in progress": {
+			scan: "  scan: resilver in progress since Mon Jun  3 10:00:00 2019\n" +
+				"\t1.50G scanned out of 10.0G at 100M/s, 0h1m to go\n" +
+				"\t500M resilvered, 15.00% done",
+			errors: "No known data errors",
+			expected: apis.CStorPoolScrubStatus{
+				Function:  apis.CStorPoolScanResilver,
+				State:     apis.CStorPoolScanStateInProgress,
+				Progress:  "15.00%",
+				Repaired:  "500M",
+				StartTime: v1.NewTime(startTime),
+			},
+		},
+		"resilver finished": {
+			scan:   "  scan: resilvered 1.2G in 0h1m with 0 errors on Mon Jun  3 10:05:00 2019",
+			errors: "No known data errors",
+			expected: apis.CStorPoolScrubStatus{
+				Function: apis.CStorPoolScanResilver,
+				State:    apis.CStorPoolScanStateFinished,
+				Progress: "100%",
+				Repaired: "1.2G",
+				EndTime:  v1.NewTime(endTime),
+			},
+		},
+	}
+	for name, test := range testCases {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			output := fmt.Sprintf(statusOutputTemplate, test.scan, test.errors)
+			got := scanStatusOutputParser(output)
+			if !reflect.DeepEqual(*got, test.expected) {
+				t.Fatalf("Test %q failed: expected %+v: got %+v", name, test.expected, *got)
+			}
+		})
+	}
+}
+
+func TestScrubInterval(t *testing.T) {
+	testCases := map[string]struct {
+		interval string
+		expected time.Duration
+		isErr    bool
+	}{
+		"not scheduled":    {interval: "", expected: 0},
+		"weekly":           {interval: "168h", expected: 168 * time.Hour},
+		"invalid interval": {interval: "weekly", isErr: true},
+		"zero interval":    {interval: "0s", isErr: true},
+	}
+	for name, test := range testCases {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			csp := &apis.CStorPool{Spec: apis.CStorPoolSpec{PoolSpec: apis.CStorPoolAttr{ScrubInterval: test.interval}}}
+			got, err := ScrubInterval(csp)
+			if test.isErr != (err != nil) {
+				t.Fatalf("Test %q failed: expected error %t: got %v", name, test.isErr, err)
+			}
+			if got != test.expected {
+				t.Fatalf("Test %q failed: expected %s: got %s", name, test.expected, got)
+			}
+		})
+	}
+}
+
+func TestNextScrubAction(t *testing.T) {
+	now := time.Date(2019, 6, 10, 10, 0, 0, 0, time.UTC)
+	created := now.Add(-30 * 24 * time.Hour)
+	week := 7 * 24 * time.Hour
+	scan := func(function apis.CStorPoolScanFunction, state apis.CStorPoolScanState, ago time.Duration) *apis.CStorPoolScrubStatus {
+		return &apis.CStorPoolScrubStatus{Function: function, State: state, StartTime: v1.NewTime(now.Add(-ago))}
+	}
+	none := &apis.CStorPoolScrubStatus{State: apis.CStorPoolScanStateNone}
+	testCases := map[string]struct {
+		cur      *apis.CStorPoolScrubStatus
+		prev     *apis.CStorPoolScrubStatus
+		interval time.Duration
+		busy     bool
+		expected ScrubAction
+	}{
+		"not scheduled": {
+			cur: none, prev: none, expected: ScrubActionNone,
+		},
+		"never scrubbed": {
+			cur: none, prev: none, interval: week, expected: ScrubActionStart,
+		},
+		"scrubbed within interval": {
+			cur:      scan(apis.CStorPoolScanScrub, apis.CStorPoolScanStateFinished, 24*time.Hour),
+			prev:     none,
+			interval: week,
+			expected: ScrubActionNone,
+		},
+		"scheduled within interval": {
+			cur:      none,
+			prev:     &apis.CStorPoolScrubStatus{LastScheduledTime: v1.NewTime(now.Add(-24 * time.Hour))},
+			interval: week,
+			expected: ScrubActionNone,
+		},
+		"scrub is due": {
+			cur:      scan(apis.CStorPoolScanScrub, apis.CStorPoolScanStateFinished, 8*24*time.Hour),
+			prev:     none,
+			interval: week,
+			expected: ScrubActionStart,
+		},
+		"scrub is due but pool is busy": {
+			cur:      scan(apis.CStorPoolScanScrub, apis.CStorPoolScanStateFinished, 8*24*time.Hour),
+			prev:     none,
+			interval: week,
+			busy:     true,
+			expected: ScrubActionNone,
+		},
+		"scrub in progress": {
+			cur:      scan(apis.CStorPoolScanScrub, apis.CStorPoolScanStateInProgress, time.Hour),
+			prev:     none,
+			expected: ScrubActionNone,
+		},
+		"scrub in progress while pool is busy": {
+			cur:      scan(apis.CStorPoolScanScrub, apis.CStorPoolScanStateInProgress, time.Hour),
+			prev:     none,
+			busy:     true,
+			expected: ScrubActionPause,
+		},
+		"auto paused scrub after pool is idle": {
+			cur:      scan(apis.CStorPoolScanScrub, apis.CStorPoolScanStatePaused, time.Hour),
+			prev:     &apis.CStorPoolScrubStatus{AutoPaused: true},
+			expected: ScrubActionResume,
+		},
+		"auto paused scrub while pool is busy": {
+			cur:      scan(apis.CStorPoolScanScrub, apis.CStorPoolScanStatePaused, time.Hour),
+			prev:     &apis.CStorPoolScrubStatus{AutoPaused: true},
+			busy:     true,
+			expected: ScrubActionNone,
+		},
+		"scrub paused by user": {
+			cur:      scan(apis.CStorPoolScanScrub, apis.CStorPoolScanStatePaused, 8*24*time.Hour),
+			prev:     none,
+			interval: week,
+			expected: ScrubActionNone,
+		},
+	}
+	for name, test := range testCases {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			got := NextScrubAction(test.cur, test.prev, test.interval, test.busy, created, now)
+			if got != test.expected {
+				t.Fatalf("Test %q failed: expected %q: got %q", name, test.expected, got)
+			}
+		})
+	}
+}
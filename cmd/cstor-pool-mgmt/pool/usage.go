@@ -0,0 +1,40 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+// UsageThresholds are the percentages of the pool size that raise a
+// warning on the volume replicas of the pool once used up. Since the
+// volume replicas are thin provisioned, writes to them fail once the
+// pool is full.
+var UsageThresholds = []int{80, 90, 95}
+
+// CrossedUsageThreshold returns the highest usage threshold that the
+// pool crossed when its used capacity grew from prevUsed to used.
+// Zero is returned if no threshold was crossed.
+func CrossedUsageThreshold(prevUsed, used, size int64) int {
+	if size <= 0 || used <= prevUsed {
+		return 0
+	}
+	crossed := 0
+	for _, threshold := range UsageThresholds {
+		limit := size * int64(threshold) / 100
+		if prevUsed < limit && used >= limit {
+			crossed = threshold
+		}
+	}
+	return crossed
+}
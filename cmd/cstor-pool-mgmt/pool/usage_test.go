@@ -0,0 +1,43 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import "testing"
+
+func TestCrossedUsageThreshold(t *testing.T) {
+	testCases := map[string]struct {
+		prevUsed, used, size int64
+		expected             int
+	}{
+		"below thresholds":          {prevUsed: 10, used: 50, size: 100, expected: 0},
+		"crossed first threshold":   {prevUsed: 79, used: 80, size: 100, expected: 80},
+		"crossed many thresholds":   {prevUsed: 50, used: 96, size: 100, expected: 95},
+		"remained above threshold":  {prevUsed: 85, used: 88, size: 100, expected: 0},
+		"usage dropped":             {prevUsed: 96, used: 70, size: 100, expected: 0},
+		"size is yet to be synced":  {prevUsed: 0, used: 90, size: 0, expected: 0},
+		"first sync of a full pool": {prevUsed: 0, used: 91, size: 100, expected: 90},
+	}
+	for name, test := range testCases {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			got := CrossedUsageThreshold(test.prevUsed, test.used, test.size)
+			if got != test.expected {
+				t.Fatalf("Test %q failed: expected %d: got %d", name, test.expected, got)
+			}
+		})
+	}
+}
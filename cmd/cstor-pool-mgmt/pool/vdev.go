@@ -0,0 +1,140 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/glog"
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	zpool "github.com/openebs/maya/pkg/apis/openebs.io/zpool/v1alpha1"
+	pstatus "github.com/openebs/maya/pkg/zfs/cmd/v1alpha1/zpool/status"
+	zstatus "github.com/openebs/maya/pkg/zpool/v1alpha1"
+)
+
+const (
+	// vdevStateAvail is the state of an unused spare
+	vdevStateAvail = "AVAIL"
+	// vdevStateInUse is the state of a spare that replaced a device
+	vdevStateInUse = "INUSE"
+)
+
+// statusOutput returns the output of `zpool status <pool-name>`.
+func statusOutput(poolName string) ([]byte, error) {
+	statusCmd, err := pstatus.NewPoolStatus().
+		WithPool(poolName).
+		WithCheck(pstatus.IsPoolSet()).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	stdoutStderr, err := RunnerVar.RunCombinedOutput(zpool.PoolOperator, strings.Fields(statusCmd.Command)...)
+	if err != nil {
+		glog.Errorf("Unable to get pool status: %v", string(stdoutStderr))
+		return nil, err
+	}
+	return stdoutStderr, nil
+}
+
+// VdevStatus returns the vdev tree of the pool along with the state
+// & error counts of every vdev from the parsed output of
+// `zpool status <pool-name>`.
+func VdevStatus(status zstatus.PoolStatus) []apis.CStorPoolVdevStatus {
+	return toVdevStatus(status.Vdevs)
+}
+
+// vdevStatusOutputParser returns the vdev tree from the config
+// section of the output of `zpool status` command.
+func vdevStatusOutputParser(output string) []apis.CStorPoolVdevStatus {
+	poolStatus, err := zstatus.StatusParser([]byte(output))
+	if err != nil {
+		glog.Warningf("Unable to parse pool status: %v", err)
+		return nil
+	}
+	return VdevStatus(poolStatus)
+}
+
+// toVdevStatus converts the parsed vdev tree to the vdev status of
+// csp
+func toVdevStatus(vdevs []zstatus.VdevStats) []apis.CStorPoolVdevStatus {
+	var status []apis.CStorPoolVdevStatus
+	for _, vdev := range vdevs {
+		status = append(status, apis.CStorPoolVdevStatus{
+			Name:           vdev.Name,
+			State:          string(vdev.Status),
+			ReadErrors:     vdev.ReadErrors,
+			WriteErrors:    vdev.WriteErrors,
+			ChecksumErrors: vdev.ChecksumErrors,
+			Resilvering:    vdev.Resilvering,
+			Message:        vdev.Message,
+			Children:       toVdevStatus(vdev.Children),
+		})
+	}
+	return status
+}
+
+// VdevFailures returns a message for every block device that turned
+// unhealthy or reported more errors in cur as compared to prev.
+func VdevFailures(cur, prev []apis.CStorPoolVdevStatus) []string {
+	prevDevices := map[string]apis.CStorPoolVdevStatus{}
+	for _, device := range blockDevices(prev) {
+		prevDevices[device.Name] = device
+	}
+	var failures []string
+	for _, device := range blockDevices(cur) {
+		old, found := prevDevices[device.Name]
+		turnedUnhealthy := !isVdevHealthy(device) && (!found || old.State != device.State)
+		if !turnedUnhealthy && vdevErrors(device) <= vdevErrors(old) {
+			continue
+		}
+		message := fmt.Sprintf("Device %s is %s with %d read, %d write & %d checksum errors",
+			device.Name, device.State, device.ReadErrors, device.WriteErrors, device.ChecksumErrors)
+		if device.Message != "" {
+			message += ": " + device.Message
+		}
+		failures = append(failures, message)
+	}
+	return failures
+}
+
+// blockDevices returns the leaves of the vdev tree
+func blockDevices(vdevs []apis.CStorPoolVdevStatus) []apis.CStorPoolVdevStatus {
+	var devices []apis.CStorPoolVdevStatus
+	for _, vdev := range vdevs {
+		if len(vdev.Children) == 0 {
+			devices = append(devices, vdev)
+			continue
+		}
+		devices = append(devices, blockDevices(vdev.Children)...)
+	}
+	return devices
+}
+
+// isVdevHealthy returns true if the vdev is online or is a spare
+func isVdevHealthy(vdev apis.CStorPoolVdevStatus) bool {
+	switch vdev.State {
+	case ZpoolStatusOnline, vdevStateAvail, vdevStateInUse, "":
+		return true
+	}
+	return false
+}
+
+// vdevErrors returns the total number of errors of the vdev
+func vdevErrors(vdev apis.CStorPoolVdevStatus) int64 {
+	return vdev.ReadErrors + vdev.WriteErrors + vdev.ChecksumErrors
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"reflect"
+	"testing"
+
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+)
+
+const degradedStatusOutput = `  pool: cstor-530c9c4f
+ state: DEGRADED
+status: One or more devices are faulted in response to persistent errors.
+  scan: resilver in progress since Mon Jun  3 10:00:00 2019
+	1.50G scanned out of 10.0G at 100M/s, 0h1m to go
+	500M resilvered, 15.00% done
+config:
+
+	NAME                 STATE     READ WRITE CKSUM
+	cstor-530c9c4f       DEGRADED     0     0     0
+	  mirror-0           DEGRADED     0     0     0
+	    /dev/sdb         ONLINE       0     0     0
+	    /dev/sdc         FAULTED      3  1.5K     0  too many errors
+	    /dev/sdd         ONLINE       0     0     0  (resilvering)
+	logs
+	  /dev/sde           ONLINE       0     0     0
+	spares
+	  /dev/sdf           AVAIL
+
+errors: No known data errors
+`
+
+func TestVdevStatusOutputParser(t *testing.T) {
+	expected := []apis.CStorPoolVdevStatus{
+		{
+			Name:  "cstor-530c9c4f",
+			State: "DEGRADED",
+			Children: []apis.CStorPoolVdevStatus{
+				{
+					Name:  "mirror-0",
+					State: "DEGRADED",
+					Children: []apis.CStorPoolVdevStatus{
+						{Name: "/dev/sdb", State: "ONLINE"},
+						{Name: "/dev/sdc", State: "FAULTED", ReadErrors: 3, WriteErrors: 1536, Message: "too many errors"},
+						{Name: "/dev/sdd", State: "ONLINE", Resilvering: true},
+					},
+				},
+			},
+		},
+		{
+			Name:     "logs",
+			Children: []apis.CStorPoolVdevStatus{{Name: "/dev/sde", State: "ONLINE"}},
+		},
+		{
+			Name:     "spares",
+			Children: []apis.CStorPoolVdevStatus{{Name: "/dev/sdf", State: "AVAIL"}},
+		},
+	}
+	got := vdevStatusOutputParser(degradedStatusOutput)
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Test failed: expected %+v: got %+v", expected, got)
+	}
+	if got := vdevStatusOutputParser(StatusNoPoolsAvailable); got != nil {
+		t.Fatalf("Test failed: expected no vdevs: got %+v", got)
+	}
+}
+
+func TestVdevFailures(t *testing.T) {
+	pool := func(devices ...apis.CStorPoolVdevStatus) []apis.CStorPoolVdevStatus {
+		return []apis.CStorPoolVdevStatus{{Name: "cstor-1", State: "ONLINE", Children: devices}}
+	}
+	online := apis.CStorPoolVdevStatus{Name: "/dev/sdb", State: "ONLINE"}
+	faulted := apis.CStorPoolVdevStatus{Name: "/dev/sdb", State: "FAULTED", ReadErrors: 3, Message: "too many errors"}
+	erroring := apis.CStorPoolVdevStatus{Name: "/dev/sdb", State: "ONLINE", ChecksumErrors: 2}
+	spare := apis.CStorPoolVdevStatus{Name: "/dev/sdc", State: "AVAIL"}
+	testCases := map[string]struct {
+		cur, prev []apis.CStorPoolVdevStatus
+		expected  []string
+	}{
+		"healthy pool": {
+			cur: pool(online, spare), prev: nil,
+		},
+		"device turned faulted": {
+			cur:      pool(faulted),
+			prev:     pool(online),
+			expected: []string{"Device /dev/sdb is FAULTED with 3 read, 0 write & 0 checksum errors: too many errors"},
+		},
+		"device still faulted": {
+			cur: pool(faulted), prev: pool(faulted),
+		},
+		"device reported errors": {
+			cur:      pool(erroring),
+			prev:     pool(online),
+			expected: []string{"Device /dev/sdb is ONLINE with 0 read, 0 write & 2 checksum errors"},
+		},
+		"errors are cleared": {
+			cur: pool(online), prev: pool(erroring),
+		},
+	}
+	for name, test := range testCases {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			got := VdevFailures(test.cur, test.prev)
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Fatalf("Test %q failed: expected %v: got %v", name, test.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumereplica
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/glog"
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	"github.com/openebs/maya/pkg/util"
+)
+
+const (
+	// DefaultBlockSize is the volblocksize of zfs volume if the cvr
+	// does not set one
+	DefaultBlockSize = "4K"
+	// DefaultCompression is the compression of zfs volume if the cvr
+	// does not set one
+	DefaultCompression = "on"
+	// minBlockSize is the smallest volblocksize supported by zfs
+	minBlockSize = 512
+	// maxBlockSize is the largest volblocksize supported by zfs
+	maxBlockSize = 128 * 1024
+)
+
+// volumePropertyNames is the list of tunable properties of zfs
+// volume in the order they are applied
+var volumePropertyNames = []string{"compression", "sync", "checksum", "logbias"}
+
+// validPropertyValues is the list of values zfs accepts for each of
+// the tunable properties of zfs volume
+var validPropertyValues = map[string][]string{
+	"compression": {"on", "off", "lz4", "lzjb", "zle", "gzip",
+		"gzip-1", "gzip-2", "gzip-3", "gzip-4", "gzip-5", "gzip-6", "gzip-7", "gzip-8", "gzip-9"},
+	"sync":     {"standard", "always", "disabled"},
+	"checksum": {"on", "off", "fletcher2", "fletcher4", "sha256", "sha512", "skein", "edonr"},
+	"logbias":  {"latency", "throughput"},
+}
+
+// volumeProperties returns the tunable properties of zfs volume set
+// on the cvr in the form of property name to value. Properties that
+// are not set on the cvr are left out.
+func volumeProperties(cStorVolumeReplica *apis.CStorVolumeReplica) map[string]string {
+	spec := cStorVolumeReplica.Spec
+	properties := map[string]string{}
+	for name, value := range map[string]string{
+		"compression": spec.Compression,
+		"sync":        spec.Sync,
+		"checksum":    spec.Checksum,
+		"logbias":     spec.LogBias,
+	} {
+		if value != "" {
+			properties[name] = value
+		}
+	}
+	return properties
+}
+
+// propertyOptions returns the `-o property=value` options of zfs
+// create & clone commands for the properties set on the cvr.
+// Compression is on unless the cvr sets it.
+func propertyOptions(cStorVolumeReplica *apis.CStorVolumeReplica) []string {
+	properties := volumeProperties(cStorVolumeReplica)
+	if _, ok := properties["compression"]; !ok {
+		properties["compression"] = DefaultCompression
+	}
+	var options []string
+	for _, name := range volumePropertyNames {
+		if value, ok := properties[name]; ok {
+			options = append(options, "-o", name+"="+value)
+		}
+	}
+	return options
+}
+
+// blockSize returns the volblocksize of zfs volume to be created
+func blockSize(cStorVolumeReplica *apis.CStorVolumeReplica) string {
+	if cStorVolumeReplica.Spec.BlockSize == "" {
+		return DefaultBlockSize
+	}
+	return cStorVolumeReplica.Spec.BlockSize
+}
+
+// ValidateVolumeProperties validates the zfs volume properties set
+// on the cvr that the zfs volume is created with.
+func ValidateVolumeProperties(cStorVolumeReplica *apis.CStorVolumeReplica) error {
+	if cStorVolumeReplica.Spec.BlockSize != "" {
+		size, err := CapacityInBytes(cStorVolumeReplica.Spec.BlockSize)
+		if err != nil || size < minBlockSize || size > maxBlockSize || size&(size-1) != 0 {
+			return fmt.Errorf("Invalid blockSize %q: expected a power of 2 from 512 to 128K",
+				cStorVolumeReplica.Spec.BlockSize)
+		}
+	}
+	return validatePropertyValues(volumeProperties(cStorVolumeReplica))
+}
+
+// validatePropertyValues validates the values of the given tunable
+// properties of zfs volume
+func validatePropertyValues(properties map[string]string) error {
+	for _, name := range volumePropertyNames {
+		value, ok := properties[name]
+		if ok && !util.ContainsString(validPropertyValues[name], value) {
+			return fmt.Errorf("Invalid %s %q: expected one of %s",
+				name, value, strings.Join(validPropertyValues[name], ", "))
+		}
+	}
+	return nil
+}
+
+// BlockSizeChangeError is returned if the blockSize set on the cvr
+// differs from the volblocksize of the zfs volume. The volblocksize
+// can not be changed once the zfs volume is created.
+type BlockSizeChangeError struct {
+	Current string
+	Desired string
+}
+
+func (e *BlockSizeChangeError) Error() string {
+	return fmt.Sprintf("blockSize can not be changed from %s to %s once the volume is created",
+		e.Current, e.Desired)
+}
+
+// IsBlockSizeChange flags if the given error is returned for the
+// blockSize that was changed on the cvr
+func IsBlockSizeChange(err error) bool {
+	_, ok := err.(*BlockSizeChangeError)
+	return ok
+}
+
+// SetVolumeProperties sets the properties of zfs volume that differ
+// from the ones set on the cvr. The names of properties that were
+// set are returned. BlockSizeChangeError is returned after setting
+// the properties if the blockSize on the cvr has been changed.
+// The output of command executed to get the properties is as follows:
+/*
+root@cstor-sparse-pool-6dft-5b5c78ccc7-dls8s:/# zfs get -H -o property,value compression,sync,checksum,logbias,volblocksize cstor-d82bd105-f3a8-11e8-87fd-42010a800087/pvc-1b2a7d4b-f3a9-11e8-87fd-42010a800087
+compression	on
+sync	standard
+checksum	on
+logbias	latency
+volblocksize	4K
+*/
+func SetVolumeProperties(cStorVolumeReplica *apis.CStorVolumeReplica, fullVolName string) ([]string, error) {
+	desired := volumeProperties(cStorVolumeReplica)
+	if len(desired) == 0 && cStorVolumeReplica.Spec.BlockSize == "" {
+		return nil, nil
+	}
+	if err := validatePropertyValues(desired); err != nil {
+		return nil, err
+	}
+	current, err := getVolumeProperties(fullVolName)
+	if err != nil {
+		return nil, err
+	}
+	var changed []string
+	for _, name := range volumePropertyNames {
+		value, ok := desired[name]
+		if !ok || current[name] == value {
+			continue
+		}
+		cmd := []string{SetCmd, name + "=" + value, fullVolName}
+		stdoutStderr, err := RunnerVar.RunCombinedOutput(VolumeReplicaOperator, cmd...)
+		if err != nil {
+			glog.Errorf("Unable to set %s of volume %s. error : %v", name, fullVolName, string(stdoutStderr))
+			return changed, fmt.Errorf("unable to set %s=%s: %v", name, value, err)
+		}
+		glog.Infof("Set %s of volume %s from %s to %s", name, fullVolName, current[name], value)
+		changed = append(changed, name)
+	}
+	if isBlockSizeChanged(cStorVolumeReplica.Spec.BlockSize, current["volblocksize"]) {
+		return changed, &BlockSizeChangeError{
+			Current: current["volblocksize"],
+			Desired: cStorVolumeReplica.Spec.BlockSize,
+		}
+	}
+	return changed, nil
+}
+
+// isBlockSizeChanged flags if the desired blockSize differs from the
+// current volblocksize of zfs volume. Sizes that are not set or can
+// not be parsed are not considered as changed.
+func isBlockSizeChanged(desired, current string) bool {
+	if desired == "" || current == "" {
+		return false
+	}
+	d, err := CapacityInBytes(desired)
+	if err != nil {
+		return false
+	}
+	c, err := CapacityInBytes(current)
+	if err != nil {
+		return false
+	}
+	return d != c
+}
+
+// getVolumeProperties returns the tunable properties of zfs volume
+// along with its volblocksize
+func getVolumeProperties(fullVolName string) (map[string]string, error) {
+	names := append(append([]string{}, volumePropertyNames...), "volblocksize")
+	cmd := []string{"get", "-H", "-o", "property,value", strings.Join(names, ","), fullVolName}
+	stdoutStderr, err := RunnerVar.RunCombinedOutput(VolumeReplicaOperator, cmd...)
+	if err != nil {
+		glog.Errorf("Unable to get volume properties: %v", string(stdoutStderr))
+		return nil, err
+	}
+	return propertyOutputParser(string(stdoutStderr)), nil
+}
+
+// propertyOutputParser parses the output of `zfs get -H -o property,value`
+// command into property name to value.
+func propertyOutputParser(output string) map[string]string {
+	properties := map[string]string{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 {
+			properties[fields[0]] = fields[1]
+		}
+	}
+	return properties
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumereplica
+
+import (
+	"reflect"
+	"testing"
+
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateVolumeProperties(t *testing.T) {
+	testCases := map[string]struct {
+		spec  apis.CStorVolumeReplicaSpec
+		isErr bool
+	}{
+		"no properties":          {spec: apis.CStorVolumeReplicaSpec{}},
+		"valid properties":       {spec: apis.CStorVolumeReplicaSpec{BlockSize: "64K", Compression: "lz4", Sync: "always", Checksum: "sha256", LogBias: "throughput"}},
+		"block size in bytes":    {spec: apis.CStorVolumeReplicaSpec{BlockSize: "8192"}},
+		"block size too small":   {spec: apis.CStorVolumeReplicaSpec{BlockSize: "256"}, isErr: true},
+		"block size too large":   {spec: apis.CStorVolumeReplicaSpec{BlockSize: "1M"}, isErr: true},
+		"block size not power 2": {spec: apis.CStorVolumeReplicaSpec{BlockSize: "12K"}, isErr: true},
+		"invalid compression":    {spec: apis.CStorVolumeReplicaSpec{Compression: "zstd"}, isErr: true},
+		"invalid sync":           {spec: apis.CStorVolumeReplicaSpec{Sync: "never"}, isErr: true},
+		"invalid checksum":       {spec: apis.CStorVolumeReplicaSpec{Checksum: "md5"}, isErr: true},
+		"noparity checksum":      {spec: apis.CStorVolumeReplicaSpec{Checksum: "noparity"}, isErr: true},
+		"invalid log bias":       {spec: apis.CStorVolumeReplicaSpec{LogBias: "fast"}, isErr: true},
+	}
+	for name, test := range testCases {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			err := ValidateVolumeProperties(&apis.CStorVolumeReplica{Spec: test.spec})
+			if test.isErr != (err != nil) {
+				t.Fatalf("Test %q failed: expected error %t: got %v", name, test.isErr, err)
+			}
+		})
+	}
+}
+
+func TestBuildVolumeCreateCommand(t *testing.T) {
+	testCases := map[string]struct {
+		spec     apis.CStorVolumeReplicaSpec
+		expected []string
+	}{
+		"default properties": {
+			spec: apis.CStorVolumeReplicaSpec{TargetIP: "10.0.0.1", Capacity: "1G"},
+			expected: []string{CreateCmd, "-b", "4K", "-s", "-o", "compression=on",
+				"-o", "quorum=on", "-o", "io.openebs:volname=cvr1",
+				"-o", "io.openebs:targetip=10.0.0.1", "-V", "1G", "pool/vol"},
+		},
+		"tuned properties": {
+			spec: apis.CStorVolumeReplicaSpec{TargetIP: "10.0.0.1", Capacity: "1G",
+				BlockSize: "64K", Compression: "lz4", Sync: "always", LogBias: "throughput"},
+			expected: []string{CreateCmd, "-b", "64K", "-s", "-o", "compression=lz4",
+				"-o", "sync=always", "-o", "logbias=throughput",
+				"-o", "quorum=on", "-o", "io.openebs:volname=cvr1",
+				"-o", "io.openebs:targetip=10.0.0.1", "-V", "1G", "pool/vol"},
+		},
+	}
+	for name, test := range testCases {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			cvr := &apis.CStorVolumeReplica{ObjectMeta: metav1.ObjectMeta{Name: "cvr1"}, Spec: test.spec}
+			got := builldVolumeCreateCommand(cvr, "pool/vol", true)
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Fatalf("Test %q failed: expected %v: got %v", name, test.expected, got)
+			}
+		})
+	}
+}
+
+func TestPropertyOutputParser(t *testing.T) {
+	output := "compression\tlz4\nsync\tstandard\nchecksum\ton\nlogbias\tlatency\n"
+	expected := map[string]string{
+		"compression": "lz4",
+		"sync":        "standard",
+		"checksum":    "on",
+		"logbias":     "latency",
+	}
+	if got := propertyOutputParser(output); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Test failed: expected %v: got %v", expected, got)
+	}
+}
+
+func TestIsBlockSizeChanged(t *testing.T) {
+	testCases := map[string]struct {
+		desired, current string
+		expected         bool
+	}{
+		"block size not set on cvr": {desired: "", current: "4K"},
+		"same block size":           {desired: "4K", current: "4K"},
+		"same block size in bytes":  {desired: "4096", current: "4K"},
+		"block size changed":        {desired: "8K", current: "4K", expected: true},
+		"volblocksize unknown":      {desired: "8K", current: ""},
+	}
+	for name, test := range testCases {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			if got := isBlockSizeChanged(test.desired, test.current); got != test.expected {
+				t.Fatalf("Test %q failed: expected %t: got %t", name, test.expected, got)
+			}
+		})
+	}
+}
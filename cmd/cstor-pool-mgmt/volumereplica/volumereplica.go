@@ -18,6 +18,7 @@ package volumereplica
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,6 +27,7 @@ import (
 	"github.com/golang/glog"
 	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
 	"github.com/openebs/maya/pkg/util"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 const (
@@ -43,6 +45,8 @@ const (
 	RestoreCmd = "recv"
 	// StatsCmd is the zfs volume stats command.
 	StatsCmd = "stats"
+	// SetCmd is the zfs volume set property command.
+	SetCmd = "set"
 	// ZfsStatusDegraded is the degraded state of zfs volume.
 	ZfsStatusDegraded = "Degraded"
 	// ZfsStatusOffline is the offline state of zfs volume.
@@ -169,8 +173,9 @@ func builldVolumeCreateCommand(cStorVolumeReplica *apis.CStorVolumeReplica, full
 	}
 
 	// set volume property
-	createVolCmd = append(createVolCmd, CreateCmd,
-		"-b", "4K", "-s", "-o", "compression=on", "-o", quorumValue, "-o", openebsVolname)
+	createVolCmd = append(createVolCmd, CreateCmd, "-b", blockSize(cStorVolumeReplica), "-s")
+	createVolCmd = append(createVolCmd, propertyOptions(cStorVolumeReplica)...)
+	createVolCmd = append(createVolCmd, "-o", quorumValue, "-o", openebsVolname)
 
 	if len(cStorVolumeReplica.Spec.ZvolWorkers) != 0 {
 		createVolCmd = append(createVolCmd, "-o", openebsZvolWorkers)
@@ -193,16 +198,14 @@ func builldVolumeCloneCommand(cStorVolumeReplica *apis.CStorVolumeReplica, snapN
 	// ZvolWorkers represents number of threads that executes client IOs
 	openebsZvolWorkers := "io.openebs:zvol_workers=" + cStorVolumeReplica.Spec.ZvolWorkers
 
+	// volblocksize of a clone is inherited from the snapshot
+	cloneVolCmd = append(cloneVolCmd, CloneCmd)
+	cloneVolCmd = append(cloneVolCmd, propertyOptions(cStorVolumeReplica)...)
+	cloneVolCmd = append(cloneVolCmd, "-o", openebsTargetIP, "-o", "quorum=on")
 	if len(cStorVolumeReplica.Spec.ZvolWorkers) != 0 {
-		cloneVolCmd = append(cloneVolCmd, CloneCmd,
-			"-o", "compression=on", "-o", openebsTargetIP, "-o", "quorum=on",
-			"-o", openebsZvolWorkers, "-o", openebsVolname, snapName, fullVolName)
-	} else {
-		cloneVolCmd = append(cloneVolCmd, CloneCmd,
-			"-o", "compression=on", "-o", openebsTargetIP, "-o", "quorum=on",
-			"-o", openebsVolname, snapName, fullVolName)
+		cloneVolCmd = append(cloneVolCmd, "-o", openebsZvolWorkers)
 	}
-	return cloneVolCmd
+	return append(cloneVolCmd, "-o", openebsVolname, snapName, fullVolName)
 }
 
 // CreateVolumeBackup sends cStor snapshots to remote location specified by cstorbackup.
@@ -343,6 +346,99 @@ func Capacity(volName string) (*apis.CStorVolumeCapacityAttr, error) {
 	return poolCapacity, nil
 }
 
+// VolSize finds the size of the zfs volume in bytes.
+// The output of command executed is as follows:
+/*
+root@cstor-sparse-pool-6dft-5b5c78ccc7-dls8s:/# zfs get -Hp -o value volsize cstor-d82bd105-f3a8-11e8-87fd-42010a800087/pvc-1b2a7d4b-f3a9-11e8-87fd-42010a800087
+5368709120
+*/
+func VolSize(volName string) (int64, error) {
+	volSizeStr := []string{"get", "-Hp", "-o", "value", "volsize", volName}
+	stdoutStderr, err := RunnerVar.RunCombinedOutput(VolumeReplicaOperator, volSizeStr...)
+	if err != nil {
+		glog.Errorf("Unable to get volume size: %v", string(stdoutStderr))
+		return 0, err
+	}
+	volSize, err := strconv.ParseInt(strings.TrimSpace(string(stdoutStderr)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse volume size %q: %v", string(stdoutStderr), err)
+	}
+	return volSize, nil
+}
+
+// ProvisionedCapacity finds the sum of the sizes of the zfs volumes
+// of the pool in bytes.
+// The output of command executed is as follows:
+/*
+root@cstor-sparse-pool-6dft-5b5c78ccc7-dls8s:/# zfs get -Hp -o value -r -t volume volsize cstor-d82bd105-f3a8-11e8-87fd-42010a800087
+5368709120
+10737418240
+*/
+func ProvisionedCapacity(poolName string) (int64, error) {
+	volSizeStr := []string{"get", "-Hp", "-o", "value", "-r", "-t", "volume", "volsize", poolName}
+	stdoutStderr, err := RunnerVar.RunCombinedOutput(VolumeReplicaOperator, volSizeStr...)
+	if err != nil {
+		glog.Errorf("Unable to get provisioned capacity: %v", string(stdoutStderr))
+		return 0, err
+	}
+	return provisionedOutputParser(string(stdoutStderr))
+}
+
+// provisionedOutputParser sums the volume sizes in the output of
+// `zfs get -Hp -o value volsize` command.
+func provisionedOutputParser(output string) (int64, error) {
+	var provisioned int64
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		volSize, err := strconv.ParseInt(line, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("unable to parse volume size %q: %v", line, err)
+		}
+		provisioned += volSize
+	}
+	return provisioned, nil
+}
+
+// ResizeVolumeReplica expands the zfs volume to the capacity set on the cvr,
+// if the present size i.e. volSize of the zfs volume is less than the
+// capacity. It returns the size of the zfs volume after the resize.
+func ResizeVolumeReplica(cStorVolumeReplica *apis.CStorVolumeReplica, fullVolName string, volSize int64) (int64, error) {
+	desiredSize, err := CapacityInBytes(cStorVolumeReplica.Spec.Capacity)
+	if err != nil {
+		return volSize, err
+	}
+	if desiredSize <= volSize {
+		return volSize, nil
+	}
+
+	cmd := []string{SetCmd, "volsize=" + parseCapacityUnit(cStorVolumeReplica.Spec.Capacity), fullVolName}
+	stdoutStderr, err := RunnerVar.RunCombinedOutput(VolumeReplicaOperator, cmd...)
+	if err != nil {
+		glog.Errorf("Unable to resize volume %s. error : %v", fullVolName, string(stdoutStderr))
+		return volSize, err
+	}
+	glog.Infof("Resized volume %s from %d to %s", fullVolName, volSize, cStorVolumeReplica.Spec.Capacity)
+	return desiredSize, nil
+}
+
+// CapacityInBytes converts the capacity set on the cvr to bytes.
+// zfs interprets the size units as binary units, hence capacity
+// without the binary unit suffix e.g. 5G is treated as 5Gi.
+func CapacityInBytes(capacity string) (int64, error) {
+	capacity = strings.TrimSpace(capacity)
+	if capacity != "" && strings.ContainsAny(capacity[len(capacity)-1:], "KMGTPE") {
+		capacity = capacity + BinaryCapacityUnitSuffix
+	}
+	qty, err := resource.ParseQuantity(capacity)
+	if err != nil {
+		return 0, fmt.Errorf("invalid capacity %q: %v", capacity, err)
+	}
+	return qty.Value(), nil
+}
+
 // Status function gives the status of cvr which extracted and mapped to a set of cvr statuses
 // after getting the zfs volume status
 func Status(volumeName string) (string, error) {
@@ -368,6 +464,28 @@ func Status(volumeName string) (string, error) {
 	return cvrStatus, nil
 }
 
+// RebuildingVolumes returns the names of the zfs volumes of the given
+// pool that are being rebuilt.
+func RebuildingVolumes(poolName string) ([]string, error) {
+	stdoutStderr, err := RunnerVar.RunCombinedOutput(VolumeReplicaOperator, StatsCmd)
+	if err != nil {
+		glog.Errorf("Unable to get volume stats: %v", string(stdoutStderr))
+		return nil, fmt.Errorf("Unable to get volume stats: %s", err.Error())
+	}
+	volumeStats := &CvrStats{}
+	err = json.Unmarshal(stdoutStderr, volumeStats)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to unmarshal volume stats:%s", err)
+	}
+	var volumes []string
+	for _, stats := range volumeStats.Stats {
+		if strings.HasPrefix(stats.Name, poolName+"/") && stats.Status == ZfsStatusRebuilding {
+			volumes = append(volumes, stats.Name)
+		}
+	}
+	return volumes, nil
+}
+
 // GetVolumeName finds the zctual zfs volume name for the given cvr.
 func GetVolumeName(cVR *apis.CStorVolumeReplica) (string, error) {
 	var volumeName string
@@ -418,8 +536,8 @@ func capacityOutputParser(output string) *apis.CStorVolumeCapacityAttr {
 	// 'TotalAllocated' value(on cvr) is filled from the value of 'used' property in 'zfs get' output.
 	// 'Used' value(on cvr) is filled from the value of 'logicalused' property in 'zfs get' output.
 	capacity := &apis.CStorVolumeCapacityAttr{
-		"",
-		"",
+		TotalAllocated: "",
+		Used:           "",
 	}
 	if strings.TrimSpace(string(output)) != "" {
 		outputStr = strings.Split(string(output), "\n")
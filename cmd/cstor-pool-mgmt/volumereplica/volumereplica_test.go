@@ -459,6 +459,68 @@ func TestParseCapacityUnit(t *testing.T) {
 	}
 }
 
+func TestCapacityInBytes(t *testing.T) {
+	testVolumeCapacity := map[string]struct {
+		volumeCapacity string
+		expectedBytes  int64
+		expectedErr    bool
+	}{
+		"binary unit capacity": {
+			volumeCapacity: "5Gi",
+			expectedBytes:  5368709120,
+		},
+		"zfs unit capacity": {
+			volumeCapacity: "5G",
+			expectedBytes:  5368709120,
+		},
+		"capacity in bytes": {
+			volumeCapacity: "1048576",
+			expectedBytes:  1048576,
+		},
+		"invalid capacity": {
+			volumeCapacity: "5Gx",
+			expectedErr:    true,
+		},
+	}
+	for name, test := range testVolumeCapacity {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			gotBytes, err := CapacityInBytes(test.volumeCapacity)
+			if test.expectedErr && err == nil {
+				t.Fatalf("Test case %q failed as expected error but got nil", name)
+			}
+			if !test.expectedErr && gotBytes != test.expectedBytes {
+				t.Errorf("Test case %q failed as expected bytes '%v' but got '%v'", name, test.expectedBytes, gotBytes)
+			}
+		})
+	}
+}
+
+// TestProvisionedOutputParser tests provisionedOutputParser function
+// which sums the sizes of the zfs volumes of a pool.
+func TestProvisionedOutputParser(t *testing.T) {
+	testOutputs := map[string]struct {
+		output        string
+		expectedBytes int64
+		expectedErr   bool
+	}{
+		"#1 NoVolumes":   {output: "", expectedBytes: 0},
+		"#2 Volumes":     {output: "5368709120\n10737418240\n", expectedBytes: 16106127360},
+		"#3 InvalidSize": {output: "5G\n", expectedErr: true},
+	}
+	for name, test := range testOutputs {
+		t.Run(name, func(t *testing.T) {
+			gotBytes, err := provisionedOutputParser(test.output)
+			if test.expectedErr != (err != nil) {
+				t.Fatalf("Test case %q failed as expected error '%v' but got '%v'", name, test.expectedErr, err)
+			}
+			if gotBytes != test.expectedBytes {
+				t.Errorf("Test case %q failed as expected bytes '%v' but got '%v'", name, test.expectedBytes, gotBytes)
+			}
+		})
+	}
+}
+
 // TestPoolStatus tests Status function which retunr cvr status.
 func TestVolumeStatus(t *testing.T) {
 	testPoolResource := map[string]struct {
@@ -530,8 +592,8 @@ func TestVolumeCapacity(t *testing.T) {
 		"#1 VolumeCapacity": {
 			volumeName: "cstor-530c9c4f-e0df-11e8-94a8-42010a80013b",
 			expectedCapacity: &apis.CStorVolumeCapacityAttr{
-				"10K",
-				"6K",
+				TotalAllocated: "10K",
+				Used:           "6K",
 			},
 		},
 	}
@@ -548,3 +610,62 @@ func TestVolumeCapacity(t *testing.T) {
 		})
 	}
 }
+
+// resizeRunner records the commands run by the resize of a volume.
+type resizeRunner struct {
+	TestRunner
+	cmds [][]string
+}
+
+// RunCombinedOutput records the command instead of running it.
+func (r *resizeRunner) RunCombinedOutput(command string, args ...string) ([]byte, error) {
+	r.cmds = append(r.cmds, args)
+	return []byte{}, nil
+}
+
+func TestResizeVolumeReplica(t *testing.T) {
+	testCases := map[string]struct {
+		capacity     string
+		volSize      int64
+		expectedSize int64
+		expectedCmds int
+	}{
+		"volume is already resized": {
+			capacity:     "5G",
+			volSize:      5 << 30,
+			expectedSize: 5 << 30,
+		},
+		"volume is not shrunk": {
+			capacity:     "5G",
+			volSize:      10 << 30,
+			expectedSize: 10 << 30,
+		},
+		"volume is resized": {
+			capacity:     "10G",
+			volSize:      5 << 30,
+			expectedSize: 10 << 30,
+			expectedCmds: 1,
+		},
+	}
+	for name, test := range testCases {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			runner := &resizeRunner{}
+			RunnerVar = runner
+			defer func() { RunnerVar = TestRunner{} }()
+			cvr := &apis.CStorVolumeReplica{
+				Spec: apis.CStorVolumeReplicaSpec{Capacity: test.capacity},
+			}
+			size, err := ResizeVolumeReplica(cvr, "cstor-pool/pv-1", test.volSize)
+			if err != nil {
+				t.Fatalf("Test %q failed: expected no error: got %v", name, err)
+			}
+			if size != test.expectedSize {
+				t.Fatalf("Test %q failed: expected size %d: got %d", name, test.expectedSize, size)
+			}
+			if len(runner.cmds) != test.expectedCmds {
+				t.Fatalf("Test %q failed: expected %d commands: got %v", name, test.expectedCmds, runner.cmds)
+			}
+		})
+	}
+}
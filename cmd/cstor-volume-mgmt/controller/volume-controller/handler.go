@@ -33,6 +33,7 @@ import (
 	"github.com/openebs/maya/pkg/client/k8s"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/cache"
@@ -132,6 +133,17 @@ func (c *CStorVolumeController) cStorVolumeEventHandler(
 		if err != nil {
 			return common.CVStatusError, err
 		}
+
+		// Resize the LUN in case the capacity of the volume has been
+		// increased, and record the result in the status of the volume.
+		if resizeTargetVolume(cStorVolumeGot) {
+			_, err = c.clientset.OpenebsV1alpha1().
+				CStorVolumes(cStorVolumeGot.Namespace).
+				Update(cStorVolumeGot)
+			if err != nil {
+				glog.Errorf("Error updating cStorVolume object: %s", err)
+			}
+		}
 		break
 
 	case common.QOpPeriodicSync:
@@ -150,6 +162,8 @@ func (c *CStorVolumeController) cStorVolumeEventHandler(
 					common.CVStatusInit,
 				)
 			}
+			// retry the resize of the LUN if it failed earlier
+			resizeTargetVolume(cStorVolumeGot)
 		}
 		cStorVolumeGot.Status.LastUpdateTime = metav1.Now()
 		if cStorVolumeGot.Status.Phase != lastKnownPhase {
@@ -187,6 +201,54 @@ func (c *CStorVolumeController) cStorVolumeEventHandler(
 	return common.CVStatusIgnore, nil
 }
 
+// resizeTargetVolume resizes the LUN of the target if the capacity in
+// status is smaller than the capacity in spec. A volume that has no
+// capacity in status yet is served with the capacity in spec, hence the
+// capacity is only recorded for it. The resized capacity or the resize
+// error is recorded in the status, which is used by the cvc controller to
+// complete the resize of the volume. It returns true if the status has
+// been changed.
+func resizeTargetVolume(cStorVolume *apis.CStorVolume) bool {
+	if cStorVolume.Status.Capacity == cStorVolume.Spec.Capacity {
+		return false
+	}
+	if cStorVolume.Status.Capacity == "" {
+		cStorVolume.Status.Capacity = cStorVolume.Spec.Capacity
+		return true
+	}
+	if !isCapacityIncreased(cStorVolume.Status.Capacity, cStorVolume.Spec.Capacity) {
+		return false
+	}
+	err := volume.ResizeTargetVolume(cStorVolume)
+	if err != nil {
+		glog.Errorf("Error resizing cStorVolume %s: %s", cStorVolume.Name, err)
+		if cStorVolume.Status.LastResizeError == err.Error() {
+			return false
+		}
+		cStorVolume.Status.LastResizeError = err.Error()
+		return true
+	}
+	cStorVolume.Status.Capacity = cStorVolume.Spec.Capacity
+	cStorVolume.Status.LastResizeError = ""
+	return true
+}
+
+// isCapacityIncreased returns true if the desired capacity is
+// larger than the recorded capacity.
+func isCapacityIncreased(recorded, desired string) bool {
+	recordedQty, err := resource.ParseQuantity(recorded)
+	if err != nil {
+		glog.Errorf("Invalid recorded capacity %q: %s", recorded, err)
+		return false
+	}
+	desiredQty, err := resource.ParseQuantity(desired)
+	if err != nil {
+		glog.Errorf("Invalid desired capacity %q: %s", desired, err)
+		return false
+	}
+	return recordedQty.Cmp(desiredQty) < 0
+}
+
 // getEventType returns the event type based on the passed CStorVolumeStatus
 func getEventType(phase common.CStorVolumeStatus) string {
 	// It is normal event only when phase is Running or Degraded
@@ -22,10 +22,12 @@ import (
 	"time"
 
 	"github.com/openebs/maya/cmd/cstor-volume-mgmt/controller/common"
+	"github.com/openebs/maya/cmd/cstor-volume-mgmt/volume"
 	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
 	openebsFakeClientset "github.com/openebs/maya/pkg/client/generated/clientset/versioned/fake"
 	informers "github.com/openebs/maya/pkg/client/generated/informers/externalversions"
 	"github.com/openebs/maya/pkg/client/k8s"
+	"github.com/openebs/maya/pkg/util"
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -280,3 +282,76 @@ func TestGetEventType(t *testing.T) {
 		})
 	}
 }
+
+// errUnixSock is a UnixSock that responds with an error to every command
+type errUnixSock struct{}
+
+func (r errUnixSock) SendCommand(cmd string) ([]string, error) {
+	return []string{"ERR resize failed"}, nil
+}
+
+// TestResizeTargetVolume checks that the result of the resize is
+// recorded in the status of the volume.
+func TestResizeTargetVolume(t *testing.T) {
+	testCases := map[string]struct {
+		sock              util.UnixSock
+		status            apis.CStorVolumeStatus
+		expectedChanged   bool
+		expectedCapacity  string
+		expectedResizeErr bool
+	}{
+		"target is already resized": {
+			sock:             util.TestUnixSock{},
+			status:           apis.CStorVolumeStatus{Capacity: "10G"},
+			expectedCapacity: "10G",
+		},
+		"target is resized": {
+			sock:             util.TestUnixSock{},
+			status:           apis.CStorVolumeStatus{Capacity: "5G", LastResizeError: "timeout"},
+			expectedChanged:  true,
+			expectedCapacity: "10G",
+		},
+		"capacity of pre-existing target is recorded": {
+			sock:             errUnixSock{},
+			expectedChanged:  true,
+			expectedCapacity: "10G",
+		},
+		"target is not shrunk": {
+			sock:             errUnixSock{},
+			status:           apis.CStorVolumeStatus{Capacity: "20G"},
+			expectedCapacity: "20G",
+		},
+		"target resize fails": {
+			sock:              errUnixSock{},
+			status:            apis.CStorVolumeStatus{Capacity: "5G"},
+			expectedChanged:   true,
+			expectedCapacity:  "5G",
+			expectedResizeErr: true,
+		},
+	}
+	for name, test := range testCases {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			volume.UnixSockVar = test.sock
+			cv := &apis.CStorVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-1"},
+				Spec:       apis.CStorVolumeSpec{Capacity: "10G"},
+				Status:     test.status,
+			}
+			changed := resizeTargetVolume(cv)
+			if changed != test.expectedChanged {
+				t.Fatalf("Test %q failed: expected changed %t: got %t", name, test.expectedChanged, changed)
+			}
+			if cv.Status.Capacity != test.expectedCapacity {
+				t.Fatalf("Test %q failed: expected capacity %s: got %s", name, test.expectedCapacity, cv.Status.Capacity)
+			}
+			if test.expectedResizeErr != (cv.Status.LastResizeError != "") {
+				t.Fatalf("Test %q failed: expected resize error %t: got %q", name, test.expectedResizeErr, cv.Status.LastResizeError)
+			}
+			// a repeated failure does not change the status again
+			if test.expectedResizeErr && resizeTargetVolume(cv) {
+				t.Fatalf("Test %q failed: expected no change on repeated failure", name)
+			}
+		})
+	}
+}
@@ -66,6 +66,28 @@ func CreateVolumeTarget(cStorVolume *apis.CStorVolume) error {
 
 }
 
+// ResizeTargetVolume sends the resize command to istgt so that the
+// size of the LUN is updated to the capacity of the cstor volume.
+func ResizeTargetVolume(cStorVolume *apis.CStorVolume) error {
+	resizeCmd := util.IstgtResizeCmd + " " + cStorVolume.Name + " " + cStorVolume.Spec.Capacity
+	resp, err := UnixSockVar.SendCommand(resizeCmd)
+	if err != nil {
+		return errors.Wrapf(err, "failed to resize volume %s", cStorVolume.Name)
+	}
+	for _, line := range resp {
+		if strings.HasPrefix(line, "ERR") {
+			return errors.Errorf(
+				"failed to resize volume %s to %s: %s",
+				cStorVolume.Name,
+				cStorVolume.Spec.Capacity,
+				strings.TrimSpace(line),
+			)
+		}
+	}
+	glog.Infof("Resized volume %s to %s", cStorVolume.Name, cStorVolume.Spec.Capacity)
+	return nil
+}
+
 // GetVolumeStatus retrieves an array of replica statuses.
 func GetVolumeStatus(cStorVolume *apis.CStorVolume) (*apis.CVStatus, error) {
 	// send replica command to istgt and read the response
@@ -17,14 +17,15 @@ limitations under the License.
 package cstorvolumeclaim
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"strconv"
 	"time"
 
 	"github.com/golang/glog"
 	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
 	merrors "github.com/openebs/maya/pkg/errors/v1alpha1"
+	trace "github.com/openebs/maya/pkg/trace/v1alpha1"
 
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
@@ -94,8 +95,12 @@ func (c *CVCController) syncHandler(key string) error {
 	if err != nil {
 		return err
 	}
+	ctx, span := trace.Start(context.Background(), "cvc.sync", trace.String("cvc", key))
+	defer span.End()
+
 	cvcCopy := cvc.DeepCopy()
-	err = c.syncCVC(cvcCopy)
+	err = c.syncCVC(ctx, cvcCopy)
+	span.RecordError(err)
 	return err
 }
 
@@ -127,8 +132,11 @@ func (c *CVCController) enqueueCVC(obj interface{}) {
 }
 
 // synCVC is the function which tries to converge to a desired state for the
-// CStorVolumeClaims
-func (c *CVCController) syncCVC(cvc *apis.CStorVolumeClaim) error {
+// CStorVolumeClaims. The API calls of the sync are traced as children of
+// the span in the given context.
+func (c *CVCController) syncCVC(ctx context.Context, cvc *apis.CStorVolumeClaim) error {
+	c = c.withContext(ctx)
+
 	//	var newCVCLease Leaser
 	//	newCVCLease = &Lease{cvc, cvcLeaseKey, c.clientset, c.kubeclientset}
 	//	err := newCVCLease.Hold()
@@ -158,6 +166,13 @@ func (c *CVCController) syncCVC(cvc *apis.CStorVolumeClaim) error {
 	// where the app has been scheduled.
 	nodeID := cvc.Publish.NodeId
 	if nodeID == "" {
+		// replica migrations are requested independent of the volume
+		// being published, hence these are reconciled before waiting
+		// for the publish
+		err := c.migrateUnpublishedReplicas(cvc)
+		if err != nil {
+			return err
+		}
 		// We choose to absorb the error here as the worker would requeue the
 		// resource otherwise. Instead, the next time the resource is updated
 		// the resource will be queued again.
@@ -167,9 +182,10 @@ func (c *CVCController) syncCVC(cvc *apis.CStorVolumeClaim) error {
 
 	// Get the cstorvolume with the name specified, if not found create all the
 	// required resources
-	_, err := c.cvLister.CStorVolumes(cvc.Namespace).Get(volName)
+	cvObj, err := c.cvLister.CStorVolumes(cvc.Namespace).Get(volName)
 	if k8serror.IsNotFound(err) {
 		glog.Infof("create cstor based volume using cvc %+v", cvc)
+		cvObj = nil
 		_, err = c.createVolumeOperation(cvc)
 	}
 	// If an error occurs during Get/Create, we'll requeue the item so we can
@@ -185,13 +201,39 @@ func (c *CVCController) syncCVC(cvc *apis.CStorVolumeClaim) error {
 	if err != nil {
 		return err
 	}
-	if pending {
+	migrating := false
+	if pending && (cvObj == nil || cvc.Status.Phase != "Bound") {
 		glog.Infof("create remaining volume replica %+v", cvc)
 		_, err = c.createVolumeOperation(cvc)
+		if err != nil {
+			return err
+		}
+	} else if cvObj != nil {
+		// replicas are migrated between pools before the volume is scaled
+		// since a migration adds a replica to the volume till it completes
+		migrating, err = c.migrateReplicas(cvc, cvObj)
+		if err != nil {
+			return err
+		}
+		// add or remove replicas of a bound volume if the desired replica
+		// count on the cvc has been changed
+		if !migrating {
+			err = c.scaleCStorVolume(cvc, cvObj)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
-	if err != nil {
-		return err
+	// resize the cstorvolume if the capacity requested on the cvc has been
+	// increased, this is skipped while the volume or its replicas are being
+	// created, scaled or migrated
+	scaling := getCVCCondition(cvc, apis.CStorVolumeClaimReplicaScaling) != nil
+	if cvObj != nil && !pending && !scaling && !migrating {
+		err = c.resizeCStorVolume(cvc, cvObj)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Finally, we update the status block of the CVC resource to reflect the
@@ -233,25 +275,25 @@ func (c *CVCController) updateCVCObj(
 func (c *CVCController) createVolumeOperation(cvc *apis.CStorVolumeClaim) (*apis.CStorVolumeClaim, error) {
 
 	scName := cvc.Annotations[string(apis.StorageConfigClassKey)]
-	scObj, err := getStorageClass(scName)
+	scObj, err := c.getStorageClass(scName)
 	if err != nil {
 		return nil, err
 	}
 
 	glog.V(2).Infof("creating cstorvolume service resource")
-	svcObj, err := getOrCreateTargetService(scName, cvc)
+	svcObj, err := c.getOrCreateTargetService(scName, cvc)
 	if err != nil {
 		return nil, err
 	}
 
 	glog.V(2).Infof("creating cstorvolume resource")
-	cvObj, err := getOrCreateCStorVolumeResource(svcObj, cvc, scObj)
+	cvObj, err := c.getOrCreateCStorVolumeResource(svcObj, cvc, scObj)
 	if err != nil {
 		return nil, err
 	}
 
 	glog.V(2).Infof("creating cstorvolume target deployment")
-	_, err = getOrCreateCStorTargetDeployment(cvObj)
+	_, err = c.getOrCreateCStorTargetDeployment(cvObj)
 	if err != nil {
 		return nil, err
 	}
@@ -268,6 +310,7 @@ func (c *CVCController) createVolumeOperation(cvc *apis.CStorVolumeClaim) (*apis
 	}
 	cvc.Spec.CStorVolumeRef = volumeRef
 	cvc.Status.Phase = "Bound"
+	cvc.Status.Capacity = cvc.Spec.Capacity
 
 	err = c.updateCVCObj(cvc, cvObj)
 	if err != nil {
@@ -289,7 +332,7 @@ func (c *CVCController) distributePendingCVRs(
 	if err != nil {
 		return err
 	}
-	err = distributeCVRs(desiredReplicaCount, service, cv, class)
+	err = c.distributeCVRs(desiredReplicaCount, service, cv, class)
 	if err != nil {
 		return err
 	}
@@ -344,7 +387,7 @@ func (c *CVCController) getPendingCVRCount(
 	class *storagev1.StorageClass,
 ) (int, error) {
 
-	desiredReplicaCount, err := getReplicationFactor(class)
+	desiredReplicaCount, err := getDesiredReplicaCount(cvc, class)
 	if err != nil {
 		return 0, err
 	}
@@ -380,8 +423,7 @@ func (c *CVCController) getCurrentReplicaCount(cvc *apis.CStorVolumeClaim) (int,
 // IsCVRPending look for pending cstorvolume replicas compared to desired
 // replica count. returns true if count doesn't matches.
 func (c *CVCController) IsCVRPending(cvc *apis.CStorVolumeClaim) (bool, error) {
-	rCount := cvc.Annotations["openebs.io/replicaCount"]
-	desiredReplicaCount, err := strconv.Atoi(rCount)
+	desiredReplicaCount, err := getDesiredReplicaCount(cvc, nil)
 	if err != nil {
 		return false, err
 	}
@@ -391,14 +433,19 @@ func (c *CVCController) IsCVRPending(cvc *apis.CStorVolumeClaim) (bool, error) {
 	if err != nil {
 		return false, merrors.Errorf("failed to list cvr : %v", err)
 	}
-	// TODO: check for greater values
-	return desiredReplicaCount != len(CVRs), nil
+	current := 0
+	for _, cvrObj := range CVRs {
+		if cvrObj.DeletionTimestamp == nil {
+			current++
+		}
+	}
+	return desiredReplicaCount != current, nil
 }
 
 // BaseLabels returns the base labels we apply to cstorvolumereplicas created
 func BaseLabels(cvc *apis.CStorVolumeClaim) map[string]string {
 	base := map[string]string{
-		pvAnnotaion: cvc.Name,
+		persistentVolumeLabel: cvc.Name,
 	}
 	return base
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package cstorvolumeclaim
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -27,12 +28,14 @@ import (
 	informers "github.com/openebs/maya/pkg/client/generated/informers/externalversions"
 	listers "github.com/openebs/maya/pkg/client/generated/listers/openebs.io/v1alpha1"
 	ndmclientset "github.com/openebs/maya/pkg/client/generated/openebs.io/ndm/v1alpha1/clientset/internalclientset"
+	trace "github.com/openebs/maya/pkg/trace/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
@@ -42,6 +45,10 @@ const controllerAgentName = "cstorvolumeclaim-controller"
 
 // CVCController is the controller implementation for CVC resources
 type CVCController struct {
+	// config is the kubernetes config the clientsets are built
+	// from; it builds the clientsets of traced syncs
+	config *rest.Config
+
 	// kubeclientset is a standard kubernetes clientset
 	kubeclientset kubernetes.Interface
 
@@ -58,6 +65,9 @@ type CVCController struct {
 
 	cvrSynced cache.InformerSynced
 	cspLister listers.CStorPoolLister
+
+	crmLister listers.CStorReplicaMigrationLister
+	crmSynced cache.InformerSynced
 	// cvcSynced is used for caches sync to get populated
 	cvcSynced cache.InformerSynced
 
@@ -91,6 +101,12 @@ func NewCVCControllerBuilder() *CVCControllerBuilder {
 	}
 }
 
+// withConfig fills kubernetes config to controller object.
+func (cb *CVCControllerBuilder) withConfig(cfg *rest.Config) *CVCControllerBuilder {
+	cb.CVCController.config = cfg
+	return cb
+}
+
 // withKubeClient fills kube client to controller object.
 func (cb *CVCControllerBuilder) withKubeClient(ks kubernetes.Interface) *CVCControllerBuilder {
 	cb.CVCController.kubeclientset = ks
@@ -144,6 +160,14 @@ func (cb *CVCControllerBuilder) withCSPLister(sl informers.SharedInformerFactory
 	return cb
 }
 
+// withCRMLister fills replica migration lister to controller object.
+func (cb *CVCControllerBuilder) withCRMLister(sl informers.SharedInformerFactory) *CVCControllerBuilder {
+	crmInformer := sl.Openebs().V1alpha1().CStorReplicaMigrations()
+	cb.CVCController.crmLister = crmInformer.Lister()
+	cb.CVCController.crmSynced = crmInformer.Informer().HasSynced
+	return cb
+}
+
 // withCVCLister returns a Store implemented simply with a map and a lock.
 func (cb *CVCControllerBuilder) withCVCStore() *CVCControllerBuilder {
 	cb.CVCController.cvcStore = cache.NewStore(cache.DeletionHandlingMetaNamespaceKeyFunc)
@@ -183,6 +207,15 @@ func (cb *CVCControllerBuilder) withEventHandler(cvcInformerFactory informers.Sh
 		UpdateFunc: cb.CVCController.updateCVC,
 		DeleteFunc: cb.CVCController.deleteCVC,
 	})
+	crmInformer := cvcInformerFactory.Openebs().V1alpha1().CStorReplicaMigrations()
+	// Set up an event handler to sync the CVC when its replica migrations
+	// are created or changed
+	crmInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: cb.CVCController.enqueueCRM,
+		UpdateFunc: func(oldCRM, newCRM interface{}) {
+			cb.CVCController.enqueueCRM(newCRM)
+		},
+	})
 	return cb
 }
 
@@ -236,6 +269,20 @@ func (c *CVCController) deleteCVC(obj interface{}) {
 	c.enqueueCVC(cvc)
 }
 
+// enqueueCRM queues the CVC of the volume whose replica is being migrated
+func (c *CVCController) enqueueCRM(obj interface{}) {
+	crm, ok := obj.(*apis.CStorReplicaMigration)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("Couldn't get replica migration object %#v", obj))
+		return
+	}
+	if crm.IsDone() {
+		return
+	}
+	glog.V(4).Infof("Queuing CVC %s for replica migration %s", crm.Spec.VolumeName, crm.Name)
+	c.workqueue.Add(crm.Namespace + "/" + crm.Spec.VolumeName)
+}
+
 // Run will set up the event handlers for types we are interested in, as well
 // as syncing informer caches and starting workers. It will block until stopCh
 // is closed, at which point it will shutdown the workqueue and wait for
@@ -249,7 +296,7 @@ func (c *CVCController) Run(threadiness int, stopCh <-chan struct{}) error {
 
 	// Wait for the k8s caches to be synced before starting workers
 	glog.Info("Waiting for informer caches to sync")
-	if ok := cache.WaitForCacheSync(stopCh, c.cvcSynced); !ok {
+	if ok := cache.WaitForCacheSync(stopCh, c.cvcSynced, c.crmSynced); !ok {
 		return fmt.Errorf("failed to wait for caches to sync")
 	}
 	glog.Info("Starting CVC workers")
@@ -328,3 +375,30 @@ func (c *CVCController) processNextWorkItem() bool {
 
 	return true
 }
+
+// withContext returns a copy of the controller whose API calls are
+// traced as children of the span in the given context. The controller
+// is returned as is if tracing is not enabled.
+func (c *CVCController) withContext(ctx context.Context) *CVCController {
+	if c.config == nil || !trace.Enabled() || !trace.SpanContextFromContext(ctx).IsValid() {
+		return c
+	}
+	config := rest.CopyConfig(c.config)
+	config.WrapTransport = trace.WrapTransport(ctx)
+
+	traced := *c
+	var err error
+	if traced.kubeclientset, err = kubernetes.NewForConfig(config); err != nil {
+		glog.Warningf("failed to trace cvc controller clients: %v", err)
+		return c
+	}
+	if traced.clientset, err = clientset.NewForConfig(config); err != nil {
+		glog.Warningf("failed to trace cvc controller clients: %v", err)
+		return c
+	}
+	if traced.ndmclientset, err = ndmclientset.NewForConfig(config); err != nil {
+		glog.Warningf("failed to trace cvc controller clients: %v", err)
+		return c
+	}
+	return &traced
+}
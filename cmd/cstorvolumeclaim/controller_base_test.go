@@ -0,0 +1,92 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cstorvolumeclaim
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	trace "github.com/openebs/maya/pkg/trace/v1alpha1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+type fakeExporter struct{}
+
+func (fakeExporter) Export(service string, spans []trace.SpanData) error { return nil }
+
+func TestWithContext(t *testing.T) {
+	tests := map[string]struct {
+		isTracingEnabled bool
+		isTraced         bool
+	}{
+		"api calls are not traced if tracing is disabled": {},
+		"api calls are traced as children of sync span": {
+			isTracingEnabled: true,
+			isTraced:         true,
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			var (
+				mu     sync.Mutex
+				parent string
+			)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				parent = r.Header.Get(trace.TraceParentHeader)
+				mu.Unlock()
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"kind":"StorageClass","apiVersion":"storage.k8s.io/v1","metadata":{"name":"sc-1"}}`))
+			}))
+			defer server.Close()
+
+			config := &rest.Config{Host: server.URL}
+			ks, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				t.Fatalf("Test %q failed: unexpected error '%v'", name, err)
+			}
+			c := &CVCController{config: config, kubeclientset: ks}
+			if mock.isTracingEnabled {
+				shutdown := trace.InitWithExporter("test", fakeExporter{})
+				defer shutdown()
+			}
+			ctx, span := trace.Start(context.Background(), "cvc.sync")
+			defer span.End()
+
+			_, err = c.withContext(ctx).getStorageClass("sc-1")
+			if err != nil {
+				t.Fatalf("Test %q failed: unexpected error '%v'", name, err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if mock.isTraced != (parent != "") {
+				t.Fatalf("Test %q failed: expected traced '%t': actual traceparent '%s'", name, mock.isTraced, parent)
+			}
+			if mock.isTraced {
+				sc, err := trace.ParseTraceParent(parent)
+				if err != nil || sc.TraceID != span.Context().TraceID {
+					t.Fatalf("Test %q failed: expected trace of sync span: actual traceparent '%s'", name, parent)
+				}
+			}
+		})
+	}
+}
@@ -17,20 +17,16 @@ limitations under the License.
 package cstorvolumeclaim
 
 import (
-	"math/rand"
 	"strconv"
-	"time"
 
 	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
 	menv "github.com/openebs/maya/pkg/env/v1alpha1"
 	"github.com/openebs/maya/pkg/version"
 
-	csp "github.com/openebs/maya/pkg/cstor/pool/v1alpha3"
 	cv "github.com/openebs/maya/pkg/cstor/volume/v1alpha1"
 	cvr "github.com/openebs/maya/pkg/cstor/volumereplica/v1alpha1"
 	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
 	svc "github.com/openebs/maya/pkg/kubernetes/service/v1alpha1"
-	sc "github.com/openebs/maya/pkg/kubernetes/storageclass/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	k8serror "k8s.io/apimachinery/pkg/api/errors"
@@ -175,13 +171,14 @@ func getNamespace() string {
 
 // getStorageClass return storageclass object for a given storageClass Name.
 // or error if any.
-func getStorageClass(
+func (c *CVCController) getStorageClass(
 	scName string,
 ) (*storagev1.StorageClass, error) {
 	if scName == "" {
 		return nil, errors.New("failed to get storageclass: name missing")
 	}
-	scObj, err := sc.NewKubeClient().Get(scName, metav1.GetOptions{})
+	scObj, err := c.kubeclientset.StorageV1().StorageClasses().
+		Get(scName, metav1.GetOptions{})
 	if err != nil {
 		return nil, errors.Wrapf(
 			err,
@@ -216,9 +213,36 @@ func getSPC(
 	return spcName
 }
 
+// getCSPC gets cstorPoolCluster from
+// storageclass parameter
+func getCSPC(
+	sc *storagev1.StorageClass,
+) string {
+
+	cspcName := sc.Parameters["cstorPoolCluster"]
+	return cspcName
+}
+
+// listPools returns the pools to place the replicas of a volume of the
+// given storageclass i.e. the cstor pools of its storagePoolClaim or
+// the pool instances of its cstorPoolCluster.
+func (c *CVCController) listPools(
+	class *storagev1.StorageClass,
+	replicaCount int,
+) (*apis.CStorPoolList, error) {
+
+	if spcName := getSPC(class); spcName != "" {
+		return c.listCStorPools(spcName, replicaCount)
+	}
+	if cspcName := getCSPC(class); cspcName != "" {
+		return c.listPoolClusterPools(cspcName, replicaCount)
+	}
+	return nil, errors.New("failed to get spc or cspc name from storageClass")
+}
+
 // listCStorPools get the list of available pool using the storagePoolClaim
 // as labelSelector.
-func listCStorPools(
+func (c *CVCController) listCStorPools(
 	spcName string,
 	replicaCount int,
 ) (*apis.CStorPoolList, error) {
@@ -229,9 +253,10 @@ func listCStorPools(
 
 	labelSelector := spcAnnotation + spcName
 
-	cstorPoolList, err := csp.KubeClient().List(metav1.ListOptions{
-		LabelSelector: labelSelector,
-	})
+	cstorPoolList, err := c.clientset.OpenebsV1alpha1().CStorPools().
+		List(metav1.ListOptions{
+			LabelSelector: labelSelector,
+		})
 	if err != nil {
 		return nil, errors.Wrapf(
 			err,
@@ -245,12 +270,50 @@ func listCStorPools(
 	return cstorPoolList, nil
 }
 
+// listPoolClusterPools gets the list of pool instances of the given
+// cstorPoolCluster. Pool instances are returned as cstor pools with the
+// pool config of the instance so that they are placed alike.
+func (c *CVCController) listPoolClusterPools(
+	cspcName string,
+	replicaCount int,
+) (*apis.CStorPoolList, error) {
+
+	cspList, err := c.clientset.OpenebsV1alpha1().NewTestCStorPools(getNamespace()).
+		List(metav1.ListOptions{
+			LabelSelector: string(apis.CStorPoolClusterCPK) + "=" + cspcName,
+		})
+	if err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"failed to list cstorpool for cspc {%s}",
+			cspcName,
+		)
+	}
+	if len(cspList.Items) < replicaCount {
+		return nil, errors.New("not enough pools available to create replicas")
+	}
+	cstorPoolList := &apis.CStorPoolList{}
+	for _, csp := range cspList.Items {
+		cstorPoolList.Items = append(cstorPoolList.Items, apis.CStorPool{
+			ObjectMeta: csp.ObjectMeta,
+			Spec: apis.CStorPoolSpec{
+				PoolSpec: apis.CStorPoolAttr{
+					OverProvisioning: csp.Spec.PoolConfig.OverProvisioning,
+					OverCommitRatio:  csp.Spec.PoolConfig.OverCommitRatio,
+				},
+			},
+			Status: csp.Status,
+		})
+	}
+	return cstorPoolList, nil
+}
+
 // getOrCreateTargetService creates cstor volume target service
-func getOrCreateTargetService(storageClassName string,
+func (c *CVCController) getOrCreateTargetService(storageClassName string,
 	claim *apis.CStorVolumeClaim,
 ) (*corev1.Service, error) {
 
-	svcObj, err := svc.NewKubeClient(svc.WithNamespace(getNamespace())).
+	svcObj, err := c.kubeclientset.CoreV1().Services(getNamespace()).
 		Get(claim.Name, metav1.GetOptions{})
 
 	if err == nil {
@@ -282,19 +345,18 @@ func getOrCreateTargetService(storageClassName string,
 		)
 	}
 
-	svcObj, err = svc.NewKubeClient(svc.WithNamespace(getNamespace())).Create(svcObj)
-	return svcObj, err
+	return c.kubeclientset.CoreV1().Services(getNamespace()).Create(svcObj)
 }
 
 // getOrCreateCStorVolumeResource creates CStorVolume resource for a cstor volume
-func getOrCreateCStorVolumeResource(
+func (c *CVCController) getOrCreateCStorVolumeResource(
 	service *corev1.Service,
 	claim *apis.CStorVolumeClaim,
 	class *storagev1.StorageClass,
 ) (*apis.CStorVolume, error) {
 
 	qCap := claim.Spec.Capacity[corev1.ResourceStorage]
-	rfactor, err := getReplicationFactor(class)
+	rfactor, err := getDesiredReplicaCount(claim, class)
 	if err != nil {
 		return nil, errors.Wrapf(
 			err,
@@ -303,9 +365,9 @@ func getOrCreateCStorVolumeResource(
 		)
 	}
 
-	cfactor := rfactor/2 + 1
+	cfactor := getConsistencyFactor(rfactor)
 
-	cvObj, err := cv.NewKubeclient(cv.WithNamespace(getNamespace())).
+	cvObj, err := c.clientset.OpenebsV1alpha1().CStorVolumes(getNamespace()).
 		Get(claim.Name, metav1.GetOptions{})
 	if err != nil && !k8serror.IsNotFound(err) {
 		return nil, errors.Wrapf(
@@ -335,57 +397,113 @@ func getOrCreateCStorVolumeResource(
 				cvObj,
 			)
 		}
-		return cv.NewKubeclient(cv.WithNamespace(getNamespace())).Create(cvObj)
+		return c.clientset.OpenebsV1alpha1().CStorVolumes(getNamespace()).Create(cvObj)
 	}
 	return cvObj, err
 }
 
 // distributeCVRs create cstorvolume replica based on the replicaCount
-// on the available cstor pools created for storagepoolclaim.
+// on the available cstor pools created for storagepoolclaim or
+// cstorpoolcluster.
+// Pools are selected as per the placement policy of the storageclass.
 // if pools are less then desired replicaCount its return an error.
-func distributeCVRs(
+func (c *CVCController) distributeCVRs(
 	replicaCount int,
 	service *corev1.Service,
 	volume *apis.CStorVolume,
 	class *storagev1.StorageClass,
 ) error {
 
-	spcName := getSPC(class)
-	if len(spcName) == 0 {
-		return errors.New("failed to get spc name from storageClass")
+	policy, err := getPlacementPolicy(class)
+	if err != nil {
+		return err
+	}
+
+	poolList, err := c.listPools(class, replicaCount)
+	if err != nil {
+		return err
+	}
+
+	// replicas of all the volumes are listed to score the
+	// pools by the number of replicas placed on them
+	cvrList, err := c.clientset.OpenebsV1alpha1().CStorVolumeReplicas(getNamespace()).
+		List(metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrapf(
+			err,
+			"failed to list cstorvolumereplicas for volume {%s}",
+			volume.Name,
+		)
 	}
 
-	poolList, err := listCStorPools(spcName, replicaCount)
+	topology, err := c.getTopologyFn(policy.topologyKey)
 	if err != nil {
 		return err
 	}
 
-	usablePoolList := getUsablePoolList(volume.Name, poolList)
+	capacity := parsePoolCapacity(volume.Spec.Capacity)
+	pools, err := newPlacement(policy, volume.Name, capacity, poolList, cvrList, topology).
+		selectPools(replicaCount)
+	if err != nil {
+		return errors.Wrapf(
+			err,
+			"failed to place replicas of volume {%s}",
+			volume.Name,
+		)
+	}
 
-	// randomizePoolList to get the pool list in random order
-	usablePoolList = randomizePoolList(usablePoolList)
-	for i, pool := range usablePoolList.Items {
+	for _, pool := range pools {
 		pool := pool
-		if i < replicaCount {
-			_, err = creatCVR(service, volume, &pool)
-			if err != nil {
-				return err
-			}
+		_, err = c.creatCVR(service, volume, &pool)
+		if err != nil {
+			return err
 		}
 	}
-	return err
+	return nil
+}
+
+// getCVRName returns the name of the cstorvolumereplica of the volume on
+// the given cstor pool
+func getCVRName(volume *apis.CStorVolume, pool *apis.CStorPool) string {
+	return volume.Name + "-" + pool.Name
+}
+
+// buildCVR builds the cstorvolumereplica of the volume on the given cstor
+// pool that connects to the target at the given ip
+func buildCVR(
+	targetIP string,
+	volume *apis.CStorVolume,
+	pool *apis.CStorPool,
+) (*apis.CStorVolumeReplica, error) {
+	cvrObj, err := cvr.NewBuilder().
+		WithName(getCVRName(volume, pool)).
+		WithLabelsNew(getCVRLabels(pool, volume.Name)).
+		WithAnnotationsNew(getCVRAnnotations(pool)).
+		WithOwnerRefernceNew(getCVROwnerReference(volume)).
+		WithFinalizers(getCVRFinalizer()).
+		WithTargetIP(targetIP).
+		WithCapacity(volume.Spec.Capacity).
+		Build()
+	if err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"failed to build cstorvolumereplica {%v}",
+			getCVRName(volume, pool),
+		)
+	}
+	return cvrObj, nil
 }
 
 // createCVR is actual method to create cstorvolumereplica resource on a given
 // cstor pool
-func creatCVR(
+func (c *CVCController) creatCVR(
 	service *corev1.Service,
 	volume *apis.CStorVolume,
 	pool *apis.CStorPool,
 ) (*apis.CStorVolumeReplica, error) {
 
-	cvrObj, err := cvr.NewKubeclient(cvr.WithNamespace(getNamespace())).
-		Get(volume.Name+"-"+pool.Name, metav1.GetOptions{})
+	cvrObj, err := c.clientset.OpenebsV1alpha1().CStorVolumeReplicas(getNamespace()).
+		Get(getCVRName(volume, pool), metav1.GetOptions{})
 
 	if err != nil && !k8serror.IsNotFound(err) {
 		return nil, errors.Wrapf(
@@ -395,23 +513,11 @@ func creatCVR(
 		)
 	}
 	if k8serror.IsNotFound(err) {
-		cvrObj, err = cvr.NewBuilder().
-			WithName(volume.Name + "-" + pool.Name).
-			WithLabelsNew(getCVRLabels(pool, volume.Name)).
-			WithAnnotationsNew(getCVRAnnotations(pool)).
-			WithOwnerRefernceNew(getCVROwnerReference(volume)).
-			WithFinalizers(getCVRFinalizer()).
-			WithTargetIP(service.Spec.ClusterIP).
-			WithCapacity(volume.Spec.Capacity).
-			Build()
+		cvrObj, err = buildCVR(service.Spec.ClusterIP, volume, pool)
 		if err != nil {
-			return nil, errors.Wrapf(
-				err,
-				"failed to build cstorvolumereplica {%v}",
-				cvrObj.Name,
-			)
+			return nil, err
 		}
-		cvrObj, err = cvr.NewKubeclient(cvr.WithNamespace(getNamespace())).Create(cvrObj)
+		cvrObj, err = c.clientset.OpenebsV1alpha1().CStorVolumeReplicas(getNamespace()).Create(cvrObj)
 		if err != nil {
 			return nil, errors.Wrapf(
 				err,
@@ -423,53 +529,3 @@ func creatCVR(
 	}
 	return cvrObj, nil
 }
-
-// GetUsedPoolNames returns a list of cstor pool
-// name corresponding to cstor volume replica
-// instances
-func getUsedPoolNames(cvrList *apis.CStorVolumeReplicaList) map[string]bool {
-	var usedPoolMap = make(map[string]bool)
-	for _, cvr := range cvrList.Items {
-		poolName := cvr.GetLabels()[string(cstorpoolNameLabel)]
-		if poolName != "" {
-			usedPoolMap[poolName] = true
-		}
-	}
-	return usedPoolMap
-}
-
-// GetUsablePoolList returns a list of usable cstorpools
-// which hasn't been used to create cstor volume replica
-// instances
-func getUsablePoolList(pvName string, poolList *apis.CStorPoolList) *apis.CStorPoolList {
-	usablePoolList := &apis.CStorPoolList{}
-
-	pvLabel := pvAnnotaion + pvName
-	cvrList, err := cvr.NewKubeclient(cvr.WithNamespace(getNamespace())).List(metav1.ListOptions{
-		LabelSelector: pvLabel,
-	})
-	if err != nil {
-		return nil
-	}
-
-	usedPoolMap := getUsedPoolNames(cvrList)
-	for _, pool := range poolList.Items {
-		if !usedPoolMap[pool.Name] {
-			usablePoolList.Items = append(usablePoolList.Items, pool)
-		}
-	}
-	return usablePoolList
-}
-
-// randomizePoolList returns randomized pool list
-func randomizePoolList(list *apis.CStorPoolList) *apis.CStorPoolList {
-	res := &apis.CStorPoolList{}
-
-	r := rand.New(rand.NewSource(time.Now().Unix()))
-	perm := r.Perm(len(list.Items))
-	for _, randomIdx := range perm {
-		res.Items = append(res.Items, list.Items[randomIdx])
-	}
-
-	return res
-}
@@ -277,12 +277,12 @@ func getContainerPort(port int32) []corev1.ContainerPort {
 
 // getOrCreateCStorTargetDeployment get or create the cstor target deployment
 // for a given cstorvolume.
-func getOrCreateCStorTargetDeployment(
+func (c *CVCController) getOrCreateCStorTargetDeployment(
 	vol *apis.CStorVolume,
 ) (*appsv1.Deployment, error) {
 
-	deployObj, err := deploy.NewKubeClient(deploy.WithNamespace("openebs")).
-		Get(vol.Name + "-target")
+	deployObj, err := c.kubeclientset.AppsV1().Deployments("openebs").
+		Get(vol.Name+"-target", metav1.GetOptions{})
 
 	if err != nil && !k8serror.IsNotFound(err) {
 		return nil, errors.Wrapf(
@@ -357,7 +357,7 @@ func getOrCreateCStorTargetDeployment(
 			return nil, errors.Wrapf(err, "failed to build deployment object")
 		}
 
-		deployObj, err = deploy.NewKubeClient(deploy.WithNamespace("openebs")).Create(deployObj)
+		deployObj, err = c.kubeclientset.AppsV1().Deployments("openebs").Create(deployObj)
 
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to create deployment object")
@@ -0,0 +1,343 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cstorvolumeclaim
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	merrors "github.com/openebs/maya/pkg/errors/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// ResizeStarted is the condition reason when resize of the
+	// cstor volume has been detected
+	ResizeStarted = "ResizeStarted"
+	// ReplicaResizeInProgress is the condition reason when the
+	// cstor volume replicas are being resized
+	ReplicaResizeInProgress = "ReplicaResizeInProgress"
+	// TargetResizeInProgress is the condition reason when the
+	// cstor volume target is being resized
+	TargetResizeInProgress = "TargetResizeInProgress"
+	// ResizeSuccess is the condition reason when the cstor volume
+	// replicas and target have been resized
+	ResizeSuccess = "ResizeSuccess"
+	// ResizeFailed is the event and condition reason when resize of
+	// the cstor volume can not be completed
+	ResizeFailed = "ResizeFailed"
+)
+
+// resizeCStorVolume expands the cstor volume replicas and the target when
+// the capacity requested on the cvc is more than the capacity of the
+// cstor volume. Each step of the resize is recorded as a condition on the
+// cvc and the steps are retried on every sync of the cvc:
+// 1. Resizing condition is added and capacity on all the cvrs is updated.
+//    cstor-pool-mgmt then resizes the zfs volume of the cvr.
+// 2. Once all the cvrs report the new size, capacity of the cstorvolume
+//    is updated. cstor-volume-mgmt then resizes the LUN of the target and
+//    reports the resized capacity or the error in the cstorvolume status.
+// 3. If the target could not be resized, VolumeResizeFailed condition is
+//    added and the resize is retried by cstor-volume-mgmt.
+// 4. Once the target is resized, Resizing condition is replaced with
+//    VolumeResizeSuccessful and FileSystemResizePending conditions and the
+//    status capacity of the cvc is updated, after which the filesystem can
+//    be expanded on the node.
+func (c *CVCController) resizeCStorVolume(
+	cvc *apis.CStorVolumeClaim,
+	cvObj *apis.CStorVolume,
+) error {
+	desiredCap := cvc.Spec.Capacity[corev1.ResourceStorage]
+	cvCap, err := resource.ParseQuantity(cvObj.Spec.Capacity)
+	if err != nil {
+		return merrors.Wrapf(
+			err,
+			"failed to resize cstorvolume {%s}: invalid capacity",
+			cvObj.Name,
+		)
+	}
+
+	switch desiredCap.Cmp(cvCap) {
+	case -1:
+		return c.markResizeFailed(cvc,
+			fmt.Sprintf("can not shrink volume from %s to %s", cvCap.String(), desiredCap.String()),
+		)
+	case 0:
+		if getCVCCondition(cvc, apis.CStorVolumeClaimResizing) == nil {
+			return c.markResizeComplete(cvc, desiredCap)
+		}
+		return c.syncTargetResize(cvc, cvObj, desiredCap)
+	}
+
+	if getCVCCondition(cvc, apis.CStorVolumeClaimResizing) == nil {
+		glog.Infof("resize of cstorvolume {%s} from %s to %s started",
+			cvObj.Name, cvCap.String(), desiredCap.String())
+		removeCVCCondition(cvc, apis.CStorVolumeClaimResizeFailed)
+		removeCVCCondition(cvc, apis.CStorVolumeClaimResizeSuccess)
+		setCVCCondition(cvc, apis.CStorVolumeClaimResizing, ResizeStarted,
+			fmt.Sprintf("resizing volume from %s to %s", cvCap.String(), desiredCap.String()),
+		)
+		cvc, err = c.clientset.OpenebsV1alpha1().CStorVolumeClaims(cvc.Namespace).Update(cvc)
+		if err != nil {
+			return err
+		}
+	}
+
+	pending, err := c.resizeCVRs(cvc, desiredCap)
+	if err != nil {
+		c.recorder.Event(cvc, corev1.EventTypeWarning, ResizeFailed, err.Error())
+		return err
+	}
+	if pending != 0 {
+		// wait for the cvrs to be resized, cvc will be synced again
+		// on the next resync
+		return c.updateResizeCondition(cvc, ReplicaResizeInProgress,
+			fmt.Sprintf("waiting for %d replica(s) to be resized to %s", pending, desiredCap.String()),
+		)
+	}
+
+	cvCopy := cvObj.DeepCopy()
+	cvCopy.Spec.Capacity = desiredCap.String()
+	_, err = c.clientset.OpenebsV1alpha1().CStorVolumes(cvCopy.Namespace).Update(cvCopy)
+	if err != nil {
+		c.recorder.Event(cvc, corev1.EventTypeWarning, ResizeFailed, err.Error())
+		return merrors.Wrapf(
+			err,
+			"failed to update capacity of cstorvolume {%s}",
+			cvCopy.Name,
+		)
+	}
+	return c.updateResizeCondition(cvc, TargetResizeInProgress,
+		fmt.Sprintf("resizing target to %s", desiredCap.String()),
+	)
+}
+
+// resizeCVRs updates the capacity of the cvrs that belong to the cvc and
+// returns the number of cvrs which have not yet been resized by
+// cstor-pool-mgmt
+func (c *CVCController) resizeCVRs(
+	cvc *apis.CStorVolumeClaim,
+	desiredCap resource.Quantity,
+) (int, error) {
+	selector := klabels.SelectorFromSet(BaseLabels(cvc))
+	cvrs, err := c.cvrLister.CStorVolumeReplicas(cvc.Namespace).List(selector)
+	if err != nil {
+		return 0, merrors.Wrapf(err, "failed to list cvrs of cvc {%s}", cvc.Name)
+	}
+
+	pending := 0
+	for _, cvrObj := range cvrs {
+		cvrCap, err := resource.ParseQuantity(cvrObj.Spec.Capacity)
+		if err != nil || cvrCap.Cmp(desiredCap) < 0 {
+			cvrCopy := cvrObj.DeepCopy()
+			cvrCopy.Spec.Capacity = desiredCap.String()
+			_, err = c.clientset.OpenebsV1alpha1().
+				CStorVolumeReplicas(cvrCopy.Namespace).
+				Update(cvrCopy)
+			if err != nil {
+				return 0, merrors.Wrapf(
+					err,
+					"failed to update capacity of cvr {%s}",
+					cvrCopy.Name,
+				)
+			}
+			pending++
+			continue
+		}
+		if !isCVRResized(cvrObj, desiredCap) {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// isCVRResized returns true if the zfs volume size reported on the cvr is
+// at least the desired capacity
+func isCVRResized(cvrObj *apis.CStorVolumeReplica, desiredCap resource.Quantity) bool {
+	volSize, err := resource.ParseQuantity(cvrObj.Status.Capacity.VolSize)
+	if err != nil {
+		return false
+	}
+	return volSize.Cmp(desiredCap) >= 0
+}
+
+// syncTargetResize completes the resize of the cvc once cstor-volume-mgmt
+// reports that the target has been resized, or marks the resize as failed
+// if cstor-volume-mgmt reports an error
+func (c *CVCController) syncTargetResize(
+	cvc *apis.CStorVolumeClaim,
+	cvObj *apis.CStorVolume,
+	desiredCap resource.Quantity,
+) error {
+	if isTargetResized(cvObj, desiredCap) {
+		return c.markResizeComplete(cvc, desiredCap)
+	}
+	if cvObj.Status.LastResizeError != "" {
+		return c.markResizeFailed(cvc,
+			fmt.Sprintf("failed to resize target to %s: %s",
+				desiredCap.String(), cvObj.Status.LastResizeError),
+		)
+	}
+	return c.updateResizeCondition(cvc, TargetResizeInProgress,
+		fmt.Sprintf("resizing target to %s", desiredCap.String()),
+	)
+}
+
+// isTargetResized returns true if the capacity of the LUN reported in the
+// cstorvolume status is at least the desired capacity
+func isTargetResized(cvObj *apis.CStorVolume, desiredCap resource.Quantity) bool {
+	targetCap, err := resource.ParseQuantity(cvObj.Status.Capacity)
+	if err != nil {
+		return false
+	}
+	return targetCap.Cmp(desiredCap) >= 0
+}
+
+// markResizeFailed adds the resize failed condition with the given message
+// to the cvc. The resizing condition is retained, so that the resize is
+// completed if the failure is resolved.
+func (c *CVCController) markResizeFailed(
+	cvc *apis.CStorVolumeClaim,
+	message string,
+) error {
+	cond := getCVCCondition(cvc, apis.CStorVolumeClaimResizeFailed)
+	if cond != nil && cond.Message == message {
+		return nil
+	}
+	setCVCCondition(cvc, apis.CStorVolumeClaimResizeFailed, ResizeFailed, message)
+	_, err := c.clientset.OpenebsV1alpha1().CStorVolumeClaims(cvc.Namespace).Update(cvc)
+	if err != nil {
+		return err
+	}
+	c.recorder.Event(cvc, corev1.EventTypeWarning, ResizeFailed, message)
+	return nil
+}
+
+// markResizeComplete updates the status capacity of the cvc and replaces
+// the resizing condition with resize success and filesystem resize pending
+// once the target has been resized
+func (c *CVCController) markResizeComplete(
+	cvc *apis.CStorVolumeClaim,
+	capacity resource.Quantity,
+) error {
+	statusCap, found := cvc.Status.Capacity[corev1.ResourceStorage]
+	resizing := getCVCCondition(cvc, apis.CStorVolumeClaimResizing) != nil
+	if !resizing && found && statusCap.Cmp(capacity) == 0 {
+		return nil
+	}
+
+	cvc.Status.Capacity = corev1.ResourceList{
+		corev1.ResourceStorage: capacity,
+	}
+	if resizing {
+		removeCVCCondition(cvc, apis.CStorVolumeClaimResizing)
+		removeCVCCondition(cvc, apis.CStorVolumeClaimResizeFailed)
+		setCVCCondition(cvc, apis.CStorVolumeClaimResizeSuccess, ResizeSuccess,
+			fmt.Sprintf("volume resized to %s", capacity.String()),
+		)
+		setCVCCondition(cvc, apis.CStorVolumeClaimResizePending, ResizeSuccess,
+			fmt.Sprintf("volume resized to %s, waiting for filesystem resize", capacity.String()),
+		)
+	}
+	_, err := c.clientset.OpenebsV1alpha1().CStorVolumeClaims(cvc.Namespace).Update(cvc)
+	if err != nil {
+		return err
+	}
+	if resizing {
+		glog.Infof("resize of cstorvolume {%s} to %s completed", cvc.Name, capacity.String())
+		c.recorder.Event(cvc, corev1.EventTypeNormal, ResizeSuccess,
+			fmt.Sprintf("volume resized to %s", capacity.String()),
+		)
+	}
+	return nil
+}
+
+// updateResizeCondition updates the reason and message of the resizing
+// condition if it has changed
+func (c *CVCController) updateResizeCondition(
+	cvc *apis.CStorVolumeClaim,
+	reason, message string,
+) error {
+	cond := getCVCCondition(cvc, apis.CStorVolumeClaimResizing)
+	if cond != nil && cond.Reason == reason && cond.Message == message {
+		return nil
+	}
+	setCVCCondition(cvc, apis.CStorVolumeClaimResizing, reason, message)
+	_, err := c.clientset.OpenebsV1alpha1().CStorVolumeClaims(cvc.Namespace).Update(cvc)
+	return err
+}
+
+// getCVCCondition returns the condition of the given type if present on
+// the cvc
+func getCVCCondition(
+	cvc *apis.CStorVolumeClaim,
+	condType apis.CStorVolumeClaimConditionType,
+) *apis.CStorVolumeClaimCondition {
+	for i := range cvc.Status.Condition {
+		if cvc.Status.Condition[i].Type == condType {
+			return &cvc.Status.Condition[i]
+		}
+	}
+	return nil
+}
+
+// setCVCCondition adds or updates the condition of the given type on the
+// cvc
+func setCVCCondition(
+	cvc *apis.CStorVolumeClaim,
+	condType apis.CStorVolumeClaimConditionType,
+	reason, message string,
+) {
+	now := metav1.Now()
+	cond := getCVCCondition(cvc, condType)
+	if cond == nil {
+		cvc.Status.Condition = append(cvc.Status.Condition,
+			apis.CStorVolumeClaimCondition{
+				Type:               condType,
+				LastProbeTime:      now,
+				LastTransitionTime: now,
+				Reason:             reason,
+				Message:            message,
+			},
+		)
+		return
+	}
+	if cond.Reason != reason {
+		cond.LastTransitionTime = now
+	}
+	cond.LastProbeTime = now
+	cond.Reason = reason
+	cond.Message = message
+}
+
+// removeCVCCondition removes the condition of the given type from the cvc
+func removeCVCCondition(
+	cvc *apis.CStorVolumeClaim,
+	condType apis.CStorVolumeClaimConditionType,
+) {
+	var conditions []apis.CStorVolumeClaimCondition
+	for _, cond := range cvc.Status.Condition {
+		if cond.Type != condType {
+			conditions = append(conditions, cond)
+		}
+	}
+	cvc.Status.Condition = conditions
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cstorvolumeclaim
+
+import (
+	"testing"
+
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	openebsFakeClientset "github.com/openebs/maya/pkg/client/generated/clientset/versioned/fake"
+	listers "github.com/openebs/maya/pkg/client/generated/listers/openebs.io/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestResizeCStorVolume(t *testing.T) {
+	tests := map[string]struct {
+		cvCapacity         string
+		cvStatus           apis.CStorVolumeStatus
+		cvrs               []*apis.CStorVolumeReplica
+		resizing           bool
+		expectedCVCapacity string
+		expectedCVRCap     string
+		expectedReason     string
+		expectedConditions []apis.CStorVolumeClaimConditionType
+		expectedStatusCap  string
+	}{
+		"resize is started and replicas are resized": {
+			cvCapacity: "5G",
+			cvrs: []*apis.CStorVolumeReplica{
+				fakeCVR("pv-1", "pool-1", withCVRCapacity("5G", "5G")),
+				fakeCVR("pv-1", "pool-2", withCVRCapacity("5G", "5G")),
+			},
+			expectedCVCapacity: "5G",
+			expectedCVRCap:     "10G",
+			expectedReason:     ReplicaResizeInProgress,
+			expectedConditions: []apis.CStorVolumeClaimConditionType{apis.CStorVolumeClaimResizing},
+		},
+		"target is resized once replicas are resized": {
+			cvCapacity: "5G",
+			cvrs: []*apis.CStorVolumeReplica{
+				fakeCVR("pv-1", "pool-1", withCVRCapacity("10G", "10G")),
+				fakeCVR("pv-1", "pool-2", withCVRCapacity("10G", "10G")),
+			},
+			resizing:           true,
+			expectedCVCapacity: "10G",
+			expectedCVRCap:     "10G",
+			expectedReason:     TargetResizeInProgress,
+			expectedConditions: []apis.CStorVolumeClaimConditionType{apis.CStorVolumeClaimResizing},
+		},
+		"resize waits for the target to report the new capacity": {
+			cvCapacity:         "10G",
+			cvStatus:           apis.CStorVolumeStatus{Capacity: "5G"},
+			resizing:           true,
+			expectedCVCapacity: "10G",
+			expectedReason:     TargetResizeInProgress,
+			expectedConditions: []apis.CStorVolumeClaimConditionType{apis.CStorVolumeClaimResizing},
+		},
+		"resize fails if the target reports an error": {
+			cvCapacity:         "10G",
+			cvStatus:           apis.CStorVolumeStatus{Capacity: "5G", LastResizeError: "ERR resize"},
+			resizing:           true,
+			expectedCVCapacity: "10G",
+			expectedReason:     TargetResizeInProgress,
+			expectedConditions: []apis.CStorVolumeClaimConditionType{
+				apis.CStorVolumeClaimResizing,
+				apis.CStorVolumeClaimResizeFailed,
+			},
+		},
+		"resize succeeds once the target reports the new capacity": {
+			cvCapacity:         "10G",
+			cvStatus:           apis.CStorVolumeStatus{Capacity: "10G"},
+			resizing:           true,
+			expectedCVCapacity: "10G",
+			expectedConditions: []apis.CStorVolumeClaimConditionType{
+				apis.CStorVolumeClaimResizeSuccess,
+				apis.CStorVolumeClaimResizePending,
+			},
+			expectedStatusCap: "10G",
+		},
+		"shrinking the volume fails": {
+			cvCapacity:         "20G",
+			expectedCVCapacity: "20G",
+			expectedConditions: []apis.CStorVolumeClaimConditionType{apis.CStorVolumeClaimResizeFailed},
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			cvObj := &apis.CStorVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-1", Namespace: "openebs"},
+				Spec:       apis.CStorVolumeSpec{Capacity: mock.cvCapacity},
+				Status:     mock.cvStatus,
+			}
+			cvc := &apis.CStorVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-1", Namespace: "openebs"},
+				Spec: apis.CStorVolumeClaimSpec{
+					Capacity: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse("10G"),
+					},
+				},
+			}
+			if mock.resizing {
+				setCVCCondition(cvc, apis.CStorVolumeClaimResizing, TargetResizeInProgress, "resizing target to 10G")
+			}
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			objs := []runtime.Object{cvObj, cvc}
+			for _, cvrObj := range mock.cvrs {
+				objs = append(objs, cvrObj)
+				indexer.Add(cvrObj)
+			}
+			c := &CVCController{
+				clientset: openebsFakeClientset.NewSimpleClientset(objs...),
+				cvrLister: listers.NewCStorVolumeReplicaLister(indexer),
+				recorder:  record.NewFakeRecorder(10),
+			}
+
+			err := c.resizeCStorVolume(cvc.DeepCopy(), cvObj.DeepCopy())
+			if err != nil {
+				t.Fatalf("Test %q failed: expected no error: actual error '%v'", name, err)
+			}
+
+			gotCV, _ := c.clientset.OpenebsV1alpha1().CStorVolumes("openebs").Get("pv-1", metav1.GetOptions{})
+			if gotCV.Spec.Capacity != mock.expectedCVCapacity {
+				t.Fatalf("Test %q failed: expected cv capacity '%s': actual '%s'", name, mock.expectedCVCapacity, gotCV.Spec.Capacity)
+			}
+			for _, cvrObj := range mock.cvrs {
+				gotCVR, _ := c.clientset.OpenebsV1alpha1().CStorVolumeReplicas("openebs").Get(cvrObj.Name, metav1.GetOptions{})
+				if gotCVR.Spec.Capacity != mock.expectedCVRCap {
+					t.Fatalf("Test %q failed: expected cvr capacity '%s': actual '%s'", name, mock.expectedCVRCap, gotCVR.Spec.Capacity)
+				}
+			}
+			gotCVC, _ := c.clientset.OpenebsV1alpha1().CStorVolumeClaims("openebs").Get("pv-1", metav1.GetOptions{})
+			if len(gotCVC.Status.Condition) != len(mock.expectedConditions) {
+				t.Fatalf("Test %q failed: expected conditions %v: actual %v", name, mock.expectedConditions, gotCVC.Status.Condition)
+			}
+			for _, condType := range mock.expectedConditions {
+				if getCVCCondition(gotCVC, condType) == nil {
+					t.Fatalf("Test %q failed: expected condition '%s': actual %v", name, condType, gotCVC.Status.Condition)
+				}
+			}
+			if cond := getCVCCondition(gotCVC, apis.CStorVolumeClaimResizing); cond != nil && cond.Reason != mock.expectedReason {
+				t.Fatalf("Test %q failed: expected reason '%s': actual '%s'", name, mock.expectedReason, cond.Reason)
+			}
+			statusCap := gotCVC.Status.Capacity[corev1.ResourceStorage]
+			if mock.expectedStatusCap != "" && statusCap.String() != mock.expectedStatusCap {
+				t.Fatalf("Test %q failed: expected status capacity '%s': actual '%s'", name, mock.expectedStatusCap, statusCap.String())
+			}
+		})
+	}
+}
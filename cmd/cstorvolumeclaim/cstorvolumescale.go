@@ -0,0 +1,363 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cstorvolumeclaim
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/golang/glog"
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	merrors "github.com/openebs/maya/pkg/errors/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// replicaCountAnnotation is the annotation set on the cvc with the
+	// replica count of the volume at the time of provisioning
+	replicaCountAnnotation = "openebs.io/replicaCount"
+
+	// ScaleUpStarted is the condition reason when replicas are being
+	// added to the cstor volume
+	ScaleUpStarted = "ScaleUpStarted"
+	// ScaleDownStarted is the condition reason when replicas are being
+	// removed from the cstor volume
+	ScaleDownStarted = "ScaleDownStarted"
+	// ReplicaRebuildInProgress is the condition reason when the added
+	// replicas are being rebuilt from the existing replicas
+	ReplicaRebuildInProgress = "ReplicaRebuildInProgress"
+	// ScaleSuccess is the event reason when the cstor volume has been
+	// scaled to the desired replica count
+	ScaleSuccess = "ScaleSuccess"
+	// ScaleFailed is the event reason when the cstor volume can not be
+	// scaled to the desired replica count
+	ScaleFailed = "ScaleFailed"
+)
+
+// getDesiredReplicaCount returns the desired replica count of the volume.
+// The replica count on the cvc spec takes precedence over the replica count
+// annotation which in turn takes precedence over the storageclass.
+func getDesiredReplicaCount(
+	cvc *apis.CStorVolumeClaim,
+	class *storagev1.StorageClass,
+) (int, error) {
+	if cvc.Spec.ReplicaCount > 0 {
+		return cvc.Spec.ReplicaCount, nil
+	}
+	if count, ok := cvc.Annotations[replicaCountAnnotation]; ok {
+		desired, err := strconv.Atoi(count)
+		if err != nil {
+			return 0, merrors.Wrapf(
+				err,
+				"invalid replica count {%s} on cvc {%s}",
+				count,
+				cvc.Name,
+			)
+		}
+		return desired, nil
+	}
+	if class == nil {
+		return 0, merrors.Errorf("replica count not set on cvc {%s}", cvc.Name)
+	}
+	return getReplicationFactor(class)
+}
+
+// getConsistencyFactor returns the number of replicas that need to
+// acknowledge an io for the given replication factor
+func getConsistencyFactor(replicationFactor int) int {
+	return replicationFactor/2 + 1
+}
+
+// listCVRs returns the cvrs of the cvc which are not being deleted. The
+// cvrs are listed from the api server since the replicas created in the
+// previous sync might not yet be in the lister cache.
+func (c *CVCController) listCVRs(
+	cvc *apis.CStorVolumeClaim,
+) ([]apis.CStorVolumeReplica, error) {
+	cvrList, err := c.clientset.
+		OpenebsV1alpha1().
+		CStorVolumeReplicas(cvc.Namespace).
+		List(metav1.ListOptions{LabelSelector: persistentVolumeLabel + "=" + cvc.Name})
+	if err != nil {
+		return nil, merrors.Wrapf(err, "failed to list cvrs of cvc {%s}", cvc.Name)
+	}
+
+	var cvrs []apis.CStorVolumeReplica
+	for _, cvrObj := range cvrList.Items {
+		if cvrObj.DeletionTimestamp == nil {
+			cvrs = append(cvrs, cvrObj)
+		}
+	}
+	return cvrs, nil
+}
+
+// scaleCStorVolume adds or removes replicas of a bound cstor volume to
+// match the desired replica count of the cvc. Progress is recorded as the
+// ReplicaScaling condition on the cvc and the steps are retried on every
+// sync of the cvc:
+// 1. Scale up raises the replication factor of the cstorvolume and
+//    creates the new cvrs. cstor-volume-mgmt reconfigures the target with
+//    the new replication factor.
+// 2. Once the new cvrs have been rebuilt and all cvrs are healthy, the
+//    consistency factor of the cstorvolume is raised.
+// 3. Scale down lowers both the factors of the cstorvolume before the
+//    cvrs are deleted, which is only done if the remaining healthy cvrs
+//    can still form a quorum.
+func (c *CVCController) scaleCStorVolume(
+	cvc *apis.CStorVolumeClaim,
+	cvObj *apis.CStorVolume,
+) error {
+	desired, err := getDesiredReplicaCount(cvc, nil)
+	if err != nil {
+		return err
+	}
+
+	cvrs, err := c.listCVRs(cvc)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case desired > len(cvrs):
+		return c.scaleUpCStorVolume(cvc, cvObj, desired, len(cvrs))
+	case desired < len(cvrs):
+		return c.scaleDownCStorVolume(cvc, cvObj, desired, cvrs)
+	}
+	return c.markScaleComplete(cvc, cvObj, desired, cvrs)
+}
+
+// scaleUpCStorVolume raises the replication factor of the cstorvolume and
+// creates the missing cvrs
+func (c *CVCController) scaleUpCStorVolume(
+	cvc *apis.CStorVolumeClaim,
+	cvObj *apis.CStorVolume,
+	desired, current int,
+) error {
+	err := c.updateScaleCondition(cvc, ScaleUpStarted,
+		fmt.Sprintf("adding %d replica(s) to scale from %d to %d", desired-current, current, desired),
+	)
+	if err != nil {
+		return err
+	}
+
+	// consistency factor is left as is until the new replicas are
+	// healthy, else io would wait on the replicas being rebuilt
+	if cvObj.Spec.ReplicationFactor != desired {
+		err = c.updateCVFactors(cvObj, desired, cvObj.Spec.ConsistencyFactor)
+		if err != nil {
+			c.recorder.Event(cvc, corev1.EventTypeWarning, ScaleFailed, err.Error())
+			return err
+		}
+	}
+
+	scName := cvc.Annotations[string(apis.StorageConfigClassKey)]
+	class, err := c.getStorageClass(scName)
+	if err != nil {
+		return err
+	}
+	svcObj, err := c.getOrCreateTargetService(scName, cvc)
+	if err != nil {
+		return err
+	}
+	err = c.distributeCVRs(desired-current, svcObj, cvObj, class)
+	if err != nil {
+		c.recorder.Event(cvc, corev1.EventTypeWarning, ScaleFailed, err.Error())
+		return err
+	}
+	glog.Infof("added %d replica(s) to cstorvolume {%s}", desired-current, cvObj.Name)
+	return c.updateScaleCondition(cvc, ReplicaRebuildInProgress,
+		fmt.Sprintf("waiting for %d replica(s) to be rebuilt", desired-current),
+	)
+}
+
+// scaleDownCStorVolume lowers the factors of the cstorvolume and deletes
+// the cvrs that are not needed
+func (c *CVCController) scaleDownCStorVolume(
+	cvc *apis.CStorVolumeClaim,
+	cvObj *apis.CStorVolume,
+	desired int,
+	cvrs []apis.CStorVolumeReplica,
+) error {
+	if desired < 1 {
+		c.recorder.Event(cvc, corev1.EventTypeWarning, ScaleFailed,
+			fmt.Sprintf("can not scale volume to %d replica(s)", desired),
+		)
+		return nil
+	}
+
+	removed, remaining := selectCVRsToRemove(cvrs, len(cvrs)-desired)
+	if healthy := countHealthyCVRs(remaining); healthy < getConsistencyFactor(desired) {
+		c.recorder.Event(cvc, corev1.EventTypeWarning, ScaleFailed,
+			fmt.Sprintf(
+				"can not scale volume to %d replica(s): only %d of the remaining replica(s) are healthy",
+				desired, healthy,
+			),
+		)
+		return nil
+	}
+
+	err := c.updateScaleCondition(cvc, ScaleDownStarted,
+		fmt.Sprintf("removing %d replica(s) to scale from %d to %d", len(removed), len(cvrs), desired),
+	)
+	if err != nil {
+		return err
+	}
+
+	// target should stop expecting the replicas before these are deleted
+	err = c.updateCVFactors(cvObj, desired, getConsistencyFactor(desired))
+	if err != nil {
+		c.recorder.Event(cvc, corev1.EventTypeWarning, ScaleFailed, err.Error())
+		return err
+	}
+
+	for _, cvrObj := range removed {
+		err = c.clientset.OpenebsV1alpha1().
+			CStorVolumeReplicas(cvrObj.Namespace).
+			Delete(cvrObj.Name, &metav1.DeleteOptions{})
+		if err != nil {
+			c.recorder.Event(cvc, corev1.EventTypeWarning, ScaleFailed, err.Error())
+			return merrors.Wrapf(err, "failed to delete cvr {%s}", cvrObj.Name)
+		}
+		glog.Infof("deleted cvr {%s} to scale down cstorvolume {%s}", cvrObj.Name, cvObj.Name)
+	}
+	return nil
+}
+
+// markScaleComplete raises the consistency factor of the cstorvolume and
+// removes the scaling condition once all the cvrs of the cvc are healthy
+func (c *CVCController) markScaleComplete(
+	cvc *apis.CStorVolumeClaim,
+	cvObj *apis.CStorVolume,
+	desired int,
+	cvrs []apis.CStorVolumeReplica,
+) error {
+	if getCVCCondition(cvc, apis.CStorVolumeClaimReplicaScaling) == nil {
+		return nil
+	}
+
+	if unhealthy := len(cvrs) - countHealthyCVRs(cvrs); unhealthy != 0 {
+		// wait for the replicas to be rebuilt, cvc will be synced
+		// again on the next resync
+		return c.updateScaleCondition(cvc, ReplicaRebuildInProgress,
+			fmt.Sprintf("waiting for %d replica(s) to be rebuilt", unhealthy),
+		)
+	}
+
+	if cvObj.Spec.ReplicationFactor != desired ||
+		cvObj.Spec.ConsistencyFactor != getConsistencyFactor(desired) {
+		err := c.updateCVFactors(cvObj, desired, getConsistencyFactor(desired))
+		if err != nil {
+			c.recorder.Event(cvc, corev1.EventTypeWarning, ScaleFailed, err.Error())
+			return err
+		}
+	}
+
+	removeCVCCondition(cvc, apis.CStorVolumeClaimReplicaScaling)
+	_, err := c.clientset.OpenebsV1alpha1().CStorVolumeClaims(cvc.Namespace).Update(cvc)
+	if err != nil {
+		return err
+	}
+	glog.Infof("scale of cstorvolume {%s} to %d replica(s) completed", cvObj.Name, desired)
+	c.recorder.Event(cvc, corev1.EventTypeNormal, ScaleSuccess,
+		fmt.Sprintf("volume scaled to %d replica(s)", desired),
+	)
+	return nil
+}
+
+// updateCVFactors updates the replication and consistency factors of the
+// cstorvolume. cstor-volume-mgmt then reconfigures the target.
+func (c *CVCController) updateCVFactors(
+	cvObj *apis.CStorVolume,
+	replicationFactor, consistencyFactor int,
+) error {
+	cvCopy := cvObj.DeepCopy()
+	cvCopy.Spec.ReplicationFactor = replicationFactor
+	cvCopy.Spec.ConsistencyFactor = consistencyFactor
+	_, err := c.clientset.OpenebsV1alpha1().CStorVolumes(cvCopy.Namespace).Update(cvCopy)
+	if err != nil {
+		return merrors.Wrapf(
+			err,
+			"failed to update replication factor of cstorvolume {%s}",
+			cvCopy.Name,
+		)
+	}
+	cvObj.Spec.ReplicationFactor = replicationFactor
+	cvObj.Spec.ConsistencyFactor = consistencyFactor
+	return nil
+}
+
+// updateScaleCondition updates the reason and message of the replica
+// scaling condition if it has changed
+func (c *CVCController) updateScaleCondition(
+	cvc *apis.CStorVolumeClaim,
+	reason, message string,
+) error {
+	cond := getCVCCondition(cvc, apis.CStorVolumeClaimReplicaScaling)
+	if cond != nil && cond.Reason == reason && cond.Message == message {
+		return nil
+	}
+	setCVCCondition(cvc, apis.CStorVolumeClaimReplicaScaling, reason, message)
+	updated, err := c.clientset.OpenebsV1alpha1().CStorVolumeClaims(cvc.Namespace).Update(cvc)
+	if err != nil {
+		return err
+	}
+	*cvc = *updated
+	return nil
+}
+
+// isCVRHealthy returns true if the cvr is online
+func isCVRHealthy(cvrObj *apis.CStorVolumeReplica) bool {
+	return cvrObj.Status.Phase == apis.CVRStatusOnline
+}
+
+// countHealthyCVRs returns the number of healthy cvrs
+func countHealthyCVRs(cvrs []apis.CStorVolumeReplica) int {
+	healthy := 0
+	for i := range cvrs {
+		if isCVRHealthy(&cvrs[i]) {
+			healthy++
+		}
+	}
+	return healthy
+}
+
+// selectCVRsToRemove returns the given number of cvrs to be removed and
+// the cvrs that remain. Unhealthy cvrs are removed first followed by the
+// most recently created ones.
+func selectCVRsToRemove(
+	cvrs []apis.CStorVolumeReplica,
+	count int,
+) (removed, remaining []apis.CStorVolumeReplica) {
+	sorted := append([]apis.CStorVolumeReplica{}, cvrs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := &sorted[i], &sorted[j]
+		if isCVRHealthy(a) != isCVRHealthy(b) {
+			return !isCVRHealthy(a)
+		}
+		if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+			return b.CreationTimestamp.Before(&a.CreationTimestamp)
+		}
+		return a.Name < b.Name
+	})
+	if count > len(sorted) {
+		count = len(sorted)
+	}
+	return sorted[:count], sorted[count:]
+}
@@ -0,0 +1,304 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cstorvolumeclaim
+
+import (
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	openebsFakeClientset "github.com/openebs/maya/pkg/client/generated/clientset/versioned/fake"
+	menv "github.com/openebs/maya/pkg/env/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestGetDesiredReplicaCount(t *testing.T) {
+	tests := map[string]struct {
+		spec        int
+		annotations map[string]string
+		class       *storagev1.StorageClass
+		expected    int
+		isErr       bool
+	}{
+		"replica count from spec": {
+			spec:        5,
+			annotations: map[string]string{replicaCountAnnotation: "3"},
+			expected:    5,
+		},
+		"replica count from annotation": {
+			annotations: map[string]string{replicaCountAnnotation: "3"},
+			expected:    3,
+		},
+		"replica count from storageclass": {
+			class:    &storagev1.StorageClass{Parameters: map[string]string{ReplicaCount: "2"}},
+			expected: 2,
+		},
+		"invalid annotation": {
+			annotations: map[string]string{replicaCountAnnotation: "three"},
+			isErr:       true,
+		},
+		"replica count not set": {
+			isErr: true,
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			cvc := &apis.CStorVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-1", Annotations: mock.annotations},
+				Spec:       apis.CStorVolumeClaimSpec{ReplicaCount: mock.spec},
+			}
+			got, err := getDesiredReplicaCount(cvc, mock.class)
+			if mock.isErr != (err != nil) {
+				t.Fatalf("Test %q failed: expected error '%t': actual error '%v'", name, mock.isErr, err)
+			}
+			if !mock.isErr && got != mock.expected {
+				t.Fatalf("Test %q failed: expected '%d': actual '%d'", name, mock.expected, got)
+			}
+		})
+	}
+}
+
+func TestSelectCVRsToRemove(t *testing.T) {
+	tests := map[string]struct {
+		cvrs              []apis.CStorVolumeReplica
+		count             int
+		expectedRemoved   []string
+		expectedRemaining []string
+	}{
+		"unhealthy replicas are removed first": {
+			cvrs: []apis.CStorVolumeReplica{
+				*fakeCVR("pv-1", "pool-1", withCVRPhase(apis.CVRStatusOnline), withCVRAge(1)),
+				*fakeCVR("pv-1", "pool-2", withCVRPhase(apis.CVRStatusOffline), withCVRAge(2)),
+				*fakeCVR("pv-1", "pool-3", withCVRPhase(apis.CVRStatusOnline), withCVRAge(3)),
+			},
+			count:             1,
+			expectedRemoved:   []string{"pv-1-pool-2"},
+			expectedRemaining: []string{"pv-1-pool-3", "pv-1-pool-1"},
+		},
+		"newest replicas are removed first": {
+			cvrs: []apis.CStorVolumeReplica{
+				*fakeCVR("pv-1", "pool-1", withCVRPhase(apis.CVRStatusOnline), withCVRAge(1)),
+				*fakeCVR("pv-1", "pool-2", withCVRPhase(apis.CVRStatusOnline), withCVRAge(3)),
+				*fakeCVR("pv-1", "pool-3", withCVRPhase(apis.CVRStatusOnline), withCVRAge(2)),
+			},
+			count:             2,
+			expectedRemoved:   []string{"pv-1-pool-2", "pv-1-pool-3"},
+			expectedRemaining: []string{"pv-1-pool-1"},
+		},
+		"count more than replicas": {
+			cvrs: []apis.CStorVolumeReplica{
+				*fakeCVR("pv-1", "pool-1", withCVRPhase(apis.CVRStatusOnline), withCVRAge(1)),
+			},
+			count:           2,
+			expectedRemoved: []string{"pv-1-pool-1"},
+		},
+	}
+	names := func(cvrs []apis.CStorVolumeReplica) []string {
+		var n []string
+		for _, cvrObj := range cvrs {
+			n = append(n, cvrObj.Name)
+		}
+		return n
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			removed, remaining := selectCVRsToRemove(mock.cvrs, mock.count)
+			if !reflect.DeepEqual(names(removed), mock.expectedRemoved) {
+				t.Fatalf("Test %q failed: expected removed '%v': actual removed '%v'",
+					name, mock.expectedRemoved, names(removed))
+			}
+			if !reflect.DeepEqual(names(remaining), mock.expectedRemaining) {
+				t.Fatalf("Test %q failed: expected remaining '%v': actual remaining '%v'",
+					name, mock.expectedRemaining, names(remaining))
+			}
+		})
+	}
+}
+
+func TestScaleCStorVolume(t *testing.T) {
+	os.Setenv(string(menv.OpenEBSNamespace), "openebs")
+	defer os.Unsetenv(string(menv.OpenEBSNamespace))
+
+	online, offline := apis.CVRStatusOnline, apis.CVRStatusOffline
+	tests := map[string]struct {
+		desired            int
+		rf, cf             int
+		cvrs               []*apis.CStorVolumeReplica
+		scaling            bool
+		expectedRF         int
+		expectedCF         int
+		expectedCVRs       []string
+		expectedReason     string
+		expectedNoScaling  bool
+		expectedScaleEvent bool
+	}{
+		"scale up raises replication factor and distributes the new replicas": {
+			desired: 3,
+			rf:      1,
+			cf:      1,
+			cvrs: []*apis.CStorVolumeReplica{
+				fakeCVR("pv-1", "pool-1", withCVRPhase(online)),
+			},
+			expectedRF:     3,
+			expectedCF:     1,
+			expectedCVRs:   []string{"pv-1-pool-1", "pv-1-pool-2", "pv-1-pool-3"},
+			expectedReason: ReplicaRebuildInProgress,
+		},
+		"consistency factor is not raised while replicas are rebuilt": {
+			desired: 3,
+			rf:      3,
+			cf:      1,
+			cvrs: []*apis.CStorVolumeReplica{
+				fakeCVR("pv-1", "pool-1", withCVRPhase(online)),
+				fakeCVR("pv-1", "pool-2", withCVRPhase(online)),
+				fakeCVR("pv-1", "pool-3", withCVRPhase(apis.CVRStatusRebuilding)),
+			},
+			scaling:        true,
+			expectedRF:     3,
+			expectedCF:     1,
+			expectedCVRs:   []string{"pv-1-pool-1", "pv-1-pool-2", "pv-1-pool-3"},
+			expectedReason: ReplicaRebuildInProgress,
+		},
+		"consistency factor is raised once replicas are healthy": {
+			desired: 3,
+			rf:      3,
+			cf:      1,
+			cvrs: []*apis.CStorVolumeReplica{
+				fakeCVR("pv-1", "pool-1", withCVRPhase(online)),
+				fakeCVR("pv-1", "pool-2", withCVRPhase(online)),
+				fakeCVR("pv-1", "pool-3", withCVRPhase(online)),
+			},
+			scaling:            true,
+			expectedRF:         3,
+			expectedCF:         2,
+			expectedCVRs:       []string{"pv-1-pool-1", "pv-1-pool-2", "pv-1-pool-3"},
+			expectedNoScaling:  true,
+			expectedScaleEvent: true,
+		},
+		"scale down removes unhealthy replicas first": {
+			desired: 2,
+			rf:      3,
+			cf:      2,
+			cvrs: []*apis.CStorVolumeReplica{
+				fakeCVR("pv-1", "pool-1", withCVRPhase(online), withCVRAge(1)),
+				fakeCVR("pv-1", "pool-2", withCVRPhase(offline), withCVRAge(2)),
+				fakeCVR("pv-1", "pool-3", withCVRPhase(online), withCVRAge(3)),
+			},
+			expectedRF:     2,
+			expectedCF:     2,
+			expectedCVRs:   []string{"pv-1-pool-1", "pv-1-pool-3"},
+			expectedReason: ScaleDownStarted,
+		},
+		"scale down without quorum of healthy replicas is not done": {
+			desired: 2,
+			rf:      3,
+			cf:      2,
+			cvrs: []*apis.CStorVolumeReplica{
+				fakeCVR("pv-1", "pool-1", withCVRPhase(online)),
+				fakeCVR("pv-1", "pool-2", withCVRPhase(offline)),
+				fakeCVR("pv-1", "pool-3", withCVRPhase(offline)),
+			},
+			expectedRF:        3,
+			expectedCF:        2,
+			expectedCVRs:      []string{"pv-1-pool-1", "pv-1-pool-2", "pv-1-pool-3"},
+			expectedNoScaling: true,
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			cvObj := &apis.CStorVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-1", Namespace: "openebs"},
+				Spec:       apis.CStorVolumeSpec{Capacity: "5G", ReplicationFactor: mock.rf, ConsistencyFactor: mock.cf},
+			}
+			cvc := &apis.CStorVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pv-1",
+					Namespace:   "openebs",
+					Annotations: map[string]string{string(apis.StorageConfigClassKey): "sc-1"},
+				},
+				Spec: apis.CStorVolumeClaimSpec{ReplicaCount: mock.desired},
+			}
+			if mock.scaling {
+				setCVCCondition(cvc, apis.CStorVolumeClaimReplicaScaling, ReplicaRebuildInProgress, "rebuilding")
+			}
+			objs := []runtime.Object{cvObj, cvc}
+			for _, cvrObj := range mock.cvrs {
+				objs = append(objs, cvrObj)
+			}
+			for _, name := range []string{"pool-1", "pool-2", "pool-3"} {
+				pool := fakePool(name, "node-"+name, "10G", withPoolSPC("spc-1"))
+				objs = append(objs, &pool)
+			}
+			recorder := record.NewFakeRecorder(10)
+			c := &CVCController{
+				clientset: openebsFakeClientset.NewSimpleClientset(objs...),
+				kubeclientset: fake.NewSimpleClientset(
+					&storagev1.StorageClass{
+						ObjectMeta: metav1.ObjectMeta{Name: "sc-1"},
+						Parameters: map[string]string{"storagePoolClaim": "spc-1"},
+					},
+					&corev1.Service{
+						ObjectMeta: metav1.ObjectMeta{Name: "pv-1", Namespace: "openebs"},
+						Spec:       corev1.ServiceSpec{ClusterIP: "10.0.0.1"},
+					},
+				),
+				recorder: recorder,
+			}
+
+			err := c.scaleCStorVolume(cvc.DeepCopy(), cvObj.DeepCopy())
+			if err != nil {
+				t.Fatalf("Test %q failed: expected no error: actual error '%v'", name, err)
+			}
+
+			gotCV, _ := c.clientset.OpenebsV1alpha1().CStorVolumes("openebs").Get("pv-1", metav1.GetOptions{})
+			if gotCV.Spec.ReplicationFactor != mock.expectedRF || gotCV.Spec.ConsistencyFactor != mock.expectedCF {
+				t.Fatalf("Test %q failed: expected factors '%d/%d': actual '%d/%d'", name,
+					mock.expectedRF, mock.expectedCF, gotCV.Spec.ReplicationFactor, gotCV.Spec.ConsistencyFactor)
+			}
+			cvrList, _ := c.clientset.OpenebsV1alpha1().CStorVolumeReplicas("openebs").List(metav1.ListOptions{})
+			var cvrNames []string
+			for _, cvrObj := range cvrList.Items {
+				cvrNames = append(cvrNames, cvrObj.Name)
+			}
+			sort.Strings(cvrNames)
+			if !reflect.DeepEqual(cvrNames, mock.expectedCVRs) {
+				t.Fatalf("Test %q failed: expected cvrs '%v': actual cvrs '%v'", name, mock.expectedCVRs, cvrNames)
+			}
+			gotCVC, _ := c.clientset.OpenebsV1alpha1().CStorVolumeClaims("openebs").Get("pv-1", metav1.GetOptions{})
+			cond := getCVCCondition(gotCVC, apis.CStorVolumeClaimReplicaScaling)
+			if mock.expectedNoScaling != (cond == nil) {
+				t.Fatalf("Test %q failed: expected no scaling condition '%t': actual %v", name, mock.expectedNoScaling, gotCVC.Status.Condition)
+			}
+			if cond != nil && cond.Reason != mock.expectedReason {
+				t.Fatalf("Test %q failed: expected reason '%s': actual '%s'", name, mock.expectedReason, cond.Reason)
+			}
+			if mock.expectedScaleEvent && len(recorder.Events) == 0 {
+				t.Fatalf("Test %q failed: expected scale success event", name)
+			}
+		})
+	}
+}
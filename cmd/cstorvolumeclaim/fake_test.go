@@ -0,0 +1,122 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cstorvolumeclaim
+
+import (
+	"time"
+
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// fakeCVROption customises the cvr returned by fakeCVR
+type fakeCVROption func(*apis.CStorVolumeReplica)
+
+// fakeCVR returns the cvr of the volume on the pool labelled the
+// way the cvc controller labels the cvrs it creates
+func fakeCVR(volume, pool string, opts ...fakeCVROption) *apis.CStorVolumeReplica {
+	cvrObj := &apis.CStorVolumeReplica{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      volume + "-" + pool,
+			Namespace: "openebs",
+			Labels: map[string]string{
+				cstorpoolNameLabel:    pool,
+				persistentVolumeLabel: volume,
+			},
+		},
+	}
+	for _, o := range opts {
+		o(cvrObj)
+	}
+	return cvrObj
+}
+
+// withCVRPhase sets the phase of the cvr
+func withCVRPhase(phase apis.CStorVolumeReplicaPhase) fakeCVROption {
+	return func(cvrObj *apis.CStorVolumeReplica) {
+		cvrObj.Status.Phase = phase
+	}
+}
+
+// withCVRAge sets the creation time of the cvr to the given
+// hours after the epoch
+func withCVRAge(hours int) fakeCVROption {
+	return func(cvrObj *apis.CStorVolumeReplica) {
+		cvrObj.CreationTimestamp = metav1.NewTime(time.Unix(0, 0).Add(time.Duration(hours) * time.Hour))
+	}
+}
+
+// withCVRCapacity sets the desired capacity & the present size
+// of the zfs volume of the cvr
+func withCVRCapacity(capacity, volSize string) fakeCVROption {
+	return func(cvrObj *apis.CStorVolumeReplica) {
+		cvrObj.Spec.Capacity = capacity
+		cvrObj.Status.Capacity.VolSize = volSize
+	}
+}
+
+// withCVRTargetIP sets the ip of the target the cvr connects to
+func withCVRTargetIP(ip string) fakeCVROption {
+	return func(cvrObj *apis.CStorVolumeReplica) {
+		cvrObj.Spec.TargetIP = ip
+	}
+}
+
+// fakePoolOption customises the pool returned by fakePool
+type fakePoolOption func(*apis.CStorPool)
+
+// fakePool returns the cstor pool on the node with the given
+// free capacity
+func fakePool(name, host, free string, opts ...fakePoolOption) apis.CStorPool {
+	pool := apis.CStorPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{hostNameLabel: host},
+		},
+		Status: apis.CStorPoolStatus{
+			Capacity: apis.CStorPoolCapacityAttr{Free: free},
+		},
+	}
+	for _, o := range opts {
+		o(&pool)
+	}
+	return pool
+}
+
+// withPoolPhase sets the phase of the pool
+func withPoolPhase(phase apis.CStorPoolPhase) fakePoolOption {
+	return func(pool *apis.CStorPool) {
+		pool.Status.Phase = phase
+	}
+}
+
+// withPoolSPC labels the pool with the spc it belongs to
+func withPoolSPC(spc string) fakePoolOption {
+	return func(pool *apis.CStorPool) {
+		pool.Labels[string(apis.StoragePoolClaimCPK)] = spc
+	}
+}
+
+// withOverCommit sets the size & provisioned capacity on the pool
+// that is over provisioned up to twice its size
+func withOverCommit(total, provisioned string) fakePoolOption {
+	return func(pool *apis.CStorPool) {
+		pool.Spec.PoolSpec = apis.CStorPoolAttr{OverProvisioning: true, OverCommitRatio: "2"}
+		pool.Status.Capacity.Total = total
+		pool.Status.Capacity.Provisioned = provisioned
+	}
+}
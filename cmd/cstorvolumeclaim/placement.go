@@ -0,0 +1,378 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cstorvolumeclaim
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/golang/glog"
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	cstorpool "github.com/openebs/maya/pkg/cstor/pool/v1alpha1"
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ReplicaTopologyKey is the storageclass parameter that refers to the
+	// node label used to spread the replicas of a volume e.g.
+	// topology.kubernetes.io/zone. Replicas are spread across nodes by
+	// default.
+	ReplicaTopologyKey = "replicaTopologyKey"
+	// ReplicaSpread is the storageclass parameter that decides if the
+	// replicas of a volume must be spread across the topology domains i.e.
+	// strict or if the spread is only preferred
+	ReplicaSpread = "replicaSpread"
+	// OverCommitPolicy is the storageclass parameter that decides if the
+	// replicas of a volume must not be placed on the pools that would
+	// cross their over commit limit i.e. strict or if such pools are
+	// only avoided
+	OverCommitPolicy = "overCommitPolicy"
+
+	// hostNameLabel is the label set on the cstor pools as well as the
+	// nodes with the hostname of the node
+	hostNameLabel = "kubernetes.io/hostname"
+	// persistentVolumeLabel is the label set on the cstor volume replicas
+	// with the name of the volume
+	persistentVolumeLabel = "openebs.io/persistent-volume"
+
+	// freeBucketRatio is the ratio between the bounds of the buckets
+	// that the free capacity of the pools is grouped into while scoring
+	// the pools. Pools whose free capacity is in the same bucket are
+	// scored by the number of replicas placed on them.
+	freeBucketRatio = 1.25
+)
+
+// spreadPolicy decides how strictly the replicas of a volume are spread
+// across topology domains
+type spreadPolicy string
+
+const (
+	// strictSpread fails the placement if the replicas can not be placed
+	// in distinct topology domains
+	strictSpread spreadPolicy = "strict"
+	// preferredSpread places the replicas in distinct topology domains when
+	// possible & falls back to the best scored pools otherwise
+	preferredSpread spreadPolicy = "preferred"
+)
+
+// overCommitPolicy decides how strictly the over commit limit of the
+// pools is honoured while placing the replicas of a volume
+type overCommitPolicy string
+
+const (
+	// strictOverCommit fails the placement if the replicas can not be
+	// placed within the over commit limit of the pools
+	strictOverCommit overCommitPolicy = "strict"
+	// preferredOverCommit places the replicas within the over commit limit
+	// of the pools when possible & falls back to over committed pools
+	// otherwise
+	preferredOverCommit overCommitPolicy = "preferred"
+)
+
+// placementPolicy holds the rules to place the replicas of a volume
+type placementPolicy struct {
+	topologyKey string
+	spread      spreadPolicy
+	overCommit  overCommitPolicy
+}
+
+// getPlacementPolicy returns the replica placement policy configured in
+// the given storageclass
+func getPlacementPolicy(class *storagev1.StorageClass) (*placementPolicy, error) {
+	p := &placementPolicy{
+		topologyKey: strings.TrimSpace(class.Parameters[ReplicaTopologyKey]),
+		spread:      spreadPolicy(strings.TrimSpace(class.Parameters[ReplicaSpread])),
+		overCommit:  overCommitPolicy(strings.TrimSpace(class.Parameters[OverCommitPolicy])),
+	}
+	if p.topologyKey == "" {
+		p.topologyKey = hostNameLabel
+	}
+	if p.spread == "" {
+		p.spread = preferredSpread
+	}
+	if p.spread != strictSpread && p.spread != preferredSpread {
+		return nil, errors.Errorf(
+			"invalid %s {%s} in storageclass {%s}: supported values are {%s, %s}",
+			ReplicaSpread, p.spread, class.Name, strictSpread, preferredSpread,
+		)
+	}
+	if p.overCommit == "" {
+		p.overCommit = preferredOverCommit
+	}
+	if p.overCommit != strictOverCommit && p.overCommit != preferredOverCommit {
+		return nil, errors.Errorf(
+			"invalid %s {%s} in storageclass {%s}: supported values are {%s, %s}",
+			OverCommitPolicy, p.overCommit, class.Name, strictOverCommit, preferredOverCommit,
+		)
+	}
+	return p, nil
+}
+
+// topologyFn returns the topology domain of the given pool
+type topologyFn func(pool *apis.CStorPool) string
+
+// getTopologyFn returns the function that resolves the topology domain of
+// a pool for the given topology key. Pools carry the hostname label of
+// their node; any other key is looked up from the labels of the node.
+func (c *CVCController) getTopologyFn(topologyKey string) (topologyFn, error) {
+	if topologyKey == hostNameLabel {
+		return func(pool *apis.CStorPool) string {
+			return pool.Labels[hostNameLabel]
+		}, nil
+	}
+
+	nodeList, err := c.kubeclientset.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list nodes for topology key {%s}", topologyKey)
+	}
+	domains := map[string]string{}
+	for _, n := range nodeList.Items {
+		domains[n.Labels[hostNameLabel]] = n.Labels[topologyKey]
+	}
+	return func(pool *apis.CStorPool) string {
+		return domains[pool.Labels[hostNameLabel]]
+	}, nil
+}
+
+// poolCandidate is a cstor pool considered to place a replica
+type poolCandidate struct {
+	pool       apis.CStorPool
+	freeBucket int
+	replicas   int
+	domain     string
+
+	// hasCapacity flags if the pool has enough free
+	// capacity to place the replica
+	hasCapacity bool
+
+	// overCommitted flags if placing the replica crosses
+	// the over commit limit of the pool
+	overCommitted bool
+}
+
+// placement selects the cstor pools to place the replicas of a volume
+type placement struct {
+	policy     *placementPolicy
+	candidates []*poolCandidate
+
+	// usedDomains holds the topology domains that already
+	// have a replica of the volume
+	usedDomains map[string]bool
+}
+
+// newPlacement returns a new instance of placement for the replicas of
+// given capacity in bytes. Pools that already have a replica of the
+// volume are not considered, though their topology domains are.
+func newPlacement(
+	policy *placementPolicy,
+	volumeName string,
+	capacity int64,
+	pools *apis.CStorPoolList,
+	cvrs *apis.CStorVolumeReplicaList,
+	topology topologyFn,
+) *placement {
+	p := &placement{policy: policy, usedDomains: map[string]bool{}}
+
+	replicas := map[string]int{}
+	volumePools := map[string]bool{}
+	for _, cvr := range cvrs.Items {
+		poolName := cvr.Labels[cstorpoolNameLabel]
+		replicas[poolName]++
+		if cvr.Labels[persistentVolumeLabel] == volumeName {
+			volumePools[poolName] = true
+		}
+	}
+
+	for i := range pools.Items {
+		pool := pools.Items[i]
+		domain := topology(&pool)
+		if volumePools[pool.Name] {
+			if domain != "" {
+				p.usedDomains[domain] = true
+			}
+			continue
+		}
+		free := parsePoolCapacity(pool.Status.Capacity.Free)
+		p.candidates = append(p.candidates, &poolCandidate{
+			pool:          pool,
+			freeBucket:    getFreeBucket(free),
+			replicas:      replicas[pool.Name],
+			domain:        domain,
+			hasCapacity:   hasCapacity(&pool, free, capacity),
+			overCommitted: isOverCommitted(&pool, capacity),
+		})
+	}
+	return p
+}
+
+// getFreeBucket returns the bucket of the given free capacity in bytes.
+// The bounds of the buckets grow by freeBucketRatio, hence pools with
+// nearly the same free capacity are placed in the same bucket.
+func getFreeBucket(free int64) int {
+	if free <= 0 {
+		return -1
+	}
+	return int(math.Log(float64(free)) / math.Log(freeBucketRatio))
+}
+
+// hasCapacity flags if the pool has the given free capacity to place a
+// replica of the given capacity. Pools that are over provisioned are
+// limited by their over commit limit instead, while pools that are yet
+// to report their free capacity are not limited.
+func hasCapacity(pool *apis.CStorPool, free, capacity int64) bool {
+	if pool.Spec.PoolSpec.OverProvisioning || pool.Status.Capacity.Free == "" {
+		return true
+	}
+	return free >= capacity
+}
+
+// isOverCommitted flags if placing a replica of the given capacity on
+// the pool crosses the over commit limit of the pool. Pools that are
+// yet to report their provisioned capacity are not considered as over
+// committed.
+func isOverCommitted(pool *apis.CStorPool, capacity int64) bool {
+	if pool.Status.Capacity.Provisioned == "" {
+		return false
+	}
+	limit, err := cstorpool.OverCommitLimit(
+		pool.Spec.PoolSpec,
+		parsePoolCapacity(pool.Status.Capacity.Total),
+	)
+	if err != nil {
+		// over commit ratio is validated while creating the
+		// pools, hence invalid ratio is considered as no limit
+		glog.Warningf("failed to get over commit limit of pool {%s}: %v", pool.Name, err)
+		return false
+	}
+	return limit != 0 &&
+		parsePoolCapacity(pool.Status.Capacity.Provisioned)+capacity > limit
+}
+
+// parsePoolCapacity returns the capacity in bytes as reported by the cstor
+// pool e.g. 9.94G. ZFS reports capacity in binary units without the 'i'
+// suffix. Capacity that can not be parsed is considered as zero.
+func parsePoolCapacity(capacity string) int64 {
+	capacity = strings.TrimSpace(capacity)
+	if capacity == "" {
+		return 0
+	}
+	if strings.ContainsAny(capacity[len(capacity)-1:], "KMGTPE") {
+		capacity = capacity + "i"
+	}
+	q, err := resource.ParseQuantity(capacity)
+	if err != nil {
+		return 0
+	}
+	return q.Value()
+}
+
+// isSpread flags if placing a replica on the given candidate spreads the
+// replicas across topology domains. Pools without the topology label are
+// not considered as spread.
+func (p *placement) isSpread(c *poolCandidate) bool {
+	return c.domain != "" && !p.usedDomains[c.domain]
+}
+
+// less flags if candidate a scores better than candidate b. Candidates are
+// scored by:
+// 1. spread across topology domains,
+// 2. within the over commit limit of the pool,
+// 3. more free capacity, compared in buckets of freeBucketRatio,
+// 4. fewer replicas already placed on the pool.
+func (p *placement) less(a, b *poolCandidate) bool {
+	if p.isSpread(a) != p.isSpread(b) {
+		return p.isSpread(a)
+	}
+	if a.overCommitted != b.overCommitted {
+		return !a.overCommitted
+	}
+	if a.freeBucket != b.freeBucket {
+		return a.freeBucket > b.freeBucket
+	}
+	if a.replicas != b.replicas {
+		return a.replicas < b.replicas
+	}
+	return a.pool.Name < b.pool.Name
+}
+
+// selectPools returns the given number of pools to place the replicas.
+// Pools are picked one at a time since every pick uses up a topology
+// domain.
+func (p *placement) selectPools(count int) ([]apis.CStorPool, error) {
+	if count > len(p.candidates) {
+		return nil, errors.Errorf(
+			"not enough pools available to create replicas: required {%d}: available {%d}",
+			count, len(p.candidates),
+		)
+	}
+
+	var candidates []*poolCandidate
+	for _, c := range p.candidates {
+		if !c.hasCapacity {
+			continue
+		}
+		candidates = append(candidates, c)
+	}
+	if count > len(candidates) {
+		return nil, errors.Errorf(
+			"not enough pools with free capacity available to create replicas: required {%d}: available {%d}",
+			count, len(candidates),
+		)
+	}
+
+	var withinLimit []*poolCandidate
+	for _, c := range candidates {
+		if p.policy.overCommit == strictOverCommit && c.overCommitted {
+			continue
+		}
+		withinLimit = append(withinLimit, c)
+	}
+	candidates = withinLimit
+	if count > len(candidates) {
+		return nil, errors.Errorf(
+			"not enough pools within over commit limit available to create replicas: required {%d}: available {%d}",
+			count, len(candidates),
+		)
+	}
+
+	var selected []apis.CStorPool
+	for i := 0; i < count; i++ {
+		sort.SliceStable(candidates, func(x, y int) bool {
+			return p.less(candidates[x], candidates[y])
+		})
+		best := candidates[0]
+		if p.policy.spread == strictSpread && !p.isSpread(best) {
+			return nil, errors.Errorf(
+				"not enough topology domains available to create replicas: topology key {%s}: required {%d}: available {%d}",
+				p.policy.topologyKey, count, i,
+			)
+		}
+		if best.overCommitted {
+			glog.Warningf("placing replica on pool {%s} past its over commit limit", best.pool.Name)
+		}
+		selected = append(selected, best.pool)
+		if best.domain != "" {
+			p.usedDomains[best.domain] = true
+		}
+		candidates = candidates[1:]
+	}
+	return selected, nil
+}
@@ -0,0 +1,410 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cstorvolumeclaim
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	openebsFakeClientset "github.com/openebs/maya/pkg/client/generated/clientset/versioned/fake"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetPlacementPolicy(t *testing.T) {
+	tests := map[string]struct {
+		params             map[string]string
+		expectedTopology   string
+		expectedSpread     spreadPolicy
+		expectedOverCommit overCommitPolicy
+		isErr              bool
+	}{
+		"default policy": {
+			expectedTopology:   hostNameLabel,
+			expectedSpread:     preferredSpread,
+			expectedOverCommit: preferredOverCommit,
+		},
+		"strict zone spread": {
+			params:             map[string]string{ReplicaTopologyKey: "topology.kubernetes.io/zone", ReplicaSpread: "strict"},
+			expectedTopology:   "topology.kubernetes.io/zone",
+			expectedSpread:     strictSpread,
+			expectedOverCommit: preferredOverCommit,
+		},
+		"strict over commit": {
+			params:             map[string]string{OverCommitPolicy: "strict"},
+			expectedTopology:   hostNameLabel,
+			expectedSpread:     preferredSpread,
+			expectedOverCommit: strictOverCommit,
+		},
+		"invalid spread": {
+			params: map[string]string{ReplicaSpread: "always"},
+			isErr:  true,
+		},
+		"invalid over commit policy": {
+			params: map[string]string{OverCommitPolicy: "never"},
+			isErr:  true,
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			class := &storagev1.StorageClass{Parameters: mock.params}
+			p, err := getPlacementPolicy(class)
+			if mock.isErr != (err != nil) {
+				t.Fatalf("Test %q failed: expected error '%t': actual error '%v'", name, mock.isErr, err)
+			}
+			if mock.isErr {
+				return
+			}
+			if p.topologyKey != mock.expectedTopology || p.spread != mock.expectedSpread ||
+				p.overCommit != mock.expectedOverCommit {
+				t.Fatalf("Test %q failed: expected policy '%s/%s/%s': actual policy '%s/%s/%s'",
+					name, mock.expectedTopology, mock.expectedSpread, mock.expectedOverCommit,
+					p.topologyKey, p.spread, p.overCommit)
+			}
+		})
+	}
+}
+
+func TestParsePoolCapacity(t *testing.T) {
+	tests := map[string]struct {
+		capacity string
+		expected int64
+	}{
+		"gigabytes": {"2G", 2 * 1024 * 1024 * 1024},
+		"decimal":   {"1.5K", 1536},
+		"bytes":     {"512", 512},
+		"empty":     {"", 0},
+		"invalid":   {"abc", 0},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			if got := parsePoolCapacity(mock.capacity); got != mock.expected {
+				t.Fatalf("Test %q failed: expected '%d': actual '%d'", name, mock.expected, got)
+			}
+		})
+	}
+}
+
+func TestSelectPools(t *testing.T) {
+	zones := map[string]string{
+		"node-1": "zone-a",
+		"node-2": "zone-a",
+		"node-3": "zone-b",
+		"node-4": "zone-c",
+	}
+	zoneTopology := func(pool *apis.CStorPool) string {
+		return zones[pool.Labels[hostNameLabel]]
+	}
+	hostTopology := func(pool *apis.CStorPool) string {
+		return pool.Labels[hostNameLabel]
+	}
+
+	tests := map[string]struct {
+		policy        placementPolicy
+		topology      topologyFn
+		pools         []apis.CStorPool
+		cvrs          []apis.CStorVolumeReplica
+		count         int
+		expectedPools []string
+		isErr         bool
+	}{
+		"pools with more free capacity are preferred": {
+			policy:   placementPolicy{topologyKey: hostNameLabel, spread: preferredSpread},
+			topology: hostTopology,
+			pools: []apis.CStorPool{
+				fakePool("pool-1", "node-1", "1G"),
+				fakePool("pool-2", "node-2", "10G"),
+				fakePool("pool-3", "node-3", "5G"),
+			},
+			count:         2,
+			expectedPools: []string{"pool-2", "pool-3"},
+		},
+		"pools with fewer replicas are preferred on same capacity": {
+			policy:   placementPolicy{topologyKey: hostNameLabel, spread: preferredSpread},
+			topology: hostTopology,
+			pools: []apis.CStorPool{
+				fakePool("pool-1", "node-1", "5G"),
+				fakePool("pool-2", "node-2", "5G"),
+			},
+			cvrs:          []apis.CStorVolumeReplica{*fakeCVR("pv-2", "pool-1")},
+			count:         1,
+			expectedPools: []string{"pool-2"},
+		},
+		"pools with fewer replicas are preferred on nearly same capacity": {
+			policy:   placementPolicy{topologyKey: hostNameLabel, spread: preferredSpread},
+			topology: hostTopology,
+			pools: []apis.CStorPool{
+				fakePool("pool-1", "node-1", "10G"),
+				fakePool("pool-2", "node-2", "9.5G"),
+			},
+			cvrs: []apis.CStorVolumeReplica{
+				*fakeCVR("pv-2", "pool-1"),
+				*fakeCVR("pv-3", "pool-1"),
+			},
+			count:         1,
+			expectedPools: []string{"pool-2"},
+		},
+		"pools without free capacity for the volume are skipped": {
+			policy:   placementPolicy{topologyKey: hostNameLabel, spread: preferredSpread},
+			topology: hostTopology,
+			pools: []apis.CStorPool{
+				fakePool("pool-1", "node-1", "512M"),
+				fakePool("pool-2", "node-2", "2G"),
+			},
+			count:         1,
+			expectedPools: []string{"pool-2"},
+		},
+		"not enough pools with free capacity for the volume": {
+			policy:   placementPolicy{topologyKey: hostNameLabel, spread: preferredSpread},
+			topology: hostTopology,
+			pools: []apis.CStorPool{
+				fakePool("pool-1", "node-1", "512M"),
+				fakePool("pool-2", "node-2", "2G"),
+			},
+			count: 2,
+			isErr: true,
+		},
+		"over provisioned pools are not limited by free capacity": {
+			policy:   placementPolicy{topologyKey: hostNameLabel, spread: preferredSpread, overCommit: strictOverCommit},
+			topology: hostTopology,
+			pools: []apis.CStorPool{
+				fakePool("pool-1", "node-1", "512M", withOverCommit("10G", "5G")),
+			},
+			count:         1,
+			expectedPools: []string{"pool-1"},
+		},
+		"pools already used by the volume are skipped": {
+			policy:   placementPolicy{topologyKey: hostNameLabel, spread: preferredSpread},
+			topology: hostTopology,
+			pools: []apis.CStorPool{
+				fakePool("pool-1", "node-1", "10G"),
+				fakePool("pool-2", "node-2", "5G"),
+			},
+			cvrs:          []apis.CStorVolumeReplica{*fakeCVR("pv-1", "pool-1")},
+			count:         1,
+			expectedPools: []string{"pool-2"},
+		},
+		"replicas are spread across zones": {
+			policy:   placementPolicy{topologyKey: "zone", spread: preferredSpread},
+			topology: zoneTopology,
+			pools: []apis.CStorPool{
+				fakePool("pool-1", "node-1", "10G"),
+				fakePool("pool-2", "node-2", "9G"),
+				fakePool("pool-3", "node-3", "1G"),
+				fakePool("pool-4", "node-4", "2G"),
+			},
+			count:         3,
+			expectedPools: []string{"pool-1", "pool-4", "pool-3"},
+		},
+		"zones used by existing replicas are avoided": {
+			policy:   placementPolicy{topologyKey: "zone", spread: preferredSpread},
+			topology: zoneTopology,
+			pools: []apis.CStorPool{
+				fakePool("pool-1", "node-1", "10G"),
+				fakePool("pool-2", "node-2", "9G"),
+				fakePool("pool-3", "node-3", "1G"),
+			},
+			cvrs:          []apis.CStorVolumeReplica{*fakeCVR("pv-1", "pool-1")},
+			count:         1,
+			expectedPools: []string{"pool-3"},
+		},
+		"preferred spread falls back to same zone": {
+			policy:   placementPolicy{topologyKey: "zone", spread: preferredSpread},
+			topology: zoneTopology,
+			pools: []apis.CStorPool{
+				fakePool("pool-1", "node-1", "10G"),
+				fakePool("pool-2", "node-2", "9G"),
+				fakePool("pool-3", "node-3", "1G"),
+			},
+			count:         3,
+			expectedPools: []string{"pool-1", "pool-3", "pool-2"},
+		},
+		"strict spread fails without enough zones": {
+			policy:   placementPolicy{topologyKey: "zone", spread: strictSpread},
+			topology: zoneTopology,
+			pools: []apis.CStorPool{
+				fakePool("pool-1", "node-1", "10G"),
+				fakePool("pool-2", "node-2", "9G"),
+				fakePool("pool-3", "node-3", "1G"),
+			},
+			count: 3,
+			isErr: true,
+		},
+		"strict spread fails on pools without topology": {
+			policy:   placementPolicy{topologyKey: "zone", spread: strictSpread},
+			topology: zoneTopology,
+			pools: []apis.CStorPool{
+				fakePool("pool-1", "node-5", "10G"),
+			},
+			count: 1,
+			isErr: true,
+		},
+		"pools within over commit limit are preferred": {
+			policy:   placementPolicy{topologyKey: hostNameLabel, spread: preferredSpread, overCommit: preferredOverCommit},
+			topology: hostTopology,
+			pools: []apis.CStorPool{
+				fakePool("pool-1", "node-1", "10G", withOverCommit("10G", "19.5G")),
+				fakePool("pool-2", "node-2", "5G", withOverCommit("10G", "10G")),
+			},
+			count:         1,
+			expectedPools: []string{"pool-2"},
+		},
+		"preferred over commit falls back to over committed pools": {
+			policy:   placementPolicy{topologyKey: hostNameLabel, spread: preferredSpread, overCommit: preferredOverCommit},
+			topology: hostTopology,
+			pools: []apis.CStorPool{
+				fakePool("pool-1", "node-1", "10G", withOverCommit("10G", "19.5G")),
+				fakePool("pool-2", "node-2", "5G", withOverCommit("10G", "10G")),
+			},
+			count:         2,
+			expectedPools: []string{"pool-2", "pool-1"},
+		},
+		"strict over commit fails on over committed pools": {
+			policy:   placementPolicy{topologyKey: hostNameLabel, spread: preferredSpread, overCommit: strictOverCommit},
+			topology: hostTopology,
+			pools: []apis.CStorPool{
+				fakePool("pool-1", "node-1", "10G", withOverCommit("10G", "19.5G")),
+				fakePool("pool-2", "node-2", "5G", withOverCommit("10G", "10G")),
+			},
+			count: 2,
+			isErr: true,
+		},
+		"pools without over commit ratio are not limited": {
+			policy:   placementPolicy{topologyKey: hostNameLabel, spread: preferredSpread, overCommit: strictOverCommit},
+			topology: hostTopology,
+			pools: []apis.CStorPool{
+				func() apis.CStorPool {
+					pool := fakePool("pool-1", "node-1", "10G", withOverCommit("10G", "19.5G"))
+					pool.Spec.PoolSpec = apis.CStorPoolAttr{}
+					return pool
+				}(),
+			},
+			count:         1,
+			expectedPools: []string{"pool-1"},
+		},
+		"pools with over commit ratio of one are limited to their size": {
+			policy:   placementPolicy{topologyKey: hostNameLabel, spread: preferredSpread, overCommit: strictOverCommit},
+			topology: hostTopology,
+			pools: []apis.CStorPool{
+				func() apis.CStorPool {
+					pool := fakePool("pool-1", "node-1", "10G", withOverCommit("10G", "9.5G"))
+					pool.Spec.PoolSpec = apis.CStorPoolAttr{OverCommitRatio: "1"}
+					return pool
+				}(),
+			},
+			count: 1,
+			isErr: true,
+		},
+		"not enough pools": {
+			policy:   placementPolicy{topologyKey: hostNameLabel, spread: preferredSpread},
+			topology: hostTopology,
+			pools: []apis.CStorPool{
+				fakePool("pool-1", "node-1", "10G"),
+			},
+			count: 2,
+			isErr: true,
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			p := newPlacement(
+				&mock.policy,
+				"pv-1",
+				1<<30,
+				&apis.CStorPoolList{Items: mock.pools},
+				&apis.CStorVolumeReplicaList{Items: mock.cvrs},
+				mock.topology,
+			)
+			pools, err := p.selectPools(mock.count)
+			if mock.isErr != (err != nil) {
+				t.Fatalf("Test %q failed: expected error '%t': actual error '%v'", name, mock.isErr, err)
+			}
+			if mock.isErr {
+				return
+			}
+			var names []string
+			for _, pool := range pools {
+				names = append(names, pool.Name)
+			}
+			if !reflect.DeepEqual(names, mock.expectedPools) {
+				t.Fatalf("Test %q failed: expected pools '%v': actual pools '%v'", name, mock.expectedPools, names)
+			}
+		})
+	}
+}
+
+func TestListPoolClusterPools(t *testing.T) {
+	fakePoolInstance := func(name, host, ratio, provisioned string) *apis.NewTestCStorPool {
+		return &apis.NewTestCStorPool{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					hostNameLabel:                    host,
+					string(apis.CStorPoolClusterCPK): "cspc-1",
+				},
+			},
+			Spec: apis.NewCStorPoolSpec{
+				PoolConfig: apis.PoolConfig{OverProvisioning: true, OverCommitRatio: ratio},
+			},
+			Status: apis.CStorPoolStatus{
+				Capacity: apis.CStorPoolCapacityAttr{Free: "10G", Total: "10G", Provisioned: provisioned},
+			},
+		}
+	}
+	c := &CVCController{
+		clientset: openebsFakeClientset.NewSimpleClientset(
+			fakePoolInstance("cspi-1", "node-1", "2", "19.5G"),
+			fakePoolInstance("cspi-2", "node-2", "2", "5G"),
+			fakePoolInstance("cspi-3", "node-3", "", "50G"),
+		),
+	}
+	class := &storagev1.StorageClass{
+		Parameters: map[string]string{"cstorPoolCluster": "cspc-1"},
+	}
+
+	if _, err := c.listPools(class, 4); err == nil {
+		t.Fatalf("Test failed: expected error for not enough pools")
+	}
+	poolList, err := c.listPools(class, 2)
+	if err != nil {
+		t.Fatalf("Test failed: expected no error: actual '%v'", err)
+	}
+	if len(poolList.Items) != 3 {
+		t.Fatalf("Test failed: expected 3 pools: actual '%d'", len(poolList.Items))
+	}
+
+	policy := &placementPolicy{topologyKey: hostNameLabel, spread: preferredSpread, overCommit: strictOverCommit}
+	topology := func(pool *apis.CStorPool) string { return pool.Labels[hostNameLabel] }
+	pools, err := newPlacement(policy, "pv-1", 1<<30, poolList, &apis.CStorVolumeReplicaList{}, topology).
+		selectPools(2)
+	if err != nil {
+		t.Fatalf("Test failed: expected no error: actual '%v'", err)
+	}
+	var names []string
+	for _, pool := range pools {
+		names = append(names, pool.Name)
+	}
+	sort.Strings(names)
+	if !reflect.DeepEqual(names, []string{"cspi-2", "cspi-3"}) {
+		t.Fatalf("Test failed: expected pools within over commit limit: actual pools '%v'", names)
+	}
+}
@@ -0,0 +1,448 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cstorvolumeclaim
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/golang/glog"
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	merrors "github.com/openebs/maya/pkg/errors/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// MigrationStarted is the event reason when the replica on the target
+	// pool has been created
+	MigrationStarted = "MigrationStarted"
+	// MigrationSuccess is the event reason when the replica on the source
+	// pool has been removed
+	MigrationSuccess = "MigrationSuccess"
+	// MigrationFailed is the event reason when the replica can not be
+	// migrated
+	MigrationFailed = "MigrationFailed"
+	// MigrationAborted is the event reason when the migration has been
+	// stopped on request
+	MigrationAborted = "MigrationAborted"
+
+	// replicaModeHealthy is the mode reported by the target for a replica
+	// that is in sync with the other replicas
+	replicaModeHealthy = "Healthy"
+)
+
+// migrateReplicas drives the pending replica migrations of the cvc and
+// returns true if a migration is in progress. Migrations of a volume are
+// done one at a time in the order these were created. The volume is not
+// scaled or resized while a migration is in progress since the volume has
+// an additional replica till the migration completes.
+func (c *CVCController) migrateReplicas(
+	cvc *apis.CStorVolumeClaim,
+	cvObj *apis.CStorVolume,
+) (bool, error) {
+	pending, err := c.listPendingReplicaMigrations(cvc)
+	if err != nil {
+		return false, err
+	}
+	if len(pending) == 0 {
+		return false, nil
+	}
+
+	// NEVER modify objects from the store
+	return true, c.syncReplicaMigration(cvc, cvObj, pending[0].DeepCopy())
+}
+
+// migrateUnpublishedReplicas drives the replica migrations of a cvc that
+// is not published to a node. Migrations of a volume whose cstorvolume
+// exists are done as usual. Replicas of a volume that is yet to be
+// created can not be migrated, hence its migrations are left pending
+// with a message that explains the wait.
+func (c *CVCController) migrateUnpublishedReplicas(cvc *apis.CStorVolumeClaim) error {
+	cvObj, err := c.cvLister.CStorVolumes(cvc.Namespace).Get(cvc.Name)
+	if err == nil {
+		_, err = c.migrateReplicas(cvc, cvObj)
+		return err
+	}
+	if !k8serror.IsNotFound(err) {
+		return merrors.Wrapf(err, "failed to get cstorvolume {%s}", cvc.Name)
+	}
+
+	pending, err := c.listPendingReplicaMigrations(cvc)
+	if err != nil {
+		return err
+	}
+	message := fmt.Sprintf("waiting for volume {%s} to be published to a node", cvc.Name)
+	for _, crm := range pending {
+		// NEVER modify objects from the store
+		crm = crm.DeepCopy()
+		if crm.Spec.Abort && crm.Status.TargetReplica == "" {
+			// target replica is yet to be created, there is nothing to undo
+			err = c.abortReplicaMigration(crm, nil, nil)
+		} else {
+			err = c.updateReplicaMigration(crm, crm.Status.Phase, message)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listPendingReplicaMigrations returns the migrations of the cvc that are
+// not done in the order these were created
+func (c *CVCController) listPendingReplicaMigrations(
+	cvc *apis.CStorVolumeClaim,
+) ([]*apis.CStorReplicaMigration, error) {
+	crms, err := c.crmLister.CStorReplicaMigrations(cvc.Namespace).List(klabels.Everything())
+	if err != nil {
+		return nil, merrors.Wrapf(err, "failed to list replica migrations of cvc {%s}", cvc.Name)
+	}
+
+	var pending []*apis.CStorReplicaMigration
+	for _, crm := range crms {
+		if crm.Spec.VolumeName == cvc.Name && !crm.IsDone() {
+			pending = append(pending, crm)
+		}
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].CreationTimestamp.Before(&pending[j].CreationTimestamp)
+	})
+	return pending, nil
+}
+
+// syncReplicaMigration moves the migration to its next phase:
+// 1. Pending: name of the target cvr is recorded, replication factor of
+//    the cstorvolume is raised and the cvr is created on the target pool.
+// 2. Rebuilding: once the new cvr and the target report the replicas as
+//    healthy, replication factor is lowered and the cvr on the source pool
+//    is deleted.
+// An aborted migration deletes the cvr created on the target pool.
+func (c *CVCController) syncReplicaMigration(
+	cvc *apis.CStorVolumeClaim,
+	cvObj *apis.CStorVolume,
+	crm *apis.CStorReplicaMigration,
+) error {
+	cvrs, err := c.listCVRs(cvc)
+	if err != nil {
+		return err
+	}
+
+	if crm.Spec.Abort {
+		return c.abortReplicaMigration(crm, cvObj, cvrs)
+	}
+	switch crm.Status.Phase {
+	case apis.CRMPhasePending:
+		return c.startReplicaMigration(crm, cvObj, cvrs)
+	case apis.CRMPhaseRebuilding:
+		return c.completeReplicaMigration(crm, cvObj, cvrs)
+	}
+	return nil
+}
+
+// startReplicaMigration creates the cvr on the target pool. The name of
+// the target cvr is recorded in the migration before the cvr is created,
+// so that a migration interrupted after the cvr is created is resumed on
+// the next sync instead of being failed.
+func (c *CVCController) startReplicaMigration(
+	crm *apis.CStorReplicaMigration,
+	cvObj *apis.CStorVolume,
+	cvrs []apis.CStorVolumeReplica,
+) error {
+	source := findPoolCVR(cvrs, crm.Spec.SourcePool)
+	if source == nil {
+		return c.failReplicaMigration(crm,
+			fmt.Sprintf("volume has no replica on pool {%s}", crm.Spec.SourcePool),
+		)
+	}
+	target := findPoolCVR(cvrs, crm.Spec.TargetPool)
+	if target != nil && target.Name != crm.Status.TargetReplica {
+		return c.failReplicaMigration(crm,
+			fmt.Sprintf("volume already has a replica on pool {%s}", crm.Spec.TargetPool),
+		)
+	}
+
+	// healthy replicas other than the target replica
+	replicas := len(cvrs)
+	healthy := countHealthyCVRs(cvrs)
+	if target != nil && isCVRHealthy(target) {
+		healthy--
+	}
+	if target == nil {
+		pool, err := c.getMigrationTargetPool(crm)
+		if err != nil || pool == nil {
+			return err
+		}
+		if crm.Status.TargetReplica == "" {
+			crm.Status.SourceReplica = source.Name
+			crm.Status.TargetReplica = getCVRName(cvObj, pool)
+			err = c.updateReplicaMigration(crm, apis.CRMPhasePending,
+				fmt.Sprintf("creating replica {%s} on pool {%s}", crm.Status.TargetReplica, pool.Name),
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		// target should expect the additional replica before it is created
+		replicas++
+		err = c.updateMigrationCVFactors(cvObj, replicas, healthy)
+		if err != nil {
+			return err
+		}
+		target, err = c.createMigrationCVR(source, cvObj, pool)
+		if err != nil {
+			return err
+		}
+		glog.Infof("replica migration {%s}: created replica {%s} on pool {%s}",
+			crm.Name, target.Name, pool.Name)
+	} else {
+		// the target cvr was created by an earlier sync that failed
+		// to move the migration to the next phase
+		glog.Infof("replica migration {%s}: resuming with replica {%s}", crm.Name, target.Name)
+		err := c.updateMigrationCVFactors(cvObj, replicas, healthy)
+		if err != nil {
+			return err
+		}
+	}
+
+	message := fmt.Sprintf("waiting for replica {%s} to be rebuilt", target.Name)
+	c.recorder.Event(crm, corev1.EventTypeNormal, MigrationStarted, message)
+	return c.updateReplicaMigration(crm, apis.CRMPhaseRebuilding, message)
+}
+
+// getMigrationTargetPool returns the target pool of the migration if it
+// is healthy. The migration is marked as failed otherwise, in which case
+// nil is returned.
+func (c *CVCController) getMigrationTargetPool(
+	crm *apis.CStorReplicaMigration,
+) (*apis.CStorPool, error) {
+	pool, err := c.clientset.OpenebsV1alpha1().CStorPools().
+		Get(crm.Spec.TargetPool, metav1.GetOptions{})
+	if k8serror.IsNotFound(err) {
+		return nil, c.failReplicaMigration(crm,
+			fmt.Sprintf("pool {%s} not found", crm.Spec.TargetPool),
+		)
+	}
+	if err != nil {
+		return nil, merrors.Wrapf(err, "failed to get cstorpool {%s}", crm.Spec.TargetPool)
+	}
+	if pool.Status.Phase != apis.CStorPoolStatusOnline {
+		return nil, c.failReplicaMigration(crm,
+			fmt.Sprintf("pool {%s} is not healthy: phase {%s}", pool.Name, pool.Status.Phase),
+		)
+	}
+	return pool, nil
+}
+
+// createMigrationCVR creates the cvr on the target pool, that connects to
+// the same target as the cvr on the source pool. The cvr is returned as is
+// if it already exists.
+func (c *CVCController) createMigrationCVR(
+	source *apis.CStorVolumeReplica,
+	cvObj *apis.CStorVolume,
+	pool *apis.CStorPool,
+) (*apis.CStorVolumeReplica, error) {
+	cvrObj, err := buildCVR(source.Spec.TargetIP, cvObj, pool)
+	if err != nil {
+		return nil, err
+	}
+	cvrObj.Namespace = source.Namespace
+	created, err := c.clientset.OpenebsV1alpha1().
+		CStorVolumeReplicas(cvrObj.Namespace).
+		Create(cvrObj)
+	if k8serror.IsAlreadyExists(err) {
+		created, err = c.clientset.OpenebsV1alpha1().
+			CStorVolumeReplicas(cvrObj.Namespace).
+			Get(cvrObj.Name, metav1.GetOptions{})
+	}
+	if err != nil {
+		return nil, merrors.Wrapf(err, "failed to create cvr {%s}", cvrObj.Name)
+	}
+	return created, nil
+}
+
+// updateMigrationCVFactors updates the factors of the cstorvolume for the
+// given number of replicas during a migration. The consistency factor is
+// raised to the quorum of the replicas if as many of the other replicas
+// are healthy, else it is left as is since io would wait on the replica
+// being rebuilt.
+func (c *CVCController) updateMigrationCVFactors(
+	cvObj *apis.CStorVolume,
+	replicas, healthy int,
+) error {
+	consistencyFactor := getConsistencyFactor(replicas)
+	if healthy < consistencyFactor {
+		consistencyFactor = cvObj.Spec.ConsistencyFactor
+	}
+	if cvObj.Spec.ReplicationFactor == replicas &&
+		cvObj.Spec.ConsistencyFactor == consistencyFactor {
+		return nil
+	}
+	return c.updateCVFactors(cvObj, replicas, consistencyFactor)
+}
+
+// completeReplicaMigration deletes the cvr on the source pool once the cvr
+// on the target pool has been rebuilt
+func (c *CVCController) completeReplicaMigration(
+	crm *apis.CStorReplicaMigration,
+	cvObj *apis.CStorVolume,
+	cvrs []apis.CStorVolumeReplica,
+) error {
+	target := findPoolCVR(cvrs, crm.Spec.TargetPool)
+	if target == nil {
+		return c.failReplicaMigration(crm,
+			fmt.Sprintf("replica {%s} on pool {%s} not found", crm.Status.TargetReplica, crm.Spec.TargetPool),
+		)
+	}
+
+	// the new replica is considered rebuilt once the target reports at
+	// least as many healthy replicas as there are healthy cvrs
+	healthy := countHealthyReplicaStatuses(cvObj)
+	expected := countHealthyCVRs(cvrs)
+	crm.Status.HealthyReplicas = healthy
+	if !isCVRHealthy(target) || healthy < expected {
+		return c.updateReplicaMigration(crm, apis.CRMPhaseRebuilding,
+			fmt.Sprintf(
+				"waiting for replica {%s} to be rebuilt: replica phase {%s}: %d of %d replica(s) healthy",
+				target.Name, target.Status.Phase, healthy, len(cvrs),
+			),
+		)
+	}
+
+	source := findPoolCVR(cvrs, crm.Spec.SourcePool)
+	replicas := len(cvrs)
+	if source != nil {
+		replicas--
+	}
+	// target should stop expecting the source replica before it is deleted
+	err := c.updateCVFactors(cvObj, replicas, getConsistencyFactor(replicas))
+	if err != nil {
+		return err
+	}
+	if source != nil {
+		err = c.clientset.OpenebsV1alpha1().
+			CStorVolumeReplicas(source.Namespace).
+			Delete(source.Name, &metav1.DeleteOptions{})
+		if err != nil && !k8serror.IsNotFound(err) {
+			return merrors.Wrapf(err, "failed to delete cvr {%s}", source.Name)
+		}
+	}
+
+	message := fmt.Sprintf("replica migrated from pool {%s} to pool {%s}",
+		crm.Spec.SourcePool, crm.Spec.TargetPool)
+	glog.Infof("replica migration {%s}: %s", crm.Name, message)
+	c.recorder.Event(crm, corev1.EventTypeNormal, MigrationSuccess, message)
+	return c.updateReplicaMigration(crm, apis.CRMPhaseCompleted, message)
+}
+
+// abortReplicaMigration deletes the cvr created on the target pool and
+// restores the replication and consistency factors of the cstorvolume
+func (c *CVCController) abortReplicaMigration(
+	crm *apis.CStorReplicaMigration,
+	cvObj *apis.CStorVolume,
+	cvrs []apis.CStorVolumeReplica,
+) error {
+	if crm.Status.Phase == apis.CRMPhaseRebuilding || crm.Status.TargetReplica != "" {
+		target := findPoolCVR(cvrs, crm.Spec.TargetPool)
+		if target != nil && target.Name != crm.Status.TargetReplica {
+			// replica on the target pool was not created by the migration
+			target = nil
+		}
+		replicas := len(cvrs)
+		if target != nil {
+			replicas--
+		}
+		err := c.updateCVFactors(cvObj, replicas, getConsistencyFactor(replicas))
+		if err != nil {
+			return err
+		}
+		if target != nil {
+			err = c.clientset.OpenebsV1alpha1().
+				CStorVolumeReplicas(target.Namespace).
+				Delete(target.Name, &metav1.DeleteOptions{})
+			if err != nil && !k8serror.IsNotFound(err) {
+				return merrors.Wrapf(err, "failed to delete cvr {%s}", target.Name)
+			}
+		}
+	}
+
+	message := fmt.Sprintf("migration of replica to pool {%s} aborted", crm.Spec.TargetPool)
+	glog.Infof("replica migration {%s}: %s", crm.Name, message)
+	c.recorder.Event(crm, corev1.EventTypeNormal, MigrationAborted, message)
+	return c.updateReplicaMigration(crm, apis.CRMPhaseAborted, message)
+}
+
+// failReplicaMigration marks the migration as failed
+func (c *CVCController) failReplicaMigration(
+	crm *apis.CStorReplicaMigration,
+	message string,
+) error {
+	glog.Errorf("replica migration {%s} failed: %s", crm.Name, message)
+	c.recorder.Event(crm, corev1.EventTypeWarning, MigrationFailed, message)
+	return c.updateReplicaMigration(crm, apis.CRMPhaseFailed, message)
+}
+
+// updateReplicaMigration updates the phase and message of the migration
+// if these have changed
+func (c *CVCController) updateReplicaMigration(
+	crm *apis.CStorReplicaMigration,
+	phase apis.CStorReplicaMigrationPhase,
+	message string,
+) error {
+	if crm.Status.Phase == phase && crm.Status.Message == message {
+		return nil
+	}
+	now := metav1.Now()
+	if crm.Status.Phase != phase {
+		crm.Status.LastTransitionTime = now
+	}
+	crm.Status.LastUpdateTime = now
+	crm.Status.Phase = phase
+	crm.Status.Message = message
+	updated, err := c.clientset.OpenebsV1alpha1().CStorReplicaMigrations(crm.Namespace).Update(crm)
+	if err != nil {
+		return merrors.Wrapf(err, "failed to update replica migration {%s}", crm.Name)
+	}
+	// later updates in the same sync need the latest resource version
+	*crm = *updated
+	return nil
+}
+
+// findPoolCVR returns the cvr placed on the given pool
+func findPoolCVR(cvrs []apis.CStorVolumeReplica, poolName string) *apis.CStorVolumeReplica {
+	for i := range cvrs {
+		if cvrs[i].Labels[cstorpoolNameLabel] == poolName {
+			return &cvrs[i]
+		}
+	}
+	return nil
+}
+
+// countHealthyReplicaStatuses returns the number of replicas reported as
+// healthy by the target of the volume
+func countHealthyReplicaStatuses(cvObj *apis.CStorVolume) int {
+	healthy := 0
+	for _, rs := range cvObj.Status.ReplicaStatuses {
+		if rs.Mode == replicaModeHealthy {
+			healthy++
+		}
+	}
+	return healthy
+}
@@ -0,0 +1,337 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cstorvolumeclaim
+
+import (
+	"testing"
+
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	openebsFakeClientset "github.com/openebs/maya/pkg/client/generated/clientset/versioned/fake"
+	listers "github.com/openebs/maya/pkg/client/generated/listers/openebs.io/v1alpha1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestSyncReplicaMigration(t *testing.T) {
+	tests := map[string]struct {
+		pending         bool
+		targetReplica   string
+		rf, cf          int
+		cvrs            []*apis.CStorVolumeReplica
+		replicaModes    []string
+		abort           bool
+		expectedPhase   apis.CStorReplicaMigrationPhase
+		expectedRF      int
+		expectedCF      int
+		isSourceDeleted bool
+		isTargetDeleted bool
+		isTargetCreated bool
+	}{
+		"pending migration creates the target replica": {
+			pending: true,
+			rf:      2,
+			cf:      2,
+			cvrs: []*apis.CStorVolumeReplica{
+				fakeCVR("pv-1", "pool-1", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+				fakeCVR("pv-1", "pool-2", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+			},
+			expectedPhase:   apis.CRMPhaseRebuilding,
+			expectedRF:      3,
+			expectedCF:      2,
+			isTargetCreated: true,
+		},
+		"pending migration resumes with the recorded target replica": {
+			pending:       true,
+			targetReplica: "pv-1-pool-3",
+			rf:            2,
+			cf:            2,
+			cvrs: []*apis.CStorVolumeReplica{
+				fakeCVR("pv-1", "pool-1", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+				fakeCVR("pv-1", "pool-2", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+				fakeCVR("pv-1", "pool-3", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusInit)),
+			},
+			expectedPhase:   apis.CRMPhaseRebuilding,
+			expectedRF:      3,
+			expectedCF:      2,
+			isTargetCreated: true,
+		},
+		"pending migration fails if the target pool has another replica": {
+			pending: true,
+			rf:      2,
+			cf:      2,
+			cvrs: []*apis.CStorVolumeReplica{
+				fakeCVR("pv-1", "pool-1", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+				fakeCVR("pv-1", "pool-2", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+				fakeCVR("pv-1", "pool-3", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+			},
+			expectedPhase: apis.CRMPhaseFailed,
+			expectedRF:    2,
+			expectedCF:    2,
+		},
+		"pending migration raises consistency factor to the quorum": {
+			pending: true,
+			cvrs: []*apis.CStorVolumeReplica{
+				fakeCVR("pv-1", "pool-1", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+				fakeCVR("pv-1", "pool-2", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+				fakeCVR("pv-1", "pool-4", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+			},
+			expectedPhase:   apis.CRMPhaseRebuilding,
+			expectedRF:      4,
+			expectedCF:      3,
+			isTargetCreated: true,
+		},
+		"pending migration of a degraded volume retains consistency factor": {
+			pending: true,
+			cvrs: []*apis.CStorVolumeReplica{
+				fakeCVR("pv-1", "pool-1", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+				fakeCVR("pv-1", "pool-2", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOffline)),
+				fakeCVR("pv-1", "pool-4", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+			},
+			expectedPhase:   apis.CRMPhaseRebuilding,
+			expectedRF:      4,
+			expectedCF:      2,
+			isTargetCreated: true,
+		},
+		"target replica is being rebuilt": {
+			cvrs: []*apis.CStorVolumeReplica{
+				fakeCVR("pv-1", "pool-1", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+				fakeCVR("pv-1", "pool-2", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+				fakeCVR("pv-1", "pool-3", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusRebuilding)),
+			},
+			replicaModes:  []string{"Healthy", "Healthy", "Degraded"},
+			expectedPhase: apis.CRMPhaseRebuilding,
+			expectedRF:    3,
+		},
+		"target replica is not yet healthy on the target": {
+			cvrs: []*apis.CStorVolumeReplica{
+				fakeCVR("pv-1", "pool-1", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+				fakeCVR("pv-1", "pool-2", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+				fakeCVR("pv-1", "pool-3", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+			},
+			replicaModes:  []string{"Healthy", "Healthy", "Degraded"},
+			expectedPhase: apis.CRMPhaseRebuilding,
+			expectedRF:    3,
+		},
+		"source replica is removed once target replica is healthy": {
+			cvrs: []*apis.CStorVolumeReplica{
+				fakeCVR("pv-1", "pool-1", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+				fakeCVR("pv-1", "pool-2", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+				fakeCVR("pv-1", "pool-3", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+			},
+			replicaModes:    []string{"Healthy", "Healthy", "Healthy"},
+			expectedPhase:   apis.CRMPhaseCompleted,
+			expectedRF:      2,
+			isSourceDeleted: true,
+		},
+		"offline source replica does not block the migration": {
+			cvrs: []*apis.CStorVolumeReplica{
+				fakeCVR("pv-1", "pool-1", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOffline)),
+				fakeCVR("pv-1", "pool-2", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+				fakeCVR("pv-1", "pool-3", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+			},
+			replicaModes:    []string{"Healthy", "Healthy"},
+			expectedPhase:   apis.CRMPhaseCompleted,
+			expectedRF:      2,
+			isSourceDeleted: true,
+		},
+		"aborted migration removes the target replica": {
+			cvrs: []*apis.CStorVolumeReplica{
+				fakeCVR("pv-1", "pool-1", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+				fakeCVR("pv-1", "pool-2", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+				fakeCVR("pv-1", "pool-3", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusRebuilding)),
+			},
+			abort:           true,
+			expectedPhase:   apis.CRMPhaseAborted,
+			expectedRF:      2,
+			isTargetDeleted: true,
+		},
+		"missing target replica fails the migration": {
+			cvrs: []*apis.CStorVolumeReplica{
+				fakeCVR("pv-1", "pool-1", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+				fakeCVR("pv-1", "pool-2", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+			},
+			expectedPhase: apis.CRMPhaseFailed,
+			expectedRF:    3,
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			phase := apis.CRMPhasePending
+			if !mock.pending {
+				phase = apis.CRMPhaseRebuilding
+				mock.targetReplica = "pv-1-pool-3"
+			}
+			if mock.rf == 0 {
+				mock.rf, mock.cf = 3, 2
+			}
+			cvObj := &apis.CStorVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-1", Namespace: "openebs"},
+				Spec:       apis.CStorVolumeSpec{Capacity: "5G", ReplicationFactor: mock.rf, ConsistencyFactor: mock.cf},
+			}
+			for _, mode := range mock.replicaModes {
+				cvObj.Status.ReplicaStatuses = append(cvObj.Status.ReplicaStatuses, apis.ReplicaStatus{Mode: mode})
+			}
+			crm := &apis.CStorReplicaMigration{
+				ObjectMeta: metav1.ObjectMeta{Name: "crm-1", Namespace: "openebs"},
+				Spec: apis.CStorReplicaMigrationSpec{
+					VolumeName: "pv-1",
+					SourcePool: "pool-1",
+					TargetPool: "pool-3",
+					Abort:      mock.abort,
+				},
+				Status: apis.CStorReplicaMigrationStatus{
+					Phase:         phase,
+					TargetReplica: mock.targetReplica,
+				},
+			}
+			targetPool := fakePool("pool-3", "node-3", "10G", withPoolPhase(apis.CStorPoolStatusOnline))
+			objs := []runtime.Object{cvObj, crm, &targetPool}
+			for _, cvrObj := range mock.cvrs {
+				objs = append(objs, cvrObj)
+			}
+			c := &CVCController{
+				clientset: openebsFakeClientset.NewSimpleClientset(objs...),
+				recorder:  record.NewFakeRecorder(10),
+			}
+			cvc := &apis.CStorVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-1", Namespace: "openebs"},
+			}
+
+			err := c.syncReplicaMigration(cvc, cvObj.DeepCopy(), crm.DeepCopy())
+			if err != nil {
+				t.Fatalf("Test %q failed: expected no error: actual error '%v'", name, err)
+			}
+
+			gotCRM, _ := c.clientset.OpenebsV1alpha1().CStorReplicaMigrations("openebs").Get("crm-1", metav1.GetOptions{})
+			if gotCRM.Status.Phase != mock.expectedPhase {
+				t.Fatalf("Test %q failed: expected phase '%s': actual phase '%s'", name, mock.expectedPhase, gotCRM.Status.Phase)
+			}
+			gotCV, _ := c.clientset.OpenebsV1alpha1().CStorVolumes("openebs").Get("pv-1", metav1.GetOptions{})
+			if gotCV.Spec.ReplicationFactor != mock.expectedRF {
+				t.Fatalf("Test %q failed: expected replication factor '%d': actual '%d'", name, mock.expectedRF, gotCV.Spec.ReplicationFactor)
+			}
+			if mock.expectedCF != 0 && gotCV.Spec.ConsistencyFactor != mock.expectedCF {
+				t.Fatalf("Test %q failed: expected consistency factor '%d': actual '%d'", name, mock.expectedCF, gotCV.Spec.ConsistencyFactor)
+			}
+			if mock.isTargetCreated {
+				_, err = c.clientset.OpenebsV1alpha1().CStorVolumeReplicas("openebs").Get("pv-1-pool-3", metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("Test %q failed: expected target replica to be created: actual error '%v'", name, err)
+				}
+				if gotCRM.Status.TargetReplica != "pv-1-pool-3" {
+					t.Fatalf("Test %q failed: expected target replica 'pv-1-pool-3' in status: actual '%s'", name, gotCRM.Status.TargetReplica)
+				}
+			}
+			_, err = c.clientset.OpenebsV1alpha1().CStorVolumeReplicas("openebs").Get("pv-1-pool-1", metav1.GetOptions{})
+			if mock.isSourceDeleted != k8serror.IsNotFound(err) {
+				t.Fatalf("Test %q failed: expected source replica deleted '%t': actual error '%v'", name, mock.isSourceDeleted, err)
+			}
+			if mock.isTargetDeleted {
+				_, err = c.clientset.OpenebsV1alpha1().CStorVolumeReplicas("openebs").Get("pv-1-pool-3", metav1.GetOptions{})
+				if !k8serror.IsNotFound(err) {
+					t.Fatalf("Test %q failed: expected target replica to be deleted: actual error '%v'", name, err)
+				}
+			}
+		})
+	}
+}
+
+func TestMigrateUnpublishedReplicas(t *testing.T) {
+	tests := map[string]struct {
+		isVolumeCreated bool
+		abort           bool
+		expectedPhase   apis.CStorReplicaMigrationPhase
+		expectedMessage string
+		isTargetCreated bool
+	}{
+		"migration of a created volume creates the target replica": {
+			isVolumeCreated: true,
+			expectedPhase:   apis.CRMPhaseRebuilding,
+			isTargetCreated: true,
+		},
+		"migration of a volume yet to be created waits for publish": {
+			expectedPhase:   apis.CRMPhasePending,
+			expectedMessage: "waiting for volume {pv-1} to be published to a node",
+		},
+		"aborted migration of a volume yet to be created is aborted": {
+			abort:           true,
+			expectedPhase:   apis.CRMPhaseAborted,
+			expectedMessage: "migration of replica to pool {pool-3} aborted",
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			crm := &apis.CStorReplicaMigration{
+				ObjectMeta: metav1.ObjectMeta{Name: "crm-1", Namespace: "openebs"},
+				Spec: apis.CStorReplicaMigrationSpec{
+					VolumeName: "pv-1",
+					SourcePool: "pool-1",
+					TargetPool: "pool-3",
+					Abort:      mock.abort,
+				},
+			}
+			targetPool := fakePool("pool-3", "node-3", "10G", withPoolPhase(apis.CStorPoolStatusOnline))
+			objs := []runtime.Object{crm, &targetPool}
+			cvIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			if mock.isVolumeCreated {
+				cvObj := &apis.CStorVolume{
+					ObjectMeta: metav1.ObjectMeta{Name: "pv-1", Namespace: "openebs"},
+					Spec:       apis.CStorVolumeSpec{Capacity: "5G", ReplicationFactor: 2, ConsistencyFactor: 2},
+				}
+				objs = append(objs,
+					cvObj,
+					fakeCVR("pv-1", "pool-1", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+					fakeCVR("pv-1", "pool-2", withCVRTargetIP("10.0.0.1"), withCVRPhase(apis.CVRStatusOnline)),
+				)
+				cvIndexer.Add(cvObj)
+			}
+			crmIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			crmIndexer.Add(crm)
+			c := &CVCController{
+				clientset: openebsFakeClientset.NewSimpleClientset(objs...),
+				cvLister:  listers.NewCStorVolumeLister(cvIndexer),
+				crmLister: listers.NewCStorReplicaMigrationLister(crmIndexer),
+				recorder:  record.NewFakeRecorder(10),
+			}
+			cvc := &apis.CStorVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-1", Namespace: "openebs"},
+			}
+
+			err := c.migrateUnpublishedReplicas(cvc)
+			if err != nil {
+				t.Fatalf("Test %q failed: expected no error: actual error '%v'", name, err)
+			}
+
+			gotCRM, _ := c.clientset.OpenebsV1alpha1().CStorReplicaMigrations("openebs").Get("crm-1", metav1.GetOptions{})
+			if gotCRM.Status.Phase != mock.expectedPhase {
+				t.Fatalf("Test %q failed: expected phase '%s': actual phase '%s'", name, mock.expectedPhase, gotCRM.Status.Phase)
+			}
+			if mock.expectedMessage != "" && gotCRM.Status.Message != mock.expectedMessage {
+				t.Fatalf("Test %q failed: expected message '%s': actual message '%s'", name, mock.expectedMessage, gotCRM.Status.Message)
+			}
+			_, err = c.clientset.OpenebsV1alpha1().CStorVolumeReplicas("openebs").Get("pv-1-pool-3", metav1.GetOptions{})
+			if mock.isTargetCreated != (err == nil) {
+				t.Fatalf("Test %q failed: expected target replica created '%t': actual error '%v'", name, mock.isTargetCreated, err)
+			}
+		})
+	}
+}
@@ -78,6 +78,7 @@ func Start(controllerMtx *sync.RWMutex) error {
 	controllerMtx.Lock()
 
 	controller, err := NewCVCControllerBuilder().
+		withConfig(cfg).
 		withKubeClient(kubeClient).
 		withOpenEBSClient(openebsClient).
 		withNDMClient(ndmClient).
@@ -86,6 +87,7 @@ func Start(controllerMtx *sync.RWMutex) error {
 		withCVLister(cvcInformerFactory).
 		withCVRLister(cvcInformerFactory).
 		withCVRInformerSync(cvcInformerFactory).
+		withCRMLister(cvcInformerFactory).
 		withCVCStore().
 		withRecorder(kubeClient).
 		withEventHandler(cvcInformerFactory).
@@ -30,6 +30,7 @@ func NewCommand() *cobra.Command {
 	cmd.AddCommand(
 		NewCmdVersion(),
 		NewCmdStart(),
+		NewCmdOpenAPI(),
 	)
 
 	// fix glog parse error
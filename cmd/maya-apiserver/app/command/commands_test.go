@@ -22,7 +22,7 @@ func TestNewCommand(t *testing.T) {
 	cases := []struct {
 		use string
 	}{
-		{"start"}, {"version"},
+		{"openapi"}, {"start"}, {"version"},
 	}
 
 	cmd := NewCommand()
@@ -0,0 +1,69 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+
+	"github.com/openebs/maya/cmd/maya-apiserver/app/server"
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdOpenAPI creates the openapi command
+func NewCmdOpenAPI() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "openapi",
+		Short: "Prints the OpenAPI document of maya-apiserver REST API",
+		Long: `Prints the OpenAPI document of the versioned maya-apiserver
+REST API. The document is used to generate typed clients.
+
+Usage:
+maya-apiserver openapi [-o openapi.json]
+	`,
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return writeOpenAPI(output)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "",
+		"file to write the document to; defaults to stdout")
+
+	return cmd
+}
+
+// writeOpenAPI writes the OpenAPI document to the given file or
+// else to stdout
+func writeOpenAPI(output string) error {
+	body, err := json.MarshalIndent(server.NewOpenAPIDocument(), "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal openapi document")
+	}
+	body = append(body, '\n')
+	if output == "" {
+		_, err = os.Stdout.Write(body)
+		return err
+	}
+	if err := ioutil.WriteFile(output, body, 0644); err != nil {
+		return errors.Wrapf(err, "failed to write openapi document to {%s}", output)
+	}
+	return nil
+}
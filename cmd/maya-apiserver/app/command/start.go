@@ -34,9 +34,12 @@ import (
 	"github.com/openebs/maya/cmd/maya-apiserver/app/server"
 	"github.com/openebs/maya/cmd/maya-apiserver/cstor-operator/cspc"
 	"github.com/openebs/maya/cmd/maya-apiserver/cstor-operator/spc"
+	jivahealth "github.com/openebs/maya/cmd/maya-apiserver/jiva-operator/health"
+	jivasnapshot "github.com/openebs/maya/cmd/maya-apiserver/jiva-operator/snapshot"
 	env "github.com/openebs/maya/pkg/env/v1alpha1"
 	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
 	install "github.com/openebs/maya/pkg/install/v1alpha1"
+	trace "github.com/openebs/maya/pkg/trace/v1alpha1"
 	"github.com/openebs/maya/pkg/usage"
 	"github.com/openebs/maya/pkg/util"
 	"github.com/openebs/maya/pkg/version"
@@ -87,7 +90,17 @@ type CmdStartOptions struct {
 	LogLevel   string
 	ConfigPath string
 	ShutdownCh <-chan struct{}
-	args       []string
+
+	// TLS & auth options of http api that override
+	// the config file
+	TLSCertFile          string
+	TLSKeyFile           string
+	TLSCAFile            string
+	TLSVerifyIncoming    bool
+	EnableAuthentication bool
+	EnableAuthorization  bool
+
+	args []string
 
 	// TODO
 	// Check if both maya & httpServer instances are required ?
@@ -120,6 +133,24 @@ func NewCmdStart() *cobra.Command {
 	cmd.Flags().StringVarP(&options.ConfigPath, "config", "", options.ConfigPath,
 		"Path to a single config file or directory.")
 
+	cmd.Flags().StringVarP(&options.TLSCertFile, "tls-cert-file", "", options.TLSCertFile,
+		"Path of the certificate to serve maya apiserver over TLS.")
+
+	cmd.Flags().StringVarP(&options.TLSKeyFile, "tls-private-key-file", "", options.TLSKeyFile,
+		"Path of the private key of the TLS certificate.")
+
+	cmd.Flags().StringVarP(&options.TLSCAFile, "client-ca-file", "", options.TLSCAFile,
+		"Path of the CA certificate to verify the client certificates.")
+
+	cmd.Flags().BoolVarP(&options.TLSVerifyIncoming, "tls-verify-incoming", "", options.TLSVerifyIncoming,
+		"Require clients to present a certificate signed by client CA i.e. mutual TLS.")
+
+	cmd.Flags().BoolVarP(&options.EnableAuthentication, "enable-authentication", "", options.EnableAuthentication,
+		"Authenticate requests via client certificate or bearer token reviewed by Kubernetes.")
+
+	cmd.Flags().BoolVarP(&options.EnableAuthorization, "enable-authorization", "", options.EnableAuthorization,
+		"Authorize requests via Kubernetes SubjectAccessReview.")
+
 	return cmd
 }
 
@@ -136,6 +167,13 @@ func Run(cmd *cobra.Command, c *CmdStartOptions) error {
 
 	//TODO Setup Log Level
 
+	// Setup tracing of volume provisioning
+	shutdownTracing, err := trace.Init("maya-apiserver")
+	if err != nil {
+		return err
+	}
+	defer shutdownTracing()
+
 	// Setup Maya server
 	if err := c.setupMayaServer(mconfig); err != nil {
 		return err
@@ -198,6 +236,18 @@ func Run(cmd *cobra.Command, c *CmdStartOptions) error {
 			glog.Errorf("Failed to start cstorvolume claim controller: %s", err.Error())
 		}
 	}()
+	go func() {
+		err := jivasnapshot.Start()
+		if err != nil {
+			glog.Errorf("Failed to start jiva snapshot controller: %s", err.Error())
+		}
+	}()
+	go func() {
+		err := jivahealth.Start()
+		if err != nil {
+			glog.Errorf("Failed to start jiva health controller: %s", err.Error())
+		}
+	}()
 
 	if env.Truthy(env.OpenEBSEnableAnalytics) {
 		usage.New().Build().InstallBuilder(true).Send()
@@ -251,6 +301,17 @@ func (c *CmdStartOptions) readMayaConfig() *config.MayaConfig {
 	if c.LogLevel != "" {
 		mconfig.LogLevel = c.LogLevel
 	}
+	mconfig.TLSConfig = mconfig.TLSConfig.Merge(&config.TLSConfig{
+		EnableHTTP:     c.TLSCertFile != "",
+		CertFile:       c.TLSCertFile,
+		KeyFile:        c.TLSKeyFile,
+		CAFile:         c.TLSCAFile,
+		VerifyIncoming: c.TLSVerifyIncoming,
+	})
+	mconfig.AuthConfig = mconfig.AuthConfig.Merge(&config.AuthConfig{
+		EnableAuthentication: c.EnableAuthentication,
+		EnableAuthorization:  c.EnableAuthorization,
+	})
 
 	// Normalize binds, ports, addresses, and advertise
 	if err := mconfig.NormalizeAddrs(); err != nil {
@@ -97,6 +97,49 @@ type MayaConfig struct {
 	// HTTPAPIResponseHeaders allows users to configure the http agent to
 	// set arbitrary headers on API responses
 	HTTPAPIResponseHeaders map[string]string `mapstructure:"http_api_response_headers" json:"http_api_response_headers"`
+
+	// TLSConfig is used to serve the http api over TLS
+	TLSConfig *TLSConfig `mapstructure:"tls" json:"tls"`
+
+	// AuthConfig is used to authenticate & authorize the
+	// requests to http api
+	AuthConfig *AuthConfig `mapstructure:"auth" json:"auth"`
+}
+
+// TLSConfig is the TLS configuration of the http api
+type TLSConfig struct {
+	// EnableHTTP serves the http api over TLS
+	EnableHTTP bool `mapstructure:"http" json:"http"`
+
+	// CertFile is the path of the server certificate
+	CertFile string `mapstructure:"cert_file" json:"cert_file"`
+
+	// KeyFile is the path of the private key of server
+	// certificate
+	KeyFile string `mapstructure:"key_file" json:"key_file"`
+
+	// CAFile is the path of the CA certificate used to
+	// verify the client certificates
+	CAFile string `mapstructure:"ca_file" json:"ca_file"`
+
+	// VerifyIncoming requires every client to present a
+	// certificate signed by CA i.e. mutual TLS. If false, a
+	// client certificate is verified only if presented.
+	VerifyIncoming bool `mapstructure:"verify_incoming" json:"verify_incoming"`
+}
+
+// AuthConfig is the authentication & authorization
+// configuration of the http api
+type AuthConfig struct {
+	// EnableAuthentication authenticates every request via
+	// client certificate or bearer token. Bearer tokens are
+	// validated via kubernetes TokenReview.
+	EnableAuthentication bool `mapstructure:"enable_authentication" json:"enable_authentication"`
+
+	// EnableAuthorization authorizes every authenticated
+	// request via kubernetes SubjectAccessReview against the
+	// namespace of the request
+	EnableAuthorization bool `mapstructure:"enable_authorization" json:"enable_authorization"`
 }
 
 // Ports encapsulates the various ports we bind to for network services. If any
@@ -118,6 +161,39 @@ type AdvertiseAddrs struct {
 	HTTP string `mapstructure:"http" json:"http"`
 }
 
+// Merge is used to merge two TLS configs together
+func (t *TLSConfig) Merge(b *TLSConfig) *TLSConfig {
+	result := *t
+	if b.EnableHTTP {
+		result.EnableHTTP = true
+	}
+	if b.CertFile != "" {
+		result.CertFile = b.CertFile
+	}
+	if b.KeyFile != "" {
+		result.KeyFile = b.KeyFile
+	}
+	if b.CAFile != "" {
+		result.CAFile = b.CAFile
+	}
+	if b.VerifyIncoming {
+		result.VerifyIncoming = true
+	}
+	return &result
+}
+
+// Merge is used to merge two auth configs together
+func (a *AuthConfig) Merge(b *AuthConfig) *AuthConfig {
+	result := *a
+	if b.EnableAuthentication {
+		result.EnableAuthentication = true
+	}
+	if b.EnableAuthorization {
+		result.EnableAuthorization = true
+	}
+	return &result
+}
+
 // String implements Stringer interface
 func (c *MayaConfig) String() string {
 	return stringer.Yaml("maya config", c)
@@ -140,6 +216,8 @@ func DefaultMayaConfig() *MayaConfig {
 		},
 		Addresses:      &Addresses{},
 		AdvertiseAddrs: &AdvertiseAddrs{},
+		TLSConfig:      &TLSConfig{},
+		AuthConfig:     &AuthConfig{},
 		SyslogFacility: "LOCAL0",
 		LeaveOnTerm:    true,
 	}
@@ -232,6 +310,22 @@ func (mc *MayaConfig) Merge(b *MayaConfig) *MayaConfig {
 		result.AdvertiseAddrs = result.AdvertiseAddrs.Merge(b.AdvertiseAddrs)
 	}
 
+	// Apply the tls config
+	if result.TLSConfig == nil && b.TLSConfig != nil {
+		tlsConfig := *b.TLSConfig
+		result.TLSConfig = &tlsConfig
+	} else if b.TLSConfig != nil {
+		result.TLSConfig = result.TLSConfig.Merge(b.TLSConfig)
+	}
+
+	// Apply the auth config
+	if result.AuthConfig == nil && b.AuthConfig != nil {
+		authConfig := *b.AuthConfig
+		result.AuthConfig = &authConfig
+	} else if b.AuthConfig != nil {
+		result.AuthConfig = result.AuthConfig.Merge(b.AuthConfig)
+	}
+
 	// Merge config files lists
 	result.Files = append(result.Files, b.Files...)
 
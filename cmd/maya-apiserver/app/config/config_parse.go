@@ -104,6 +104,8 @@ func parseConfig(result *MayaConfig, list *ast.ObjectList) error {
 		"enable_syslog",
 		"syslog_facility",
 		"http_api_response_headers",
+		"tls",
+		"auth",
 	}
 	err := checkHCLKeys(list, valid)
 	if err != nil {
@@ -121,6 +123,8 @@ func parseConfig(result *MayaConfig, list *ast.ObjectList) error {
 	delete(m, "interfaces")
 	delete(m, "advertise")
 	delete(m, "http_api_response_headers")
+	delete(m, "tls")
+	delete(m, "auth")
 
 	// Decode the rest
 	err = mapstructure.WeakDecode(m, result)
@@ -152,6 +156,22 @@ func parseConfig(result *MayaConfig, list *ast.ObjectList) error {
 		}
 	}
 
+	// Parse tls
+	if o := list.Filter("tls"); len(o.Items) > 0 {
+		err := parseTLSConfig(&result.TLSConfig, o)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse maya config: failed to parse tls: %+v", o)
+		}
+	}
+
+	// Parse auth
+	if o := list.Filter("auth"); len(o.Items) > 0 {
+		err := parseAuthConfig(&result.AuthConfig, o)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse maya config: failed to parse auth: %+v", o)
+		}
+	}
+
 	// Parse out http_api_response_headers fields. These are in HCL as a list so
 	// we need to iterate over them and merge them.
 	if headersO := list.Filter("http_api_response_headers"); len(headersO.Items) > 0 {
@@ -270,6 +290,77 @@ func parseAdvertise(result **AdvertiseAddrs, list *ast.ObjectList) error {
 	return nil
 }
 
+func parseTLSConfig(result **TLSConfig, list *ast.ObjectList) error {
+	list = list.Elem()
+	if len(list.Items) > 1 {
+		return errors.Errorf("failed to parse tls: only one 'tls' block allowed")
+	}
+
+	// Get our tls object
+	listVal := list.Items[0].Val
+
+	// Check for invalid keys
+	valid := []string{
+		"http",
+		"cert_file",
+		"key_file",
+		"ca_file",
+		"verify_incoming",
+	}
+	err := checkHCLKeys(listVal, valid)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse tls: invalid keys found {%+v}: supported keys {%+v}", listVal, valid)
+	}
+
+	var m map[string]interface{}
+	err = hcl.DecodeObject(&m, listVal)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse tls: %+v", listVal)
+	}
+
+	var tlsConfig TLSConfig
+	err = mapstructure.WeakDecode(m, &tlsConfig)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse tls: %+v", m)
+	}
+	*result = &tlsConfig
+	return nil
+}
+
+func parseAuthConfig(result **AuthConfig, list *ast.ObjectList) error {
+	list = list.Elem()
+	if len(list.Items) > 1 {
+		return errors.Errorf("failed to parse auth: only one 'auth' block allowed")
+	}
+
+	// Get our auth object
+	listVal := list.Items[0].Val
+
+	// Check for invalid keys
+	valid := []string{
+		"enable_authentication",
+		"enable_authorization",
+	}
+	err := checkHCLKeys(listVal, valid)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse auth: invalid keys found {%+v}: supported keys {%+v}", listVal, valid)
+	}
+
+	var m map[string]interface{}
+	err = hcl.DecodeObject(&m, listVal)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse auth: %+v", listVal)
+	}
+
+	var authConfig AuthConfig
+	err = mapstructure.WeakDecode(m, &authConfig)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse auth: %+v", m)
+	}
+	*result = &authConfig
+	return nil
+}
+
 func checkHCLKeys(node ast.Node, valid []string) error {
 	var list *ast.ObjectList
 	switch n := node.(type) {
@@ -50,6 +50,17 @@ func TestMayaConfig_Parse(t *testing.T) {
 				HTTPAPIResponseHeaders: map[string]string{
 					"Access-Control-Allow-Origin": "*",
 				},
+				TLSConfig: &TLSConfig{
+					EnableHTTP:     true,
+					CertFile:       "/etc/maya/tls/server.crt",
+					KeyFile:        "/etc/maya/tls/server.key",
+					CAFile:         "/etc/maya/tls/ca.crt",
+					VerifyIncoming: true,
+				},
+				AuthConfig: &AuthConfig{
+					EnableAuthentication: true,
+					EnableAuthorization:  true,
+				},
 			},
 			false,
 		},
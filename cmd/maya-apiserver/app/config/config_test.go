@@ -79,6 +79,14 @@ func TestMayaConfig_Merge(t *testing.T) {
 			"Access-Control-Allow-Origin":  "*",
 			"Access-Control-Allow-Methods": "GET, POST, OPTIONS",
 		},
+		TLSConfig: &TLSConfig{
+			EnableHTTP: true,
+			CertFile:   "/etc/maya/tls/server.crt",
+			KeyFile:    "/etc/maya/tls/server.key",
+		},
+		AuthConfig: &AuthConfig{
+			EnableAuthentication: true,
+		},
 	}
 
 	result := c1.Merge(c2)
@@ -20,3 +20,14 @@ syslog_facility = "LOCAL1"
 http_api_response_headers {
 	Access-Control-Allow-Origin = "*"
 }
+tls {
+	http = true
+	cert_file = "/etc/maya/tls/server.crt"
+	key_file = "/etc/maya/tls/server.key"
+	ca_file = "/etc/maya/tls/ca.crt"
+	verify_incoming = true
+}
+auth {
+	enable_authentication = true
+	enable_authorization = true
+}
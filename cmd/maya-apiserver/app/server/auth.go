@@ -0,0 +1,325 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/glog"
+	"github.com/openebs/maya/cmd/maya-apiserver/app/config"
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+	authnv1 "k8s.io/api/authentication/v1"
+	authzv1 "k8s.io/api/authorization/v1"
+	authnclient "k8s.io/client-go/kubernetes/typed/authentication/v1"
+	authzclient "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+const (
+	// authorizationHeader is the http header that has the
+	// bearer token of the user
+	authorizationHeader = "Authorization"
+
+	// bearerPrefix is the prefix of the bearer token in
+	// authorization header
+	bearerPrefix = "Bearer "
+
+	// authzAPIGroup is the api group of the resources served
+	// by maya apiserver that are authorized via RBAC e.g.
+	// a Role granting verbs {get, create, delete} on resource
+	// {volumes} of api group {openebs.io}
+	authzAPIGroup = "openebs.io"
+
+	// namespaceParam is the query param that has the namespace
+	// of the resource e.g. of the volume of snapshot requests
+	namespaceParam = "namespace"
+)
+
+// userInfo is the authenticated user of a request
+type userInfo struct {
+	name   string
+	uid    string
+	groups []string
+	extra  map[string]authnv1.ExtraValue
+}
+
+// accessAttributes are the attributes of a request that are
+// authorized for the user
+type accessAttributes struct {
+	verb      string
+	resource  string
+	namespace string
+	name      string
+}
+
+// authenticator authenticates the user of a request
+type authenticator interface {
+	authenticate(req *http.Request) (*userInfo, error)
+}
+
+// authorizer authorizes the user to access the resource of a
+// request
+type authorizer interface {
+	authorize(user *userInfo, attrs accessAttributes) error
+}
+
+// requestAuthenticator authenticates the user via the verified
+// client certificate or else via the bearer token that is
+// validated by kubernetes TokenReview
+type requestAuthenticator struct {
+	tokenReviews authnclient.TokenReviewInterface
+}
+
+// authenticate implements authenticator
+func (a *requestAuthenticator) authenticate(req *http.Request) (*userInfo, error) {
+	if req.TLS != nil && len(req.TLS.VerifiedChains) != 0 && len(req.TLS.VerifiedChains[0]) != 0 {
+		cert := req.TLS.VerifiedChains[0][0]
+		if cert.Subject.CommonName != "" {
+			return &userInfo{
+				name:   cert.Subject.CommonName,
+				groups: cert.Subject.Organization,
+			}, nil
+		}
+	}
+
+	header := req.Header.Get(authorizationHeader)
+	if !strings.HasPrefix(header, bearerPrefix) {
+		return nil, CodedError(401, "unauthorized: missing client certificate or bearer token")
+	}
+	token := strings.TrimSpace(strings.TrimPrefix(header, bearerPrefix))
+	if token == "" {
+		return nil, CodedError(401, "unauthorized: empty bearer token")
+	}
+
+	review, err := a.tokenReviews.Create(&authnv1.TokenReview{
+		Spec: authnv1.TokenReviewSpec{Token: token},
+	})
+	if err != nil {
+		return nil, CodedErrorWrap(500, errors.Wrap(err, "failed to authenticate: failed to review token"))
+	}
+	if !review.Status.Authenticated {
+		return nil, CodedErrorf(401, "unauthorized: invalid bearer token: %s", review.Status.Error)
+	}
+	return &userInfo{
+		name:   review.Status.User.Username,
+		uid:    review.Status.User.UID,
+		groups: review.Status.User.Groups,
+		extra:  review.Status.User.Extra,
+	}, nil
+}
+
+// subjectAccessReviewer authorizes the user via kubernetes
+// SubjectAccessReview so that the RBAC rules of the cluster
+// decide who can access the volumes of which namespace
+type subjectAccessReviewer struct {
+	reviews authzclient.SubjectAccessReviewInterface
+}
+
+// authorize implements authorizer
+func (a *subjectAccessReviewer) authorize(user *userInfo, attrs accessAttributes) error {
+	extra := map[string]authzv1.ExtraValue{}
+	for k, v := range user.extra {
+		extra[k] = authzv1.ExtraValue(v)
+	}
+	review, err := a.reviews.Create(&authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			User:   user.name,
+			UID:    user.uid,
+			Groups: user.groups,
+			Extra:  extra,
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Namespace: attrs.namespace,
+				Verb:      attrs.verb,
+				Group:     authzAPIGroup,
+				Resource:  attrs.resource,
+				Name:      attrs.name,
+			},
+		},
+	})
+	if err != nil {
+		return CodedErrorWrap(500, errors.Wrap(err, "failed to authorize: failed to review subject access"))
+	}
+	if !review.Status.Allowed {
+		return CodedErrorf(403,
+			"forbidden: user {%s} cannot %s resource {%s} in api group {%s} in namespace {%s}: %s",
+			user.name, attrs.verb, attrs.resource, authzAPIGroup, attrs.namespace, review.Status.Reason)
+	}
+	return nil
+}
+
+// authorize wraps the handler of the given resource to authenticate
+// & authorize every request. The handler is returned as is if
+// authentication is not enabled.
+func (s *HTTPServer) authorize(
+	resource string,
+	handler func(resp http.ResponseWriter, req *http.Request) (interface{}, error),
+) func(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if s.authn == nil {
+		return handler
+	}
+	prefix := apis.LatestPrefix + resource + "/"
+	return func(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+		user, err := s.authn.authenticate(req)
+		if err != nil {
+			return nil, err
+		}
+		if s.authz != nil {
+			attrs, err := getAccessAttributes(req, resource, prefix)
+			if err != nil {
+				return nil, err
+			}
+			if err := s.authz.authorize(user, attrs); err != nil {
+				return nil, err
+			}
+		}
+		glog.V(4).Infof("authorized user {%s}: %s %s", user.name, req.Method, req.URL.Path)
+		return handler(resp, req)
+	}
+}
+
+// getAccessAttributes returns the attributes of the given request.
+// The namespace of the request is taken from the namespace header,
+// the namespace query param or the metadata of the request body
+// since the handlers read it from either of these. A request whose
+// sources have different namespaces is rejected, so that the user
+// can not act on a namespace other than the authorized one.
+func getAccessAttributes(req *http.Request, resource, prefix string) (accessAttributes, error) {
+	attrs := accessAttributes{resource: resource}
+	if strings.HasPrefix(req.URL.Path, prefix) {
+		attrs.name = strings.Split(strings.TrimPrefix(req.URL.Path, prefix), "/")[0]
+	}
+
+	switch req.Method {
+	case "GET":
+		attrs.verb = "get"
+		if attrs.name == "" {
+			attrs.verb = "list"
+			if watch, _ := strconv.ParseBool(req.URL.Query().Get(apis.WatchParam)); watch {
+				attrs.verb = "watch"
+			}
+		}
+	case "POST":
+		attrs.verb = "create"
+	case "PUT":
+		attrs.verb = "update"
+	case "PATCH":
+		attrs.verb = "patch"
+	case "DELETE":
+		attrs.verb = "delete"
+	default:
+		return attrs, CodedErrorf(405, "%s: %s", ErrInvalidMethod, req.Method)
+	}
+
+	bodyNamespace, err := getBodyNamespace(req)
+	if err != nil {
+		return attrs, CodedErrorWrap(400, err)
+	}
+	sources := []struct{ source, namespace string }{
+		{"request header", req.Header.Get(NamespaceKey)},
+		{"query param", req.URL.Query().Get(namespaceParam)},
+		{"request body", bodyNamespace},
+	}
+	from := ""
+	for _, s := range sources {
+		if s.namespace == "" {
+			continue
+		}
+		if attrs.namespace == "" {
+			attrs.namespace, from = s.namespace, s.source
+			continue
+		}
+		if s.namespace != attrs.namespace {
+			return attrs, CodedErrorf(403,
+				"forbidden: namespace {%s} of %s does not match namespace {%s} of %s",
+				s.namespace, s.source, attrs.namespace, from)
+		}
+	}
+	return attrs, nil
+}
+
+// getBodyNamespace returns the namespace set in the metadata of the
+// request body. The body is restored to be decoded by the handler.
+func getBodyNamespace(req *http.Request) (string, error) {
+	if req.Body == nil {
+		return "", nil
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read request body")
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if len(bytes.TrimSpace(body)) == 0 {
+		return "", nil
+	}
+
+	obj := struct {
+		Metadata struct {
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+	}{}
+	// body that is not an object e.g. list is decoded by
+	// the handler & has no namespace to authorize
+	if err := yaml.Unmarshal(body, &obj); err != nil {
+		return "", nil
+	}
+	return obj.Metadata.Namespace, nil
+}
+
+// newTLSConfig returns the TLS configuration of http server
+func newTLSConfig(c *config.TLSConfig) (*tls.Config, error) {
+	if c.CertFile == "" || c.KeyFile == "" {
+		return nil, errors.New("failed to build tls config: missing cert file or key file")
+	}
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build tls config: failed to load key pair {%s, %s}",
+			c.CertFile, c.KeyFile)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if c.CAFile == "" {
+		if c.VerifyIncoming {
+			return nil, errors.New("failed to build tls config: verify incoming requires ca file")
+		}
+		return tlsConfig, nil
+	}
+	ca, err := ioutil.ReadFile(c.CAFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build tls config: failed to read ca file {%s}", c.CAFile)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, errors.Errorf("failed to build tls config: invalid ca file {%s}", c.CAFile)
+	}
+	tlsConfig.ClientCAs = pool
+	tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	if c.VerifyIncoming {
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
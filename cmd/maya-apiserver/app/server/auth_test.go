@@ -0,0 +1,204 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/openebs/maya/cmd/maya-apiserver/app/config"
+	authnv1 "k8s.io/api/authentication/v1"
+	authzv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// fakeAuthClientset returns a clientset that authenticates the
+// token "valid" as user "tenant" who is allowed to access the
+// namespace "tenant-ns" only
+func fakeAuthClientset() *fake.Clientset {
+	cs := fake.NewSimpleClientset()
+	cs.PrependReactor("create", "tokenreviews",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			tr := action.(k8stesting.CreateAction).GetObject().(*authnv1.TokenReview)
+			if tr.Spec.Token == "valid" {
+				tr.Status.Authenticated = true
+				tr.Status.User = authnv1.UserInfo{Username: "tenant", Groups: []string{"tenants"}}
+			}
+			return true, tr, nil
+		})
+	cs.PrependReactor("create", "subjectaccessreviews",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			sar := action.(k8stesting.CreateAction).GetObject().(*authzv1.SubjectAccessReview)
+			attrs := sar.Spec.ResourceAttributes
+			sar.Status.Allowed = sar.Spec.User == "tenant" &&
+				attrs.Namespace == "tenant-ns" &&
+				attrs.Group == authzAPIGroup
+			return true, sar, nil
+		})
+	return cs
+}
+
+func TestAuthorize(t *testing.T) {
+	cs := fakeAuthClientset()
+	s := &HTTPServer{
+		authn: &requestAuthenticator{tokenReviews: cs.AuthenticationV1().TokenReviews()},
+		authz: &subjectAccessReviewer{reviews: cs.AuthorizationV1().SubjectAccessReviews()},
+	}
+	handler := s.authorize("volumes", func(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+		return "ok", nil
+	})
+
+	cases := map[string]struct {
+		method       string
+		token        string
+		namespace    string
+		query        string
+		body         string
+		expectedCode int
+	}{
+		"missing token": {
+			method: "GET", namespace: "tenant-ns", expectedCode: 401,
+		},
+		"invalid token": {
+			method: "GET", token: "invalid", namespace: "tenant-ns", expectedCode: 401,
+		},
+		"own namespace": {
+			method: "GET", token: "valid", namespace: "tenant-ns",
+		},
+		"other namespace": {
+			method: "DELETE", token: "valid", namespace: "other-ns", expectedCode: 403,
+		},
+		"namespace from body": {
+			method: "POST", token: "valid", body: `{"metadata":{"namespace":"tenant-ns"}}`,
+		},
+		"body namespace differs from header": {
+			method: "POST", token: "valid", namespace: "tenant-ns",
+			body: `{"metadata":{"namespace":"other-ns"}}`, expectedCode: 403,
+		},
+		"namespace from query": {
+			method: "GET", token: "valid", query: "?namespace=tenant-ns",
+		},
+		"query namespace differs from header": {
+			method: "DELETE", token: "valid", namespace: "tenant-ns",
+			query: "?volume=pvc-1&namespace=other-ns", expectedCode: 403,
+		},
+		"query namespace of other namespace": {
+			method: "GET", token: "valid", query: "?namespace=other-ns", expectedCode: 403,
+		},
+	}
+	for name, tt := range cases {
+		name, tt := name, tt
+		t.Run(name, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, "/latest/volumes/pvc-1"+tt.query, strings.NewReader(tt.body))
+			if tt.token != "" {
+				req.Header.Set(authorizationHeader, bearerPrefix+tt.token)
+			}
+			if tt.namespace != "" {
+				req.Header.Set(NamespaceKey, tt.namespace)
+			}
+			_, err := handler(nil, req)
+			code := 0
+			if err != nil {
+				code = 500
+				if coded, ok := err.(HTTPCodedError); ok {
+					code = coded.Code()
+				}
+			}
+			if code != tt.expectedCode {
+				t.Fatalf("Test %q failed: expected code '%d': actual '%d': %v", name, tt.expectedCode, code, err)
+			}
+			if tt.body != "" && err == nil {
+				body, _ := ioutil.ReadAll(req.Body)
+				if string(body) != tt.body {
+					t.Fatalf("Test %q failed: expected body to be restored: actual '%s'", name, body)
+				}
+			}
+		})
+	}
+}
+
+func TestGetAccessAttributes(t *testing.T) {
+	cases := map[string]struct {
+		method   string
+		path     string
+		expected accessAttributes
+	}{
+		"list": {
+			method:   "GET",
+			path:     "/latest/volumes/",
+			expected: accessAttributes{verb: "list", resource: "volumes"},
+		},
+		"watch": {
+			method:   "GET",
+			path:     "/latest/volumes/?watch=true",
+			expected: accessAttributes{verb: "watch", resource: "volumes"},
+		},
+		"get": {
+			method:   "GET",
+			path:     "/latest/volumes/pvc-1",
+			expected: accessAttributes{verb: "get", resource: "volumes", name: "pvc-1"},
+		},
+		"get sub path": {
+			method:   "GET",
+			path:     "/latest/volumes/stats/pvc-1",
+			expected: accessAttributes{verb: "get", resource: "volumes", name: "stats"},
+		},
+		"delete": {
+			method:   "DELETE",
+			path:     "/latest/volumes/pvc-1",
+			expected: accessAttributes{verb: "delete", resource: "volumes", name: "pvc-1"},
+		},
+	}
+	for name, tt := range cases {
+		name, tt := name, tt
+		t.Run(name, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			got, err := getAccessAttributes(req, "volumes", "/latest/volumes/")
+			if err != nil {
+				t.Fatalf("Test %q failed: unexpected error: %v", name, err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Test %q failed: expected '%+v': actual '%+v'", name, tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	cases := map[string]struct {
+		config *config.TLSConfig
+	}{
+		"missing cert file": {
+			config: &config.TLSConfig{EnableHTTP: true, KeyFile: "server.key"},
+		},
+		"missing key pair": {
+			config: &config.TLSConfig{EnableHTTP: true, CertFile: "/invalid/server.crt", KeyFile: "/invalid/server.key"},
+		},
+	}
+	for name, tt := range cases {
+		name, tt := name, tt
+		t.Run(name, func(t *testing.T) {
+			if _, err := newTLSConfig(tt.config); err == nil {
+				t.Fatalf("Test %q failed: expected error: actual nil", name)
+			}
+		})
+	}
+}
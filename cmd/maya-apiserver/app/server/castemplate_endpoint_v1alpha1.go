@@ -0,0 +1,117 @@
+// Copyright © 2019 The OpenEBS Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+	"github.com/openebs/maya/pkg/volume"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type castemplateAPIOpsV1alpha1 struct {
+	req  *http.Request
+	resp http.ResponseWriter
+}
+
+// castemplateV1alpha1SpecificRequest is a http handler to handle HTTP
+// requests w.r.t cas templates
+func (s *HTTPServer) castemplateV1alpha1SpecificRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req == nil {
+		return nil, CodedError(400, "failed to handle castemplate request: nil http request received")
+	}
+
+	glog.Infof("received castemplate request: http method {%s}", req.Method)
+
+	castOp := &castemplateAPIOpsV1alpha1{
+		req:  req,
+		resp: resp,
+	}
+
+	path := strings.Trim(strings.TrimPrefix(req.URL.Path, "/latest/castemplates"), "/")
+	switch path {
+	case "dryrun":
+		if req.Method != "POST" {
+			return nil, CodedError(405, ErrPostMethodRequired)
+		}
+		return castOp.dryRun()
+	default:
+		return nil, CodedError(404, ErrInvalidPath)
+	}
+}
+
+// dryRun renders a cas template for the volume specified in the request
+// without creating any resource in the cluster
+func (c *castemplateAPIOpsV1alpha1) dryRun() (*v1alpha1.CASTemplateDryRun, error) {
+	glog.Infof("received castemplate dry run request")
+	dryRun := &v1alpha1.CASTemplateDryRun{}
+	err := decodeBody(c.req, dryRun)
+	if err != nil {
+		return nil, CodedErrorWrap(400, errors.Wrap(err, "failed to dry run castemplate"))
+	}
+
+	// volume name is expected
+	if len(dryRun.Name) == 0 {
+		return nil, CodedErrorf(400, "failed to dry run castemplate: missing volume name")
+	}
+
+	// storageclass is expected
+	if len(dryRun.Spec.StorageClass) == 0 {
+		return nil, CodedErrorf(400, "failed to dry run castemplate: missing storageclass")
+	}
+
+	// use run namespace from http request header if namespace is still not set
+	if len(dryRun.Namespace) == 0 {
+		dryRun.Namespace = c.req.Header.Get(NamespaceKey)
+	}
+
+	vol := &v1alpha1.CASVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dryRun.Name,
+			Namespace: dryRun.Namespace,
+			Labels: map[string]string{
+				string(v1alpha1.StorageClassKey):          dryRun.Spec.StorageClass,
+				string(v1alpha1.PersistentVolumeClaimKey): dryRun.Spec.PVC,
+			},
+		},
+		Spec: v1alpha1.CASVolumeSpec{
+			Capacity: dryRun.Spec.Capacity,
+		},
+	}
+
+	vOps, err := volume.NewOperation(vol)
+	if err != nil {
+		return nil, CodedErrorWrap(
+			400,
+			errors.Wrapf(err, "failed to dry run castemplate: failed to init volume operation: %s", vol),
+		)
+	}
+
+	status, err := vOps.DryRun(dryRun.Spec.CASTemplate, dryRun.Spec.CASConfig)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, CodedErrorWrap(404, errors.Wrap(err, "failed to dry run castemplate"))
+		}
+		return nil, CodedErrorWrap(500, errors.Wrap(err, "failed to dry run castemplate"))
+	}
+
+	dryRun.Status = *status
+	glog.Infof("castemplate dry run for volume '%s' completed", dryRun.Name)
+	return dryRun, nil
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCASTemplateDryRunMethod(t *testing.T) {
+	for _, method := range []string{"GET", "PUT", "DELETE"} {
+		method := method
+		t.Run(method, func(t *testing.T) {
+			s := &HTTPServer{}
+			req, _ := http.NewRequest(method, "/latest/castemplates/dryrun", nil)
+			_, err := s.castemplateV1alpha1SpecificRequest(httptest.NewRecorder(), req)
+			codedErr, ok := err.(HTTPCodedError)
+			if !ok {
+				t.Fatalf("ERR: expected coded error, got: %v", err)
+			}
+			if codedErr.Code() != 405 || codedErr.Error() != ErrPostMethodRequired {
+				t.Fatalf("ERR: expected: 405 %s, got: %d %s", ErrPostMethodRequired, codedErr.Code(), codedErr.Error())
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package server
 
 import (
 	"bytes"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -32,7 +33,10 @@ import (
 	"github.com/ghodss/yaml"
 	"github.com/golang/glog"
 	"github.com/openebs/maya/cmd/maya-apiserver/app/config"
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
 	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+	kclient "github.com/openebs/maya/pkg/kubernetes/client/v1alpha1"
+	trace "github.com/openebs/maya/pkg/trace/v1alpha1"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/ugorji/go/codec"
@@ -50,6 +54,9 @@ const (
 
 	// ErrPutMethodRequired is used if the HTTP PUT/POST method is required"
 	ErrPutMethodRequired = "PUT/POST method required"
+
+	// ErrPostMethodRequired is used if the HTTP POST method is required
+	ErrPostMethodRequired = "POST method required"
 )
 
 var (
@@ -198,6 +205,48 @@ var (
 		},
 		[]string{"code", "method"},
 	)
+
+	// latestOpenEBSPoolClusterRequestDuration Collects the response time
+	// since a request has been made on /latest/poolclusters/
+	latestOpenEBSPoolClusterRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "latest_openebs_poolcluster_request_duration_seconds",
+			Help:    "Request response time of the /latest/poolclusters/.",
+			Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.5, 1, 2.5, 5, 10},
+		},
+		[]string{"code", "method"},
+	)
+
+	// Count the no of request Since a request has been made on /latest/poolclusters/
+	latestOpenEBSPoolClusterRequestCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "latest_openebs_poolcluster_requests_total",
+			Help: "Total number of /latest/poolclusters/ requests.",
+		},
+		[]string{"code", "method"},
+	)
+
+	// latestOpenEBSCASTemplateRequestDuration Collects the response time since
+	// a request has been made on /latest/castemplates/
+	latestOpenEBSCASTemplateRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "latest_openebs_castemplate_request_duration_seconds",
+			Help:    "Request response time of the /latest/castemplates/.",
+			Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.5, 1, 2.5, 5, 10},
+		},
+		// code is http code and method is http method returned by
+		// endpoint "/latest/castemplates/"
+		[]string{"code", "method"},
+	)
+
+	// Count the no of request Since a request has been made on /latest/castemplates/
+	latestOpenEBSCASTemplateRequestCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "latest_openebs_castemplate_requests_total",
+			Help: "Total number of /latest/castemplates/ requests.",
+		},
+		[]string{"code", "method"},
+	)
 )
 
 // HTTPServer is used to wrap maya api server and expose it over an HTTP interface
@@ -211,6 +260,18 @@ type HTTPServer struct {
 	listener net.Listener
 	logger   *log.Logger
 	addr     string
+
+	// authn authenticates the requests if authentication
+	// is enabled
+	authn authenticator
+	// authz authorizes the requests if authorization is
+	// enabled
+	authz authorizer
+
+	// watches notifies the watch requests of changes to the
+	// resources. It is shared with the other http servers of
+	// maya api server.
+	watches *informerHub
 }
 
 // init registers Prometheus metrics.It's good to register these variables here
@@ -226,6 +287,12 @@ func init() {
 
 	prometheus.MustRegister(latestOpenEBSSnapshotRequestDuration)
 	prometheus.MustRegister(latestOpenEBSSnapshotRequestCounter)
+
+	prometheus.MustRegister(latestOpenEBSCASTemplateRequestDuration)
+	prometheus.MustRegister(latestOpenEBSCASTemplateRequestCounter)
+
+	prometheus.MustRegister(latestOpenEBSPoolClusterRequestDuration)
+	prometheus.MustRegister(latestOpenEBSPoolClusterRequestCounter)
 }
 
 // NewHTTPServer starts new HTTP server over Maya server
@@ -241,21 +308,17 @@ func NewHTTPServer(maya *MayaApiServer, config *config.MayaConfig, logOutput io.
 	}
 
 	// If TLS is enabled, wrap the listener with a TLS listener
-	//if config.TLSConfig.EnableHTTP {
-	//	tlsConf := &tlsutil.Config{
-	//		VerifyIncoming:       false,
-	//		VerifyOutgoing:       true,
-	//		VerifyServerHostname: config.TLSConfig.VerifyServerHostname,
-	//		CAFile:               config.TLSConfig.CAFile,
-	//		CertFile:             config.TLSConfig.CertFile,
-	//		KeyFile:              config.TLSConfig.KeyFile,
-	//	}
-	//	tlsConfig, err := tlsConf.IncomingTLSConfig()
-	//	if err != nil {
-	//		return nil, err
-	//	}
-	//	ln = tls.NewListener(tcpKeepAliveListener{ln.(*net.TCPListener)}, tlsConfig)
-	//}
+	if config.TLSConfig != nil && config.TLSConfig.EnableHTTP {
+		tlsConfig, err := newTLSConfig(config.TLSConfig)
+		if err != nil {
+			ln.Close()
+			return nil, errors.Wrapf(err, "failed to instantiate http server")
+		}
+		if tcpLn, ok := ln.(*net.TCPListener); ok {
+			ln = tcpKeepAliveListener{tcpLn}
+		}
+		ln = tls.NewListener(ln, tlsConfig)
+	}
 
 	// Create the mux
 	mux := http.NewServeMux()
@@ -267,6 +330,11 @@ func NewHTTPServer(maya *MayaApiServer, config *config.MayaConfig, logOutput io.
 		listener: ln,
 		logger:   maya.logger,
 		addr:     ln.Addr().String(),
+		watches:  maya.watches,
+	}
+	if err := srv.setupAuth(config); err != nil {
+		ln.Close()
+		return nil, errors.Wrapf(err, "failed to instantiate http server")
 	}
 	srv.registerHandlers(config.ServiceProvider, config.EnableDebug)
 
@@ -282,6 +350,31 @@ func NewHTTPServer(maya *MayaApiServer, config *config.MayaConfig, logOutput io.
 	return srv, nil
 }
 
+// setupAuth sets the authenticator & authorizer of the http
+// server as per the auth config
+func (s *HTTPServer) setupAuth(config *config.MayaConfig) error {
+	auth := config.AuthConfig
+	if auth == nil || (!auth.EnableAuthentication && !auth.EnableAuthorization) {
+		return nil
+	}
+	if !auth.EnableAuthentication {
+		return errors.New("failed to setup auth: authorization requires authentication to be enabled")
+	}
+	if config.TLSConfig == nil || !config.TLSConfig.EnableHTTP {
+		glog.Warningf("authentication is enabled without tls: bearer tokens will be sent in plain text")
+	}
+
+	cs, err := kclient.New().Clientset()
+	if err != nil {
+		return errors.Wrapf(err, "failed to setup auth")
+	}
+	s.authn = &requestAuthenticator{tokenReviews: cs.AuthenticationV1().TokenReviews()}
+	if auth.EnableAuthorization {
+		s.authz = &subjectAccessReviewer{reviews: cs.AuthorizationV1().SubjectAccessReviews()}
+	}
+	return nil
+}
+
 // tcpKeepAliveListener sets TCP keep-alive timeouts on accepted
 // connections. It's used by NewHttpServer so
 // dead TCP connections eventually go away.
@@ -314,28 +407,43 @@ func (s *HTTPServer) Shutdown() {
 // NOTE - For every endpoint you need to create a Counter and a Duration
 //        variable to capture the response. These variables will store
 //        the response time and no of times they are requested.
+//
+// NOTE - Every resource is served at the versioned path e.g.
+//        /v1/volumes/ & at its alias e.g. /latest/volumes/
 func (s *HTTPServer) registerHandlers(serviceProvider string, enableDebug bool) {
-	s.mux.HandleFunc("/latest/meta-data/", s.wrap(latestOpenEBSMetaDataRequestCounter,
+	s.handle("meta-data", s.wrap(latestOpenEBSMetaDataRequestCounter,
 		latestOpenEBSMetaDataRequestDuration, s.MetaSpecificRequest))
 
 	// Request w.r.t to storage pools is handled here
-	s.mux.HandleFunc("/latest/pools/", s.wrap(latestOpenEBSPoolRequestCounter, latestOpenEBSPoolRequestDuration, s.poolV1alpha1SpecificRequest))
+	s.handle("pools", s.wrap(latestOpenEBSPoolRequestCounter,
+		latestOpenEBSPoolRequestDuration, s.authorize("pools", s.poolV1alpha1SpecificRequest)))
+
+	// Request w.r.t to storage pool clusters is handled here
+	s.handle("poolclusters", s.wrap(latestOpenEBSPoolClusterRequestCounter,
+		latestOpenEBSPoolClusterRequestDuration, s.authorize("poolclusters", s.poolClusterV1alpha1SpecificRequest)))
 
 	// Request w.r.t to a single VSM entity is handled here
-	s.mux.HandleFunc("/latest/volumes/", s.wrap(latestOpenEBSVolumeRequestCounter,
-		latestOpenEBSVolumeRequestDuration, s.volumeV1alpha1SpecificRequest))
+	s.handle("volumes", s.wrap(latestOpenEBSVolumeRequestCounter,
+		latestOpenEBSVolumeRequestDuration, s.authorize("volumes", s.volumeV1alpha1SpecificRequest)))
 
 	// Request w.r.t cas snapshot is handled here
-	s.mux.HandleFunc("/latest/snapshots/", s.wrap(latestOpenEBSSnapshotRequestCounter,
-		latestOpenEBSSnapshotRequestDuration, s.snapshotV1alpha1SpecificRequest))
+	s.handle("snapshots", s.wrap(latestOpenEBSSnapshotRequestCounter,
+		latestOpenEBSSnapshotRequestDuration, s.authorize("snapshots", s.snapshotV1alpha1SpecificRequest)))
 
 	// Request w.r.t to backup is handled here
-	s.mux.HandleFunc("/latest/backups/", s.wrap(latestOpenEBSBackupRequestCounter,
-		latestOpenEBSBackupRequestDuration, s.backupV1alpha1SpecificRequest))
+	s.handle("backups", s.wrap(latestOpenEBSBackupRequestCounter,
+		latestOpenEBSBackupRequestDuration, s.authorize("backups", s.backupV1alpha1SpecificRequest)))
 
 	// Request w.r.t to restore is handled here
-	s.mux.HandleFunc("/latest/restore/", s.wrap(latestOpenEBSRestoreRequestCounter,
-		latestOpenEBRestoreRequestDuration, s.restoreV1alpha1SpecificRequest))
+	s.handle("restore", s.wrap(latestOpenEBSRestoreRequestCounter,
+		latestOpenEBRestoreRequestDuration, s.authorize("restore", s.restoreV1alpha1SpecificRequest)))
+
+	// Request w.r.t to cas template is handled here
+	s.handle("castemplates", s.wrap(latestOpenEBSCASTemplateRequestCounter,
+		latestOpenEBSCASTemplateRequestDuration, s.authorize("castemplates", s.castemplateV1alpha1SpecificRequest)))
+
+	// OpenAPI document of the versioned API is served here
+	s.mux.HandleFunc(OpenAPIPath, s.openAPI)
 
 	// request for metrics is handled here. It displays metrics related to
 	// garbage collection, process, cpu...etc, and other custom metrics
@@ -351,6 +459,10 @@ type HTTPCodedError interface {
 type codedError struct {
 	s    string
 	code int
+	// cause & msg are set if the error is wrapped, msg being
+	// the message of caller if any
+	cause error
+	msg   string
 }
 
 func (e *codedError) Error() string {
@@ -367,14 +479,14 @@ func (e *codedError) Code() int {
 func CodedErrorWrapf(code int, err error, msg string, args ...interface{}) HTTPCodedError {
 	errMsg := fmt.Sprintf("error: {%s}, msg: {%s}", err, msg)
 	finalMsg := fmt.Sprintf(errMsg, args...)
-	return CodedError(code, finalMsg)
+	return &codedError{s: finalMsg, code: code, cause: err, msg: fmt.Sprintf(msg, args...)}
 }
 
 // CodedErrorWrap is used to provide HTTP error
 // Code and corresponding error
 func CodedErrorWrap(code int, err error) HTTPCodedError {
 	errMsg := fmt.Sprintf("%+v", err)
-	return CodedError(code, errMsg)
+	return &codedError{s: errMsg, code: code, cause: err}
 }
 
 // CodedErrorf is used to provide HTTP error
@@ -389,7 +501,26 @@ func CodedErrorf(code int, msg string, args ...interface{}) HTTPCodedError {
 // CodedError is used to provide HTTP error
 // Code and corresponding error msg
 func CodedError(c int, msg string) HTTPCodedError {
-	return &codedError{msg, c}
+	return &codedError{s: msg, code: c}
+}
+
+// toAPIError returns the error envelope of versioned API
+// for the given error. The stack trace of a wrapped error
+// is left out & its root cause is set as details.
+func toAPIError(code int, err error) *apis.Error {
+	coded, ok := err.(*codedError)
+	if !ok || coded.cause == nil {
+		return apis.NewError(code, err.Error())
+	}
+	message := coded.cause.Error()
+	if coded.msg != "" {
+		message = coded.msg
+	}
+	var details []string
+	if root := errors.Cause(coded.cause); root != nil && root.Error() != message {
+		details = append(details, root.Error())
+	}
+	return apis.NewError(code, message, details...)
 }
 
 // wrap is a convenient method used to wrap the handler function &
@@ -421,6 +552,18 @@ func (s *HTTPServer) wrap(RequestCounter *prometheus.CounterVec, RequestDuration
 			RequestCounter.WithLabelValues(strconv.Itoa(code), req.Method).Inc()
 		}()
 
+		// span of the request is a child of the caller's span e.g.
+		// provisioner if the caller propagated its span
+		ctx, span := trace.StartWithKind(
+			trace.Extract(req.Context(), req.Header),
+			trace.SpanKindServer,
+			req.Method+" "+req.URL.Path,
+			trace.String("http.method", req.Method),
+			trace.String("http.url", reqURL),
+		)
+		defer span.End()
+		req = req.WithContext(ctx)
+
 		glog.V(5).Infof("[DEBUG] http: Request %v (%v)", reqURL, req.Method)
 		// Original handler is invoked
 		obj, err := handler(resp, req)
@@ -434,8 +577,18 @@ func (s *HTTPServer) wrap(RequestCounter *prometheus.CounterVec, RequestDuration
 			if http, ok := err.(HTTPCodedError); ok {
 				code = http.Code()
 			}
+			span.RecordError(err)
+			span.SetAttributes(trace.String("http.status_code", strconv.Itoa(code)))
+			if !isVersionedRequest(req) {
+				// legacy api responds with plain text errors
+				resp.WriteHeader(code)
+				resp.Write([]byte(err.Error()))
+				return
+			}
+			body, _ := json.Marshal(toAPIError(code, err))
+			resp.Header().Set("Content-Type", "application/json")
 			resp.WriteHeader(code)
-			resp.Write([]byte(err.Error()))
+			resp.Write(body)
 			return
 		}
 
@@ -0,0 +1,175 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// listItem is an item of a list that is filtered by list
+// options
+type listItem struct {
+	// key is the unique key of item i.e. namespace/name
+	// that orders the items of list
+	key    string
+	labels map[string]string
+	fields fields.Set
+	// index of the item in the list
+	index int
+}
+
+// newListItem returns the list item of the given object
+// metadata & its resource specific fields
+func newListItem(index int, meta metav1.ObjectMeta, f fields.Set) listItem {
+	set := fields.Set{
+		"metadata.name":      meta.Name,
+		"metadata.namespace": meta.Namespace,
+	}
+	for k, v := range f {
+		set[k] = v
+	}
+	return listItem{
+		key:    meta.Namespace + "/" + meta.Name,
+		labels: meta.Labels,
+		fields: set,
+		index:  index,
+	}
+}
+
+// listOptions returns the list options of the given request
+func listOptions(req *http.Request) (*apis.ListOptions, error) {
+	opts, err := apis.ParseListOptions(req.URL.Query())
+	if err != nil {
+		return nil, CodedErrorWrap(400, err)
+	}
+	return opts, nil
+}
+
+// selectItems returns the indices of items that are selected
+// by the list options & ordered by their key. The continue token
+// is set if there are more items than the limit.
+func selectItems(items []listItem, opts *apis.ListOptions) ([]int, string, error) {
+	after := ""
+	if opts.Continue != "" {
+		key, err := apis.DecodeContinue(opts.Continue)
+		if err != nil {
+			return nil, "", CodedErrorWrap(400, err)
+		}
+		after = key
+	}
+
+	selected := []listItem{}
+	for _, item := range items {
+		if after != "" && item.key <= after {
+			continue
+		}
+		if opts.Matches(item.labels, item.fields) {
+			selected = append(selected, item)
+		}
+	}
+	sort.SliceStable(selected, func(i, j int) bool {
+		return selected[i].key < selected[j].key
+	})
+
+	token := ""
+	if opts.Limit > 0 && int64(len(selected)) > opts.Limit {
+		selected = selected[:opts.Limit]
+		token = apis.EncodeContinue(selected[len(selected)-1].key)
+	}
+	indices := make([]int, 0, len(selected))
+	for _, item := range selected {
+		indices = append(indices, item.index)
+	}
+	return indices, token, nil
+}
+
+// filterVolumes filters the given volumes as per the list
+// options. Volumes can be selected by fields spec.casType &
+// status.phase besides the metadata.
+func filterVolumes(vols *v1alpha1.CASVolumeList, opts *apis.ListOptions) error {
+	items := make([]listItem, 0, len(vols.Items))
+	for i, vol := range vols.Items {
+		items = append(items, newListItem(i, vol.ObjectMeta, fields.Set{
+			"spec.casType": vol.Spec.CasType,
+			"status.phase": string(vol.Status.Phase),
+		}))
+	}
+	indices, token, err := selectItems(items, opts)
+	if err != nil {
+		return err
+	}
+	selected := make([]v1alpha1.CASVolume, 0, len(indices))
+	for _, i := range indices {
+		selected = append(selected, vols.Items[i])
+	}
+	vols.Items = selected
+	vols.ListMeta.Continue = token
+	return nil
+}
+
+// filterPools filters the given pools as per the list options.
+// Pools can be selected by field status.phase besides the
+// metadata.
+func filterPools(pools *v1alpha1.CStorPoolList, opts *apis.ListOptions) error {
+	items := make([]listItem, 0, len(pools.Items))
+	for i, pool := range pools.Items {
+		items = append(items, newListItem(i, pool.ObjectMeta, fields.Set{
+			"status.phase": string(pool.Status.Phase),
+		}))
+	}
+	indices, token, err := selectItems(items, opts)
+	if err != nil {
+		return err
+	}
+	selected := make([]v1alpha1.CStorPool, 0, len(indices))
+	for _, i := range indices {
+		selected = append(selected, pools.Items[i])
+	}
+	pools.Items = selected
+	pools.ListMeta.Continue = token
+	return nil
+}
+
+// filterSnapshots filters the given snapshots as per the list
+// options. Snapshots can be selected by fields spec.casType &
+// spec.volumeName besides the metadata.
+func filterSnapshots(snaps *v1alpha1.CASSnapshotList, opts *apis.ListOptions) error {
+	items := make([]listItem, 0, len(snaps.Items))
+	for i, snap := range snaps.Items {
+		items = append(items, newListItem(i, snap.ObjectMeta, fields.Set{
+			"spec.casType":    snap.Spec.CasType,
+			"spec.volumeName": snap.Spec.VolumeName,
+		}))
+	}
+	indices, token, err := selectItems(items, opts)
+	if err != nil {
+		return err
+	}
+	selected := make([]v1alpha1.CASSnapshot, 0, len(indices))
+	for _, i := range indices {
+		selected = append(selected, snaps.Items[i])
+	}
+	snaps.Items = selected
+	snaps.ListMeta.Continue = token
+	return nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func fakeVolumeList() *v1alpha1.CASVolumeList {
+	vol := func(ns, name, casType, app string) v1alpha1.CASVolume {
+		return v1alpha1.CASVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns, Labels: map[string]string{"app": app}},
+			Spec:       v1alpha1.CASVolumeSpec{CasType: casType},
+		}
+	}
+	return &v1alpha1.CASVolumeList{
+		Items: []v1alpha1.CASVolume{
+			vol("ns2", "pvc-3", "jiva", "nginx"),
+			vol("ns1", "pvc-2", "cstor", "mysql"),
+			vol("ns1", "pvc-1", "cstor", "nginx"),
+			vol("ns2", "pvc-4", "cstor", "mysql"),
+		},
+	}
+}
+
+func TestFilterVolumes(t *testing.T) {
+	tests := map[string]struct {
+		query        string
+		expected     []string
+		isContinue   bool
+		isErr        bool
+		nextExpected []string
+	}{
+		"all volumes ordered by key": {
+			expected: []string{"pvc-1", "pvc-2", "pvc-3", "pvc-4"},
+		},
+		"label selector": {
+			query:    "labelSelector=app%3Dmysql",
+			expected: []string{"pvc-2", "pvc-4"},
+		},
+		"field selector": {
+			query:    "fieldSelector=spec.casType%3Dcstor,metadata.namespace%3Dns1",
+			expected: []string{"pvc-1", "pvc-2"},
+		},
+		"paginated": {
+			query:        "limit=3",
+			expected:     []string{"pvc-1", "pvc-2", "pvc-3"},
+			isContinue:   true,
+			nextExpected: []string{"pvc-4"},
+		},
+		"paginated with selector": {
+			query:        "limit=1&fieldSelector=spec.casType%3Dcstor",
+			expected:     []string{"pvc-1"},
+			isContinue:   true,
+			nextExpected: []string{"pvc-2"},
+		},
+		"invalid continue": {
+			query: "continue=%25%25",
+			isErr: true,
+		},
+	}
+	names := func(vols *v1alpha1.CASVolumeList) []string {
+		got := []string{}
+		for _, vol := range vols.Items {
+			got = append(got, vol.Name)
+		}
+		return got
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			query, _ := url.ParseQuery(mock.query)
+			opts, err := apis.ParseListOptions(query)
+			if err != nil {
+				t.Fatalf("Test %q failed: unexpected error: %v", name, err)
+			}
+			vols := fakeVolumeList()
+			err = filterVolumes(vols, opts)
+			if mock.isErr != (err != nil) {
+				t.Fatalf("Test %q failed: expected error '%t': actual '%v'", name, mock.isErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if got := names(vols); !reflect.DeepEqual(got, mock.expected) {
+				t.Fatalf("Test %q failed: expected '%v': actual '%v'", name, mock.expected, got)
+			}
+			if mock.isContinue != (vols.ListMeta.Continue != "") {
+				t.Fatalf("Test %q failed: expected continue '%t': actual '%s'", name, mock.isContinue, vols.ListMeta.Continue)
+			}
+			if !mock.isContinue {
+				return
+			}
+			opts.Continue = vols.ListMeta.Continue
+			next := fakeVolumeList()
+			if err := filterVolumes(next, opts); err != nil {
+				t.Fatalf("Test %q failed: unexpected error of next page: %v", name, err)
+			}
+			if got := names(next); !reflect.DeepEqual(got, mock.nextExpected) {
+				t.Fatalf("Test %q failed: expected next page '%v': actual '%v'", name, mock.nextExpected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,411 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
+	"github.com/openebs/maya/pkg/version"
+)
+
+const (
+	// OpenAPIPath is the path of the OpenAPI document of the
+	// versioned REST API
+	OpenAPIPath = apis.APIPrefix + "openapi.json"
+
+	openAPIVersion    = "3.0.0"
+	openAPISchemaRefs = "#/components/schemas/"
+	jsonContentType   = "application/json"
+)
+
+// versionKey is the context key of the API version of a request
+type versionKey struct{}
+
+// handle registers the handler of the given resource at the
+// versioned path e.g. /v1/volumes/ & at its alias e.g.
+// /latest/volumes/
+func (s *HTTPServer) handle(resource string, handler http.HandlerFunc) {
+	s.mux.HandleFunc(apis.LatestPrefix+resource+"/", handler)
+	s.mux.HandleFunc(apis.APIPrefix+resource+"/", withVersion(handler))
+}
+
+// withVersion serves the request of versioned path via the handler
+// of its alias. The request is marked as versioned so that its
+// errors are responded in the error envelope.
+func withVersion(handler http.HandlerFunc) http.HandlerFunc {
+	return func(resp http.ResponseWriter, req *http.Request) {
+		r := req.WithContext(context.WithValue(req.Context(), versionKey{}, apis.APIVersion))
+		u := *req.URL
+		u.Path = apis.LatestPrefix + strings.TrimPrefix(req.URL.Path, apis.APIPrefix)
+		u.RawPath = ""
+		r.URL = &u
+		handler(resp, r)
+	}
+}
+
+// isVersionedRequest returns true if the request was sent to the
+// versioned path
+func isVersionedRequest(req *http.Request) bool {
+	v, _ := req.Context().Value(versionKey{}).(string)
+	return v != ""
+}
+
+// openAPI serves the OpenAPI document of the versioned REST API
+func (s *HTTPServer) openAPI(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != "GET" {
+		resp.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := json.MarshalIndent(NewOpenAPIDocument(), "", "  ")
+	if err != nil {
+		resp.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp.Header().Set("Content-Type", jsonContentType)
+	resp.Write(body)
+}
+
+// OpenAPIDocument is the OpenAPI v3 document of the REST API
+type OpenAPIDocument struct {
+	OpenAPI    string                     `json:"openapi"`
+	Info       OpenAPIInfo                `json:"info"`
+	Paths      map[string]OpenAPIPathItem `json:"paths"`
+	Components OpenAPIComponents          `json:"components"`
+}
+
+// OpenAPIInfo is the metadata of the REST API
+type OpenAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// OpenAPIPathItem has the operations of a path keyed by
+// http method in lower case
+type OpenAPIPathItem map[string]*OpenAPIOperation
+
+// OpenAPIOperation is an operation on a path
+type OpenAPIOperation struct {
+	OperationID string                     `json:"operationId"`
+	Summary     string                     `json:"summary"`
+	Tags        []string                   `json:"tags"`
+	Parameters  []OpenAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]OpenAPIResponse `json:"responses"`
+}
+
+// OpenAPIParameter is a parameter of an operation
+type OpenAPIParameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required,omitempty"`
+	Schema      *OpenAPISchema `json:"schema"`
+}
+
+// OpenAPIRequestBody is the request body of an operation
+type OpenAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]OpenAPIMediaType `json:"content"`
+}
+
+// OpenAPIResponse is a response of an operation
+type OpenAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]OpenAPIMediaType `json:"content,omitempty"`
+}
+
+// OpenAPIMediaType has the schema of a request or response body
+type OpenAPIMediaType struct {
+	Schema *OpenAPISchema `json:"schema"`
+}
+
+// OpenAPIComponents has the schemas referred by the operations
+type OpenAPIComponents struct {
+	Schemas map[string]*OpenAPISchema `json:"schemas"`
+}
+
+// OpenAPISchema is the schema of a type
+type OpenAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Properties           map[string]*OpenAPISchema `json:"properties,omitempty"`
+	Items                *OpenAPISchema            `json:"items,omitempty"`
+	AdditionalProperties *OpenAPISchema            `json:"additionalProperties,omitempty"`
+}
+
+// apiOperation describes an operation of the REST API
+type apiOperation struct {
+	method      string
+	path        string
+	id          string
+	summary     string
+	namespaced  bool
+	query       []string
+	request     interface{}
+	response    interface{}
+	description string
+}
+
+// listQuery are the query parameters of list operations. The
+// watch parameter is last as snapshots can not be watched.
+var listQuery = []string{
+	apis.LabelSelectorParam,
+	apis.FieldSelectorParam,
+	apis.LimitParam,
+	apis.ContinueParam,
+	apis.WatchParam,
+}
+
+// apiResources are the resources of the REST API & their
+// operations. The request & response are the go types of
+// the bodies.
+var apiResources = map[string][]apiOperation{
+	"volumes": {
+		{method: "GET", path: "", id: "listVolumes", summary: "List or watch volumes",
+			namespaced: true, query: listQuery, response: v1alpha1.CASVolumeList{}},
+		{method: "POST", path: "", id: "createVolume", summary: "Create a volume",
+			namespaced: true, request: v1alpha1.CASVolume{}, response: v1alpha1.CASVolume{}},
+		{method: "GET", path: "{name}", id: "readVolume", summary: "Read a volume",
+			namespaced: true, response: v1alpha1.CASVolume{}},
+		{method: "DELETE", path: "{name}", id: "deleteVolume", summary: "Delete a volume",
+			namespaced: true, response: v1alpha1.CASVolume{}},
+		{method: "GET", path: "stats/{name}", id: "readVolumeStats", summary: "Read the stats of a volume",
+			namespaced: true, response: map[string]interface{}{}},
+	},
+	"snapshots": {
+		{method: "GET", path: "", id: "listSnapshots", summary: "List the snapshots of a volume",
+			query:    append([]string{"volume", "namespace", "casType"}, listQuery[:4]...),
+			response: v1alpha1.CASSnapshotList{}},
+		{method: "POST", path: "", id: "createSnapshot", summary: "Create a snapshot of a volume",
+			request: v1alpha1.CASSnapshot{}, response: v1alpha1.CASSnapshot{}},
+		{method: "GET", path: "{name}", id: "readSnapshot", summary: "Read a snapshot of a volume",
+			query: []string{"volume", "namespace", "casType"}, response: v1alpha1.CASSnapshot{}},
+		{method: "DELETE", path: "{name}", id: "deleteSnapshot", summary: "Delete a snapshot of a volume",
+			query: []string{"volume", "namespace", "casType"}, response: v1alpha1.CASSnapshot{}},
+	},
+	"backups": {
+		{method: "POST", path: "", id: "createBackup", summary: "Create a backup of a cstor volume",
+			request: v1alpha1.CStorBackup{}, response: ""},
+		{method: "GET", path: "", id: "readLastBackup", summary: "Read the last completed backup of a cstor volume",
+			request: v1alpha1.CStorBackup{}, response: ""},
+	},
+	"restore": {
+		{method: "POST", path: "", id: "createRestore", summary: "Restore a backup to a cstor volume",
+			request: v1alpha1.CStorRestore{}, response: ""},
+		{method: "GET", path: "", id: "readRestore", summary: "Read the status of a restore",
+			request: v1alpha1.CStorRestore{}, response: v1alpha1.CStorRestoreStatus("")},
+	},
+	"pools": {
+		{method: "GET", path: "", id: "listPools", summary: "List or watch cstor pools",
+			query: listQuery, response: v1alpha1.CStorPoolList{}},
+		{method: "GET", path: "{name}", id: "readPool", summary: "Read a cstor pool", response: v1alpha1.CStorPool{}},
+	},
+	"poolclusters": {
+		{method: "GET", path: "", id: "listPoolClusters", summary: "List cstor pool clusters",
+			response: v1alpha1.CStorPoolClusterList{}},
+		{method: "GET", path: "{name}", id: "readPoolCluster", summary: "Read a cstor pool cluster",
+			response: v1alpha1.CStorPoolCluster{}},
+		{method: "POST", path: "", id: "createPoolCluster", summary: "Create a cstor pool cluster",
+			request: apis.PoolCreateRequest{}, response: v1alpha1.CStorPoolCluster{}},
+		{method: "PATCH", path: "{name}", id: "expandPoolCluster", summary: "Add raid groups to a pool of cstor pool cluster",
+			request: apis.PoolExpandRequest{}, response: v1alpha1.CStorPoolCluster{}},
+		{method: "DELETE", path: "{name}", id: "deletePoolCluster",
+			summary: "Delete a cstor pool cluster that has no volume replicas", response: v1alpha1.CStorPoolCluster{}},
+	},
+	"castemplates": {
+		{method: "POST", path: "dryrun", id: "dryRunCASTemplate", summary: "Render a cas template without executing it",
+			request: v1alpha1.CASTemplateDryRun{}, response: v1alpha1.CASTemplateDryRun{}},
+	},
+	"meta-data": {
+		{method: "GET", path: "instance-id", id: "readInstanceID", summary: "Read the instance id", response: ""},
+		{method: "GET", path: "placement/availability-zone", id: "readAvailabilityZone",
+			summary: "Read the availability zone", response: ""},
+	},
+}
+
+// NewOpenAPIDocument returns the OpenAPI document of the versioned
+// REST API
+func NewOpenAPIDocument() *OpenAPIDocument {
+	g := &schemaGenerator{schemas: map[string]*OpenAPISchema{}}
+	errSchema := g.schema(reflect.TypeOf(apis.Error{}))
+	doc := &OpenAPIDocument{
+		OpenAPI: openAPIVersion,
+		Info: OpenAPIInfo{
+			Title:   "maya-apiserver",
+			Version: apis.APIVersion + "-" + version.GetVersion(),
+		},
+		Paths: map[string]OpenAPIPathItem{},
+	}
+	for resource, ops := range apiResources {
+		for _, op := range ops {
+			path := apis.APIPrefix + resource + "/" + op.path
+			if doc.Paths[path] == nil {
+				doc.Paths[path] = OpenAPIPathItem{}
+			}
+			o := &OpenAPIOperation{
+				OperationID: op.id,
+				Summary:     op.summary,
+				Tags:        []string{resource},
+				Responses: map[string]OpenAPIResponse{
+					"200": {
+						Description: "OK",
+						Content:     jsonContent(g.schema(reflect.TypeOf(op.response))),
+					},
+					"default": {
+						Description: "Error",
+						Content:     jsonContent(errSchema),
+					},
+				},
+			}
+			if strings.Contains(op.path, "{name}") {
+				o.Parameters = append(o.Parameters, OpenAPIParameter{
+					Name: "name", In: "path", Required: true, Schema: &OpenAPISchema{Type: "string"},
+				})
+			}
+			if op.namespaced {
+				o.Parameters = append(o.Parameters, OpenAPIParameter{
+					Name: NamespaceKey, In: "header", Schema: &OpenAPISchema{Type: "string"},
+					Description: "Namespace of the resource",
+				})
+			}
+			for _, q := range op.query {
+				o.Parameters = append(o.Parameters, OpenAPIParameter{
+					Name: q, In: "query", Schema: &OpenAPISchema{Type: "string"},
+				})
+			}
+			if op.request != nil {
+				o.RequestBody = &OpenAPIRequestBody{
+					Required: true,
+					Content:  jsonContent(g.schema(reflect.TypeOf(op.request))),
+				}
+			}
+			doc.Paths[path][strings.ToLower(op.method)] = o
+		}
+	}
+	doc.Components.Schemas = g.schemas
+	return doc
+}
+
+func jsonContent(schema *OpenAPISchema) map[string]OpenAPIMediaType {
+	return map[string]OpenAPIMediaType{jsonContentType: {Schema: schema}}
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// schemaGenerator generates the schemas of go types as per
+// their json encoding. Named structs are added to the schemas
+// & are referred by name.
+type schemaGenerator struct {
+	schemas map[string]*OpenAPISchema
+}
+
+func (g *schemaGenerator) schema(t reflect.Type) *OpenAPISchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	// types with custom encoding e.g. time & quantity are
+	// encoded as strings
+	if t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType) ||
+		t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType) {
+		return &OpenAPISchema{Type: "string"}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return &OpenAPISchema{Type: "string"}
+	case reflect.Bool:
+		return &OpenAPISchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &OpenAPISchema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &OpenAPISchema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &OpenAPISchema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &OpenAPISchema{Type: "string", Format: "byte"}
+		}
+		return &OpenAPISchema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &OpenAPISchema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		name := schemaName(t)
+		if _, ok := g.schemas[name]; !ok {
+			// placeholder guards against recursive types
+			g.schemas[name] = &OpenAPISchema{}
+			*g.schemas[name] = *g.structSchema(t)
+		}
+		return &OpenAPISchema{Ref: openAPISchemaRefs + name}
+	default:
+		// any value e.g. interface{}
+		return &OpenAPISchema{}
+	}
+}
+
+func (g *schemaGenerator) structSchema(t reflect.Type) *OpenAPISchema {
+	s := &OpenAPISchema{Type: "object", Properties: map[string]*OpenAPISchema{}}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			// fields of embedded struct are inlined
+			for k, v := range g.structSchema(ft).Properties {
+				s.Properties[k] = v
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		s.Properties[name] = g.schema(f.Type)
+	}
+	return s
+}
+
+// schemaName returns the name of the schema of the given type
+// e.g. openebs.io.v1alpha1.CASVolume
+func schemaName(t reflect.Type) string {
+	parts := strings.Split(t.PkgPath(), "/")
+	if len(parts) > 2 {
+		parts = parts[len(parts)-2:]
+	}
+	return strings.Join(append(parts, t.Name()), ".")
+}
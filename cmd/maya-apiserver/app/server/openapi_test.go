@@ -0,0 +1,141 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+)
+
+func TestVersionedAPI(t *testing.T) {
+	s := makeHTTPTestServer(t, nil)
+	defer s.Cleanup()
+
+	tests := map[string]struct {
+		path         string
+		expectedCode int
+		expectedBody string
+		isEnvelope   bool
+	}{
+		"versioned path": {
+			path:         "/v1/meta-data/instance-id",
+			expectedCode: 200,
+			expectedBody: `"any-compute"`,
+		},
+		"latest alias": {
+			path:         "/latest/meta-data/instance-id",
+			expectedCode: 200,
+			expectedBody: `"any-compute"`,
+		},
+		"versioned path error": {
+			path:         "/v1/meta-data/invalid",
+			expectedCode: 421,
+			isEnvelope:   true,
+		},
+		"latest alias error": {
+			path:         "/latest/meta-data/invalid",
+			expectedCode: 421,
+			expectedBody: ErrInvalidPath,
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", mock.path, nil)
+			s.Server.mux.ServeHTTP(resp, req)
+			if resp.Code != mock.expectedCode {
+				t.Fatalf("Test %q failed: expected code '%d': actual '%d'", name, mock.expectedCode, resp.Code)
+			}
+			if !mock.isEnvelope {
+				if got := strings.TrimSpace(resp.Body.String()); got != mock.expectedBody {
+					t.Fatalf("Test %q failed: expected body '%s': actual '%s'", name, mock.expectedBody, got)
+				}
+				return
+			}
+			apiErr := &apis.Error{}
+			if err := json.Unmarshal(resp.Body.Bytes(), apiErr); err != nil {
+				t.Fatalf("Test %q failed: expected error envelope: actual '%s'", name, resp.Body.String())
+			}
+			if apiErr.Code != mock.expectedCode || apiErr.Message != ErrInvalidPath {
+				t.Fatalf("Test %q failed: unexpected error envelope '%+v'", name, apiErr)
+			}
+		})
+	}
+}
+
+func TestToAPIError(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected *apis.Error
+	}{
+		"coded error": {
+			err:      CodedError(404, "volume not found"),
+			expected: apis.NewError(404, "volume not found"),
+		},
+		"wrapped error": {
+			err:      CodedErrorWrap(500, errors.Wrap(errors.New("connection refused"), "failed to read volume")),
+			expected: apis.NewError(500, "failed to read volume", "connection refused"),
+		},
+		"wrapped error with message": {
+			err:      CodedErrorWrapf(400, errors.New("invalid size"), "failed to create volume {%s}", "pvc-1"),
+			expected: apis.NewError(400, "failed to create volume {pvc-1}", "invalid size"),
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			got := toAPIError(mock.err.(HTTPCodedError).Code(), mock.err)
+			if got.Error() != mock.expected.Error() || got.Reason != mock.expected.Reason {
+				t.Fatalf("Test %q failed: expected '%+v': actual '%+v'", name, mock.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewOpenAPIDocument(t *testing.T) {
+	doc := NewOpenAPIDocument()
+	for _, schema := range []string{
+		"openebs.io.v1alpha1.CASVolume",
+		"openebs.io.v1alpha1.CASVolumeList",
+		"openebs.io.v1alpha1.CStorPoolList",
+		"apiserver.v1alpha1.Error",
+	} {
+		if _, ok := doc.Components.Schemas[schema]; !ok {
+			t.Fatalf("Test failed: expected schema {%s} in openapi document", schema)
+		}
+	}
+	for path, method := range map[string]string{
+		"/v1/volumes/":       "post",
+		"/v1/volumes/{name}": "delete",
+		"/v1/pools/":         "get",
+		"/v1/poolclusters/":  "post",
+	} {
+		if _, ok := doc.Paths[path][method]; !ok {
+			t.Fatalf("Test failed: expected operation {%s %s} in openapi document", method, path)
+		}
+	}
+	if _, err := json.Marshal(doc); err != nil {
+		t.Fatalf("Test failed: failed to marshal openapi document: %v", err)
+	}
+}
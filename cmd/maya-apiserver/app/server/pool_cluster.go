@@ -0,0 +1,154 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
+	cspc "github.com/openebs/maya/pkg/cstor/poolcluster/v1alpha1"
+	poolspec "github.com/openebs/maya/pkg/cstor/poolcluster/v1alpha1/cstorpoolspecs"
+	raidgroup "github.com/openebs/maya/pkg/cstor/poolcluster/v1alpha1/raidgroups"
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+)
+
+const (
+	// hostNameLabel is the node selector of the pool of a node
+	hostNameLabel = "kubernetes.io/hostname"
+
+	// cstorPoolUIDLabel is the label of cstor volume replica
+	// that has the uid of its cstor pool
+	cstorPoolUIDLabel = "cstorpool.openebs.io/uid"
+)
+
+// jsonPatchOp is an operation of json patch
+type jsonPatchOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// newPoolCluster returns the cstor pool cluster of the given
+// create request
+func newPoolCluster(req *apis.PoolCreateRequest, namespace string) (*v1alpha1.CStorPoolCluster, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	b := cspc.NewBuilder().
+		WithName(req.Name).
+		WithNamespace(namespace)
+	for _, node := range req.Nodes {
+		psb := poolspec.NewBuilder().
+			WithNodeSelector(map[string]string{hostNameLabel: node.NodeName}).
+			WithDefaultRaidGroupType(req.RaidGroupType)
+		if req.Compression != "" {
+			psb.WithCompression(req.Compression)
+		}
+		if req.OverProvisioning {
+			psb.WithOverProvisioning()
+		}
+		if req.OverCommitRatio != "" {
+			psb.WithOverCommitRatio(req.OverCommitRatio)
+		}
+		for _, rg := range node.RaidGroups {
+			psb.WithRaidGroupBuilder(newRaidGroupBuilder(rg, req.RaidGroupType))
+		}
+		b.WithPoolSpecBuilder(psb)
+	}
+	obj, err := b.Build()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build cspc {%s}", req.Name)
+	}
+	return obj.ToAPI(), nil
+}
+
+// newRaidGroupBuilder returns the builder of the raid group of
+// the given request
+func newRaidGroupBuilder(rg apis.PoolRaidGroup, defaultType string) *raidgroup.Builder {
+	return raidgroup.NewBuilder().
+		WithType(rg.TypeOrDefault(defaultType)).
+		WithBlockDevices(rg.BlockDevices...)
+}
+
+// expandPoolClusterPatch returns the json patch that adds the
+// raid groups of the given expand request to the pool of its
+// node
+func expandPoolClusterPatch(obj *v1alpha1.CStorPoolCluster, req *apis.PoolExpandRequest) ([]byte, error) {
+	index := -1
+	used := map[string]bool{}
+	for i, pool := range obj.Spec.Pools {
+		if pool.NodeSelector[hostNameLabel] == req.NodeName {
+			index = i
+		}
+		for _, rg := range pool.RaidGroups {
+			for _, bd := range rg.BlockDevices {
+				used[bd.BlockDeviceName] = true
+			}
+		}
+	}
+	if index < 0 {
+		return nil, errors.Errorf("failed to expand cspc {%s}: node {%s} is not part of cspc", obj.Name, req.NodeName)
+	}
+	defaultType := obj.Spec.Pools[index].PoolConfig.DefaultRaidGroupType
+	if err := req.Validate(defaultType); err != nil {
+		return nil, errors.Wrapf(err, "failed to expand cspc {%s}", obj.Name)
+	}
+
+	ops := []jsonPatchOp{}
+	for _, rg := range req.RaidGroups {
+		for _, bd := range rg.BlockDevices {
+			if used[bd] {
+				return nil, errors.Errorf("failed to expand cspc {%s}: block device {%s} is already used", obj.Name, bd)
+			}
+		}
+		rgObj, err := newRaidGroupBuilder(rg, defaultType).Build()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to expand cspc {%s}", obj.Name)
+		}
+		ops = append(ops, jsonPatchOp{
+			Op:    "add",
+			Path:  fmt.Sprintf("/spec/pools/%d/raidGroups/-", index),
+			Value: rgObj.ToAPI(),
+		})
+	}
+	return json.Marshal(ops)
+}
+
+// poolReplicaSelector returns the label selector of cstor volume
+// replicas that are placed on the given pools
+func poolReplicaSelector(pools *v1alpha1.NewTestCStorPoolList) string {
+	uids := []string{}
+	for _, pool := range pools.Items {
+		uids = append(uids, string(pool.UID))
+	}
+	if len(uids) == 0 {
+		return ""
+	}
+	return cstorPoolUIDLabel + " in (" + strings.Join(uids, ",") + ")"
+}
+
+// replicaNames returns the names of the given replicas
+func replicaNames(replicas *v1alpha1.CStorVolumeReplicaList) []string {
+	names := []string{}
+	for _, r := range replicas.Items {
+		names = append(names, r.Name)
+	}
+	return names
+}
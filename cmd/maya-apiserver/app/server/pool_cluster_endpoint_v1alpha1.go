@@ -0,0 +1,262 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
+	csp "github.com/openebs/maya/pkg/cstor/newpool/v1alpha3"
+	cspc "github.com/openebs/maya/pkg/cstor/poolcluster/v1alpha1"
+	cvr "github.com/openebs/maya/pkg/cstor/volumereplica/v1alpha1"
+	menv "github.com/openebs/maya/pkg/env/v1alpha1"
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type poolClusterAPIOpsV1alpha1 struct {
+	req  *http.Request
+	resp http.ResponseWriter
+}
+
+// poolClusterV1alpha1SpecificRequest is a http handler to handle
+// HTTP requests to a OpenEBS pool cluster i.e. the cstor pool
+// cluster that creates & manages the cstor pools of its nodes.
+func (s *HTTPServer) poolClusterV1alpha1SpecificRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req == nil {
+		return nil, CodedError(400, "failed to handle storage pool cluster request: nil http request received")
+	}
+
+	glog.Infof(" received storage pool cluster request: method '%s'", req.Method)
+
+	poolClusterOp := &poolClusterAPIOpsV1alpha1{
+		req:  req,
+		resp: resp,
+	}
+
+	switch req.Method {
+	case "GET":
+		return poolClusterOp.httpGet()
+	case "POST":
+		return poolClusterOp.create()
+	case "PATCH":
+		return poolClusterOp.httpExpand()
+	case "DELETE":
+		return poolClusterOp.httpDelete()
+	default:
+		return nil, CodedError(405, http.StatusText(405))
+	}
+}
+
+// httpGet deals with http GET request to list the pool clusters
+// or to read a pool cluster
+func (p *poolClusterAPIOpsV1alpha1) httpGet() (interface{}, error) {
+	name := p.poolClusterName()
+	if len(name) == 0 {
+		return p.list()
+	}
+	return p.read(name)
+}
+
+// poolClusterName returns the name of pool cluster in the
+// request path
+func (p *poolClusterAPIOpsV1alpha1) poolClusterName() string {
+	return strings.TrimSpace(strings.TrimPrefix(p.req.URL.Path, "/latest/poolclusters/"))
+}
+
+// httpExpand deals with http PATCH request to add raid groups
+// to a pool cluster
+func (p *poolClusterAPIOpsV1alpha1) httpExpand() (*v1alpha1.CStorPoolCluster, error) {
+	name := p.poolClusterName()
+	if len(name) == 0 {
+		return nil, CodedError(400, "failed to expand pool: missing pool name")
+	}
+	return p.expand(name)
+}
+
+// httpDelete deals with http DELETE request to delete a pool
+// cluster
+func (p *poolClusterAPIOpsV1alpha1) httpDelete() (*v1alpha1.CStorPoolCluster, error) {
+	name := p.poolClusterName()
+	if len(name) == 0 {
+		return nil, CodedError(400, "failed to delete pool: missing pool name")
+	}
+	return p.delete(name)
+}
+
+// openebsNamespace returns the namespace of pool clusters
+func openebsNamespace() (string, error) {
+	ns := menv.Get(menv.OpenEBSNamespace)
+	if ns == "" {
+		return "", CodedErrorf(500, "missing env {%s}", menv.OpenEBSNamespace)
+	}
+	return ns, nil
+}
+
+// create creates a cstor pool cluster from the simplified pool
+// create request
+func (p *poolClusterAPIOpsV1alpha1) create() (*v1alpha1.CStorPoolCluster, error) {
+	glog.Infof("received storage pool create request")
+	req := &apis.PoolCreateRequest{}
+	if err := decodeBody(p.req, req); err != nil {
+		return nil, CodedErrorWrap(400, errors.Wrap(err, "failed to create pool"))
+	}
+	ns, err := openebsNamespace()
+	if err != nil {
+		return nil, err
+	}
+	obj, err := newPoolCluster(req, ns)
+	if err != nil {
+		return nil, CodedErrorWrap(400, errors.Wrap(err, "failed to create pool"))
+	}
+
+	created, err := cspc.NewKubeClient().WithNamespace(ns).Create(obj)
+	if err != nil {
+		glog.Errorf("failed to create storage pool '%s': %+v", req.Name, err)
+		if k8serrors.IsAlreadyExists(errors.Cause(err)) {
+			return nil, CodedErrorWrapf(409, err, "pool '%s' already exists", req.Name)
+		}
+		return nil, CodedErrorWrapf(500, err, "failed to create storage pool '%s'", req.Name)
+	}
+
+	glog.Infof("storage pool '%s' created successfully", created.Name)
+	return created, nil
+}
+
+// expand adds the raid groups of the pool expand request to
+// the pool of its node
+func (p *poolClusterAPIOpsV1alpha1) expand(name string) (*v1alpha1.CStorPoolCluster, error) {
+	glog.Infof("received storage pool expand request: %s", name)
+	req := &apis.PoolExpandRequest{}
+	if err := decodeBody(p.req, req); err != nil {
+		return nil, CodedErrorWrap(400, errors.Wrapf(err, "failed to expand pool '%s'", name))
+	}
+	ns, err := openebsNamespace()
+	if err != nil {
+		return nil, err
+	}
+	client := cspc.NewKubeClient().WithNamespace(ns)
+	obj, err := client.Get(name, metav1.GetOptions{})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, CodedErrorWrapf(404, err, "pool '%s' not found", name)
+		}
+		return nil, CodedErrorWrapf(500, err, "failed to expand storage pool '%s'", name)
+	}
+	patch, err := expandPoolClusterPatch(obj, req)
+	if err != nil {
+		return nil, CodedErrorWrap(400, err)
+	}
+
+	expanded, err := client.Patch(name, types.JSONPatchType, patch)
+	if err != nil {
+		glog.Errorf("failed to expand storage pool '%s': %+v", name, err)
+		return nil, CodedErrorWrapf(500, err, "failed to expand storage pool '%s'", name)
+	}
+
+	glog.Infof("storage pool '%s' expanded successfully", name)
+	return expanded, nil
+}
+
+// delete deletes the cstor pool cluster. The pool cluster is
+// not deleted if any of its pools has volume replicas, since
+// deleting the pools would lose the data of the replicas.
+func (p *poolClusterAPIOpsV1alpha1) delete(name string) (*v1alpha1.CStorPoolCluster, error) {
+	glog.Infof("received storage pool delete request: %s", name)
+	ns, err := openebsNamespace()
+	if err != nil {
+		return nil, err
+	}
+	client := cspc.NewKubeClient().WithNamespace(ns)
+	obj, err := client.Get(name, metav1.GetOptions{})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, CodedErrorWrapf(404, err, "pool '%s' not found", name)
+		}
+		return nil, CodedErrorWrapf(500, err, "failed to delete storage pool '%s'", name)
+	}
+
+	pools, err := csp.NewKubeClient().WithNamespace(ns).
+		List(metav1.ListOptions{LabelSelector: string(v1alpha1.CStorPoolClusterCPK) + "=" + name})
+	if err != nil {
+		return nil, CodedErrorWrapf(500, err, "failed to delete storage pool '%s': failed to list cstor pools", name)
+	}
+	if selector := poolReplicaSelector(pools); selector != "" {
+		replicas, err := cvr.NewKubeclient(cvr.WithNamespace(ns)).
+			List(metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return nil, CodedErrorWrapf(500, err, "failed to delete storage pool '%s': failed to list volume replicas", name)
+		}
+		if len(replicas.Items) != 0 {
+			return nil, CodedErrorf(409, "failed to delete storage pool '%s': pool has volume replicas {%s}",
+				name, strings.Join(replicaNames(replicas), ", "))
+		}
+	}
+
+	if err := client.Delete(name, &metav1.DeleteOptions{}); err != nil {
+		glog.Errorf("failed to delete storage pool '%s': %+v", name, err)
+		if isNotFound(err) {
+			return nil, CodedErrorWrapf(404, err, "pool '%s' not found", name)
+		}
+		return nil, CodedErrorWrapf(500, err, "failed to delete storage pool '%s'", name)
+	}
+
+	glog.Infof("storage pool '%s' deleted successfully", name)
+	return obj, nil
+}
+
+// list lists the cstor pool clusters
+func (p *poolClusterAPIOpsV1alpha1) list() (*v1alpha1.CStorPoolClusterList, error) {
+	glog.Infof("received storage pool cluster list request")
+	ns, err := openebsNamespace()
+	if err != nil {
+		return nil, err
+	}
+	poolClusters, err := cspc.NewKubeClient().WithNamespace(ns).List(metav1.ListOptions{})
+	if err != nil {
+		glog.Errorf("failed to list storage pool clusters: '%+v'", err)
+		return nil, CodedErrorWrap(500, err)
+	}
+
+	glog.Infof("storage pool clusters listed successfully")
+	return poolClusters, nil
+}
+
+// read reads the cstor pool cluster
+func (p *poolClusterAPIOpsV1alpha1) read(name string) (*v1alpha1.CStorPoolCluster, error) {
+	glog.Infof("received storage pool cluster read request: %s", name)
+	ns, err := openebsNamespace()
+	if err != nil {
+		return nil, err
+	}
+	poolCluster, err := cspc.NewKubeClient().WithNamespace(ns).Get(name, metav1.GetOptions{})
+	if err != nil {
+		glog.Errorf("failed to read storage pool cluster '%s': %+v", name, err)
+		if isNotFound(err) {
+			return nil, CodedErrorWrapf(404, err, "pool cluster '%s' not found", name)
+		}
+		return nil, CodedErrorWrapf(500, err, "failed to read storage pool cluster '%s'", name)
+	}
+
+	glog.Infof("storage pool cluster '%s' read successfully", name)
+	return poolCluster, nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestNewPoolCluster(t *testing.T) {
+	req := &apis.PoolCreateRequest{
+		Name:            "cstor-pool",
+		RaidGroupType:   "mirror",
+		Compression:     "lz4",
+		OverCommitRatio: "1.5",
+		Nodes: []apis.PoolNode{
+			{
+				NodeName: "node-1",
+				RaidGroups: []apis.PoolRaidGroup{
+					{BlockDevices: []string{"bd-1", "bd-2"}},
+					{Type: "stripe", BlockDevices: []string{"bd-3"}},
+				},
+			},
+		},
+	}
+	obj, err := newPoolCluster(req, "openebs")
+	if err != nil {
+		t.Fatalf("Test failed: expected no error: actual '%+v'", err)
+	}
+	if obj.Name != "cstor-pool" || obj.Namespace != "openebs" || len(obj.Spec.Pools) != 1 {
+		t.Fatalf("Test failed: unexpected cspc '%+v'", obj)
+	}
+	pool := obj.Spec.Pools[0]
+	if pool.NodeSelector[hostNameLabel] != "node-1" ||
+		pool.PoolConfig.DefaultRaidGroupType != "mirror" ||
+		pool.PoolConfig.Compression != "lz4" ||
+		pool.PoolConfig.OverCommitRatio != "1.5" {
+		t.Fatalf("Test failed: unexpected pool spec '%+v'", pool)
+	}
+	if len(pool.RaidGroups) != 2 ||
+		pool.RaidGroups[0].Type != "mirror" || len(pool.RaidGroups[0].BlockDevices) != 2 ||
+		pool.RaidGroups[1].Type != "stripe" || pool.RaidGroups[1].BlockDevices[0].BlockDeviceName != "bd-3" {
+		t.Fatalf("Test failed: unexpected raid groups '%+v'", pool.RaidGroups)
+	}
+
+	req.Nodes[0].RaidGroups[0].BlockDevices = []string{"bd-1"}
+	if _, err := newPoolCluster(req, "openebs"); err == nil {
+		t.Fatalf("Test failed: expected error for invalid raid group")
+	}
+}
+
+func TestExpandPoolClusterPatch(t *testing.T) {
+	obj := &v1alpha1.CStorPoolCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cstor-pool"},
+		Spec: v1alpha1.CStorPoolClusterSpec{
+			Pools: []v1alpha1.PoolSpec{
+				{
+					NodeSelector: map[string]string{hostNameLabel: "node-1"},
+					RaidGroups: []v1alpha1.RaidGroup{
+						{Type: "mirror", BlockDevices: []v1alpha1.CStorPoolClusterBlockDevice{
+							{BlockDeviceName: "bd-1"}, {BlockDeviceName: "bd-2"},
+						}},
+					},
+					PoolConfig: v1alpha1.PoolConfig{DefaultRaidGroupType: "mirror"},
+				},
+				{
+					NodeSelector: map[string]string{hostNameLabel: "node-2"},
+					PoolConfig:   v1alpha1.PoolConfig{DefaultRaidGroupType: "stripe"},
+				},
+			},
+		},
+	}
+	tests := map[string]struct {
+		node     string
+		bds      []string
+		isErr    bool
+		expected string
+	}{
+		"expand node-2": {
+			node:     "node-2",
+			bds:      []string{"bd-3"},
+			expected: "/spec/pools/1/raidGroups/-",
+		},
+		"expand node-1 with default raid group type": {
+			node:     "node-1",
+			bds:      []string{"bd-3", "bd-4"},
+			expected: "/spec/pools/0/raidGroups/-",
+		},
+		"invalid block device count": {node: "node-1", bds: []string{"bd-3"}, isErr: true},
+		"block device in use":        {node: "node-2", bds: []string{"bd-1"}, isErr: true},
+		"node not in cspc":           {node: "node-3", bds: []string{"bd-3"}, isErr: true},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			req := &apis.PoolExpandRequest{PoolNode: apis.PoolNode{
+				NodeName:   mock.node,
+				RaidGroups: []apis.PoolRaidGroup{{BlockDevices: mock.bds}},
+			}}
+			patch, err := expandPoolClusterPatch(obj, req)
+			if mock.isErr != (err != nil) {
+				t.Fatalf("Test %q failed: expected error '%t': actual '%v'", name, mock.isErr, err)
+			}
+			if err != nil {
+				return
+			}
+			ops := []jsonPatchOp{}
+			if err := json.Unmarshal(patch, &ops); err != nil {
+				t.Fatalf("Test %q failed: invalid patch '%s': %v", name, patch, err)
+			}
+			if len(ops) != 1 || ops[0].Op != "add" || ops[0].Path != mock.expected {
+				t.Fatalf("Test %q failed: unexpected patch '%s'", name, patch)
+			}
+		})
+	}
+}
+
+func TestPoolReplicaSelector(t *testing.T) {
+	tests := map[string]struct {
+		uids     []string
+		expected string
+	}{
+		"no pools":  {expected: ""},
+		"one pool":  {uids: []string{"uid-1"}, expected: "cstorpool.openebs.io/uid in (uid-1)"},
+		"two pools": {uids: []string{"uid-1", "uid-2"}, expected: "cstorpool.openebs.io/uid in (uid-1,uid-2)"},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			pools := &v1alpha1.NewTestCStorPoolList{}
+			for _, uid := range mock.uids {
+				pools.Items = append(pools.Items, v1alpha1.NewTestCStorPool{
+					ObjectMeta: metav1.ObjectMeta{UID: types.UID(uid)},
+				})
+			}
+			if got := poolReplicaSelector(pools); got != mock.expected {
+				t.Fatalf("Test %q failed: expected '%s': actual '%s'", name, mock.expected, got)
+			}
+		})
+	}
+}
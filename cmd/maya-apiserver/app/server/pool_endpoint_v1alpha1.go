@@ -26,8 +26,9 @@ import (
 )
 
 type poolAPIOpsV1alpha1 struct {
-	req  *http.Request
-	resp http.ResponseWriter
+	req     *http.Request
+	resp    http.ResponseWriter
+	watches *informerHub
 }
 
 // poolV1alpha1SpecificRequest is a http handler
@@ -40,10 +41,13 @@ func (s *HTTPServer) poolV1alpha1SpecificRequest(resp http.ResponseWriter, req *
 	glog.Infof(" received storage pool request: method '%s'", req.Method)
 
 	poolOp := &poolAPIOpsV1alpha1{
-		req:  req,
-		resp: resp,
+		req:     req,
+		resp:    resp,
+		watches: s.watches,
 	}
 
+	// pools are created, expanded & deleted via their
+	// pool clusters i.e. /latest/poolclusters/
 	switch req.Method {
 	case "GET":
 		return poolOp.httpGet()
@@ -56,13 +60,55 @@ func (p *poolAPIOpsV1alpha1) httpGet() (interface{}, error) {
 	path := strings.TrimSpace(strings.TrimPrefix(p.req.URL.Path, "/latest/pools"))
 
 	if path == "/" {
-		return p.list()
+		return p.httpList()
 	}
 	poolName := strings.TrimSpace(strings.TrimPrefix(path, "/"))
 	return p.read(poolName)
 }
 
+// httpList lists or watches the pools as per the list options
+// of the request
+func (p *poolAPIOpsV1alpha1) httpList() (interface{}, error) {
+	opts, err := listOptions(p.req)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Watch {
+		// all the watches share the list of pools
+		list := func() (interface{}, error) {
+			return listPools()
+		}
+		return p.watches.watchList(p.resp, p.req, "pools", "", list, func(l interface{}) (map[string]interface{}, error) {
+			// list is shared with other watches, hence the
+			// copy is filtered
+			pools := *l.(*v1alpha1.CStorPoolList)
+			if err := filterPools(&pools, opts); err != nil {
+				return nil, err
+			}
+			items := map[string]interface{}{}
+			for _, pool := range pools.Items {
+				items[pool.Namespace+"/"+pool.Name] = pool
+			}
+			return items, nil
+		})
+	}
+
+	pools, err := p.list()
+	if err != nil {
+		return nil, err
+	}
+	if err := filterPools(pools, opts); err != nil {
+		return nil, err
+	}
+	return pools, nil
+}
+
 func (p *poolAPIOpsV1alpha1) list() (*v1alpha1.CStorPoolList, error) {
+	return listPools()
+}
+
+// listPools lists the storage pools
+func listPools() (*v1alpha1.CStorPoolList, error) {
 	glog.Infof("received storage pool list request")
 	sOps, err := pool.NewStoragePoolOperation("")
 	if err != nil {
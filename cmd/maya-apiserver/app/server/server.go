@@ -32,6 +32,10 @@ type MayaApiServer struct {
 	shutdown     bool
 	shutdownCh   chan struct{}
 	shutdownLock sync.Mutex
+
+	// watches notifies the watch requests of all the http
+	// servers of changes to the resources
+	watches *informerHub
 }
 
 // NewMayaApiServer is used to create a new maya api server
@@ -42,6 +46,7 @@ func NewMayaApiServer(config *config.MayaConfig, logOutput io.Writer) (*MayaApiS
 		logger:     log.New(logOutput, "", log.LstdFlags|log.Lmicroseconds),
 		logOutput:  logOutput,
 		shutdownCh: make(chan struct{}),
+		watches:    newInformerHub(),
 	}
 	return ms, nil
 }
@@ -58,6 +63,8 @@ func (ms *MayaApiServer) Shutdown() error {
 		return nil
 	}
 
+	ms.watches.stop()
+
 	ms.logger.Println("[INFO] maya api server: shutdown complete")
 	ms.shutdown = true
 
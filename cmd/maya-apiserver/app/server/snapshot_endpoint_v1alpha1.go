@@ -66,6 +66,16 @@ func (s *HTTPServer) snapshotV1alpha1SpecificRequest(resp http.ResponseWriter, r
 func (sOps *snapshotAPIOps) list(volName, namespace, casType string) (interface{}, error) {
 	glog.Infof("Snapshot list request was received")
 
+	opts, err := listOptions(sOps.req)
+	if err != nil {
+		return nil, err
+	}
+	// snapshots are read from the volume targets & have no
+	// informer to watch them
+	if opts.Watch {
+		return nil, CodedError(400, "failed to list snapshot: watch is not supported")
+	}
+
 	// Volume name is expected
 	if len(strings.TrimSpace(volName)) == 0 {
 		return nil, CodedError(400, fmt.Sprintf("failed to list snapshot: missing snapshot name "))
@@ -93,6 +103,10 @@ func (sOps *snapshotAPIOps) list(volName, namespace, casType string) (interface{
 		return nil, CodedError(500, err.Error())
 	}
 
+	if err := filterSnapshots(snaps, opts); err != nil {
+		return nil, err
+	}
+
 	glog.Infof("Snapshots listed successfully for volume '%s'", volName)
 	return snaps, nil
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package server
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
@@ -50,8 +51,9 @@ func isNotFound(err error) bool {
 }
 
 type volumeAPIOpsV1alpha1 struct {
-	req  *http.Request
-	resp http.ResponseWriter
+	req     *http.Request
+	resp    http.ResponseWriter
+	watches *informerHub
 }
 
 // sendEventOrIgnore sends anonymous volume (de)-provision events
@@ -77,8 +79,9 @@ func (s *HTTPServer) volumeV1alpha1SpecificRequest(resp http.ResponseWriter, req
 	glog.Infof("received cas volume request: http method {%s}", req.Method)
 
 	volOp := &volumeAPIOpsV1alpha1{
-		req:  req,
-		resp: resp,
+		req:     req,
+		resp:    resp,
+		watches: s.watches,
 	}
 
 	switch req.Method {
@@ -103,7 +106,7 @@ func (v *volumeAPIOpsV1alpha1) httpGet() (interface{}, error) {
 	path := strings.TrimSpace(strings.TrimPrefix(v.req.URL.Path, "/latest/volumes"))
 	// list cas volumes
 	if path == "/" {
-		return v.list()
+		return v.httpList()
 	} else if strings.Contains(path, "/stats/") {
 		return v.readStats(strings.TrimPrefix(path, "/stats/"))
 	}
@@ -113,6 +116,44 @@ func (v *volumeAPIOpsV1alpha1) httpGet() (interface{}, error) {
 	return v.read(volName)
 }
 
+// httpList deals with http GET request to list or watch the
+// volumes as per the list options of the request
+func (v *volumeAPIOpsV1alpha1) httpList() (interface{}, error) {
+	opts, err := listOptions(v.req)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Watch {
+		// watches of the same namespace share the list of volumes
+		namespace := v.req.Header.Get(NamespaceKey)
+		list := func() (interface{}, error) {
+			return listVolumes(namespace)
+		}
+		return v.watches.watchList(v.resp, v.req, "volumes", namespace, list, func(l interface{}) (map[string]interface{}, error) {
+			// list is shared with other watches, hence the
+			// copy is filtered
+			vols := *l.(*v1alpha1.CASVolumeList)
+			if err := filterVolumes(&vols, opts); err != nil {
+				return nil, err
+			}
+			items := map[string]interface{}{}
+			for _, vol := range vols.Items {
+				items[vol.Namespace+"/"+vol.Name] = vol
+			}
+			return items, nil
+		})
+	}
+
+	vols, err := v.list()
+	if err != nil {
+		return nil, err
+	}
+	if err := filterVolumes(vols, opts); err != nil {
+		return nil, err
+	}
+	return vols, nil
+}
+
 // httpDelete deals with http DELETE request
 func (v *volumeAPIOpsV1alpha1) httpDelete() (*v1alpha1.CASVolume, error) {
 	// Extract name of volume from path after trimming
@@ -151,6 +192,7 @@ func (v *volumeAPIOpsV1alpha1) create() (*v1alpha1.CASVolume, error) {
 			errors.Wrapf(err, "failed to create volume: failed to init volume operation: %s", vol),
 		)
 	}
+	vOps.WithContext(v.context())
 
 	cvol, err := vOps.Create()
 	if err != nil {
@@ -161,6 +203,15 @@ func (v *volumeAPIOpsV1alpha1) create() (*v1alpha1.CASVolume, error) {
 	return cvol, nil
 }
 
+// context returns the context of the http request that has
+// the span of the request
+func (v *volumeAPIOpsV1alpha1) context() context.Context {
+	if v.req == nil {
+		return context.Background()
+	}
+	return v.req.Context()
+}
+
 func (v *volumeAPIOpsV1alpha1) read(volumeName string) (*v1alpha1.CASVolume, error) {
 	glog.Infof("received volume read request: %s", volumeName)
 
@@ -205,6 +256,7 @@ func (v *volumeAPIOpsV1alpha1) read(volumeName string) (*v1alpha1.CASVolume, err
 			errors.Wrapf(err, "failed to read volume {%s}: failed to init volume operation", vol.Name),
 		)
 	}
+	vOps.WithContext(v.context())
 
 	cvol, err := vOps.Read()
 	if err != nil {
@@ -253,6 +305,7 @@ func (v *volumeAPIOpsV1alpha1) delete(volumeName string) (*v1alpha1.CASVolume, e
 			errors.Wrapf(err, "failed to delete volume: failed to init volume operation: %s", vol),
 		)
 	}
+	vOps.WithContext(v.context())
 
 	cvol, err := vOps.Delete()
 	if err != nil {
@@ -287,6 +340,14 @@ func (v *volumeAPIOpsV1alpha1) list() (*v1alpha1.CASVolumeList, error) {
 		vols.Namespace = hdrNS
 	}
 
+	return listVolumes(vols.Namespace)
+}
+
+// listVolumes lists the volumes of the given namespace(s)
+func listVolumes(namespace string) (*v1alpha1.CASVolumeList, error) {
+	vols := &v1alpha1.CASVolumeList{}
+	vols.Namespace = namespace
+
 	vOps, err := volume.NewListOperation(vols)
 	if err != nil {
 		return nil, CodedErrorWrap(
@@ -0,0 +1,452 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
+	clientset "github.com/openebs/maya/pkg/client/generated/clientset/versioned"
+	informers "github.com/openebs/maya/pkg/client/generated/informers/externalversions"
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+	kclient "github.com/openebs/maya/pkg/kubernetes/client/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// jivaDeploymentSelector selects the deployments of jiva
+	// volumes i.e. their targets & replicas
+	jivaDeploymentSelector = "openebs.io/cas-type=jiva"
+
+	// informerResyncPeriod is the resync period of informers
+	// that notify the watches
+	informerResyncPeriod = 30 * time.Second
+)
+
+// watchDebounceInterval is the interval that the changes are
+// batched for before the watched lists are read again
+var watchDebounceInterval = 500 * time.Millisecond
+
+// watchSource notifies the watches of a resource of its changes.
+// The resource is listed once per change for all the watches of
+// a view i.e. the watches that read the same list.
+type watchSource struct {
+	mu      sync.Mutex
+	views   map[string]*watchView
+	changed chan struct{}
+}
+
+// watchView is a list of the resource that is shared by its
+// watches
+type watchView struct {
+	list     func() (interface{}, error)
+	watchers map[chan watchResult]struct{}
+}
+
+// watchResult is the result of listing a view
+type watchResult struct {
+	list interface{}
+	err  error
+}
+
+// newWatchSource returns a new instance of watchSource
+func newWatchSource() *watchSource {
+	return &watchSource{
+		views:   map[string]*watchView{},
+		changed: make(chan struct{}, 1),
+	}
+}
+
+// notify flags a change to the resource without blocking. The
+// changes that arrive while the views are being listed are
+// handled by the next list.
+func (w *watchSource) notify() {
+	select {
+	case w.changed <- struct{}{}:
+	default:
+	}
+}
+
+// subscribe returns the channel that receives the list of the
+// given view after every change & the func to unsubscribe. The
+// view is listed by the list func of its first watch.
+func (w *watchSource) subscribe(view string, list func() (interface{}, error)) (<-chan watchResult, func()) {
+	ch := make(chan watchResult, 1)
+	w.mu.Lock()
+	v, ok := w.views[view]
+	if !ok {
+		v = &watchView{list: list, watchers: map[chan watchResult]struct{}{}}
+		w.views[view] = v
+	}
+	v.watchers[ch] = struct{}{}
+	w.mu.Unlock()
+	return ch, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(v.watchers, ch)
+		if len(v.watchers) == 0 && w.views[view] == v {
+			delete(w.views, view)
+		}
+	}
+}
+
+// run lists the watched views after every change until stopped.
+// The changes that follow a change are batched for the debounce
+// interval.
+func (w *watchSource) run(stopCh <-chan struct{}) {
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-w.changed:
+		}
+		select {
+		case <-stopCh:
+			return
+		case <-time.After(watchDebounceInterval):
+		}
+		w.refresh()
+	}
+}
+
+// refresh lists every watched view once & sends the list to its
+// watches. A watch that is yet to handle the previous list gets
+// the latest one instead.
+func (w *watchSource) refresh() {
+	w.mu.Lock()
+	views := make([]*watchView, 0, len(w.views))
+	for _, v := range w.views {
+		views = append(views, v)
+	}
+	w.mu.Unlock()
+
+	for _, v := range views {
+		list, err := v.list()
+		w.mu.Lock()
+		for ch := range v.watchers {
+			// only refresh sends on the channel, hence the
+			// send does not block once it is drained
+			select {
+			case <-ch:
+			default:
+			}
+			ch <- watchResult{list: list, err: err}
+		}
+		w.mu.Unlock()
+	}
+}
+
+// eventHandler returns the informer event handler that notifies
+// the watches. Updates that do not change the resource version
+// i.e. the periodic resyncs are ignored.
+func (w *watchSource) eventHandler() cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) { w.notify() },
+		UpdateFunc: func(old, new interface{}) {
+			if isResync(old, new) {
+				return
+			}
+			w.notify()
+		},
+		DeleteFunc: func(obj interface{}) { w.notify() },
+	}
+}
+
+// isResync flags if the given update of an object does not
+// change its resource version
+func isResync(old, new interface{}) bool {
+	o, err := meta.Accessor(old)
+	if err != nil {
+		return false
+	}
+	n, err := meta.Accessor(new)
+	if err != nil {
+		return false
+	}
+	return o.GetResourceVersion() == n.GetResourceVersion()
+}
+
+// informerHub runs the informers of the resources that can be
+// watched. There is one hub per maya api server that is shared
+// by all of its watches. The informers are started on the first
+// watch request so that maya apiserver does not cache the
+// resources unless there is a client watching them.
+type informerHub struct {
+	once    sync.Once
+	err     error
+	stopCh  chan struct{}
+	sources map[string]*watchSource
+
+	// start starts the informers that notify the sources
+	start func(h *informerHub) error
+}
+
+// newInformerHub returns a new instance of informerHub
+func newInformerHub() *informerHub {
+	return &informerHub{
+		stopCh: make(chan struct{}),
+		sources: map[string]*watchSource{
+			"volumes": newWatchSource(),
+			"pools":   newWatchSource(),
+		},
+		start: startInformers,
+	}
+}
+
+// subscribe returns the channel that receives the list of the
+// given view of the resource after every change & the func to
+// unsubscribe
+func (h *informerHub) subscribe(
+	resource, view string,
+	list func() (interface{}, error),
+) (<-chan watchResult, func(), error) {
+	source, ok := h.sources[resource]
+	if !ok {
+		return nil, nil, errors.Errorf("failed to watch {%s}: watch is not supported", resource)
+	}
+	h.once.Do(func() {
+		h.err = h.start(h)
+		if h.err != nil {
+			return
+		}
+		for _, s := range h.sources {
+			go s.run(h.stopCh)
+		}
+	})
+	if h.err != nil {
+		return nil, nil, errors.Wrapf(h.err, "failed to watch {%s}", resource)
+	}
+	ch, cancel := source.subscribe(view, list)
+	return ch, cancel, nil
+}
+
+// stop stops the informers
+func (h *informerHub) stop() {
+	if h == nil {
+		return
+	}
+	select {
+	case <-h.stopCh:
+	default:
+		close(h.stopCh)
+	}
+}
+
+// startInformers starts the informers of cstor volumes, jiva
+// deployments & cstor pools
+func startInformers(h *informerHub) error {
+	config, err := kclient.New().Config()
+	if err != nil {
+		return errors.Wrap(err, "failed to start informers: failed to get kubernetes config")
+	}
+	kubeClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return errors.Wrap(err, "failed to start informers: failed to build kubernetes clientset")
+	}
+	openebsClient, err := clientset.NewForConfig(config)
+	if err != nil {
+		return errors.Wrap(err, "failed to start informers: failed to build openebs clientset")
+	}
+
+	openebsInformerFactory := informers.NewSharedInformerFactory(openebsClient, informerResyncPeriod)
+	kubeInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(
+		kubeClient,
+		informerResyncPeriod,
+		kubeinformers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.LabelSelector = jivaDeploymentSelector
+		}),
+	)
+
+	cvInformer := openebsInformerFactory.Openebs().V1alpha1().CStorVolumes().Informer()
+	cvInformer.AddEventHandler(h.sources["volumes"].eventHandler())
+	deployInformer := kubeInformerFactory.Apps().V1().Deployments().Informer()
+	deployInformer.AddEventHandler(h.sources["volumes"].eventHandler())
+	cspInformer := openebsInformerFactory.Openebs().V1alpha1().CStorPools().Informer()
+	cspInformer.AddEventHandler(h.sources["pools"].eventHandler())
+
+	openebsInformerFactory.Start(h.stopCh)
+	kubeInformerFactory.Start(h.stopCh)
+	if !cache.WaitForCacheSync(h.stopCh, cvInformer.HasSynced, deployInformer.HasSynced, cspInformer.HasSynced) {
+		return errors.New("failed to start informers: failed to sync caches")
+	}
+	glog.Info("started informers of watch requests")
+	return nil
+}
+
+// watchList streams the changes to the items of the given view
+// of the resource until the client goes away. The view is listed
+// once per change for all of its watches by the given list func,
+// while the items func returns the items of a list that the
+// client watches keyed by namespace/name. The changes are
+// streamed as server sent events if the client accepts them or
+// else as chunks of json encoded watch events separated by
+// newline.
+func (h *informerHub) watchList(
+	resp http.ResponseWriter,
+	req *http.Request,
+	resource, view string,
+	list func() (interface{}, error),
+	items func(list interface{}) (map[string]interface{}, error),
+) (interface{}, error) {
+	flusher, ok := resp.(http.Flusher)
+	if !ok {
+		return nil, CodedErrorf(500, "failed to watch {%s}: streaming is not supported", resource)
+	}
+	changes, cancel, err := h.subscribe(resource, view, list)
+	if err != nil {
+		return nil, CodedErrorWrap(500, err)
+	}
+	defer cancel()
+
+	l, err := list()
+	if err != nil {
+		return nil, CodedErrorWrap(500, errors.Wrapf(err, "failed to watch {%s}", resource))
+	}
+	current, err := items(l)
+	if err != nil {
+		return nil, CodedErrorWrap(400, errors.Wrapf(err, "failed to watch {%s}", resource))
+	}
+
+	w := &eventWriter{
+		resp:    resp,
+		flusher: flusher,
+		sse:     strings.Contains(req.Header.Get("Accept"), apis.EventStreamContentType),
+	}
+	w.writeHeader()
+	if err := w.writeChanges(nil, current); err != nil {
+		return nil, nil
+	}
+
+	glog.V(4).Infof("started watch of {%s}", resource)
+	for {
+		var result watchResult
+		select {
+		case <-req.Context().Done():
+			glog.V(4).Infof("stopped watch of {%s}", resource)
+			return nil, nil
+		case result = <-changes:
+		}
+
+		var latest map[string]interface{}
+		err := result.err
+		if err == nil {
+			latest, err = items(result.list)
+		}
+		if err != nil {
+			glog.Errorf("failed to list {%s} of watch: %+v", resource, err)
+			err = w.write(apis.WatchEvent{
+				Type:   apis.EventError,
+				Object: apis.NewError(500, err.Error()),
+			})
+		} else {
+			err = w.writeChanges(current, latest)
+			current = latest
+		}
+		if err != nil {
+			// client has gone away
+			return nil, nil
+		}
+	}
+}
+
+// eventWriter writes the watch events to the response
+type eventWriter struct {
+	resp    http.ResponseWriter
+	flusher http.Flusher
+	sse     bool
+}
+
+func (w *eventWriter) writeHeader() {
+	if w.sse {
+		w.resp.Header().Set("Content-Type", apis.EventStreamContentType)
+	} else {
+		w.resp.Header().Set("Content-Type", "application/json")
+	}
+	w.resp.Header().Set("Cache-Control", "no-cache")
+	w.resp.WriteHeader(http.StatusOK)
+	w.flusher.Flush()
+}
+
+func (w *eventWriter) write(event apis.WatchEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if w.sse {
+		_, err = fmt.Fprintf(w.resp, "event: %s\ndata: %s\n\n", event.Type, body)
+	} else {
+		_, err = fmt.Fprintf(w.resp, "%s\n", body)
+	}
+	if err != nil {
+		return err
+	}
+	w.flusher.Flush()
+	return nil
+}
+
+// writeChanges writes the events of the changes between the
+// given items ordered by their key
+func (w *eventWriter) writeChanges(old, new map[string]interface{}) error {
+	for _, event := range diffItems(old, new) {
+		if err := w.write(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffItems returns the events that change the old items to the
+// new items
+func diffItems(old, new map[string]interface{}) []apis.WatchEvent {
+	keys := []string{}
+	for key := range old {
+		keys = append(keys, key)
+	}
+	for key := range new {
+		if _, ok := old[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	events := []apis.WatchEvent{}
+	for _, key := range keys {
+		o, inOld := old[key]
+		n, inNew := new[key]
+		switch {
+		case !inOld:
+			events = append(events, apis.WatchEvent{Type: apis.EventAdded, Object: n})
+		case !inNew:
+			events = append(events, apis.WatchEvent{Type: apis.EventDeleted, Object: o})
+		case !reflect.DeepEqual(o, n):
+			events = append(events, apis.WatchEvent{Type: apis.EventModified, Object: n})
+		}
+	}
+	return events
+}
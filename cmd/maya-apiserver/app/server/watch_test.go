@@ -0,0 +1,207 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDiffItems(t *testing.T) {
+	old := map[string]interface{}{"ns/a": "1", "ns/b": "1", "ns/c": "1"}
+	new := map[string]interface{}{"ns/a": "1", "ns/b": "2", "ns/d": "1"}
+	expected := []apis.WatchEvent{
+		{Type: apis.EventModified, Object: "2"},
+		{Type: apis.EventDeleted, Object: "1"},
+		{Type: apis.EventAdded, Object: "1"},
+	}
+	got := diffItems(old, new)
+	if len(got) != len(expected) {
+		t.Fatalf("Test failed: expected '%+v': actual '%+v'", expected, got)
+	}
+	for i := range got {
+		if got[i] != expected[i] {
+			t.Fatalf("Test failed: expected '%+v': actual '%+v'", expected, got)
+		}
+	}
+}
+
+func TestWatchList(t *testing.T) {
+	watchDebounceInterval = 10 * time.Millisecond
+	hub := newInformerHub()
+	hub.start = func(h *informerHub) error { return nil }
+	defer hub.stop()
+
+	var mu sync.Mutex
+	items := map[string]interface{}{"ns/pool-1": "Healthy"}
+	list := func() (interface{}, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		copied := map[string]interface{}{}
+		for k, v := range items {
+			copied[k] = v
+		}
+		return copied, nil
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		hub.watchList(resp, req, "pools", "", list, func(l interface{}) (map[string]interface{}, error) {
+			return l.(map[string]interface{}), nil
+		})
+	}))
+	defer server.Close()
+
+	tests := map[string]struct {
+		accept string
+		decode func(r *bufio.Reader) apis.WatchEvent
+	}{
+		"chunked json": {
+			decode: func(r *bufio.Reader) apis.WatchEvent {
+				line, _ := r.ReadString('\n')
+				event := apis.WatchEvent{}
+				json.Unmarshal([]byte(line), &event)
+				return event
+			},
+		},
+		"server sent events": {
+			accept: apis.EventStreamContentType,
+			decode: func(r *bufio.Reader) apis.WatchEvent {
+				event := apis.WatchEvent{}
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return event
+					}
+					if strings.HasPrefix(line, "data: ") {
+						json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event)
+					}
+					if line == "\n" {
+						return event
+					}
+				}
+			},
+		},
+	}
+	for name, mock := range tests {
+		mu.Lock()
+		items = map[string]interface{}{"ns/pool-1": "Healthy"}
+		mu.Unlock()
+
+		req, _ := http.NewRequest("GET", server.URL, nil)
+		if mock.accept != "" {
+			req.Header.Set("Accept", mock.accept)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Test %q failed: unexpected error: %v", name, err)
+		}
+		r := bufio.NewReader(resp.Body)
+
+		expect := func(eventType apis.EventType, object string) {
+			event := mock.decode(r)
+			if event.Type != eventType || event.Object != object {
+				t.Fatalf("Test %q failed: expected event {%s %s}: actual '%+v'", name, eventType, object, event)
+			}
+		}
+		expect(apis.EventAdded, "Healthy")
+
+		mu.Lock()
+		items["ns/pool-1"] = "Offline"
+		mu.Unlock()
+		hub.sources["pools"].notify()
+		expect(apis.EventModified, "Offline")
+
+		mu.Lock()
+		delete(items, "ns/pool-1")
+		mu.Unlock()
+		hub.sources["pools"].notify()
+		expect(apis.EventDeleted, "Offline")
+		resp.Body.Close()
+	}
+}
+
+func TestWatchSourceSharesList(t *testing.T) {
+	watchDebounceInterval = 10 * time.Millisecond
+	source := newWatchSource()
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go source.run(stopCh)
+
+	var mu sync.Mutex
+	lists := map[string]int{}
+	listFor := func(view string) func() (interface{}, error) {
+		return func() (interface{}, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			lists[view]++
+			return view, nil
+		}
+	}
+	ch1, cancel1 := source.subscribe("ns-1", listFor("ns-1"))
+	defer cancel1()
+	ch2, cancel2 := source.subscribe("ns-1", listFor("ns-1"))
+	defer cancel2()
+	ch3, cancel3 := source.subscribe("ns-2", listFor("ns-2"))
+
+	// a burst of changes is listed once per view
+	for i := 0; i < 5; i++ {
+		source.notify()
+	}
+	for _, ch := range []<-chan watchResult{ch1, ch2, ch3} {
+		select {
+		case <-ch:
+		case <-time.After(time.Second):
+			t.Fatalf("Test failed: expected list after change")
+		}
+	}
+	mu.Lock()
+	if lists["ns-1"] != 1 || lists["ns-2"] != 1 {
+		t.Fatalf("Test failed: expected one list per view: actual '%v'", lists)
+	}
+	mu.Unlock()
+
+	// views without watches are not listed
+	cancel3()
+	source.notify()
+	<-ch1
+	mu.Lock()
+	if lists["ns-2"] != 1 {
+		t.Fatalf("Test failed: expected no list of view without watches: actual '%v'", lists)
+	}
+	mu.Unlock()
+}
+
+func TestIsResync(t *testing.T) {
+	pool := func(rv string) *v1alpha1.CStorPool {
+		return &v1alpha1.CStorPool{ObjectMeta: metav1.ObjectMeta{ResourceVersion: rv}}
+	}
+	if !isResync(pool("1"), pool("1")) {
+		t.Fatalf("Test failed: expected update with same resource version to be resync")
+	}
+	if isResync(pool("1"), pool("2")) {
+		t.Fatalf("Test failed: expected update with new resource version not to be resync")
+	}
+}
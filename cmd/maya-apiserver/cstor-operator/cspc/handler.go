@@ -168,6 +168,22 @@ func (c *Controller) syncCSPC(cspc *apis.CStorPoolCluster) error {
 		pc.createDeployForCSPList(cspList)
 	}
 
+	err = pc.AlgorithmConfig.SyncScrubInterval()
+	if err != nil {
+		message := fmt.Sprintf("Could not sync scrub interval of pool(s): {%s}", err.Error())
+		c.recorder.Event(cspc, corev1.EventTypeWarning, "Pool Update", message)
+		glog.Errorf("Could not sync scrub interval of pool(s) for CSPC {%s}:{%s}", cspc.Name, err.Error())
+		return nil
+	}
+
+	err = pc.AlgorithmConfig.SyncOverCommitRatio()
+	if err != nil {
+		message := fmt.Sprintf("Could not sync over commit ratio of pool(s): {%s}", err.Error())
+		c.recorder.Event(cspc, corev1.EventTypeWarning, "Pool Update", message)
+		glog.Errorf("Could not sync over commit ratio of pool(s) for CSPC {%s}:{%s}", cspc.Name, err.Error())
+		return nil
+	}
+
 	return nil
 }
 
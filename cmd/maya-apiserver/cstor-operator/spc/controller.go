@@ -177,12 +177,23 @@ func (c *Controller) updateSpc(oldSpc, newSpc interface{}) {
 		c.recorder.Event(spc, corev1.EventTypeWarning, "Update", message)
 		return
 	}
-	// Enqueue spc only when there is a pending pool to be created.
-	if c.isPoolPending(spc) {
+	// Enqueue spc only when there is a pending pool to be created
+	// or the scrub interval of its pools has changed.
+	if c.isPoolPending(spc) || isScrubIntervalChanged(oldSpc, spc) {
 		c.enqueueSpc(newSpc)
 	}
 }
 
+// isScrubIntervalChanged returns true if the scrub interval of the
+// pools of the spc has been changed.
+func isScrubIntervalChanged(oldSpc interface{}, spc *apis.StoragePoolClaim) bool {
+	old, ok := oldSpc.(*apis.StoragePoolClaim)
+	if !ok {
+		return false
+	}
+	return old.Spec.PoolSpec.ScrubInterval != spc.Spec.PoolSpec.ScrubInterval
+}
+
 // deleteSpc is the delete event handler for spc.
 func (c *Controller) deleteSpc(obj interface{}) {
 	spc, ok := obj.(*apis.StoragePoolClaim)
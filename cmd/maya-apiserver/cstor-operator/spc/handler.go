@@ -17,6 +17,7 @@ limitations under the License.
 package spc
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -24,11 +25,13 @@ import (
 	"github.com/golang/glog"
 	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
 	openebs "github.com/openebs/maya/pkg/client/generated/clientset/versioned"
+	cstorpool "github.com/openebs/maya/pkg/cstor/pool/v1alpha1"
 	env "github.com/openebs/maya/pkg/env/v1alpha1"
 	spcv1alpha1 "github.com/openebs/maya/pkg/storagepoolclaim/v1alpha1"
 	"github.com/pkg/errors"
 	k8serror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/cache"
 )
@@ -118,6 +121,37 @@ func (c *Controller) syncSpc(spc *apis.StoragePoolClaim) error {
 			return err
 		}
 	}
+	return c.syncScrubInterval(spc)
+}
+
+// syncScrubInterval updates the scrub interval of the cstor pools of
+// the spc whose scrub interval differs from the one present in spc.
+func (c *Controller) syncScrubInterval(spc *apis.StoragePoolClaim) error {
+	interval := spc.Spec.PoolSpec.ScrubInterval
+	cspList, err := c.clientset.OpenebsV1alpha1().CStorPools().List(metav1.ListOptions{LabelSelector: string(apis.StoragePoolClaimCPK) + "=" + spc.Name})
+	if err != nil {
+		return errors.Wrapf(err, "failed to sync scrub interval: unable to list cstor pools of spc %s", spc.Name)
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"poolSpec": map[string]interface{}{
+				"scrubInterval": interval,
+			},
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to build scrub interval patch for spc %s", spc.Name)
+	}
+	for _, csp := range cspList.Items {
+		if csp.Spec.PoolSpec.ScrubInterval == interval {
+			continue
+		}
+		_, err = c.clientset.OpenebsV1alpha1().CStorPools().Patch(csp.Name, types.MergePatchType, patch)
+		if err != nil {
+			return errors.Wrapf(err, "failed to update scrub interval of cstor pool %s", csp.Name)
+		}
+		glog.Infof("Updated scrub interval of cstor pool %s to '%s' for storagepoolclaim %s", csp.Name, interval, spc.Name)
+	}
 	return nil
 }
 
@@ -161,6 +195,8 @@ var validateFuncList = []validateFunc{
 	validatePoolType,
 	validateDiskType,
 	validateAutoSpcMaxPool,
+	validateScrubInterval,
+	validateOverCommitRatio,
 }
 
 // validatePoolType validates pool type in spc.
@@ -196,6 +232,28 @@ func validateAutoSpcMaxPool(spc *apis.StoragePoolClaim) error {
 	return nil
 }
 
+// validateScrubInterval validates the scrub interval of pools in spc.
+func validateScrubInterval(spc *apis.StoragePoolClaim) error {
+	interval := spc.Spec.PoolSpec.ScrubInterval
+	if interval == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(interval)
+	if err != nil || d <= 0 {
+		return errors.Errorf("aborting storagepool create operation for %s as invalid scrubInterval '%s'", spc.Name, interval)
+	}
+	return nil
+}
+
+// validateOverCommitRatio validates the over commit ratio of pools in spc.
+func validateOverCommitRatio(spc *apis.StoragePoolClaim) error {
+	_, err := cstorpool.ParseOverCommitRatio(spc.Spec.PoolSpec.OverCommitRatio)
+	if err != nil {
+		return errors.Wrapf(err, "aborting storagepool create operation for %s", spc.Name)
+	}
+	return nil
+}
+
 // getCurrentPoolCount give the current pool count for the given auto provisioned spc.
 func (c *Controller) getCurrentPoolCount(spc *apis.StoragePoolClaim) (int, error) {
 	// Get the current count of provisioned pool for the storagepool claim
@@ -253,6 +253,81 @@ func TestValidateAutoSpcMaxPool(t *testing.T) {
 	}
 }
 
+func TestValidateScrubInterval(t *testing.T) {
+	tests := map[string]struct {
+		interval      string
+		expectedError bool
+	}{
+		"Scrub interval not specified on spc": {interval: "", expectedError: false},
+		"Valid scrub interval on spc":         {interval: "168h", expectedError: false},
+		"Negative scrub interval on spc":      {interval: "-1h", expectedError: true},
+		"Invalid scrub interval on spc":       {interval: "weekly", expectedError: true},
+	}
+
+	for name, test := range tests {
+		name := name
+		test := test
+		t.Run(name, func(t *testing.T) {
+			spc := &apis.StoragePoolClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-pool-claim-1",
+				},
+				Spec: apis.StoragePoolClaimSpec{
+					PoolSpec: apis.CStorPoolAttr{
+						ScrubInterval: test.interval,
+					},
+				},
+			}
+			err := validateScrubInterval(spc)
+			var gotError bool
+			if err != nil {
+				gotError = true
+			}
+			if gotError != test.expectedError {
+				t.Errorf("Test case failed as expected error %v but got error %v", test.expectedError, gotError)
+			}
+		})
+	}
+}
+
+func TestValidateOverCommitRatio(t *testing.T) {
+	tests := map[string]struct {
+		ratio         string
+		expectedError bool
+	}{
+		"Over commit ratio not specified on spc": {ratio: "", expectedError: false},
+		"Valid over commit ratio on spc":         {ratio: "1.5", expectedError: false},
+		"Negative over commit ratio on spc":      {ratio: "-2", expectedError: true},
+		"Invalid over commit ratio on spc":       {ratio: "twice", expectedError: true},
+	}
+
+	for name, test := range tests {
+		name := name
+		test := test
+		t.Run(name, func(t *testing.T) {
+			spc := &apis.StoragePoolClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-pool-claim-1",
+				},
+				Spec: apis.StoragePoolClaimSpec{
+					PoolSpec: apis.CStorPoolAttr{
+						OverProvisioning: true,
+						OverCommitRatio:  test.ratio,
+					},
+				},
+			}
+			err := validateOverCommitRatio(spc)
+			var gotError bool
+			if err != nil {
+				gotError = true
+			}
+			if gotError != test.expectedError {
+				t.Errorf("Test case failed as expected error %v but got error %v", test.expectedError, gotError)
+			}
+		})
+	}
+}
+
 func TestValidateSpc(t *testing.T) {
 	tests := map[string]struct {
 		spc           *apis.StoragePoolClaim
@@ -704,3 +779,91 @@ func newInt(val int) *int {
 	value := val
 	return &value
 }
+
+func TestSyncScrubInterval(t *testing.T) {
+	tests := map[string]struct {
+		poolInterval     string
+		poolLabel        string
+		spcInterval      string
+		expectedInterval string
+	}{
+		"Scrub interval changed on spc": {
+			poolInterval:     "168h",
+			poolLabel:        "test-pool-claim-1",
+			spcInterval:      "24h",
+			expectedInterval: "24h",
+		},
+		"Scrub interval set on spc": {
+			poolInterval:     "",
+			poolLabel:        "test-pool-claim-1",
+			spcInterval:      "168h",
+			expectedInterval: "168h",
+		},
+		"Scrub interval removed from spc": {
+			poolInterval:     "168h",
+			poolLabel:        "test-pool-claim-1",
+			spcInterval:      "",
+			expectedInterval: "",
+		},
+		"Pool of another spc": {
+			poolInterval:     "168h",
+			poolLabel:        "test-pool-claim-2",
+			spcInterval:      "24h",
+			expectedInterval: "168h",
+		},
+	}
+
+	for name, test := range tests {
+		name := name
+		test := test
+		t.Run(name, func(t *testing.T) {
+			fakeKubeClient := fake.NewSimpleClientset()
+			fakeOpenebsClient := openebsFakeClientset.NewSimpleClientset(&apis.CStorPool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "test-pool-1",
+					Labels: map[string]string{string(apis.StoragePoolClaimCPK): test.poolLabel},
+				},
+				Spec: apis.CStorPoolSpec{
+					PoolSpec: apis.CStorPoolAttr{
+						ScrubInterval: test.poolInterval,
+					},
+				},
+			})
+			openebsInformerFactory := informers.NewSharedInformerFactory(fakeOpenebsClient, time.Second*30)
+			controller, err := NewControllerBuilder().
+				withKubeClient(fakeKubeClient).
+				withOpenEBSClient(fakeOpenebsClient).
+				withNDMClient(ndmFakeClientset.NewSimpleClientset()).
+				withspcSynced(openebsInformerFactory).
+				withSpcLister(openebsInformerFactory).
+				withRecorder(fakeKubeClient).
+				withWorkqueueRateLimiting().
+				withEventHandler(openebsInformerFactory).
+				Build()
+			if err != nil {
+				t.Fatalf("failed to build controller instance: %s", err)
+			}
+			spc := &apis.StoragePoolClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-pool-claim-1",
+				},
+				Spec: apis.StoragePoolClaimSpec{
+					PoolSpec: apis.CStorPoolAttr{
+						ScrubInterval: test.spcInterval,
+					},
+				},
+			}
+			err = controller.syncScrubInterval(spc)
+			if err != nil {
+				t.Fatalf("Test case failed due to error %s", err)
+			}
+			csp, err := fakeOpenebsClient.OpenebsV1alpha1().CStorPools().Get("test-pool-1", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Test case failed due to error %s", err)
+			}
+			if csp.Spec.PoolSpec.ScrubInterval != test.expectedInterval {
+				t.Errorf("Test case failed as expected scrub interval '%s' but got '%s'", test.expectedInterval, csp.Spec.PoolSpec.ScrubInterval)
+			}
+		})
+	}
+}
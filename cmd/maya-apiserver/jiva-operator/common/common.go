@@ -0,0 +1,115 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package common has the bootstrap & the configuration shared by
+// the jiva controllers of the jiva operator
+package common
+
+import (
+	"strconv"
+	"time"
+
+	clientset "github.com/openebs/maya/pkg/client/generated/clientset/versioned"
+	env "github.com/openebs/maya/pkg/env/v1alpha1"
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+	kclient "github.com/openebs/maya/pkg/kubernetes/client/v1alpha1"
+	"github.com/openebs/maya/pkg/signals"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Runner runs a jiva controller till the stop channel is closed
+type Runner interface {
+	Run(stopCh <-chan struct{}) error
+}
+
+// Clientsets are the clientsets used by the jiva controllers
+type Clientsets struct {
+	Kube    kubernetes.Interface
+	OpenEBS clientset.Interface
+}
+
+// Run builds the clientsets & runs the jiva controller returned by
+// the given function till the first shutdown signal
+func Run(newController func(cs *Clientsets) Runner) error {
+	// set up signals so we handle the first shutdown signal gracefully
+	stopCh := signals.SetupSignalHandler()
+
+	cfg, err := kclient.New().Config()
+	if err != nil {
+		return errors.Wrap(err, "error building kubeconfig")
+	}
+	kubeClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return errors.Wrap(err, "error building kubernetes clientset")
+	}
+	openebsClient, err := clientset.NewForConfig(cfg)
+	if err != nil {
+		return errors.Wrap(err, "error building openebs clientset")
+	}
+	return newController(&Clientsets{Kube: kubeClient, OpenEBS: openebsClient}).
+		Run(stopCh)
+}
+
+// GetDuration returns the duration set via the environment variable
+// or the default if it is not set. A zero duration is valid only if
+// allowZero is set; it is meant to disable the controller.
+func GetDuration(
+	key env.ENVKey, defaultValue time.Duration, allowZero bool) (time.Duration, error) {
+	v := env.Get(key)
+	if v == "" {
+		return defaultValue, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 || (d == 0 && !allowZero) {
+		expected := "a positive duration"
+		if allowZero {
+			expected = "a non negative duration"
+		}
+		return 0, errors.Errorf("invalid env {%s}: expected %s: got {%s}", key, expected, v)
+	}
+	return d, nil
+}
+
+// GetNonNegativeInt returns the integer set via the environment
+// variable or the default if it is not set
+func GetNonNegativeInt(key env.ENVKey, defaultValue int) (int, error) {
+	v := env.Get(key)
+	if v == "" {
+		return defaultValue, nil
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil || i < 0 {
+		return 0, errors.Errorf("invalid env {%s}: expected a non negative integer: got {%s}", key, v)
+	}
+	return i, nil
+}
+
+// NewVolumeGaugeVec returns a gauge of the jiva volumes
+func NewVolumeGaugeVec(name, help string) *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{Name: name, Help: help},
+		[]string{"volume"},
+	)
+}
+
+// NewVolumeCounterVec returns a counter of the jiva volumes
+func NewVolumeCounterVec(name, help string) *prometheus.CounterVec {
+	return prometheus.NewCounterVec(
+		prometheus.CounterOpts{Name: name, Help: help},
+		[]string{"volume"},
+	)
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	env "github.com/openebs/maya/pkg/env/v1alpha1"
+)
+
+const testKey env.ENVKey = "OPENEBS_IO_JIVA_OPERATOR_TEST"
+
+func TestGetDuration(t *testing.T) {
+	tests := map[string]struct {
+		value     string
+		allowZero bool
+		expected  time.Duration
+		isErr     bool
+	}{
+		"default":                 {expected: time.Minute},
+		"configured":              {value: "1h", expected: time.Hour},
+		"zero disables":           {value: "0", allowZero: true, expected: 0},
+		"zero is invalid":         {value: "0", isErr: true},
+		"negative is invalid":     {value: "-1s", allowZero: true, isErr: true},
+		"non duration is invalid": {value: "often", isErr: true},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			os.Setenv(string(testKey), mock.value)
+			defer os.Unsetenv(string(testKey))
+
+			d, err := GetDuration(testKey, time.Minute, mock.allowZero)
+			if mock.isErr != (err != nil) {
+				t.Fatalf("Test %q failed: expected error '%t': actual '%v'", name, mock.isErr, err)
+			}
+			if err == nil && d != mock.expected {
+				t.Fatalf("Test %q failed: expected '%s': actual '%s'", name, mock.expected, d)
+			}
+		})
+	}
+}
+
+func TestGetNonNegativeInt(t *testing.T) {
+	tests := map[string]struct {
+		value    string
+		expected int
+		isErr    bool
+	}{
+		"default":                {expected: 100},
+		"configured":             {value: "20", expected: 20},
+		"zero":                   {value: "0", expected: 0},
+		"negative is invalid":    {value: "-1", isErr: true},
+		"non integer is invalid": {value: "many", isErr: true},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			os.Setenv(string(testKey), mock.value)
+			defer os.Unsetenv(string(testKey))
+
+			i, err := GetNonNegativeInt(testKey, 100)
+			if mock.isErr != (err != nil) {
+				t.Fatalf("Test %q failed: expected error '%t': actual '%v'", name, mock.isErr, err)
+			}
+			if err == nil && i != mock.expected {
+				t.Fatalf("Test %q failed: expected '%d': actual '%d'", name, mock.expected, i)
+			}
+		})
+	}
+}
@@ -0,0 +1,379 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	clientset "github.com/openebs/maya/pkg/client/generated/clientset/versioned"
+	jiva "github.com/openebs/maya/pkg/client/jiva"
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	controllerAgentName = "jiva-health-controller"
+
+	// jivaTargetServiceSelector selects the services of jiva
+	// targets i.e. controllers
+	jivaTargetServiceSelector = "openebs.io/controller-service=jiva-controller-svc"
+
+	// jivaReplicaPodSelector selects the pods of jiva replicas
+	jivaReplicaPodSelector = "openebs.io/replica=jiva-replica"
+
+	// persistentVolumeLabel is the label of jiva target service
+	// & replica pods that has the name of volume
+	persistentVolumeLabel = "openebs.io/persistent-volume"
+
+	// jivaControllerPort is the port of jiva controller's REST API
+	jivaControllerPort = 9501
+
+	// reasonHealthChanged is the reason of event raised when the
+	// phase of a volume changes
+	reasonHealthChanged = "JivaVolumeHealthChanged"
+
+	// reasonReplicaErrored is the reason of event raised when a
+	// replica goes to error mode
+	reasonReplicaErrored = "ReplicaErrored"
+
+	// reasonRebuildStarted is the reason of event raised when a
+	// replica starts to rebuild
+	reasonRebuildStarted = "RebuildStarted"
+
+	// reasonRebuildCompleted is the reason of event raised when a
+	// replica is back in read write mode after rebuild
+	reasonRebuildCompleted = "RebuildCompleted"
+
+	// reasonReplicaRestarted is the reason of event raised when a
+	// replica is restarted to recover it
+	reasonReplicaRestarted = "ReplicaRestarted"
+
+	// reasonRecoveryFailed is the reason of event raised when a
+	// replica could not be restarted
+	reasonRecoveryFailed = "ReplicaRecoveryFailed"
+)
+
+// replicaClient is the client of a jiva replica that is used to
+// inspect its rebuild state & revision counter
+type replicaClient interface {
+	GetReplica() (jiva.InfoReplica, error)
+}
+
+// Controller periodically records the health of the replicas of
+// jiva volumes in JivaVolumeHealth resources & restarts the
+// replicas that are in error mode or stuck in write only mode
+type Controller struct {
+	kubeClient    kubernetes.Interface
+	openebsClient clientset.Interface
+	recorder      record.EventRecorder
+
+	// interval at which the volumes are reconciled
+	interval time.Duration
+
+	// gracePeriod is the time a replica may stay in write only
+	// mode without rebuilding before it is restarted
+	gracePeriod time.Duration
+
+	// listReplicas returns the replicas of the jiva controller
+	// at given address
+	listReplicas func(address string) ([]jiva.Replica, error)
+
+	// newReplicaClient returns the client of the replica at
+	// given address
+	newReplicaClient func(address string) (replicaClient, error)
+
+	// now returns the current time
+	now func() time.Time
+}
+
+// NewController returns a new instance of jiva health controller
+func NewController(
+	kubeClient kubernetes.Interface,
+	openebsClient clientset.Interface,
+	interval, gracePeriod time.Duration,
+) *Controller {
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
+	return &Controller{
+		kubeClient:    kubeClient,
+		openebsClient: openebsClient,
+		recorder:      eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: controllerAgentName}),
+		interval:      interval,
+		gracePeriod:   gracePeriod,
+		listReplicas: func(address string) ([]jiva.Replica, error) {
+			return (&jiva.ControllerClient{}).ListReplicas(address)
+		},
+		newReplicaClient: func(address string) (replicaClient, error) {
+			return jiva.NewReplicaClient(address)
+		},
+		now: time.Now,
+	}
+}
+
+// Run reconciles the health of jiva volumes at every interval until
+// stop channel is closed
+func (c *Controller) Run(stopCh <-chan struct{}) error {
+	glog.Infof("starting jiva health controller: interval {%s} grace period {%s}", c.interval, c.gracePeriod)
+	wait.Until(c.reconcileAll, c.interval, stopCh)
+	glog.Info("shutting down jiva health controller")
+	return nil
+}
+
+// reconcileAll reconciles the health of every jiva volume
+func (c *Controller) reconcileAll() {
+	services, err := c.kubeClient.CoreV1().Services(metav1.NamespaceAll).
+		List(metav1.ListOptions{LabelSelector: jivaTargetServiceSelector})
+	if err != nil {
+		glog.Errorf("failed to reconcile jiva health: failed to list jiva target services: %+v", err)
+		return
+	}
+	for i := range services.Items {
+		svc := &services.Items[i]
+		if err := c.reconcile(svc); err != nil {
+			glog.Errorf("failed to reconcile health of jiva volume {%s}: %+v", volumeName(svc), err)
+		}
+	}
+}
+
+// reconcile records the health of the replicas of the jiva volume
+// of the given target service & restarts a replica if it is safe
+// to do so
+func (c *Controller) reconcile(svc *corev1.Service) error {
+	if svc.Spec.ClusterIP == "" || svc.Spec.ClusterIP == corev1.ClusterIPNone {
+		return nil
+	}
+	volume := volumeName(svc)
+	jvh, exists, err := c.getOrInit(svc)
+	if err != nil {
+		return err
+	}
+	old := jvh.Status.DeepCopy()
+	now := metav1.NewTime(c.now())
+
+	address := fmt.Sprintf("http://%s:%d/v1", svc.Spec.ClusterIP, jivaControllerPort)
+	replicas, err := c.listReplicas(address)
+	if err != nil {
+		glog.Errorf("failed to list replicas of jiva volume {%s}: %+v", volume, err)
+		c.setPhase(svc, jvh, apis.JVHPhaseOffline, 0, "controller is not reachable", now)
+		return c.save(jvh, exists)
+	}
+	jvh.Status.Replicas = replicaHealths(old.Replicas, replicas, c.replicaInfos(volume, replicas), now)
+	c.recordTransitions(svc, old.Replicas, jvh.Status.Replicas)
+
+	if !jvh.Spec.DisableRecovery {
+		if i := recoveryCandidate(jvh.Status.Replicas, now.Time, c.gracePeriod); i >= 0 {
+			c.recover(svc, &jvh.Status.Replicas[i], now)
+		}
+	}
+	phase, healthy, message := phaseOf(jvh.Status.Replicas, now.Time, c.gracePeriod)
+	c.setPhase(svc, jvh, phase, healthy, message, now)
+	return c.save(jvh, exists)
+}
+
+// getOrInit returns the JivaVolumeHealth of the volume of the given
+// target service & whether it exists. A new instance is returned if
+// it does not exist.
+func (c *Controller) getOrInit(svc *corev1.Service) (*apis.JivaVolumeHealth, bool, error) {
+	volume := volumeName(svc)
+	jvh, err := c.openebsClient.OpenebsV1alpha1().JivaVolumeHealths(svc.Namespace).
+		Get(volume, metav1.GetOptions{})
+	if err == nil {
+		return jvh, true, nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return nil, false, errors.Wrapf(err, "failed to get jiva volume health {%s}", volume)
+	}
+	return &apis.JivaVolumeHealth{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      volume,
+			Namespace: svc.Namespace,
+			Labels:    map[string]string{persistentVolumeLabel: volume},
+		},
+		Spec: apis.JivaVolumeHealthSpec{VolumeName: volume},
+	}, false, nil
+}
+
+// save updates the given JivaVolumeHealth if it exists or else
+// creates it
+func (c *Controller) save(jvh *apis.JivaVolumeHealth, exists bool) error {
+	var err error
+	client := c.openebsClient.OpenebsV1alpha1().JivaVolumeHealths(jvh.Namespace)
+	if exists {
+		_, err = client.Update(jvh)
+	} else {
+		_, err = client.Create(jvh)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to save jiva volume health {%s}", jvh.Name)
+	}
+	return nil
+}
+
+// replicaInfos returns the details of the given replicas keyed by
+// their address. Replicas that can not be reached are left out.
+func (c *Controller) replicaInfos(volume string, replicas []jiva.Replica) map[string]jiva.InfoReplica {
+	infos := map[string]jiva.InfoReplica{}
+	for _, r := range replicas {
+		client, err := c.newReplicaClient(r.Address)
+		if err != nil {
+			glog.Errorf("failed to get client of replica {%s} of jiva volume {%s}: %+v", r.Address, volume, err)
+			continue
+		}
+		info, err := client.GetReplica()
+		if err != nil {
+			glog.Errorf("failed to get info of replica {%s} of jiva volume {%s}: %+v", r.Address, volume, err)
+			continue
+		}
+		infos[r.Address] = info
+	}
+	return infos
+}
+
+// setPhase sets the phase of the given JivaVolumeHealth & raises an
+// event if the phase has changed
+func (c *Controller) setPhase(
+	svc *corev1.Service,
+	jvh *apis.JivaVolumeHealth,
+	phase apis.JivaVolumeHealthPhase,
+	healthy int,
+	message string,
+	now metav1.Time,
+) {
+	status := &jvh.Status
+	if status.Phase != phase {
+		eventType := corev1.EventTypeNormal
+		if phase == apis.JVHPhaseDegraded || phase == apis.JVHPhaseOffline {
+			eventType = corev1.EventTypeWarning
+		}
+		from := status.Phase
+		if from == "" {
+			from = "Unknown"
+		}
+		c.recorder.Eventf(svc, eventType, reasonHealthChanged,
+			"health of volume {%s} changed from {%s} to {%s}: %s", jvh.Spec.VolumeName, from, phase, message)
+		status.Phase = phase
+		status.LastTransitionTime = now
+	}
+	status.HealthyReplicas = healthy
+	status.Message = message
+	status.LastUpdateTime = now
+	jivaHealthyReplicas.WithLabelValues(jvh.Spec.VolumeName).Set(float64(healthy))
+}
+
+// recordTransitions raises events for the changes in the mode &
+// rebuild state of the replicas
+func (c *Controller) recordTransitions(svc *corev1.Service, old, healths []apis.JivaReplicaHealth) {
+	for _, h := range healths {
+		p := findReplica(old, h.Address)
+		if p == nil {
+			p = &apis.JivaReplicaHealth{}
+		}
+		switch {
+		case h.Mode == apis.JivaReplicaModeERR && p.Mode != apis.JivaReplicaModeERR:
+			c.recorder.Eventf(svc, corev1.EventTypeWarning, reasonReplicaErrored,
+				"replica {%s} is in error mode", h.Address)
+		case h.Rebuilding && !p.Rebuilding:
+			c.recorder.Eventf(svc, corev1.EventTypeNormal, reasonRebuildStarted,
+				"replica {%s} started to rebuild", h.Address)
+		case h.Mode == apis.JivaReplicaModeRW && p.Mode == apis.JivaReplicaModeWO:
+			c.recorder.Eventf(svc, corev1.EventTypeNormal, reasonRebuildCompleted,
+				"replica {%s} is rebuilt at revision {%s}", h.Address, h.RevisionCounter)
+		}
+	}
+}
+
+// recover restarts the given replica by deleting its pod. The pod
+// is recreated by its deployment & the replica rebuilds from the
+// healthy replicas once it registers with the controller.
+func (c *Controller) recover(svc *corev1.Service, h *apis.JivaReplicaHealth, now metav1.Time) {
+	volume := volumeName(svc)
+	h.RecoveryAttempts++
+	h.LastRecoveryTime = now
+	pod, err := c.replicaPod(svc.Namespace, volume, h.Address)
+	if err == nil {
+		err = c.kubeClient.CoreV1().Pods(pod.Namespace).Delete(pod.Name, &metav1.DeleteOptions{})
+	}
+	if err != nil {
+		glog.Errorf("failed to recover replica {%s} of jiva volume {%s}: %+v", h.Address, volume, err)
+		jivaRecoveryErrorCounter.WithLabelValues(volume).Inc()
+		c.recorder.Eventf(svc, corev1.EventTypeWarning, reasonRecoveryFailed,
+			"failed to restart replica {%s} in mode {%s}: %s", h.Address, h.Mode, err.Error())
+		return
+	}
+	glog.Infof("restarted replica {%s} of jiva volume {%s} in mode {%s}: attempt {%d}",
+		h.Address, volume, h.Mode, h.RecoveryAttempts)
+	jivaRecoveryCounter.WithLabelValues(volume).Inc()
+	c.recorder.Eventf(svc, corev1.EventTypeNormal, reasonReplicaRestarted,
+		"restarted replica {%s} pod {%s} in mode {%s}: attempt {%d} of {%d}",
+		h.Address, pod.Name, h.Mode, h.RecoveryAttempts, maxRecoveryAttempts)
+}
+
+// replicaPod returns the pod of the replica of the given volume
+// at the given address
+func (c *Controller) replicaPod(namespace, volume, address string) (*corev1.Pod, error) {
+	ip, err := replicaIP(address)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := c.kubeClient.CoreV1().Pods(namespace).List(metav1.ListOptions{
+		LabelSelector: jivaReplicaPodSelector + "," + persistentVolumeLabel + "=" + volume,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list replica pods of volume {%s}", volume)
+	}
+	for i := range pods.Items {
+		if pods.Items[i].Status.PodIP == ip {
+			return &pods.Items[i], nil
+		}
+	}
+	return nil, errors.Errorf("no replica pod of volume {%s} has ip {%s}", volume, ip)
+}
+
+// replicaIP returns the ip of the given replica address
+// e.g. 10.1.0.2 for tcp://10.1.0.2:9502
+func replicaIP(address string) (string, error) {
+	host := address
+	if i := strings.Index(address, "://"); i >= 0 {
+		host = address[i+3:]
+	}
+	ip, _, err := net.SplitHostPort(host)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid replica address {%s}", address)
+	}
+	return ip, nil
+}
+
+// volumeName returns the name of jiva volume of the given target
+// service
+func volumeName(svc *corev1.Service) string {
+	if name := svc.Labels[persistentVolumeLabel]; name != "" {
+		return name
+	}
+	return svc.Name
+}
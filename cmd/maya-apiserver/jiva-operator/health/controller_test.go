@@ -0,0 +1,208 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	openebsFakeClientset "github.com/openebs/maya/pkg/client/generated/clientset/versioned/fake"
+	jiva "github.com/openebs/maya/pkg/client/jiva"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+)
+
+type fakeReplicaClient struct {
+	info jiva.InfoReplica
+}
+
+func (f *fakeReplicaClient) GetReplica() (jiva.InfoReplica, error) {
+	return f.info, nil
+}
+
+func fakeTargetService() *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pvc-1-ctrl-svc",
+			Namespace: "default",
+			Labels: map[string]string{
+				"openebs.io/controller-service": "jiva-controller-svc",
+				persistentVolumeLabel:           "pvc-1",
+			},
+		},
+		Spec: corev1.ServiceSpec{ClusterIP: "10.0.0.1"},
+	}
+}
+
+func fakeReplicaPod(name, ip string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				"openebs.io/replica":  "jiva-replica",
+				persistentVolumeLabel: "pvc-1",
+			},
+		},
+		Status: corev1.PodStatus{PodIP: ip},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	tests := map[string]struct {
+		modes           []string
+		pods            []runtime.Object
+		existing        *apis.JivaVolumeHealth
+		unreachable     bool
+		expectedPhase   apis.JivaVolumeHealthPhase
+		expectedDeleted string
+		expectedEvents  []string
+	}{
+		"healthy volume": {
+			modes:          []string{"RW", "RW"},
+			expectedPhase:  apis.JVHPhaseHealthy,
+			expectedEvents: []string{"Normal " + reasonHealthChanged},
+		},
+		"errored replica is restarted": {
+			modes: []string{"RW", "ERR"},
+			pods: []runtime.Object{
+				fakeReplicaPod("pvc-1-rep-0", "10.1.0.0"),
+				fakeReplicaPod("pvc-1-rep-1", "10.1.0.1"),
+			},
+			expectedPhase:   apis.JVHPhaseDegraded,
+			expectedDeleted: "pvc-1-rep-1",
+			expectedEvents: []string{
+				"Warning " + reasonReplicaErrored,
+				"Normal " + reasonReplicaRestarted,
+				"Warning " + reasonHealthChanged,
+			},
+		},
+		"recovery is disabled": {
+			modes: []string{"RW", "ERR"},
+			pods:  []runtime.Object{fakeReplicaPod("pvc-1-rep-1", "10.1.0.1")},
+			existing: &apis.JivaVolumeHealth{
+				ObjectMeta: metav1.ObjectMeta{Name: "pvc-1", Namespace: "default"},
+				Spec:       apis.JivaVolumeHealthSpec{VolumeName: "pvc-1", DisableRecovery: true},
+			},
+			expectedPhase: apis.JVHPhaseDegraded,
+			expectedEvents: []string{
+				"Warning " + reasonReplicaErrored,
+				"Warning " + reasonHealthChanged,
+			},
+		},
+		"replica pod is not found": {
+			modes:         []string{"RW", "ERR"},
+			expectedPhase: apis.JVHPhaseDegraded,
+			expectedEvents: []string{
+				"Warning " + reasonReplicaErrored,
+				"Warning " + reasonRecoveryFailed,
+				"Warning " + reasonHealthChanged,
+			},
+		},
+		"controller is unreachable": {
+			unreachable:    true,
+			expectedPhase:  apis.JVHPhaseOffline,
+			expectedEvents: []string{"Warning " + reasonHealthChanged},
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			clients := map[string]*fakeReplicaClient{}
+			replicas := []jiva.Replica{}
+			for i, mode := range mock.modes {
+				address := fmt.Sprintf("tcp://10.1.0.%d:9502", i)
+				replicas = append(replicas, jiva.Replica{Address: address, Mode: mode})
+				clients[address] = &fakeReplicaClient{info: jiva.InfoReplica{RevisionCounter: "10"}}
+			}
+			openebsObjects := []runtime.Object{}
+			if mock.existing != nil {
+				openebsObjects = append(openebsObjects, mock.existing)
+			}
+			kubeClient := fake.NewSimpleClientset(append(mock.pods, fakeTargetService())...)
+			openebsClient := openebsFakeClientset.NewSimpleClientset(openebsObjects...)
+			c := &Controller{
+				kubeClient:    kubeClient,
+				openebsClient: openebsClient,
+				recorder:      recorder,
+				gracePeriod:   fakeGrace,
+				listReplicas: func(address string) ([]jiva.Replica, error) {
+					if mock.unreachable || address != "http://10.0.0.1:9501/v1" {
+						return nil, fmt.Errorf("failed to reach %s", address)
+					}
+					return replicas, nil
+				},
+				newReplicaClient: func(address string) (replicaClient, error) {
+					return clients[address], nil
+				},
+				now: func() time.Time { return fakeNow },
+			}
+			c.reconcileAll()
+
+			jvh, err := openebsClient.OpenebsV1alpha1().JivaVolumeHealths("default").
+				Get("pvc-1", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Test %q failed: expected jiva volume health: actual error %v", name, err)
+			}
+			if jvh.Status.Phase != mock.expectedPhase {
+				t.Fatalf("Test %q failed: expected phase %s: actual %s", name, mock.expectedPhase, jvh.Status.Phase)
+			}
+			if len(jvh.Status.Replicas) != len(mock.modes) {
+				t.Fatalf("Test %q failed: expected %d replicas: actual %+v", name, len(mock.modes), jvh.Status.Replicas)
+			}
+			if mock.expectedDeleted != "" {
+				_, err := kubeClient.CoreV1().Pods("default").Get(mock.expectedDeleted, metav1.GetOptions{})
+				if !k8serrors.IsNotFound(err) {
+					t.Fatalf("Test %q failed: expected pod %s to be deleted: actual %v", name, mock.expectedDeleted, err)
+				}
+				if h := findReplica(jvh.Status.Replicas, "tcp://10.1.0.1:9502"); h == nil || h.RecoveryAttempts != 1 {
+					t.Fatalf("Test %q failed: expected 1 recovery attempt: actual %+v", name, h)
+				}
+			}
+			for _, obj := range mock.pods {
+				pod := obj.(*corev1.Pod)
+				if pod.Name == mock.expectedDeleted {
+					continue
+				}
+				if _, err := kubeClient.CoreV1().Pods("default").Get(pod.Name, metav1.GetOptions{}); err != nil {
+					t.Fatalf("Test %q failed: expected pod %s to be present: actual %v", name, pod.Name, err)
+				}
+			}
+			close(recorder.Events)
+			events := []string{}
+			for event := range recorder.Events {
+				events = append(events, event)
+			}
+			if len(events) != len(mock.expectedEvents) {
+				t.Fatalf("Test %q failed: expected events %v: actual %v", name, mock.expectedEvents, events)
+			}
+			for i, event := range events {
+				if !strings.HasPrefix(event, mock.expectedEvents[i]) {
+					t.Fatalf("Test %q failed: expected events %v: actual %v", name, mock.expectedEvents, events)
+				}
+			}
+		})
+	}
+}
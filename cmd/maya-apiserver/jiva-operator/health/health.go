@@ -0,0 +1,165 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	jiva "github.com/openebs/maya/pkg/client/jiva"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxRecoveryAttempts is the number of times a replica is restarted
+// before the reconciler gives up on it. The attempts are reset once
+// the replica is back in read write mode.
+const maxRecoveryAttempts = 5
+
+// replicaHealths returns the health of the given replicas as listed
+// by the controller. The mode transition & recovery details are
+// carried over from the previous health of the same replica. infos
+// has the details of the replicas that could be reached; it is keyed
+// by replica address.
+func replicaHealths(
+	prev []apis.JivaReplicaHealth,
+	replicas []jiva.Replica,
+	infos map[string]jiva.InfoReplica,
+	now metav1.Time,
+) []apis.JivaReplicaHealth {
+	healths := []apis.JivaReplicaHealth{}
+	for _, r := range replicas {
+		h := apis.JivaReplicaHealth{Address: r.Address}
+		if p := findReplica(prev, r.Address); p != nil {
+			h = *p
+		}
+		mode := apis.JivaReplicaMode(r.Mode)
+		if h.Mode != mode {
+			h.Mode = mode
+			h.LastModeTransitionTime = now
+		}
+		h.Rebuilding = false
+		if info, ok := infos[r.Address]; ok {
+			h.Rebuilding = info.Rebuilding
+			h.RevisionCounter = info.RevisionCounter
+		}
+		if h.Mode == apis.JivaReplicaModeRW {
+			h.RecoveryAttempts = 0
+		}
+		healths = append(healths, h)
+	}
+	return healths
+}
+
+// findReplica returns the health of the replica with the given
+// address if present
+func findReplica(healths []apis.JivaReplicaHealth, address string) *apis.JivaReplicaHealth {
+	for i := range healths {
+		if healths[i].Address == address {
+			return &healths[i]
+		}
+	}
+	return nil
+}
+
+// isStuck returns true if the replica has been in write only mode
+// for longer than grace period without rebuilding
+func isStuck(h apis.JivaReplicaHealth, now time.Time, grace time.Duration) bool {
+	return h.Mode == apis.JivaReplicaModeWO &&
+		!h.Rebuilding &&
+		now.Sub(h.LastModeTransitionTime.Time) >= grace
+}
+
+// needsRecovery returns true if the replica has failed or is stuck
+// in write only mode
+func needsRecovery(h apis.JivaReplicaHealth, now time.Time, grace time.Duration) bool {
+	return h.Mode == apis.JivaReplicaModeERR || isStuck(h, now, grace)
+}
+
+// phaseOf returns the phase of the volume, the number of replicas
+// in read write mode & the reason of the phase from the health of
+// its replicas
+func phaseOf(
+	healths []apis.JivaReplicaHealth,
+	now time.Time,
+	grace time.Duration,
+) (apis.JivaVolumeHealthPhase, int, string) {
+	healthy := 0
+	rebuilding, degraded := []string{}, []string{}
+	for _, h := range healths {
+		switch {
+		case h.Mode == apis.JivaReplicaModeRW:
+			healthy++
+		case needsRecovery(h, now, grace):
+			degraded = append(degraded, fmt.Sprintf("%s is %s", h.Address, h.Mode))
+		default:
+			rebuilding = append(rebuilding, h.Address)
+		}
+	}
+	switch {
+	case len(healths) == 0:
+		return apis.JVHPhaseOffline, 0, "no replica is registered with the controller"
+	case healthy == 0:
+		return apis.JVHPhaseOffline, 0, "no replica is in read write mode"
+	case len(degraded) != 0:
+		return apis.JVHPhaseDegraded, healthy, "degraded replicas: " + strings.Join(degraded, ", ")
+	case len(rebuilding) != 0:
+		return apis.JVHPhaseRebuilding, healthy, "rebuilding replicas: " + strings.Join(rebuilding, ", ")
+	}
+	return apis.JVHPhaseHealthy, healthy, ""
+}
+
+// recoveryBackoff returns the time to wait after the last recovery
+// of a replica before it is restarted again. The wait doubles with
+// every attempt.
+func recoveryBackoff(attempts int, grace time.Duration) time.Duration {
+	if attempts <= 0 {
+		return 0
+	}
+	return grace << uint(attempts-1)
+}
+
+// recoveryCandidate returns the index of the replica that is safe to
+// restart or -1 if there is none. A replica is restarted only if
+// another replica is in read write mode to rebuild from, no replica
+// is rebuilding & the backoff since its last recovery has elapsed.
+// At most one replica of a volume is restarted at a time.
+func recoveryCandidate(healths []apis.JivaReplicaHealth, now time.Time, grace time.Duration) int {
+	healthy := 0
+	for _, h := range healths {
+		if h.Rebuilding {
+			return -1
+		}
+		if h.Mode == apis.JivaReplicaModeRW {
+			healthy++
+		}
+	}
+	if healthy == 0 {
+		return -1
+	}
+	for i, h := range healths {
+		if !needsRecovery(h, now, grace) || h.RecoveryAttempts >= maxRecoveryAttempts {
+			continue
+		}
+		if now.Sub(h.LastRecoveryTime.Time) < recoveryBackoff(h.RecoveryAttempts, grace) {
+			continue
+		}
+		return i
+	}
+	return -1
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"testing"
+	"time"
+
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	jiva "github.com/openebs/maya/pkg/client/jiva"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	fakeNow   = time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	fakeGrace = 5 * time.Minute
+)
+
+// fakeReplica returns the health of a replica whose mode changed
+// the given duration before fakeNow
+func fakeReplica(address string, mode apis.JivaReplicaMode, since time.Duration) apis.JivaReplicaHealth {
+	return apis.JivaReplicaHealth{
+		Address:                address,
+		Mode:                   mode,
+		LastModeTransitionTime: metav1.NewTime(fakeNow.Add(-since)),
+	}
+}
+
+func TestReplicaHealths(t *testing.T) {
+	earlier := metav1.NewTime(fakeNow.Add(-time.Hour))
+	now := metav1.NewTime(fakeNow)
+	prev := []apis.JivaReplicaHealth{
+		{Address: "tcp://a:9502", Mode: apis.JivaReplicaModeWO, LastModeTransitionTime: earlier, RecoveryAttempts: 2},
+		{Address: "tcp://b:9502", Mode: apis.JivaReplicaModeWO, LastModeTransitionTime: earlier, RecoveryAttempts: 1},
+		{Address: "tcp://gone:9502", Mode: apis.JivaReplicaModeRW},
+	}
+	replicas := []jiva.Replica{
+		{Address: "tcp://a:9502", Mode: "RW"},
+		{Address: "tcp://b:9502", Mode: "WO"},
+		{Address: "tcp://c:9502", Mode: "ERR"},
+	}
+	infos := map[string]jiva.InfoReplica{
+		"tcp://a:9502": {RevisionCounter: "10"},
+		"tcp://b:9502": {RevisionCounter: "4", Rebuilding: true},
+	}
+	healths := replicaHealths(prev, replicas, infos, now)
+	if len(healths) != 3 {
+		t.Fatalf("Test failed: expected 3 replicas: actual %+v", healths)
+	}
+	a, b, c := healths[0], healths[1], healths[2]
+	if a.Mode != apis.JivaReplicaModeRW || !a.LastModeTransitionTime.Equal(&now) ||
+		a.RecoveryAttempts != 0 || a.RevisionCounter != "10" {
+		t.Fatalf("Test failed: unexpected health of replica a: %+v", a)
+	}
+	if !b.Rebuilding || !b.LastModeTransitionTime.Equal(&earlier) || b.RecoveryAttempts != 1 {
+		t.Fatalf("Test failed: unexpected health of replica b: %+v", b)
+	}
+	if c.Mode != apis.JivaReplicaModeERR || !c.LastModeTransitionTime.Equal(&now) || c.RevisionCounter != "" {
+		t.Fatalf("Test failed: unexpected health of replica c: %+v", c)
+	}
+}
+
+func TestPhaseOf(t *testing.T) {
+	tests := map[string]struct {
+		replicas        []apis.JivaReplicaHealth
+		expectedPhase   apis.JivaVolumeHealthPhase
+		expectedHealthy int
+	}{
+		"no replicas": {
+			expectedPhase: apis.JVHPhaseOffline,
+		},
+		"all healthy": {
+			replicas: []apis.JivaReplicaHealth{
+				fakeReplica("a", apis.JivaReplicaModeRW, time.Hour),
+				fakeReplica("b", apis.JivaReplicaModeRW, time.Hour),
+			},
+			expectedPhase:   apis.JVHPhaseHealthy,
+			expectedHealthy: 2,
+		},
+		"write only within grace period": {
+			replicas: []apis.JivaReplicaHealth{
+				fakeReplica("a", apis.JivaReplicaModeRW, time.Hour),
+				fakeReplica("b", apis.JivaReplicaModeWO, time.Minute),
+			},
+			expectedPhase:   apis.JVHPhaseRebuilding,
+			expectedHealthy: 1,
+		},
+		"write only beyond grace period": {
+			replicas: []apis.JivaReplicaHealth{
+				fakeReplica("a", apis.JivaReplicaModeRW, time.Hour),
+				fakeReplica("b", apis.JivaReplicaModeWO, time.Hour),
+			},
+			expectedPhase:   apis.JVHPhaseDegraded,
+			expectedHealthy: 1,
+		},
+		"errored": {
+			replicas: []apis.JivaReplicaHealth{
+				fakeReplica("a", apis.JivaReplicaModeRW, time.Hour),
+				fakeReplica("b", apis.JivaReplicaModeERR, time.Minute),
+			},
+			expectedPhase:   apis.JVHPhaseDegraded,
+			expectedHealthy: 1,
+		},
+		"no healthy replica": {
+			replicas: []apis.JivaReplicaHealth{
+				fakeReplica("a", apis.JivaReplicaModeWO, time.Minute),
+				fakeReplica("b", apis.JivaReplicaModeERR, time.Minute),
+			},
+			expectedPhase: apis.JVHPhaseOffline,
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			phase, healthy, _ := phaseOf(mock.replicas, fakeNow, fakeGrace)
+			if phase != mock.expectedPhase || healthy != mock.expectedHealthy {
+				t.Fatalf("Test %q failed: expected {%s, %d}: actual {%s, %d}",
+					name, mock.expectedPhase, mock.expectedHealthy, phase, healthy)
+			}
+		})
+	}
+}
+
+func TestRecoveryCandidate(t *testing.T) {
+	recovered := func(h apis.JivaReplicaHealth, attempts int, ago time.Duration) apis.JivaReplicaHealth {
+		h.RecoveryAttempts = attempts
+		h.LastRecoveryTime = metav1.NewTime(fakeNow.Add(-ago))
+		return h
+	}
+	rebuilding := fakeReplica("b", apis.JivaReplicaModeWO, time.Minute)
+	rebuilding.Rebuilding = true
+	tests := map[string]struct {
+		replicas []apis.JivaReplicaHealth
+		expected int
+	}{
+		"all healthy": {
+			replicas: []apis.JivaReplicaHealth{
+				fakeReplica("a", apis.JivaReplicaModeRW, time.Hour),
+			},
+			expected: -1,
+		},
+		"errored replica": {
+			replicas: []apis.JivaReplicaHealth{
+				fakeReplica("a", apis.JivaReplicaModeRW, time.Hour),
+				fakeReplica("b", apis.JivaReplicaModeERR, time.Minute),
+			},
+			expected: 1,
+		},
+		"stuck replica": {
+			replicas: []apis.JivaReplicaHealth{
+				fakeReplica("a", apis.JivaReplicaModeWO, time.Hour),
+				fakeReplica("b", apis.JivaReplicaModeRW, time.Hour),
+			},
+			expected: 0,
+		},
+		"write only within grace period": {
+			replicas: []apis.JivaReplicaHealth{
+				fakeReplica("a", apis.JivaReplicaModeRW, time.Hour),
+				fakeReplica("b", apis.JivaReplicaModeWO, time.Minute),
+			},
+			expected: -1,
+		},
+		"another replica is rebuilding": {
+			replicas: []apis.JivaReplicaHealth{
+				fakeReplica("a", apis.JivaReplicaModeRW, time.Hour),
+				rebuilding,
+				fakeReplica("c", apis.JivaReplicaModeERR, time.Hour),
+			},
+			expected: -1,
+		},
+		"no healthy replica": {
+			replicas: []apis.JivaReplicaHealth{
+				fakeReplica("a", apis.JivaReplicaModeERR, time.Hour),
+				fakeReplica("b", apis.JivaReplicaModeERR, time.Hour),
+			},
+			expected: -1,
+		},
+		"within backoff": {
+			replicas: []apis.JivaReplicaHealth{
+				fakeReplica("a", apis.JivaReplicaModeRW, time.Hour),
+				recovered(fakeReplica("b", apis.JivaReplicaModeERR, time.Hour), 2, 9*time.Minute),
+			},
+			expected: -1,
+		},
+		"backoff elapsed": {
+			replicas: []apis.JivaReplicaHealth{
+				fakeReplica("a", apis.JivaReplicaModeRW, time.Hour),
+				recovered(fakeReplica("b", apis.JivaReplicaModeERR, time.Hour), 2, 10*time.Minute),
+			},
+			expected: 1,
+		},
+		"attempts exhausted": {
+			replicas: []apis.JivaReplicaHealth{
+				fakeReplica("a", apis.JivaReplicaModeRW, time.Hour),
+				recovered(fakeReplica("b", apis.JivaReplicaModeERR, time.Hour), maxRecoveryAttempts, 24*time.Hour),
+			},
+			expected: -1,
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			if actual := recoveryCandidate(mock.replicas, fakeNow, fakeGrace); actual != mock.expected {
+				t.Fatalf("Test %q failed: expected %d: actual %d", name, mock.expected, actual)
+			}
+		})
+	}
+}
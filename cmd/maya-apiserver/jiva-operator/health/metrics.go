@@ -0,0 +1,53 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"github.com/openebs/maya/cmd/maya-apiserver/jiva-operator/common"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// jivaHealthyReplicas is the number of replicas of a jiva
+	// volume in read write mode
+	jivaHealthyReplicas = common.NewVolumeGaugeVec(
+		"openebs_jiva_healthy_replicas",
+		"Number of jiva replicas in read write mode.",
+	)
+
+	// jivaRecoveryCounter counts the replicas restarted by the
+	// health controller
+	jivaRecoveryCounter = common.NewVolumeCounterVec(
+		"openebs_jiva_replica_recoveries_total",
+		"Total number of jiva replicas restarted to recover them.",
+	)
+
+	// jivaRecoveryErrorCounter counts the replicas that could not
+	// be restarted
+	jivaRecoveryErrorCounter = common.NewVolumeCounterVec(
+		"openebs_jiva_replica_recovery_errors_total",
+		"Total number of failed restarts of jiva replicas.",
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		jivaHealthyReplicas,
+		jivaRecoveryCounter,
+		jivaRecoveryErrorCounter,
+	)
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/openebs/maya/cmd/maya-apiserver/jiva-operator/common"
+	env "github.com/openebs/maya/pkg/env/v1alpha1"
+)
+
+const (
+	// defaultInterval is the default interval at which the health
+	// of jiva replicas is reconciled
+	defaultInterval = 30 * time.Second
+
+	// defaultGracePeriod is the default time a replica may stay in
+	// write only mode without rebuilding before it is restarted
+	defaultGracePeriod = 5 * time.Minute
+)
+
+// Start starts the jiva health controller
+func Start() error {
+	interval, err := common.GetDuration(env.JivaHealthCheckInterval, defaultInterval, true)
+	if err != nil {
+		return err
+	}
+	gracePeriod, err := common.GetDuration(env.JivaReplicaRecoveryGracePeriod, defaultGracePeriod, false)
+	if err != nil {
+		return err
+	}
+	if interval == 0 {
+		glog.Info("jiva health reconciliation is disabled")
+		return nil
+	}
+	return common.Run(func(cs *common.Clientsets) common.Runner {
+		return NewController(cs.Kube, cs.OpenEBS, interval, gracePeriod)
+	})
+}
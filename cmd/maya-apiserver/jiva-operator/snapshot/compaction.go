@@ -0,0 +1,80 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	jiva "github.com/openebs/maya/pkg/client/jiva"
+)
+
+// snapshotsOf returns the snapshots in the chain of the given
+// replica ordered from the latest to the oldest. The head &
+// the snapshots that are already removed are excluded.
+func snapshotsOf(info jiva.InfoReplica) []string {
+	snapshots := []string{}
+	for _, disk := range info.Chain {
+		if jiva.IsHeadDisk(disk) || info.Disks[disk].Removed {
+			continue
+		}
+		snapshots = append(snapshots, disk)
+	}
+	return snapshots
+}
+
+// compactionCandidates returns the snapshots of the given replica
+// that are removed to bring the number of its snapshots down to
+// the threshold. The oldest snapshots are removed first. User
+// created snapshots & the latest snapshot i.e. the parent of
+// head are never removed.
+func compactionCandidates(info jiva.InfoReplica, threshold int) []string {
+	snapshots := snapshotsOf(info)
+	excess := len(snapshots) - threshold
+	if excess <= 0 {
+		return nil
+	}
+	candidates := []string{}
+	for i := len(snapshots) - 1; i > 0 && len(candidates) < excess; i-- {
+		if info.Disks[snapshots[i]].UserCreated {
+			continue
+		}
+		candidates = append(candidates, snapshots[i])
+	}
+	return candidates
+}
+
+// commonCandidates returns the candidates that are common to all
+// the given replicas in the order of the first replica. Replicas
+// of a volume are expected to have the same chain; a snapshot is
+// removed only if every replica agrees on it.
+func commonCandidates(candidates [][]string) []string {
+	if len(candidates) == 0 {
+		return nil
+	}
+	common := []string{}
+	for _, snapshot := range candidates[0] {
+		found := true
+		for _, others := range candidates[1:] {
+			if !jiva.Contains(others, snapshot) {
+				found = false
+				break
+			}
+		}
+		if found {
+			common = append(common, snapshot)
+		}
+	}
+	return common
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"reflect"
+	"testing"
+
+	jiva "github.com/openebs/maya/pkg/client/jiva"
+)
+
+// fakeReplicaInfo returns the info of a replica whose chain has
+// the given snapshots ordered from the latest to the oldest. The
+// snapshots prefixed by "user-" are user created.
+func fakeReplicaInfo(snapshots ...string) jiva.InfoReplica {
+	info := jiva.InfoReplica{
+		Chain: []string{"volume-head-010.img"},
+		Disks: map[string]jiva.DiskInfo{},
+	}
+	for _, s := range snapshots {
+		disk := "volume-snap-" + s + ".img"
+		info.Chain = append(info.Chain, disk)
+		info.Disks[disk] = jiva.DiskInfo{
+			Name:        disk,
+			UserCreated: len(s) > 5 && s[:5] == "user-",
+		}
+	}
+	return info
+}
+
+func disks(snapshots ...string) []string {
+	d := []string{}
+	for _, s := range snapshots {
+		d = append(d, "volume-snap-"+s+".img")
+	}
+	return d
+}
+
+func TestCompactionCandidates(t *testing.T) {
+	tests := map[string]struct {
+		info      jiva.InfoReplica
+		threshold int
+		expected  []string
+	}{
+		"below threshold": {
+			info:      fakeReplicaInfo("s3", "s2", "s1"),
+			threshold: 3,
+			expected:  nil,
+		},
+		"oldest are removed first": {
+			info:      fakeReplicaInfo("s5", "s4", "s3", "s2", "s1"),
+			threshold: 3,
+			expected:  disks("s1", "s2"),
+		},
+		"user created are left alone": {
+			info:      fakeReplicaInfo("s5", "s4", "s3", "user-s2", "s1"),
+			threshold: 3,
+			expected:  disks("s1", "s3"),
+		},
+		"latest is never removed": {
+			info:      fakeReplicaInfo("s3", "user-s2", "user-s1"),
+			threshold: 1,
+			expected:  []string{},
+		},
+		"removed snapshots are not counted": {
+			info: func() jiva.InfoReplica {
+				info := fakeReplicaInfo("s4", "s3", "s2", "s1")
+				info.Disks["volume-snap-s1.img"] = jiva.DiskInfo{Removed: true}
+				return info
+			}(),
+			threshold: 2,
+			expected:  disks("s2"),
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			got := compactionCandidates(mock.info, mock.threshold)
+			if !reflect.DeepEqual(got, mock.expected) {
+				t.Fatalf("Test %q failed: expected %v: actual %v", name, mock.expected, got)
+			}
+		})
+	}
+}
+
+func TestCommonCandidates(t *testing.T) {
+	tests := map[string]struct {
+		candidates [][]string
+		expected   []string
+	}{
+		"no replicas":  {expected: nil},
+		"one replica":  {candidates: [][]string{{"a", "b"}}, expected: []string{"a", "b"}},
+		"same chains":  {candidates: [][]string{{"a", "b"}, {"a", "b"}}, expected: []string{"a", "b"}},
+		"differ":       {candidates: [][]string{{"a", "b", "c"}, {"c", "a"}}, expected: []string{"a", "c"}},
+		"nothing same": {candidates: [][]string{{"a"}, {"b"}}, expected: []string{}},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			got := commonCandidates(mock.candidates)
+			if !reflect.DeepEqual(got, mock.expected) {
+				t.Fatalf("Test %q failed: expected %v: actual %v", name, mock.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	jiva "github.com/openebs/maya/pkg/client/jiva"
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	controllerAgentName = "jiva-snapshot-controller"
+
+	// jivaTargetServiceSelector selects the services of jiva
+	// targets i.e. controllers
+	jivaTargetServiceSelector = "openebs.io/controller-service=jiva-controller-svc"
+
+	// persistentVolumeLabel is the label of jiva target service
+	// that has the name of volume
+	persistentVolumeLabel = "openebs.io/persistent-volume"
+
+	// jivaControllerPort is the port of jiva controller's REST API
+	jivaControllerPort = 9501
+
+	// replicaModeRW is the mode of a healthy replica
+	replicaModeRW = "RW"
+
+	// reasonCompacted is the reason of event raised when the
+	// snapshots of a volume are removed
+	reasonCompacted = "SnapshotCompacted"
+
+	// reasonCompactionFailed is the reason of event raised when
+	// the snapshots of a volume could not be removed
+	reasonCompactionFailed = "SnapshotCompactionFailed"
+)
+
+// replicaClient is the client of a jiva replica that is used to
+// inspect & compact its snapshot chain
+type replicaClient interface {
+	GetReplica() (jiva.InfoReplica, error)
+	MarkDiskAsRemoved(disk string) error
+	PrepareRemoveDisk(disk string) ([]jiva.PrepareRemoveAction, error)
+	RemoveDisk(disk string) error
+	ReplaceDisk(target, source string) error
+	Coalesce(from, to string) error
+}
+
+// Controller periodically removes the system created snapshots of
+// jiva volumes whose replicas have more snapshots than threshold
+type Controller struct {
+	kubeClient kubernetes.Interface
+	recorder   record.EventRecorder
+
+	// threshold is the number of snapshots of a replica beyond
+	// which its system created snapshots are removed
+	threshold int
+
+	// interval at which the volumes are inspected
+	interval time.Duration
+
+	// listReplicas returns the replicas of the jiva controller
+	// at given address
+	listReplicas func(address string) ([]jiva.Replica, error)
+
+	// newReplicaClient returns the client of the replica at
+	// given address
+	newReplicaClient func(address string) (replicaClient, error)
+}
+
+// NewController returns a new instance of jiva snapshot compaction
+// controller
+func NewController(kubeClient kubernetes.Interface, threshold int, interval time.Duration) *Controller {
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
+	return &Controller{
+		kubeClient: kubeClient,
+		recorder:   eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: controllerAgentName}),
+		threshold:  threshold,
+		interval:   interval,
+		listReplicas: func(address string) ([]jiva.Replica, error) {
+			return (&jiva.ControllerClient{}).ListReplicas(address)
+		},
+		newReplicaClient: func(address string) (replicaClient, error) {
+			return jiva.NewReplicaClient(address)
+		},
+	}
+}
+
+// Run compacts the snapshots of jiva volumes at every interval
+// until stop channel is closed
+func (c *Controller) Run(stopCh <-chan struct{}) error {
+	glog.Infof("starting jiva snapshot controller: threshold {%d} interval {%s}", c.threshold, c.interval)
+	wait.Until(c.compactAll, c.interval, stopCh)
+	glog.Info("shutting down jiva snapshot controller")
+	return nil
+}
+
+// compactAll compacts the snapshots of every jiva volume
+func (c *Controller) compactAll() {
+	services, err := c.kubeClient.CoreV1().Services(metav1.NamespaceAll).
+		List(metav1.ListOptions{LabelSelector: jivaTargetServiceSelector})
+	if err != nil {
+		glog.Errorf("failed to compact jiva snapshots: failed to list jiva target services: %+v", err)
+		return
+	}
+	for i := range services.Items {
+		svc := &services.Items[i]
+		volume := volumeName(svc)
+		err := c.compact(svc)
+		if err != nil {
+			glog.Errorf("failed to compact snapshots of jiva volume {%s}: %+v", volume, err)
+			jivaCompactionErrorCounter.WithLabelValues(volume).Inc()
+			c.recorder.Event(svc, corev1.EventTypeWarning, reasonCompactionFailed, err.Error())
+		}
+	}
+}
+
+// compact removes the system created snapshots of the jiva volume
+// of the given target service if its replicas have more snapshots
+// than threshold. Compaction is skipped unless all the replicas
+// are healthy, since a rebuilding replica is syncing the chain.
+func (c *Controller) compact(svc *corev1.Service) error {
+	volume := volumeName(svc)
+	if svc.Spec.ClusterIP == "" || svc.Spec.ClusterIP == corev1.ClusterIPNone {
+		return nil
+	}
+	address := fmt.Sprintf("http://%s:%d/v1", svc.Spec.ClusterIP, jivaControllerPort)
+	replicas, err := c.listReplicas(address)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list replicas of controller {%s}", address)
+	}
+	if len(replicas) == 0 {
+		return nil
+	}
+
+	clients := []replicaClient{}
+	candidates := [][]string{}
+	snapshots, remaining := 0, -1
+	for _, r := range replicas {
+		if r.Mode != replicaModeRW {
+			glog.V(4).Infof("skipped compaction of jiva volume {%s}: replica {%s} is in mode {%s}", volume, r.Address, r.Mode)
+			return nil
+		}
+		client, err := c.newReplicaClient(r.Address)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get client of replica {%s}", r.Address)
+		}
+		info, err := client.GetReplica()
+		if err != nil {
+			return errors.Wrapf(err, "failed to get info of replica {%s}", r.Address)
+		}
+		if info.Rebuilding {
+			glog.V(4).Infof("skipped compaction of jiva volume {%s}: replica {%s} is rebuilding", volume, r.Address)
+			return nil
+		}
+		if n := len(snapshotsOf(info)); n > snapshots {
+			snapshots = n
+		}
+		if remaining < 0 || info.RemainSnapshots < remaining {
+			remaining = info.RemainSnapshots
+		}
+		clients = append(clients, client)
+		candidates = append(candidates, compactionCandidates(info, c.threshold))
+	}
+	jivaSnapshots.WithLabelValues(volume).Set(float64(snapshots))
+	jivaRemainingSnapshots.WithLabelValues(volume).Set(float64(remaining))
+
+	removed := []string{}
+	defer func() {
+		if len(removed) == 0 {
+			return
+		}
+		jivaSnapshotsRemovedCounter.WithLabelValues(volume).Add(float64(len(removed)))
+		c.recorder.Eventf(svc, corev1.EventTypeNormal, reasonCompacted,
+			"removed {%d} system created snapshots {%s}", len(removed), strings.Join(removed, ", "))
+	}()
+	for _, snapshot := range commonCandidates(candidates) {
+		if err := removeSnapshot(snapshot, replicas, clients); err != nil {
+			return err
+		}
+		glog.Infof("removed snapshot {%s} of jiva volume {%s}", snapshot, volume)
+		removed = append(removed, jiva.TrimSnapshotName(snapshot))
+	}
+	return nil
+}
+
+// removeSnapshot removes the snapshot from the chain of every replica
+// the same way jiva controller does. The snapshot is marked removed
+// on all the replicas before its data is coalesced into its child,
+// so that the blocks present only in the snapshot are retained.
+func removeSnapshot(snapshot string, replicas []jiva.Replica, clients []replicaClient) error {
+	ops := make([][]jiva.PrepareRemoveAction, len(clients))
+	for i, client := range clients {
+		if err := client.MarkDiskAsRemoved(snapshot); err != nil {
+			return errors.Wrapf(err, "failed to mark snapshot {%s} of replica {%s} as removed", snapshot, replicas[i].Address)
+		}
+		replicaOps, err := client.PrepareRemoveDisk(snapshot)
+		if err != nil {
+			return errors.Wrapf(err, "failed to prepare removal of snapshot {%s} of replica {%s}", snapshot, replicas[i].Address)
+		}
+		ops[i] = replicaOps
+	}
+	for i, client := range clients {
+		for _, op := range ops[i] {
+			var err error
+			switch op.Action {
+			case jiva.OpRemove:
+				err = client.RemoveDisk(op.Source)
+			case jiva.OpCoalesce:
+				err = client.Coalesce(op.Target, op.Source)
+			case jiva.OpReplace:
+				err = client.ReplaceDisk(op.Target, op.Source)
+			default:
+				err = errors.Errorf("unknown operation {%s}", op.Action)
+			}
+			if err != nil {
+				return errors.Wrapf(err, "failed to %s {%s} {%s} to remove snapshot {%s} of replica {%s}",
+					op.Action, op.Source, op.Target, snapshot, replicas[i].Address)
+			}
+		}
+	}
+	return nil
+}
+
+// volumeName returns the name of jiva volume of the given target
+// service
+func volumeName(svc *corev1.Service) string {
+	if name := svc.Labels[persistentVolumeLabel]; name != "" {
+		return name
+	}
+	return svc.Name
+}
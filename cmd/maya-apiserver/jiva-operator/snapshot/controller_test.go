@@ -0,0 +1,263 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	jiva "github.com/openebs/maya/pkg/client/jiva"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+)
+
+// fakeReplicaClient simulates the chain of a jiva replica where every
+// disk has the blocks written while it was the head
+type fakeReplicaClient struct {
+	info    jiva.InfoReplica
+	removed []string
+	failOn  string
+	chain   []string
+	blocks  map[string]map[int]string
+}
+
+func newFakeReplicaClient(info jiva.InfoReplica, failOn string) *fakeReplicaClient {
+	f := &fakeReplicaClient{
+		info:   info,
+		failOn: failOn,
+		chain:  append([]string{}, info.Chain...),
+		blocks: map[string]map[int]string{},
+	}
+	for _, disk := range f.chain {
+		f.blocks[disk] = map[int]string{}
+	}
+	return f
+}
+
+func (f *fakeReplicaClient) GetReplica() (jiva.InfoReplica, error) {
+	return f.info, nil
+}
+
+func (f *fakeReplicaClient) MarkDiskAsRemoved(disk string) error {
+	if disk == f.failOn {
+		return fmt.Errorf("failed to remove disk %s", disk)
+	}
+	f.removed = append(f.removed, disk)
+	return nil
+}
+
+func (f *fakeReplicaClient) indexOf(disk string) int {
+	for i, d := range f.chain {
+		if d == disk {
+			return i
+		}
+	}
+	return -1
+}
+
+// PrepareRemoveDisk returns the operations as jiva replica does, the
+// chain is ordered from the head to the oldest snapshot
+func (f *fakeReplicaClient) PrepareRemoveDisk(disk string) ([]jiva.PrepareRemoveAction, error) {
+	i := f.indexOf(disk)
+	if i <= 0 {
+		return nil, fmt.Errorf("can not remove disk %s", disk)
+	}
+	child := f.chain[i-1]
+	if i-1 == 0 {
+		// child is the head which is never coalesced
+		return nil, nil
+	}
+	return []jiva.PrepareRemoveAction{
+		{Action: jiva.OpCoalesce, Source: disk, Target: child},
+		{Action: jiva.OpReplace, Source: disk, Target: child},
+	}, nil
+}
+
+func (f *fakeReplicaClient) RemoveDisk(disk string) error {
+	i := f.indexOf(disk)
+	if i < 0 {
+		return fmt.Errorf("disk %s not found", disk)
+	}
+	f.chain = append(f.chain[:i], f.chain[i+1:]...)
+	delete(f.blocks, disk)
+	return nil
+}
+
+func (f *fakeReplicaClient) Coalesce(from, to string) error {
+	if f.indexOf(from) < 0 || f.indexOf(to) < 0 {
+		return fmt.Errorf("can not coalesce %s into %s", from, to)
+	}
+	for block, data := range f.blocks[from] {
+		f.blocks[to][block] = data
+	}
+	return nil
+}
+
+func (f *fakeReplicaClient) ReplaceDisk(target, source string) error {
+	if f.indexOf(target) < 0 {
+		return fmt.Errorf("disk %s not found", target)
+	}
+	blocks := f.blocks[source]
+	if err := f.RemoveDisk(source); err != nil {
+		return err
+	}
+	f.blocks[target] = blocks
+	return nil
+}
+
+// read returns the data of the volume as seen through the chain
+func (f *fakeReplicaClient) read() map[int]string {
+	data := map[int]string{}
+	for i := len(f.chain) - 1; i >= 0; i-- {
+		for block, d := range f.blocks[f.chain[i]] {
+			data[block] = d
+		}
+	}
+	return data
+}
+
+func fakeTargetService() *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pvc-1-ctrl-svc",
+			Namespace: "default",
+			Labels: map[string]string{
+				"openebs.io/controller-service": "jiva-controller-svc",
+				persistentVolumeLabel:           "pvc-1",
+			},
+		},
+		Spec: corev1.ServiceSpec{ClusterIP: "10.0.0.1"},
+	}
+}
+
+func TestCompactAll(t *testing.T) {
+	tests := map[string]struct {
+		modes          []string
+		infos          []jiva.InfoReplica
+		failOn         string
+		expected       []string
+		expectedEvents []string
+	}{
+		"compacts all replicas": {
+			modes:          []string{"RW", "RW"},
+			infos:          []jiva.InfoReplica{fakeReplicaInfo("s4", "s3", "s2", "s1"), fakeReplicaInfo("s4", "s3", "s2", "s1")},
+			expected:       disks("s1", "s2"),
+			expectedEvents: []string{"Normal " + reasonCompacted},
+		},
+		"below threshold": {
+			modes: []string{"RW"},
+			infos: []jiva.InfoReplica{fakeReplicaInfo("s2", "s1")},
+		},
+		"replica is not healthy": {
+			modes: []string{"RW", "WO"},
+			infos: []jiva.InfoReplica{fakeReplicaInfo("s4", "s3", "s2", "s1"), fakeReplicaInfo("s4", "s3", "s2", "s1")},
+		},
+		"removal fails": {
+			modes:          []string{"RW"},
+			infos:          []jiva.InfoReplica{fakeReplicaInfo("s4", "s3", "s2", "s1")},
+			failOn:         "volume-snap-s2.img",
+			expected:       disks("s1"),
+			expectedEvents: []string{"Normal " + reasonCompacted, "Warning " + reasonCompactionFailed},
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			clients := map[string]*fakeReplicaClient{}
+			replicas := []jiva.Replica{}
+			for i, mode := range mock.modes {
+				address := fmt.Sprintf("tcp://10.1.0.%d:9502", i)
+				replicas = append(replicas, jiva.Replica{Address: address, Mode: mode})
+				clients[address] = newFakeReplicaClient(mock.infos[i], mock.failOn)
+			}
+			c := &Controller{
+				kubeClient: fake.NewSimpleClientset(fakeTargetService()),
+				recorder:   recorder,
+				threshold:  2,
+				listReplicas: func(address string) ([]jiva.Replica, error) {
+					if address != "http://10.0.0.1:9501/v1" {
+						return nil, fmt.Errorf("unexpected address %s", address)
+					}
+					return replicas, nil
+				},
+				newReplicaClient: func(address string) (replicaClient, error) {
+					return clients[address], nil
+				},
+			}
+			c.compactAll()
+
+			for address, client := range clients {
+				if len(client.removed) != 0 || len(mock.expected) != 0 {
+					if !reflect.DeepEqual(client.removed, mock.expected) {
+						t.Fatalf("Test %q failed: replica %s: expected removed %v: actual %v",
+							name, address, mock.expected, client.removed)
+					}
+				}
+			}
+			close(recorder.Events)
+			events := []string{}
+			for event := range recorder.Events {
+				events = append(events, event)
+			}
+			if len(events) != len(mock.expectedEvents) {
+				t.Fatalf("Test %q failed: expected events %v: actual %v", name, mock.expectedEvents, events)
+			}
+			for i, event := range events {
+				if !strings.HasPrefix(event, mock.expectedEvents[i]) {
+					t.Fatalf("Test %q failed: expected events %v: actual %v", name, mock.expectedEvents, events)
+				}
+			}
+		})
+	}
+}
+
+func TestCompactRetainsData(t *testing.T) {
+	info := fakeReplicaInfo("s4", "s3", "s2", "s1")
+	client := newFakeReplicaClient(info, "")
+	// every disk overwrites block 0 & writes a block of its own
+	for i, disk := range client.chain {
+		client.blocks[disk][0] = disk
+		client.blocks[disk][i+1] = disk
+	}
+	expected := client.read()
+	c := &Controller{
+		kubeClient: fake.NewSimpleClientset(),
+		recorder:   record.NewFakeRecorder(10),
+		threshold:  2,
+		listReplicas: func(address string) ([]jiva.Replica, error) {
+			return []jiva.Replica{{Address: "tcp://10.1.0.1:9502", Mode: "RW"}}, nil
+		},
+		newReplicaClient: func(address string) (replicaClient, error) {
+			return client, nil
+		},
+	}
+	if err := c.compact(fakeTargetService()); err != nil {
+		t.Fatalf("Test failed: expected no error: actual error '%v'", err)
+	}
+	expectedChain := []string{"volume-head-010.img", "volume-snap-s4.img", "volume-snap-s3.img"}
+	if !reflect.DeepEqual(client.chain, expectedChain) {
+		t.Fatalf("Test failed: expected chain %v: actual %v", expectedChain, client.chain)
+	}
+	if actual := client.read(); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Test failed: expected data %v: actual %v", expected, actual)
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"github.com/openebs/maya/cmd/maya-apiserver/jiva-operator/common"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// jivaSnapshots is the number of snapshots in the chain of
+	// the replicas of a jiva volume
+	jivaSnapshots = common.NewVolumeGaugeVec(
+		"openebs_jiva_snapshots",
+		"Number of snapshots in the chain of jiva replicas.",
+	)
+
+	// jivaRemainingSnapshots is the number of snapshots that can
+	// still be taken of a jiva volume before its chain is full
+	jivaRemainingSnapshots = common.NewVolumeGaugeVec(
+		"openebs_jiva_remaining_snapshots",
+		"Number of snapshots that can be taken before the chain of jiva replicas is full.",
+	)
+
+	// jivaSnapshotsRemovedCounter counts the system created
+	// snapshots removed by compaction
+	jivaSnapshotsRemovedCounter = common.NewVolumeCounterVec(
+		"openebs_jiva_snapshot_compaction_removed_total",
+		"Total number of system created jiva snapshots removed by compaction.",
+	)
+
+	// jivaCompactionErrorCounter counts the failed compactions
+	jivaCompactionErrorCounter = common.NewVolumeCounterVec(
+		"openebs_jiva_snapshot_compaction_errors_total",
+		"Total number of failed compactions of jiva snapshots.",
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		jivaSnapshots,
+		jivaRemainingSnapshots,
+		jivaSnapshotsRemovedCounter,
+		jivaCompactionErrorCounter,
+	)
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/openebs/maya/cmd/maya-apiserver/jiva-operator/common"
+	env "github.com/openebs/maya/pkg/env/v1alpha1"
+)
+
+const (
+	// defaultThreshold is the default number of snapshots of a
+	// replica beyond which its system created snapshots are
+	// removed
+	defaultThreshold = 100
+
+	// defaultInterval is the default interval at which the
+	// snapshots of replicas are inspected
+	defaultInterval = 10 * time.Minute
+)
+
+// Start starts the jiva snapshot controller
+func Start() error {
+	threshold, err := common.GetNonNegativeInt(env.JivaSnapshotCompactionThreshold, defaultThreshold)
+	if err != nil {
+		return err
+	}
+	interval, err := common.GetDuration(env.JivaSnapshotCompactionInterval, defaultInterval, false)
+	if err != nil {
+		return err
+	}
+	if threshold == 0 {
+		glog.Info("jiva snapshot compaction is disabled")
+		return nil
+	}
+	return common.Run(func(cs *common.Clientsets) common.Runner {
+		return NewController(cs.Kube, threshold, interval)
+	})
+}
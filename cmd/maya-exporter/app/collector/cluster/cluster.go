@@ -0,0 +1,206 @@
+// Copyright © 2019 The OpenEBS Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"strings"
+
+	units "github.com/docker/go-units"
+	"github.com/golang/glog"
+	col "github.com/openebs/maya/cmd/maya-exporter/app/collector"
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	informers "github.com/openebs/maya/pkg/client/generated/informers/externalversions"
+	listers "github.com/openebs/maya/pkg/client/generated/listers/openebs.io/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// persistentVolumeLabel is the label on a cstor volume
+	// replica that contains the name of its volume
+	persistentVolumeLabel = "openebs.io/persistent-volume"
+	// cstorPoolLabel is the label on a cstor volume replica
+	// that contains the name of its pool
+	cstorPoolLabel = "cstorpool.openebs.io/name"
+	// replicaModeHealthy is the mode reported by the target
+	// for a replica that is in sync with the other replicas
+	replicaModeHealthy = "Healthy"
+)
+
+// cluster implements prometheus.Collector interface. It exports
+// the status, capacity & replica health of the cstor volumes,
+// replicas, pools & pool clusters of the kubernetes cluster from
+// a single endpoint.
+type cluster struct {
+	*metrics
+	cvLister   listers.CStorVolumeLister
+	cvrLister  listers.CStorVolumeReplicaLister
+	cspLister  listers.CStorPoolLister
+	cspcLister listers.CStorPoolClusterLister
+}
+
+// New returns new instance of cluster collector. The informers
+// of the given factory need to be started & synced before the
+// metrics are collected.
+func New(factory informers.SharedInformerFactory) col.Collector {
+	openebs := factory.Openebs().V1alpha1()
+	return &cluster{
+		metrics: newMetrics().
+			withVolumeMetrics().
+			withReplicaMetrics().
+			withPoolMetrics().
+			withCSPCMetrics().
+			withParseErrorCounter(),
+		cvLister:   openebs.CStorVolumes().Lister(),
+		cvrLister:  openebs.CStorVolumeReplicas().Lister(),
+		cspLister:  openebs.CStorPools().Lister(),
+		cspcLister: openebs.CStorPoolClusters().Lister(),
+	}
+}
+
+// Describe is implementation of Describe method of prometheus.Collector
+// interface.
+func (c *cluster) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range c.descs() {
+		ch <- desc
+	}
+	c.parseErrorCounter.Describe(ch)
+}
+
+// Collect is implementation of prometheus's prometheus.Collector interface
+func (c *cluster) Collect(ch chan<- prometheus.Metric) {
+	c.collectVolumes(ch)
+	c.collectReplicas(ch)
+	c.collectPools(ch)
+	c.collectCSPCs(ch)
+	c.parseErrorCounter.Collect(ch)
+}
+
+func (c *cluster) collectVolumes(ch chan<- prometheus.Metric) {
+	cvs, err := c.cvLister.List(labels.Everything())
+	if err != nil {
+		glog.Errorf("failed to list cstor volumes: %v", err)
+		return
+	}
+	for _, cv := range cvs {
+		ch <- gauge(c.volumePhase, 1, cv.Namespace, cv.Name, string(cv.Status.Phase))
+		if size, ok := c.parseSize(cv.Spec.Capacity); ok {
+			ch <- gauge(c.volumeCapacity, size, cv.Namespace, cv.Name)
+		}
+		ch <- gauge(c.volumeReplicationFactor,
+			float64(cv.Spec.ReplicationFactor), cv.Namespace, cv.Name)
+		ch <- gauge(c.volumeConsistencyFactor,
+			float64(cv.Spec.ConsistencyFactor), cv.Namespace, cv.Name)
+		ch <- gauge(c.volumeHealthyReplicas,
+			float64(healthyReplicas(cv)), cv.Namespace, cv.Name)
+	}
+}
+
+func (c *cluster) collectReplicas(ch chan<- prometheus.Metric) {
+	cvrs, err := c.cvrLister.List(labels.Everything())
+	if err != nil {
+		glog.Errorf("failed to list cstor volume replicas: %v", err)
+		return
+	}
+	for _, cvr := range cvrs {
+		volume := cvr.Labels[persistentVolumeLabel]
+		pool := cvr.Labels[cstorPoolLabel]
+		ch <- gauge(c.replicaPhase, 1,
+			cvr.Namespace, cvr.Name, volume, pool, string(cvr.Status.Phase))
+		if size, ok := c.parseSize(cvr.Status.Capacity.TotalAllocated); ok {
+			ch <- gauge(c.replicaAllocated, size, cvr.Namespace, cvr.Name, volume, pool)
+		}
+		if size, ok := c.parseSize(cvr.Status.Capacity.Used); ok {
+			ch <- gauge(c.replicaUsed, size, cvr.Namespace, cvr.Name, volume, pool)
+		}
+	}
+}
+
+func (c *cluster) collectPools(ch chan<- prometheus.Metric) {
+	csps, err := c.cspLister.List(labels.Everything())
+	if err != nil {
+		glog.Errorf("failed to list cstor pools: %v", err)
+		return
+	}
+	for _, csp := range csps {
+		claim := csp.Labels[string(apis.StoragePoolClaimCPK)]
+		if claim == "" {
+			claim = csp.Labels[string(apis.CStorPoolClusterCPK)]
+		}
+		node := csp.Labels[string(apis.HostNameCPK)]
+		ch <- gauge(c.poolPhase, 1, csp.Name, claim, node, string(csp.Status.Phase))
+		total, hasTotal := c.parseSize(csp.Status.Capacity.Total)
+		if hasTotal {
+			ch <- gauge(c.poolSize, total, csp.Name, claim, node)
+		}
+		if size, ok := c.parseSize(csp.Status.Capacity.Free); ok {
+			ch <- gauge(c.poolFree, size, csp.Name, claim, node)
+		}
+		if size, ok := c.parseSize(csp.Status.Capacity.Used); ok {
+			ch <- gauge(c.poolUsed, size, csp.Name, claim, node)
+		}
+		if size, ok := c.parseSize(csp.Status.Capacity.Provisioned); ok {
+			ch <- gauge(c.poolProvisioned, size, csp.Name, claim, node)
+			if hasTotal && total > 0 {
+				ch <- gauge(c.poolOverCommit, size/total, csp.Name, claim, node)
+			}
+		}
+	}
+}
+
+func (c *cluster) collectCSPCs(ch chan<- prometheus.Metric) {
+	cspcs, err := c.cspcLister.List(labels.Everything())
+	if err != nil {
+		glog.Errorf("failed to list cstor pool clusters: %v", err)
+		return
+	}
+	for _, cspc := range cspcs {
+		ch <- gauge(c.cspcPhase, 1, cspc.Namespace, cspc.Name, cspc.Status.Phase)
+		ch <- gauge(c.cspcDesiredPools,
+			float64(len(cspc.Spec.Pools)), cspc.Namespace, cspc.Name)
+	}
+}
+
+// parseSize converts the size reported by zfs e.g. 6K, 9.94G
+// to bytes. Sizes that are yet to be reported are skipped.
+func (c *cluster) parseSize(size string) (float64, bool) {
+	size = strings.TrimSpace(size)
+	if size == "" {
+		return 0, false
+	}
+	bytes, err := units.RAMInBytes(size)
+	if err != nil {
+		glog.V(4).Infof("failed to parse size {%s}: %v", size, err)
+		c.parseErrorCounter.Inc()
+		return 0, false
+	}
+	return float64(bytes), true
+}
+
+// healthyReplicas returns the no of replicas reported as healthy
+// by the target of the volume
+func healthyReplicas(cv *apis.CStorVolume) int {
+	healthy := 0
+	for _, rs := range cv.Status.ReplicaStatuses {
+		if rs.Mode == replicaModeHealthy {
+			healthy++
+		}
+	}
+	return healthy
+}
+
+func gauge(desc *prometheus.Desc, value float64, labelValues ...string) prometheus.Metric {
+	return prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value, labelValues...)
+}
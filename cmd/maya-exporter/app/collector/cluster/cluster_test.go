@@ -0,0 +1,148 @@
+// Copyright © 2019 The OpenEBS Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"fmt"
+	"testing"
+
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	openebsFakeClientset "github.com/openebs/maya/pkg/client/generated/clientset/versioned/fake"
+	informers "github.com/openebs/maya/pkg/client/generated/informers/externalversions"
+	mockServer "github.com/openebs/maya/pkg/prometheus/exporter/mock/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestClusterCollector(t *testing.T) {
+	cases := map[string]struct {
+		objects        []runtime.Object
+		expectedOutput []string
+	}{
+		"cstor volume": {
+			objects: []runtime.Object{
+				&apis.CStorVolume{
+					ObjectMeta: metav1.ObjectMeta{Name: "pv-1", Namespace: "openebs"},
+					Spec: apis.CStorVolumeSpec{
+						Capacity:          "5G",
+						ReplicationFactor: 3,
+						ConsistencyFactor: 2,
+					},
+					Status: apis.CStorVolumeStatus{
+						Phase: "Degraded",
+						ReplicaStatuses: []apis.ReplicaStatus{
+							{Mode: "Healthy"}, {Mode: "Healthy"}, {Mode: "Degraded"},
+						},
+					},
+				},
+			},
+			expectedOutput: []string{
+				`openebs_cstor_volume_phase{namespace="openebs",phase="Degraded",volume="pv-1"} 1`,
+				`openebs_cstor_volume_capacity_bytes{namespace="openebs",volume="pv-1"} 5.36870912e\+09`,
+				`openebs_cstor_volume_replication_factor{namespace="openebs",volume="pv-1"} 3`,
+				`openebs_cstor_volume_consistency_factor{namespace="openebs",volume="pv-1"} 2`,
+				`openebs_cstor_volume_healthy_replicas{namespace="openebs",volume="pv-1"} 2`,
+			},
+		},
+		"cstor volume replica": {
+			objects: []runtime.Object{
+				&apis.CStorVolumeReplica{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "pv-1-pool-1",
+						Namespace: "openebs",
+						Labels: map[string]string{
+							persistentVolumeLabel: "pv-1",
+							cstorPoolLabel:        "pool-1",
+						},
+					},
+					Status: apis.CStorVolumeReplicaStatus{
+						Phase: apis.CVRStatusOnline,
+						Capacity: apis.CStorVolumeCapacityAttr{
+							TotalAllocated: "6K",
+							Used:           "invalid",
+						},
+					},
+				},
+			},
+			expectedOutput: []string{
+				`openebs_cstor_volume_replica_phase{namespace="openebs",phase="Healthy",pool="pool-1",replica="pv-1-pool-1",volume="pv-1"} 1`,
+				`openebs_cstor_volume_replica_allocated_bytes{namespace="openebs",pool="pool-1",replica="pv-1-pool-1",volume="pv-1"} 6144`,
+				`openebs_cluster_exporter_parse_error_count 1`,
+			},
+		},
+		"cstor pool and pool cluster": {
+			objects: []runtime.Object{
+				&apis.CStorPool{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "pool-1",
+						Labels: map[string]string{
+							string(apis.CStorPoolClusterCPK): "cspc-1",
+							string(apis.HostNameCPK):         "node-1",
+						},
+					},
+					Status: apis.CStorPoolStatus{
+						Phase: apis.CStorPoolStatusOnline,
+						Capacity: apis.CStorPoolCapacityAttr{
+							Total:       "10G",
+							Free:        "9G",
+							Used:        "1G",
+							Provisioned: "15Gi",
+						},
+					},
+				},
+				&apis.CStorPoolCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "cspc-1", Namespace: "openebs"},
+					Spec: apis.CStorPoolClusterSpec{
+						Pools: []apis.PoolSpec{{}, {}},
+					},
+					Status: apis.CStorPoolClusterStatus{Phase: "Online"},
+				},
+			},
+			expectedOutput: []string{
+				`openebs_cstor_pool_phase{node="node-1",phase="Healthy",pool="pool-1",pool_claim="cspc-1"} 1`,
+				`openebs_cstor_pool_size_bytes{node="node-1",pool="pool-1",pool_claim="cspc-1"} 1.073741824e\+10`,
+				`openebs_cstor_pool_free_bytes{node="node-1",pool="pool-1",pool_claim="cspc-1"} 9.663676416e\+09`,
+				`openebs_cstor_pool_used_bytes{node="node-1",pool="pool-1",pool_claim="cspc-1"} 1.073741824e\+09`,
+				`openebs_cstor_pool_provisioned_bytes{node="node-1",pool="pool-1",pool_claim="cspc-1"} 1.610612736e\+10`,
+				`openebs_cstor_pool_over_commit_ratio{node="node-1",pool="pool-1",pool_claim="cspc-1"} 1.5`,
+				`openebs_cstor_pool_cluster_phase{cspc="cspc-1",namespace="openebs",phase="Online"} 1`,
+				`openebs_cstor_pool_cluster_desired_pools{cspc="cspc-1",namespace="openebs"} 2`,
+			},
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			stop := make(chan struct{})
+			factory := informers.NewSharedInformerFactory(
+				openebsFakeClientset.NewSimpleClientset(tt.objects...), 0)
+			cluster := New(factory)
+			factory.Start(stop)
+			factory.WaitForCacheSync(stop)
+
+			regex := mockServer.BuildRegex(tt.expectedOutput)
+			buf := mockServer.PrometheusService(cluster, stop)
+			for _, re := range regex {
+				if !re.Match(buf) {
+					fmt.Println(string(buf))
+					t.Errorf("failed expectedOutputing: %q", re)
+				}
+			}
+			mockServer.Unregister(cluster)
+			close(stop)
+		})
+	}
+}
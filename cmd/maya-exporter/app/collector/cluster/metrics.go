@@ -0,0 +1,154 @@
+// Copyright © 2019 The OpenEBS Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metrics contains the descriptors of the metrics exported
+// for the openebs custom resources of the cluster. Values of
+// these metrics are read from the informer caches during
+// every scrape.
+type metrics struct {
+	volumePhase             *prometheus.Desc
+	volumeCapacity          *prometheus.Desc
+	volumeReplicationFactor *prometheus.Desc
+	volumeConsistencyFactor *prometheus.Desc
+	volumeHealthyReplicas   *prometheus.Desc
+
+	replicaPhase     *prometheus.Desc
+	replicaAllocated *prometheus.Desc
+	replicaUsed      *prometheus.Desc
+
+	poolPhase       *prometheus.Desc
+	poolSize        *prometheus.Desc
+	poolFree        *prometheus.Desc
+	poolUsed        *prometheus.Desc
+	poolProvisioned *prometheus.Desc
+	poolOverCommit  *prometheus.Desc
+
+	cspcPhase        *prometheus.Desc
+	cspcDesiredPools *prometheus.Desc
+
+	parseErrorCounter prometheus.Counter
+}
+
+func newDesc(name, help string, labels ...string) *prometheus.Desc {
+	return prometheus.NewDesc(
+		prometheus.BuildFQName("openebs", "", name), help, labels, nil,
+	)
+}
+
+func newMetrics() *metrics {
+	return &metrics{}
+}
+
+func (m *metrics) withVolumeMetrics() *metrics {
+	m.volumePhase = newDesc("cstor_volume_phase",
+		"Phase of cstor volume, value is 1 for the current phase",
+		"namespace", "volume", "phase")
+	m.volumeCapacity = newDesc("cstor_volume_capacity_bytes",
+		"Capacity of cstor volume in bytes",
+		"namespace", "volume")
+	m.volumeReplicationFactor = newDesc("cstor_volume_replication_factor",
+		"No of replicas the target of cstor volume expects",
+		"namespace", "volume")
+	m.volumeConsistencyFactor = newDesc("cstor_volume_consistency_factor",
+		"No of healthy replicas required by cstor volume to serve io's",
+		"namespace", "volume")
+	m.volumeHealthyReplicas = newDesc("cstor_volume_healthy_replicas",
+		"No of replicas reported healthy by the target of cstor volume",
+		"namespace", "volume")
+	return m
+}
+
+func (m *metrics) withReplicaMetrics() *metrics {
+	m.replicaPhase = newDesc("cstor_volume_replica_phase",
+		"Phase of cstor volume replica, value is 1 for the current phase",
+		"namespace", "replica", "volume", "pool", "phase")
+	m.replicaAllocated = newDesc("cstor_volume_replica_allocated_bytes",
+		"Space allocated by cstor volume replica in its pool in bytes",
+		"namespace", "replica", "volume", "pool")
+	m.replicaUsed = newDesc("cstor_volume_replica_used_bytes",
+		"Logical space used by cstor volume replica in bytes",
+		"namespace", "replica", "volume", "pool")
+	return m
+}
+
+func (m *metrics) withPoolMetrics() *metrics {
+	m.poolPhase = newDesc("cstor_pool_phase",
+		"Phase of cstor pool, value is 1 for the current phase",
+		"pool", "pool_claim", "node", "phase")
+	m.poolSize = newDesc("cstor_pool_size_bytes",
+		"Size of cstor pool in bytes",
+		"pool", "pool_claim", "node")
+	m.poolFree = newDesc("cstor_pool_free_bytes",
+		"Free capacity of cstor pool in bytes",
+		"pool", "pool_claim", "node")
+	m.poolUsed = newDesc("cstor_pool_used_bytes",
+		"Used capacity of cstor pool in bytes",
+		"pool", "pool_claim", "node")
+	m.poolProvisioned = newDesc("cstor_pool_provisioned_bytes",
+		"Sum of the capacities of the volume replicas on cstor pool in bytes",
+		"pool", "pool_claim", "node")
+	m.poolOverCommit = newDesc("cstor_pool_over_commit_ratio",
+		"Ratio of the provisioned capacity to the size of cstor pool",
+		"pool", "pool_claim", "node")
+	return m
+}
+
+func (m *metrics) withCSPCMetrics() *metrics {
+	m.cspcPhase = newDesc("cstor_pool_cluster_phase",
+		"Phase of cstor pool cluster, value is 1 for the current phase",
+		"namespace", "cspc", "phase")
+	m.cspcDesiredPools = newDesc("cstor_pool_cluster_desired_pools",
+		"No of pools specified in cstor pool cluster",
+		"namespace", "cspc")
+	return m
+}
+
+func (m *metrics) withParseErrorCounter() *metrics {
+	m.parseErrorCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "openebs",
+			Name:      "cluster_exporter_parse_error_count",
+			Help:      "Total no of errors in parsing the capacity of openebs resources",
+		},
+	)
+	return m
+}
+
+// descs returns the descriptors of all the metrics
+func (m *metrics) descs() []*prometheus.Desc {
+	return []*prometheus.Desc{
+		m.volumePhase,
+		m.volumeCapacity,
+		m.volumeReplicationFactor,
+		m.volumeConsistencyFactor,
+		m.volumeHealthyReplicas,
+		m.replicaPhase,
+		m.replicaAllocated,
+		m.replicaUsed,
+		m.poolPhase,
+		m.poolSize,
+		m.poolFree,
+		m.poolUsed,
+		m.poolProvisioned,
+		m.poolOverCommit,
+		m.cspcPhase,
+		m.cspcDesiredPools,
+	}
+}
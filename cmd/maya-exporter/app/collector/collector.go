@@ -15,11 +15,17 @@
 package collector
 
 import (
+	"encoding/json"
+
 	"github.com/golang/glog"
 	v1 "github.com/openebs/maya/pkg/stats/v1alpha1"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+// nanoSecondsPerSecond converts the io time reported by the
+// targets in nanoseconds to seconds
+const nanoSecondsPerSecond = 1e9
+
 // collector implements prometheus.Collector interface
 type collector struct {
 	Volume
@@ -72,6 +78,25 @@ func (c *collector) collectors() []prometheus.Collector {
 		c.degradedReplicaCounter,
 		c.healthyReplicaCounter,
 		c.volumeUpTime,
+		c.replicaMode,
+		c.replicaInflightIO,
+		c.replicaCheckpointedIOSeq,
+		c.replicaUpTime,
+	}
+}
+
+// descs returns the descriptors of the metrics that are built
+// afresh during every scrape
+func (c *collector) descs() []*prometheus.Desc {
+	return []*prometheus.Desc{
+		c.readsTotal,
+		c.writesTotal,
+		c.readBytesTotal,
+		c.writeBytesTotal,
+		c.readLatency,
+		c.writeLatency,
+		c.readSize,
+		c.writeSize,
 	}
 }
 
@@ -92,6 +117,9 @@ func (c *collector) Describe(ch chan<- *prometheus.Desc) {
 	for _, col := range c.collectors() {
 		col.Describe(ch)
 	}
+	for _, desc := range c.descs() {
+		ch <- desc
+	}
 }
 
 // Collect is called by the Prometheus registry when collecting
@@ -137,6 +165,54 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 	for _, col := range c.collectors() {
 		col.Collect(ch)
 	}
+	c.collectIO(ch, stats)
+}
+
+// collectIO sends the cumulative io counters and the io summaries
+// of the volume. These are skipped if the stats could not be fetched
+// since zero values would be seen as a reset of the counters.
+func (c *collector) collectIO(ch chan<- prometheus.Metric, volStats stats) {
+	if !volStats.got {
+		return
+	}
+	labels := []string{volStats.name, volStats.casType}
+	counters := []struct {
+		desc  *prometheus.Desc
+		value float64
+	}{
+		{c.readsTotal, volStats.reads},
+		{c.writesTotal, volStats.writes},
+		{c.readBytesTotal, volStats.totalReadBytes},
+		{c.writeBytesTotal, volStats.totalWriteBytes},
+	}
+	for _, counter := range counters {
+		ch <- prometheus.MustNewConstMetric(
+			counter.desc, prometheus.CounterValue, counter.value, labels...)
+	}
+	// targets report only the cumulative no of io's and the cumulative
+	// time or bytes of these io's, so these are exported as the _count
+	// & _sum of summaries without quantiles. The average over any range
+	// is rate(_sum) / rate(_count).
+	type summary struct {
+		desc       *prometheus.Desc
+		count, sum float64
+	}
+	summaries := []summary{
+		{c.readLatency, volStats.reads, volStats.totalReadTime / nanoSecondsPerSecond},
+		{c.writeLatency, volStats.writes, volStats.totalWriteTime / nanoSecondsPerSecond},
+	}
+	// only cstor target reports the bytes of the io's served by it,
+	// jiva reports the bytes of the blocks of the volume.
+	if volStats.casType == "cstor" {
+		summaries = append(summaries,
+			summary{c.readSize, volStats.reads, volStats.totalReadBytes},
+			summary{c.writeSize, volStats.writes, volStats.totalWriteBytes},
+		)
+	}
+	for _, summary := range summaries {
+		ch <- prometheus.MustNewConstSummary(
+			summary.desc, uint64(summary.count), summary.sum, nil, labels...)
+	}
 }
 
 func (c *collector) setError(err error) {
@@ -179,6 +255,33 @@ func (c *collector) set(volStats stats) {
 	c.healthyReplicaCounter.Set(volStats.healthyReplicaCount)
 
 	c.volumeStatus.Set(float64(volStats.getVolumeStatus()))
-
+	c.setReplicaStatuses(volStats)
 	return
 }
+
+// setReplicaStatuses sets the per replica gauges. These are reset
+// first so that the replicas that have disconnected from the target
+// are not reported anymore.
+func (c *collector) setReplicaStatuses(volStats stats) {
+	c.replicaMode.Reset()
+	c.replicaInflightIO.Reset()
+	c.replicaCheckpointedIOSeq.Reset()
+	c.replicaUpTime.Reset()
+	metrics := &c.metrics
+	for _, rs := range volStats.replicaStatuses {
+		c.replicaMode.WithLabelValues(volStats.name, rs.ID, rs.Mode).Set(1)
+		inflight := map[string]string{
+			"read":  rs.InflightRead,
+			"write": rs.InflightWrite,
+			"sync":  rs.InflightSync,
+		}
+		for typ, value := range inflight {
+			c.replicaInflightIO.WithLabelValues(volStats.name, rs.ID, typ).
+				Set(parseFloat64(json.Number(value), metrics))
+		}
+		c.replicaCheckpointedIOSeq.WithLabelValues(volStats.name, rs.ID).
+			Set(parseFloat64(json.Number(rs.CheckpointedIOSeq), metrics))
+		c.replicaUpTime.WithLabelValues(volStats.name, rs.ID).
+			Set(float64(rs.UpTime))
+	}
+}
@@ -18,6 +18,8 @@ import (
 	"testing"
 
 	v1 "github.com/openebs/maya/pkg/stats/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 )
 
 type fakeVol struct {
@@ -57,3 +59,79 @@ func TestCasType(t *testing.T) {
 		})
 	}
 }
+
+func TestCollectIOSummaries(t *testing.T) {
+	volStats := stats{
+		got:             true,
+		name:            "vol1",
+		reads:           4,
+		writes:          2,
+		totalReadTime:   2 * nanoSecondsPerSecond,
+		totalWriteTime:  nanoSecondsPerSecond,
+		totalReadBytes:  4096,
+		totalWriteBytes: 1024,
+	}
+	cases := map[string]struct {
+		casType  string
+		expected map[string][2]float64
+	}{
+		"cstor volume exports latency & size summaries": {
+			casType: "cstor",
+			expected: map[string][2]float64{
+				"readLatency":  {4, 2},
+				"writeLatency": {2, 1},
+				"readSize":     {4, 4096},
+				"writeSize":    {2, 1024},
+			},
+		},
+		"jiva volume exports only latency summaries": {
+			casType: "jiva",
+			expected: map[string][2]float64{
+				"readLatency":  {4, 2},
+				"writeLatency": {2, 1},
+			},
+		},
+	}
+	for name, tt := range cases {
+		name, tt := name, tt
+		t.Run(name, func(t *testing.T) {
+			c := &collector{metrics: Metrics(tt.casType)}
+			descs := map[string]*prometheus.Desc{
+				"readLatency":  c.readLatency,
+				"writeLatency": c.writeLatency,
+				"readSize":     c.readSize,
+				"writeSize":    c.writeSize,
+			}
+			volStats := volStats
+			volStats.casType = tt.casType
+			// summaries are built from the cumulative stats, so every
+			// scrape returns the same values
+			for scrape := 0; scrape < 2; scrape++ {
+				ch := make(chan prometheus.Metric, 20)
+				c.collectIO(ch, volStats)
+				close(ch)
+				got := map[*prometheus.Desc][2]float64{}
+				for m := range ch {
+					var out dto.Metric
+					if err := m.Write(&out); err != nil {
+						t.Fatalf("Test %q failed: %v", name, err)
+					}
+					if out.Summary == nil {
+						continue
+					}
+					got[m.Desc()] = [2]float64{
+						float64(out.Summary.GetSampleCount()), out.Summary.GetSampleSum()}
+				}
+				if len(got) != len(tt.expected) {
+					t.Fatalf("Test %q failed: expected summaries '%v': actual '%v'", name, tt.expected, got)
+				}
+				for metric, expected := range tt.expected {
+					if got[descs[metric]] != expected {
+						t.Fatalf("Test %q failed: expected %s count & sum '%v': actual '%v'",
+							name, metric, expected, got[descs[metric]])
+					}
+				}
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/golang/glog"
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
 	v1 "github.com/openebs/maya/pkg/stats/v1alpha1"
 	"github.com/pkg/errors"
 )
@@ -43,6 +44,9 @@ const (
 	Command = "IOSTATS"
 	// BufSize is the size of response from cstor read at one time.
 	BufSize = 1024
+	// ReplicaCommand is a command that is used to write over wire and
+	// get the status and io details of the replicas from the cstor.
+	ReplicaCommand = "REPLICA"
 )
 
 var (
@@ -158,6 +162,61 @@ func (c *cstor) readHeader() error {
 	return nil
 }
 
+// replicaReader reads the response of the replica command from the
+// socket and exits once the response ends with the status line i.e.
+// "OK REPLICA\r\n". It returns error if the status line is of any
+// other command.
+func (c *cstor) replicaReader() (string, error) {
+	buf := make([]byte, BufSize)
+	var buffer bytes.Buffer
+	for {
+		n, err := c.conn.Read(buf[:])
+		if err != nil {
+			return "", err
+		}
+		buffer.WriteString(string(buf[0:n]))
+		str := buffer.String()
+		if !strings.HasSuffix(str, EOF) {
+			continue
+		}
+		lines := strings.Split(strings.TrimSuffix(str, EOF), EOF)
+		last := lines[len(lines)-1]
+		if last == "OK "+ReplicaCommand {
+			return str, nil
+		}
+		if strings.HasPrefix(last, "OK ") || strings.HasPrefix(last, "ERR") {
+			return "", errors.Errorf("unexpected response: %s", last)
+		}
+	}
+}
+
+// getReplicaStatuses gets the status and io details of the replicas
+// connected to the cstor over the established connection.
+func (c *cstor) getReplicaStatuses() ([]apis.ReplicaStatus, error) {
+	c.conn.SetDeadline(time.Now().Add(5 * time.Second))
+	if _, err := c.conn.Write([]byte(ReplicaCommand + "\n")); err != nil {
+		return nil, err
+	}
+	resp, err := c.replicaReader()
+	if err != nil {
+		return nil, err
+	}
+	// response is of the form "REPLICA {json}\r\nOK REPLICA\r\n"
+	begin := strings.Index(resp, "{")
+	end := strings.LastIndex(resp, "}")
+	if begin < 0 || begin >= end {
+		return nil, errors.New("got empty replica status from cstor")
+	}
+	cvStatus := apis.CVStatusResponse{}
+	if err := json.Unmarshal([]byte(resp[begin:end+1]), &cvStatus); err != nil {
+		return nil, err
+	}
+	if len(cvStatus.CVStatuses) == 0 {
+		return nil, nil
+	}
+	return cvStatus.CVStatuses[0].ReplicaStatuses, nil
+}
+
 // removeItem removes the string passed as argument from the slice
 func (c *cstor) removeItem(slice []string, str string) []string {
 	for index, value := range slice {
@@ -247,6 +306,13 @@ func (c *cstor) get() (v1.VolumeStats, error) {
 		return v1.VolumeStats{}, err
 	}
 
+	// replica details are exported if available, the volume stats
+	// are exported even if these could not be fetched.
+	glog.V(2).Info("Request istgt to get replica status")
+	if stats.ReplicaStatuses, err = c.getReplicaStatuses(); err != nil {
+		glog.Warningf("failed to get replica status from istgt: %v", err)
+	}
+
 	stats.Got = true
 	return stats, nil
 }
@@ -282,6 +348,7 @@ func (c *cstor) parse(volStats v1.VolumeStats, metrics *metrics) stats {
 	volName := result[1]
 	stats.name = volName
 	stats.replicas = volStats.Replicas
+	stats.replicaStatuses = volStats.ReplicaStatuses
 	stats.status = volStats.TargetStatus
 	stats.iqn = volStats.Iqn
 	stats.address = "127.0.0.1"
@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 
 	"github.com/golang/glog"
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
 	v1 "github.com/openebs/maya/pkg/stats/v1alpha1"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -67,6 +68,24 @@ type metrics struct {
 	degradedReplicaCounter prometheus.Gauge
 	totalReplicaCounter    prometheus.Gauge
 	volumeUpTime           *prometheus.GaugeVec
+
+	// cumulative io counters of the volume
+	readsTotal      *prometheus.Desc
+	writesTotal     *prometheus.Desc
+	readBytesTotal  *prometheus.Desc
+	writeBytesTotal *prometheus.Desc
+
+	// cumulative io latency & size summaries of the volume
+	readLatency  *prometheus.Desc
+	writeLatency *prometheus.Desc
+	readSize     *prometheus.Desc
+	writeSize    *prometheus.Desc
+
+	// status & io details of each replica of the volume
+	replicaMode              *prometheus.GaugeVec
+	replicaInflightIO        *prometheus.GaugeVec
+	replicaCheckpointedIOSeq *prometheus.GaugeVec
+	replicaUpTime            *prometheus.GaugeVec
 }
 
 // stats keep the values of read/write I/O's and
@@ -94,6 +113,7 @@ type stats struct {
 	offlineReplicaCount  float64
 	name                 string
 	replicas             []v1.Replica
+	replicaStatuses      []apis.ReplicaStatus
 	status               v1.TargetMode
 	address              string
 }
@@ -250,9 +270,70 @@ func Metrics(cas string) metrics {
 				Help:      "Total no of replicas connected to cas",
 			},
 		),
+
+		readsTotal:      newVolumeDesc("reads_total", "Total no of read io's served by volume"),
+		writesTotal:     newVolumeDesc("writes_total", "Total no of write io's served by volume"),
+		readBytesTotal:  newVolumeDesc("read_bytes_total", "Total bytes read from volume"),
+		writeBytesTotal: newVolumeDesc("write_bytes_total", "Total bytes written to volume"),
+
+		readLatency: newVolumeDesc("read_latency_seconds",
+			"Latency of read io's served by volume in seconds"),
+		writeLatency: newVolumeDesc("write_latency_seconds",
+			"Latency of write io's served by volume in seconds"),
+		readSize: newVolumeDesc("read_size_bytes",
+			"Size of read io's served by volume in bytes"),
+		writeSize: newVolumeDesc("write_size_bytes",
+			"Size of write io's served by volume in bytes"),
+
+		replicaMode: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: "openebs",
+				Name:      "replica_mode",
+				Help:      "Mode of replica as reported by target, value is 1 for the current mode",
+			},
+			[]string{"volName", "replica", "mode"},
+		),
+
+		replicaInflightIO: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: "openebs",
+				Name:      "replica_inflight_io",
+				Help:      "No of io's sent to replica that are yet to complete",
+			},
+			[]string{"volName", "replica", "type"},
+		),
+
+		replicaCheckpointedIOSeq: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: "openebs",
+				Name:      "replica_checkpointed_io_seq",
+				Help:      "Last io sequence no checkpointed by replica",
+			},
+			[]string{"volName", "replica"},
+		),
+
+		replicaUpTime: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: "openebs",
+				Name:      "replica_uptime",
+				Help:      "Time since replica has connected to target in seconds",
+			},
+			[]string{"volName", "replica"},
+		),
 	}
 }
 
+// newVolumeDesc returns the descriptor of a cumulative counter
+// of the volume
+func newVolumeDesc(name, help string) *prometheus.Desc {
+	return prometheus.NewDesc(
+		prometheus.BuildFQName("openebs", "", name),
+		help,
+		[]string{"volName", "castype"},
+		nil,
+	)
+}
+
 func (v *stats) getReplicaCount() {
 	var (
 		ro, rw float64
@@ -16,6 +16,7 @@ package pool
 
 import (
 	"strconv"
+	"strings"
 
 	zpool "github.com/openebs/maya/pkg/zpool/v1alpha1"
 	"github.com/prometheus/client_golang/prometheus"
@@ -26,6 +27,8 @@ type metrics struct {
 	usedCapacity        prometheus.Gauge
 	freeCapacity        prometheus.Gauge
 	usedCapacityPercent prometheus.Gauge
+	fragmentation       prometheus.Gauge
+	dedupRatio          prometheus.Gauge
 	status              *prometheus.GaugeVec
 
 	zpoolCommandErrorCounter            prometheus.Gauge
@@ -42,6 +45,23 @@ type statsFloat64 struct {
 	used                float64
 	free                float64
 	usedCapacityPercent float64
+	fragmentation       float64
+	dedupRatio          float64
+}
+
+// statusMetrics are the metrics of the vdevs and the
+// scrub/resilver of the pool, from zpool status command
+type statusMetrics struct {
+	vdevStatus         *prometheus.GaugeVec
+	vdevReadErrors     *prometheus.GaugeVec
+	vdevWriteErrors    *prometheus.GaugeVec
+	vdevChecksumErrors *prometheus.GaugeVec
+	scanInProgress     *prometheus.GaugeVec
+	scanPercentDone    *prometheus.GaugeVec
+	scanETA            *prometheus.GaugeVec
+
+	zpoolStatusCommandErrorCounter prometheus.Gauge
+	zpoolStatusParseErrorCounter   prometheus.Gauge
 }
 
 // List returns list of type float64 of various stats
@@ -53,6 +73,8 @@ func (s *statsFloat64) List() []float64 {
 		s.used,
 		s.free,
 		s.usedCapacityPercent,
+		s.fragmentation,
+		s.dedupRatio,
 	}
 }
 
@@ -70,6 +92,17 @@ func (s *statsFloat64) parse(stats zpool.Stats, p *pool) {
 	s.free = parseFloat64(stats.Free, p.metrics)
 	s.status = zpool.Status[stats.Status]
 	s.usedCapacityPercent = parseFloat64(stats.UsedCapacityPercent, p.metrics)
+	s.fragmentation = parseOptionalFloat64(stats.Fragmentation, p.metrics)
+	s.dedupRatio = parseOptionalFloat64(stats.DedupRatio, p.metrics)
+}
+
+// parseOptionalFloat64 parses the stats that zpool list command
+// reports as - if these are not applicable for the pool
+func parseOptionalFloat64(e string, m *metrics) float64 {
+	if e == "-" {
+		return 0
+	}
+	return parseFloat64(strings.TrimSuffix(e, "x"), m)
 }
 
 func (m *metrics) withSize() *metrics {
@@ -193,6 +226,122 @@ func (m *metrics) withInitializeLibuzfsClientErrorCounter() *metrics {
 	return m
 }
 
+func (m *metrics) withFragmentation() *metrics {
+	m.fragmentation = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "openebs",
+			Name:      "pool_fragmentation_percent",
+			Help:      "Fragmentation of free space in pool in percent",
+		},
+	)
+	return m
+}
+
+func (m *metrics) withDedupRatio() *metrics {
+	m.dedupRatio = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "openebs",
+			Name:      "pool_dedup_ratio",
+			Help:      "Deduplication ratio of pool",
+		},
+	)
+	return m
+}
+
 func newMetrics() *metrics {
 	return new(metrics)
 }
+
+func newStatusMetrics() *statusMetrics {
+	return new(statusMetrics)
+}
+
+func (m *statusMetrics) withVdevStatus() *statusMetrics {
+	m.vdevStatus = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "openebs",
+			Name:      "pool_vdev_status",
+			Help:      `Status of vdev of pool (0, 1, 2, 3, 4, 5)= {"Offline", "Online", "Degraded", "Faulted", "Removed", "Unavail"}`,
+		},
+		[]string{"pool", "vdev"},
+	)
+	return m
+}
+
+func (m *statusMetrics) withVdevErrors() *statusMetrics {
+	m.vdevReadErrors = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "openebs",
+			Name:      "pool_vdev_read_errors",
+			Help:      "No of read errors of vdev of pool",
+		},
+		[]string{"pool", "vdev"},
+	)
+	m.vdevWriteErrors = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "openebs",
+			Name:      "pool_vdev_write_errors",
+			Help:      "No of write errors of vdev of pool",
+		},
+		[]string{"pool", "vdev"},
+	)
+	m.vdevChecksumErrors = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "openebs",
+			Name:      "pool_vdev_checksum_errors",
+			Help:      "No of checksum errors of vdev of pool",
+		},
+		[]string{"pool", "vdev"},
+	)
+	return m
+}
+
+func (m *statusMetrics) withScan() *statusMetrics {
+	m.scanInProgress = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "openebs",
+			Name:      "pool_scan_in_progress",
+			Help:      "Scrub or resilver of pool is in progress, value is 1 if in progress",
+		},
+		[]string{"pool", "function"},
+	)
+	m.scanPercentDone = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "openebs",
+			Name:      "pool_scan_progress_percent",
+			Help:      "Progress of ongoing scrub or resilver of pool in percent",
+		},
+		[]string{"pool", "function"},
+	)
+	m.scanETA = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "openebs",
+			Name:      "pool_scan_eta_seconds",
+			Help:      "Estimated time to complete ongoing scrub or resilver of pool in seconds",
+		},
+		[]string{"pool", "function"},
+	)
+	return m
+}
+
+func (m *statusMetrics) withCommandErrorCounter() *statusMetrics {
+	m.zpoolStatusCommandErrorCounter = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "openebs",
+			Name:      "zpool_status_command_error",
+			Help:      "Total no of zpool status command errors",
+		},
+	)
+	return m
+}
+
+func (m *statusMetrics) withParseErrorCounter() *statusMetrics {
+	m.zpoolStatusParseErrorCounter = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "openebs",
+			Name:      "zpool_status_parse_error_count",
+			Help:      "Total no of parsing errors of zpool status command",
+		},
+	)
+	return m
+}
@@ -42,6 +42,8 @@ func New(runner types.Runner) col.Collector {
 			withUsedCapacity().
 			withFreeCapacity().
 			withUsedCapacityPercent().
+			withFragmentation().
+			withDedupRatio().
 			withParseErrorCounter().
 			withRejectRequestCounter().
 			withCommandErrorCounter().
@@ -70,6 +72,8 @@ func (p *pool) collectors() []prometheus.Collector {
 		p.usedCapacity,
 		p.freeCapacity,
 		p.usedCapacityPercent,
+		p.fragmentation,
+		p.dedupRatio,
 		p.zpoolCommandErrorCounter,
 		p.zpoolRejectRequestCounter,
 		p.zpoolListParseErrorCounter,
@@ -89,6 +93,8 @@ func (p *pool) gaugeVec() []prometheus.Gauge {
 		p.usedCapacity,
 		p.freeCapacity,
 		p.usedCapacityPercent,
+		p.fragmentation,
+		p.dedupRatio,
 	}
 }
 
@@ -42,6 +42,24 @@ func TestPoolCollector(t *testing.T) {
 				`openebs_used_pool_capacity_percent 0`,
 			},
 		},
+		// pool is fragmented and deduplicated
+		"Test10": {
+			zpoolOutput: "cstor-5ce4639a-2dc1-11e9-bbe3-42010a80017a	1024	24	1000	-	12	2	1.50 ONLINE	-",
+			expectedOutput: []string{
+				`openebs_pool_fragmentation_percent 12`,
+				`openebs_pool_dedup_ratio 1.5`,
+				`openebs_zpool_list_parse_error_count 0`,
+			},
+		},
+		// fragmentation is not applicable for pool
+		"Test11": {
+			zpoolOutput: "cstor-5ce4639a-2dc1-11e9-bbe3-42010a80017a	1024	24	1000	-	-	2	1.00 ONLINE	-",
+			expectedOutput: []string{
+				`openebs_pool_fragmentation_percent 0`,
+				`openebs_pool_dedup_ratio 1`,
+				`openebs_zpool_list_parse_error_count 0`,
+			},
+		},
 		// pool status is offline
 		"Test1": {
 			zpoolOutput: "cstor-5ce4639a-2dc1-11e9-bbe3-42010a80017a	1024	24	1000	-	0	0	1.00 OFFLINE	-",
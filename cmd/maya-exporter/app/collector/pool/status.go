@@ -0,0 +1,158 @@
+// Copyright © 2019 The OpenEBS Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pool
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/golang/glog"
+	col "github.com/openebs/maya/cmd/maya-exporter/app/collector"
+	types "github.com/openebs/maya/pkg/exec"
+	zpool "github.com/openebs/maya/pkg/zpool/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// status implements prometheus.Collector interface. It exports
+// the health & errors of the vdevs of the pool and the progress
+// of scrub/resilver of the pool from zpool status command.
+type status struct {
+	sync.Mutex
+	*statusMetrics
+	runner types.Runner
+}
+
+// NewStatus returns new instance of status collector
+func NewStatus(runner types.Runner) col.Collector {
+	return &status{
+		statusMetrics: newStatusMetrics().
+			withVdevStatus().
+			withVdevErrors().
+			withScan().
+			withCommandErrorCounter().
+			withParseErrorCounter(),
+		runner: runner,
+	}
+}
+
+// collectors returns the list of the collectors
+func (s *status) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		s.vdevStatus,
+		s.vdevReadErrors,
+		s.vdevWriteErrors,
+		s.vdevChecksumErrors,
+		s.scanInProgress,
+		s.scanPercentDone,
+		s.scanETA,
+		s.zpoolStatusCommandErrorCounter,
+		s.zpoolStatusParseErrorCounter,
+	}
+}
+
+// Describe is implementation of Describe method of prometheus.Collector
+// interface.
+func (s *status) Describe(ch chan<- *prometheus.Desc) {
+	for _, col := range s.collectors() {
+		col.Describe(ch)
+	}
+}
+
+// Collect is implementation of prometheus's prometheus.Collector interface
+func (s *status) Collect(ch chan<- prometheus.Metric) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.reset()
+	glog.V(2).Info("Run zpool status command")
+	stdout, err := zpool.Run(s.runner)
+	if err != nil {
+		glog.Errorf("failed to run zpool status command: %v", err)
+		s.zpoolStatusCommandErrorCounter.Inc()
+	} else if zpool.IsNotAvailable(string(stdout)) || zpool.IsNotInitialized(string(stdout)) {
+		// these errors are counted by the collector of zpool list
+		glog.V(2).Infof("No pool to get status of, stdout: %v", string(stdout))
+	} else if poolStatus, err := zpool.StatusParser(stdout); err != nil {
+		glog.Errorf("failed to parse stdout of zpool status command: %v, stdout: %v",
+			err, string(stdout))
+		s.zpoolStatusParseErrorCounter.Inc()
+	} else {
+		glog.V(2).Infof("Got zpool status: %#v", poolStatus)
+		s.set(poolStatus)
+	}
+	for _, col := range s.collectors() {
+		col.Collect(ch)
+	}
+}
+
+// reset removes the values of the previous scrape so that
+// the vdevs that have been removed from pool are not reported
+func (s *status) reset() {
+	for _, vec := range []*prometheus.GaugeVec{
+		s.vdevStatus,
+		s.vdevReadErrors,
+		s.vdevWriteErrors,
+		s.vdevChecksumErrors,
+		s.scanInProgress,
+		s.scanPercentDone,
+		s.scanETA,
+	} {
+		vec.Reset()
+	}
+}
+
+func (s *status) set(poolStatus zpool.PoolStatus) {
+	name := poolStatus.Name
+	for _, vdev := range poolStatus.AllVdevs() {
+		if !vdev.HasErrorCounts {
+			// spares & the vdevs grouping logs, cache & spares
+			continue
+		}
+		vdevStatus, ok := zpool.Status[vdev.Status]
+		if !ok {
+			glog.Warningf("Unknown status {%s} of vdev {%s}", vdev.Status, vdev.Name)
+			vdevStatus = zpool.Status[zpool.Unavail]
+		}
+		s.vdevStatus.WithLabelValues(name, vdev.Name).Set(vdevStatus)
+		s.vdevReadErrors.WithLabelValues(name, vdev.Name).Set(float64(vdev.ReadErrors))
+		s.vdevWriteErrors.WithLabelValues(name, vdev.Name).Set(float64(vdev.WriteErrors))
+		s.vdevChecksumErrors.WithLabelValues(name, vdev.Name).Set(float64(vdev.ChecksumErrors))
+	}
+
+	scan := poolStatus.Scan
+	for _, function := range []string{zpool.ScanScrub, zpool.ScanResilver} {
+		inProgress := 0.0
+		if scan.InProgress && scan.Function == function {
+			inProgress = 1
+		}
+		s.scanInProgress.WithLabelValues(name, function).Set(inProgress)
+	}
+	if !scan.InProgress {
+		return
+	}
+	if scan.PercentDone != "" {
+		s.scanPercentDone.WithLabelValues(name, scan.Function).
+			Set(s.parseFloat64(scan.PercentDone))
+	}
+	s.scanETA.WithLabelValues(name, scan.Function).Set(scan.ETA.Seconds())
+}
+
+func (s *status) parseFloat64(e string) float64 {
+	num, err := strconv.ParseFloat(e, 64)
+	if err != nil {
+		s.zpoolStatusParseErrorCounter.Inc()
+	}
+	return num
+}
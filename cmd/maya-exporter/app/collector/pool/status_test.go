@@ -0,0 +1,145 @@
+// Copyright © 2019 The OpenEBS Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pool
+
+import (
+	"fmt"
+	"testing"
+
+	types "github.com/openebs/maya/pkg/exec"
+	mock "github.com/openebs/maya/pkg/exec/mock/v1alpha1"
+	mockServer "github.com/openebs/maya/pkg/prometheus/exporter/mock/v1alpha1"
+)
+
+const (
+	statusOutputScrub = `  pool: cstor-pool1
+ state: DEGRADED
+  scan: scrub in progress since Mon Jun 10 10:00:00 2019
+	1.23G scanned out of 10.0G at 100M/s, 1h30m to go
+	0 repaired, 12.30% done
+config:
+
+	NAME                  STATE     READ WRITE CKSUM
+	cstor-pool1           DEGRADED     0     0     0
+	  mirror-0            DEGRADED     0     0     0
+	    /dev/sdb          ONLINE       0     0     0
+	    /dev/sdc          FAULTED      3     1  1.2K
+
+errors: No known data errors`
+
+	statusOutputResilver = `  pool: cstor-pool1
+ state: ONLINE
+  scan: resilver in progress since Mon Jun 10 10:00:00 2019
+	1.23G scanned at 100M/s, 1.00G issued at 90M/s, 10.0G total
+	1.00G resilvered, 50.00% done, 0 days 00:02:30 to go
+config:
+
+	NAME                  STATE     READ WRITE CKSUM
+	cstor-pool1           ONLINE       0     0     0
+	  /dev/sdb            ONLINE       0     0     0
+	spares
+	  /dev/sdd            AVAIL
+
+errors: No known data errors`
+
+	statusOutputScrubDone = `  pool: cstor-pool1
+ state: ONLINE
+  scan: scrub repaired 0 in 0h0m with 0 errors on Sun Jun  9 00:24:01 2019
+config:
+
+	NAME                  STATE     READ WRITE CKSUM
+	cstor-pool1           ONLINE       0     0     0
+	  /dev/sdb            ONLINE       0     0     0
+
+errors: No known data errors`
+)
+
+func TestStatusCollector(t *testing.T) {
+	var runner types.Runner
+	cases := map[string]struct {
+		zpoolOutput    string
+		isError        bool
+		expectedOutput []string
+	}{
+		"scrub in progress with faulted vdev": {
+			zpoolOutput: statusOutputScrub,
+			expectedOutput: []string{
+				`openebs_pool_vdev_status{pool="cstor-pool1",vdev="cstor-pool1"} 2`,
+				`openebs_pool_vdev_status{pool="cstor-pool1",vdev="mirror-0"} 2`,
+				`openebs_pool_vdev_status{pool="cstor-pool1",vdev="/dev/sdb"} 1`,
+				`openebs_pool_vdev_status{pool="cstor-pool1",vdev="/dev/sdc"} 3`,
+				`openebs_pool_vdev_read_errors{pool="cstor-pool1",vdev="/dev/sdc"} 3`,
+				`openebs_pool_vdev_write_errors{pool="cstor-pool1",vdev="/dev/sdc"} 1`,
+				`openebs_pool_vdev_checksum_errors{pool="cstor-pool1",vdev="/dev/sdc"} 1228`,
+				`openebs_pool_scan_in_progress{function="scrub",pool="cstor-pool1"} 1`,
+				`openebs_pool_scan_in_progress{function="resilver",pool="cstor-pool1"} 0`,
+				`openebs_pool_scan_progress_percent{function="scrub",pool="cstor-pool1"} 12.3`,
+				`openebs_pool_scan_eta_seconds{function="scrub",pool="cstor-pool1"} 5400`,
+				`openebs_zpool_status_parse_error_count 0`,
+			},
+		},
+		"resilver in progress": {
+			zpoolOutput: statusOutputResilver,
+			expectedOutput: []string{
+				`openebs_pool_vdev_status{pool="cstor-pool1",vdev="/dev/sdb"} 1`,
+				`openebs_pool_scan_in_progress{function="resilver",pool="cstor-pool1"} 1`,
+				`openebs_pool_scan_progress_percent{function="resilver",pool="cstor-pool1"} 50`,
+				`openebs_pool_scan_eta_seconds{function="resilver",pool="cstor-pool1"} 150`,
+			},
+		},
+		"scrub completed": {
+			zpoolOutput: statusOutputScrubDone,
+			expectedOutput: []string{
+				`openebs_pool_vdev_status{pool="cstor-pool1",vdev="/dev/sdb"} 1`,
+				`openebs_pool_scan_in_progress{function="scrub",pool="cstor-pool1"} 0`,
+			},
+		},
+		"incomplete stdout of zpool status command": {
+			zpoolOutput: "  pool: cstor-pool1\n state: ONLINE",
+			expectedOutput: []string{
+				`openebs_zpool_status_parse_error_count 1`,
+			},
+		},
+		"error while running zpool status command": {
+			isError: true,
+			expectedOutput: []string{
+				`openebs_zpool_status_command_error 1`,
+			},
+		},
+	}
+
+	for name, tt := range cases {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			if tt.isError {
+				runner = mock.StdoutBuilder().Error().Build()
+			} else {
+				runner = mock.StdoutBuilder().WithOutput(tt.zpoolOutput).Build()
+			}
+			regex := mockServer.BuildRegex(tt.expectedOutput)
+			status := NewStatus(runner)
+			stop := make(chan struct{})
+			buf := mockServer.PrometheusService(status, stop)
+			for _, re := range regex {
+				if !re.Match(buf) {
+					fmt.Println(string(buf))
+					t.Errorf("failed expectedOutputing: %q", re)
+				}
+			}
+			mockServer.Unregister(status)
+			stop <- struct{}{}
+		})
+	}
+}
@@ -22,10 +22,14 @@ import (
 
 	"github.com/golang/glog"
 	"github.com/openebs/maya/cmd/maya-exporter/app/collector"
+	"github.com/openebs/maya/cmd/maya-exporter/app/collector/cluster"
 	"github.com/openebs/maya/cmd/maya-exporter/app/collector/pool"
 	"github.com/openebs/maya/cmd/maya-exporter/app/collector/zvol"
+	clientset "github.com/openebs/maya/pkg/client/generated/clientset/versioned"
+	informers "github.com/openebs/maya/pkg/client/generated/informers/externalversions"
 	types "github.com/openebs/maya/pkg/exec"
 	exec "github.com/openebs/maya/pkg/exec/v1alpha1"
+	kclient "github.com/openebs/maya/pkg/kubernetes/client/v1alpha1"
 	"github.com/openebs/maya/pkg/util"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/cobra"
@@ -48,6 +52,9 @@ const (
 	casType = "jiva"
 	// timeout is the timeout for executing a command
 	timeout = 30 * time.Second
+	// resyncPeriod is the interval at which the informers of
+	// the cluster exporter resync the openebs resources
+	resyncPeriod = 30 * time.Second
 )
 
 // VolumeExporterOptions is used to create flags for the monitoring command
@@ -81,7 +88,7 @@ func AddControllerAddressFlag(cmd *cobra.Command, value *string) {
 // AddCASTypeFlag is used to create flag to pass the storage engine name
 func AddCASTypeFlag(cmd *cobra.Command, value *string) {
 	cmd.Flags().StringVarP(value, "cas.type", "e", *value,
-		"Type of container attached storage engine")
+		"Type of container attached storage engine i.e. jiva, cstor, pool or cluster")
 }
 
 // NewCmdVolumeExporter is used to create command monitoring and it initialize
@@ -97,7 +104,9 @@ func NewCmdVolumeExporter() (*cobra.Command, error) {
 	cmd := &cobra.Command{
 		Short: "Collect metrics from OpenEBS volumes",
 		Long: `maya-exporter can be used to monitor openebs volumes and pools.
-It can be deployed alongside the openebs volume or pool containers as sidecars.`,
+It can be deployed alongside the openebs volume or pool containers as sidecars.
+With cas type cluster, it exports the status of all the cstor volumes, replicas
+and pools of the kubernetes cluster from a single deployment.`,
 		Example: `maya-exporter -a=http://localhost:8001 -c=:9500 -m=/metrics`,
 		Run: func(cmd *cobra.Command, args []string) {
 			util.CheckErr(Run(cmd, &options), util.Fatal)
@@ -110,6 +119,7 @@ It can be deployed alongside the openebs volume or pool containers as sidecars.`
 	AddListenAddressFlag(cmd, &options.ListenAddress)
 	AddMetricsPathFlag(cmd, &options.MetricsPath)
 	AddCASTypeFlag(cmd, &options.CASType)
+	cmd.AddCommand(NewCmdGenerate())
 	return cmd, nil
 }
 
@@ -130,6 +140,11 @@ func Run(cmd *cobra.Command, options *VolumeExporterOptions) error {
 	case "pool":
 		glog.Infof("Initialising maya-exporter for the cstor pool")
 		options.RegisterPool()
+	case "cluster":
+		glog.Infof("Initialising maya-exporter for the cluster")
+		if err := options.RegisterCluster(); err != nil {
+			return err
+		}
 	default:
 		return errors.New("unsupported CAS")
 	}
@@ -168,13 +183,43 @@ func (o *VolumeExporterOptions) RegisterCstor() {
 // pool level metrics
 func (o *VolumeExporterOptions) RegisterPool() {
 	p := pool.New(buildRunner(timeout, "zpool", "list", "-Hp"))
+	s := pool.NewStatus(buildRunner(timeout, "zpool", "status"))
 	z := zvol.New(buildRunner(timeout, "zfs", "stats"))
 	l := zvol.NewVolumeList(buildRunner(timeout, "zfs", "list", "-Hp"))
-	prometheus.MustRegister(p, z, l)
+	prometheus.MustRegister(p, s, z, l)
 	glog.Info("Registered maya exporter for cstor pool")
 	return
 }
 
+// RegisterCluster registers cluster collector which collects the
+// status of the openebs custom resources of the kubernetes cluster.
+// It returns error if the informers of these resources could not
+// be synced.
+func (o *VolumeExporterOptions) RegisterCluster() error {
+	config, err := kclient.New().Config()
+	if err != nil {
+		return errors.New("failed to get kubernetes config: " + err.Error())
+	}
+	cs, err := clientset.NewForConfig(config)
+	if err != nil {
+		return errors.New("failed to build openebs clientset: " + err.Error())
+	}
+	factory := informers.NewSharedInformerFactory(cs, resyncPeriod)
+	c := cluster.New(factory)
+
+	// informers run for the lifetime of the exporter
+	stopCh := make(chan struct{})
+	factory.Start(stopCh)
+	for informer, synced := range factory.WaitForCacheSync(stopCh) {
+		if !synced {
+			return errors.New("failed to sync informer cache of " + informer.String())
+		}
+	}
+	prometheus.MustRegister(c)
+	glog.Info("Registered maya exporter for cluster")
+	return nil
+}
+
 func buildRunner(timeout time.Duration, cmd string, args ...string) types.Runner {
 	return exec.StdoutBuilder().
 		WithTimeout(timeout).
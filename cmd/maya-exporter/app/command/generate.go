@@ -0,0 +1,149 @@
+// Copyright © 2019 The OpenEBS Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/glog"
+	"github.com/openebs/maya/cmd/maya-exporter/app/collector"
+	"github.com/openebs/maya/cmd/maya-exporter/app/collector/cluster"
+	"github.com/openebs/maya/cmd/maya-exporter/app/collector/pool"
+	"github.com/openebs/maya/cmd/maya-exporter/app/collector/zvol"
+	"github.com/openebs/maya/cmd/maya-exporter/app/generator"
+	informers "github.com/openebs/maya/pkg/client/generated/informers/externalversions"
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+	"github.com/openebs/maya/pkg/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// alertsFile is the name of the file of the generated
+	// alerting rules
+	alertsFile = "openebs-alerts.yaml"
+)
+
+// GenerateOptions is used to create flags for the generate command
+type GenerateOptions struct {
+	OutputDir string
+}
+
+// dashboardGroup is a group of collectors that are deployed
+// together & hence shown in the same dashboard
+type dashboardGroup struct {
+	uid        string
+	title      string
+	collectors []prometheus.Collector
+}
+
+// NewCmdGenerate returns the command that generates the alerting
+// rules & dashboards from the metrics of the collectors
+func NewCmdGenerate() *cobra.Command {
+	options := GenerateOptions{OutputDir: "."}
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate prometheus alerting rules and grafana dashboards",
+		Long: `generate writes the prometheus alerting rules as a PrometheusRule of
+prometheus operator and a grafana dashboard for each of volume, pool and cluster
+exporters to the output directory. These are generated from the metrics of the
+collectors, it fails if an alerting rule uses a metric that is not exported.`,
+		Example: `maya-exporter generate --output-dir=/tmp/openebs-monitoring`,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(options.Run(), util.Fatal)
+		},
+	}
+	cmd.Flags().StringVarP(&options.OutputDir, "output-dir", "o", options.OutputDir,
+		"Directory to write the alerting rules and dashboards to")
+	return cmd
+}
+
+// dashboardGroups returns the collectors of each of the exporters.
+// The collectors are only described, so these are not connected
+// to the storage engines or the kubernetes cluster.
+func dashboardGroups() []dashboardGroup {
+	// volume collector exports the same metrics for jiva & cstor
+	jiva := collector.Jiva(&url.URL{})
+	return []dashboardGroup{
+		{
+			uid:        "openebs-volume",
+			title:      "OpenEBS Volume",
+			collectors: []prometheus.Collector{collector.New(jiva)},
+		},
+		{
+			uid:   "openebs-pool",
+			title: "OpenEBS Pool",
+			collectors: []prometheus.Collector{
+				pool.New(nil),
+				pool.NewStatus(nil),
+				zvol.New(nil),
+				zvol.NewVolumeList(nil),
+			},
+		},
+		{
+			uid:   "openebs-cluster",
+			title: "OpenEBS Cluster",
+			collectors: []prometheus.Collector{
+				cluster.New(informers.NewSharedInformerFactory(nil, resyncPeriod)),
+			},
+		},
+	}
+}
+
+// Run generates the alerting rules & dashboards
+func (o *GenerateOptions) Run() error {
+	var all []prometheus.Collector
+	for _, g := range dashboardGroups() {
+		metrics, err := generator.Describe(g.collectors...)
+		if err != nil {
+			return err
+		}
+		data, err := json.MarshalIndent(generator.NewDashboard(g.uid, g.title, metrics), "", "  ")
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal dashboard {%s}", g.uid)
+		}
+		if err := o.write(g.uid+"-dashboard.json", data); err != nil {
+			return err
+		}
+		all = append(all, g.collectors...)
+	}
+
+	metrics, err := generator.Describe(all...)
+	if err != nil {
+		return err
+	}
+	rules, err := generator.Rules(metrics)
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(rules)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal alerting rules")
+	}
+	return o.write(alertsFile, data)
+}
+
+func (o *GenerateOptions) write(name string, data []byte) error {
+	path := filepath.Join(o.OutputDir, name)
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return errors.Wrapf(err, "failed to write {%s}", path)
+	}
+	glog.Infof("Generated %s", path)
+	return nil
+}
@@ -0,0 +1,47 @@
+// Copyright © 2019 The OpenEBS Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestGenerate fails if an alerting rule uses a metric that
+// is no longer exported by the collectors
+func TestGenerate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "maya-exporter-generate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	options := GenerateOptions{OutputDir: dir}
+	if err := options.Run(); err != nil {
+		t.Fatalf("failed to generate alerting rules and dashboards: %v", err)
+	}
+	for _, file := range []string{
+		alertsFile,
+		"openebs-volume-dashboard.json",
+		"openebs-pool-dashboard.json",
+		"openebs-cluster-dashboard.json",
+	} {
+		if _, err := os.Stat(filepath.Join(dir, file)); err != nil {
+			t.Errorf("expected file {%s} to be generated: %v", file, err)
+		}
+	}
+}
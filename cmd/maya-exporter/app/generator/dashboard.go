@@ -0,0 +1,176 @@
+// Copyright © 2019 The OpenEBS Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// datasource is the grafana datasource variable that is
+	// replaced by the prometheus datasource on import
+	datasource = "${DS_PROMETHEUS}"
+	// rateInterval is the range of the rate queries
+	rateInterval = "5m"
+	// panelHeight & panelWidth are in grafana grid units,
+	// the grid is 24 units wide
+	panelHeight = 8
+	panelWidth  = 12
+)
+
+// summaries are the metrics exported as prometheus summaries
+// without quantiles, these are shown as the average over the
+// rate interval in the dashboards
+var summaries = map[string]bool{
+	"openebs_read_latency_seconds":  true,
+	"openebs_write_latency_seconds": true,
+	"openebs_read_size_bytes":       true,
+	"openebs_write_size_bytes":      true,
+}
+
+// Dashboard is a grafana dashboard
+type Dashboard struct {
+	UID           string      `json:"uid"`
+	Title         string      `json:"title"`
+	Tags          []string    `json:"tags"`
+	Editable      bool        `json:"editable"`
+	SchemaVersion int         `json:"schemaVersion"`
+	Refresh       string      `json:"refresh"`
+	Time          TimeRange   `json:"time"`
+	Panels        []Panel     `json:"panels"`
+	Inputs        []Input     `json:"__inputs"`
+	Templating    interface{} `json:"templating"`
+}
+
+// TimeRange is the default time range of dashboard
+type TimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Input is a datasource that is selected on import of dashboard
+type Input struct {
+	Name     string `json:"name"`
+	Label    string `json:"label"`
+	Type     string `json:"type"`
+	PluginID string `json:"pluginId"`
+}
+
+// Panel is a graph panel of dashboard
+type Panel struct {
+	ID          int      `json:"id"`
+	Type        string   `json:"type"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Datasource  string   `json:"datasource"`
+	GridPos     GridPos  `json:"gridPos"`
+	Targets     []Target `json:"targets"`
+}
+
+// GridPos is the position of panel in dashboard
+type GridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// Target is a query of panel
+type Target struct {
+	Expr         string `json:"expr"`
+	LegendFormat string `json:"legendFormat"`
+	RefID        string `json:"refId"`
+}
+
+// NewDashboard returns a dashboard with a graph panel for each
+// of the given metrics
+func NewDashboard(uid, title string, metrics MetricList) *Dashboard {
+	d := &Dashboard{
+		UID:           uid,
+		Title:         title,
+		Tags:          []string{"openebs"},
+		Editable:      true,
+		SchemaVersion: 16,
+		Refresh:       "30s",
+		Time:          TimeRange{From: "now-1h", To: "now"},
+		Inputs: []Input{{
+			Name:     "DS_PROMETHEUS",
+			Label:    "Prometheus",
+			Type:     "datasource",
+			PluginID: "prometheus",
+		}},
+		Templating: map[string]interface{}{"list": []interface{}{}},
+	}
+	for i, m := range metrics {
+		d.Panels = append(d.Panels, Panel{
+			ID:          i + 1,
+			Type:        "graph",
+			Title:       panelTitle(m),
+			Description: m.Help,
+			Datasource:  datasource,
+			GridPos: GridPos{
+				H: panelHeight,
+				W: panelWidth,
+				X: (i % 2) * panelWidth,
+				Y: (i / 2) * panelHeight,
+			},
+			Targets: []Target{{
+				Expr:         query(m),
+				LegendFormat: legend(m),
+				RefID:        "A",
+			}},
+		})
+	}
+	return d
+}
+
+func panelTitle(m Metric) string {
+	title := strings.TrimPrefix(m.Name, "openebs_")
+	if summaries[m.Name] {
+		title = "average " + title
+	} else if strings.HasSuffix(m.Name, "_total") {
+		title = "rate of " + title
+	}
+	return strings.Replace(title, "_", " ", -1)
+}
+
+// query returns the promql to graph the metric. Counters are
+// graphed as per second rate & summaries as the average of the
+// observations made in the rate interval.
+func query(m Metric) string {
+	switch {
+	case summaries[m.Name]:
+		return fmt.Sprintf("rate(%s_sum[%s]) / rate(%s_count[%s])",
+			m.Name, rateInterval, m.Name, rateInterval)
+	case strings.HasSuffix(m.Name, "_total"):
+		return fmt.Sprintf("rate(%s[%s])", m.Name, rateInterval)
+	default:
+		return m.Name
+	}
+}
+
+// legend returns the legend of the series of the metric, metrics
+// without labels are exported one per exporter instance
+func legend(m Metric) string {
+	if len(m.Labels) == 0 {
+		return "{{instance}}"
+	}
+	var l []string
+	for _, label := range m.Labels {
+		l = append(l, "{{"+label+"}}")
+	}
+	return strings.Join(l, " ")
+}
@@ -0,0 +1,123 @@
+// Copyright © 2019 The OpenEBS Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestDescribe(t *testing.T) {
+	cases := map[string]struct {
+		collectors []prometheus.Collector
+		expected   MetricList
+	}{
+		"metrics are sorted by name": {
+			collectors: []prometheus.Collector{
+				prometheus.NewGaugeVec(prometheus.GaugeOpts{
+					Namespace: "openebs", Name: "pool_status", Help: `Status of "pool"`,
+				}, []string{"pool"}),
+				prometheus.NewGauge(prometheus.GaugeOpts{
+					Namespace: "openebs", Name: "actual_used", Help: "Actual volume size used",
+				}),
+			},
+			expected: MetricList{
+				{Name: "openebs_actual_used", Help: "Actual volume size used"},
+				{Name: "openebs_pool_status", Help: `Status of "pool"`, Labels: []string{"pool"}},
+			},
+		},
+		"duplicate metrics are described once": {
+			collectors: []prometheus.Collector{
+				prometheus.NewGauge(prometheus.GaugeOpts{Name: "reads", Help: "Read Input/Outputs on Volume"}),
+				prometheus.NewGauge(prometheus.GaugeOpts{Name: "reads", Help: "Read Input/Outputs on Volume"}),
+			},
+			expected: MetricList{
+				{Name: "reads", Help: "Read Input/Outputs on Volume"},
+			},
+		},
+	}
+	for name, tt := range cases {
+		name, tt := name, tt
+		t.Run(name, func(t *testing.T) {
+			got, err := Describe(tt.collectors...)
+			if err != nil {
+				t.Fatalf("Test %q failed: unexpected error: %v", name, err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Fatalf("Test %q failed: expected '%#v': actual '%#v'", name, tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRules(t *testing.T) {
+	var all MetricList
+	for _, a := range alerts {
+		for _, m := range a.metrics {
+			all = append(all, Metric{Name: m})
+		}
+	}
+	cases := map[string]struct {
+		metrics MetricList
+		isErr   bool
+	}{
+		"all metrics are exported": {metrics: all},
+		"metrics are not exported": {metrics: all[1:], isErr: true},
+		"no metrics are exported":  {isErr: true},
+	}
+	for name, tt := range cases {
+		name, tt := name, tt
+		t.Run(name, func(t *testing.T) {
+			rules, err := Rules(tt.metrics)
+			if tt.isErr != (err != nil) {
+				t.Fatalf("Test %q failed: expected error '%t': actual error '%v'", name, tt.isErr, err)
+			}
+			if !tt.isErr && len(rules.Spec.Groups[0].Rules) != len(alerts) {
+				t.Fatalf("Test %q failed: expected '%d' rules: actual '%d'",
+					name, len(alerts), len(rules.Spec.Groups[0].Rules))
+			}
+		})
+	}
+}
+
+func TestQuery(t *testing.T) {
+	cases := map[string]struct {
+		metric   Metric
+		expected string
+	}{
+		"gauge": {
+			metric:   Metric{Name: "openebs_pool_size"},
+			expected: "openebs_pool_size",
+		},
+		"counter": {
+			metric:   Metric{Name: "openebs_reads_total", Labels: []string{"volName"}},
+			expected: "rate(openebs_reads_total[5m])",
+		},
+		"summary": {
+			metric:   Metric{Name: "openebs_read_latency_seconds", Labels: []string{"volName"}},
+			expected: "rate(openebs_read_latency_seconds_sum[5m]) / rate(openebs_read_latency_seconds_count[5m])",
+		},
+	}
+	for name, tt := range cases {
+		name, tt := name, tt
+		t.Run(name, func(t *testing.T) {
+			if got := query(tt.metric); got != tt.expected {
+				t.Fatalf("Test %q failed: expected '%s': actual '%s'", name, tt.expected, got)
+			}
+		})
+	}
+}
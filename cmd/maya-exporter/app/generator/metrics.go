@@ -0,0 +1,120 @@
+// Copyright © 2019 The OpenEBS Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// descRegex matches the string representation of prometheus.Desc
+// e.g. Desc{fqName: "openebs_pool_size", help: "Size of pool",
+// constLabels: {}, variableLabels: [pool]}
+var descRegex = regexp.MustCompile(
+	`^Desc\{fqName: ("(?:[^"\\]|\\.)*"), help: ("(?:[^"\\]|\\.)*"), constLabels: \{.*\}, variableLabels: \[(.*)\]\}$`)
+
+// Metric is the definition of a metric exported by a collector
+type Metric struct {
+	Name   string
+	Help   string
+	Labels []string
+}
+
+// MetricList is the list of the metrics exported by a group of
+// collectors e.g. the collectors of the cstor pool
+type MetricList []Metric
+
+// Describe returns the definitions of the metrics described by
+// the given collectors. The collectors are not registered and
+// the metrics are not collected, so the collectors need not be
+// connected to the storage engine.
+func Describe(collectors ...prometheus.Collector) (MetricList, error) {
+	ch := make(chan *prometheus.Desc)
+	go func() {
+		for _, c := range collectors {
+			c.Describe(ch)
+		}
+		close(ch)
+	}()
+
+	var (
+		list MetricList
+		errs []string
+		seen = map[string]bool{}
+	)
+	for desc := range ch {
+		m, err := parseDesc(desc)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		if seen[m.Name] {
+			continue
+		}
+		seen[m.Name] = true
+		list = append(list, m)
+	}
+	if len(errs) != 0 {
+		return nil, errors.Errorf("failed to describe metrics: %s", strings.Join(errs, ", "))
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list, nil
+}
+
+func parseDesc(desc *prometheus.Desc) (Metric, error) {
+	match := descRegex.FindStringSubmatch(desc.String())
+	if match == nil {
+		return Metric{}, errors.Errorf("invalid descriptor {%s}", desc.String())
+	}
+	name, err := strconv.Unquote(match[1])
+	if err != nil {
+		return Metric{}, errors.Wrapf(err, "invalid name of descriptor {%s}", desc.String())
+	}
+	help, err := strconv.Unquote(match[2])
+	if err != nil {
+		return Metric{}, errors.Wrapf(err, "invalid help of descriptor {%s}", desc.String())
+	}
+	m := Metric{Name: name, Help: help}
+	if labels := strings.Fields(match[3]); len(labels) != 0 {
+		m.Labels = labels
+	}
+	return m, nil
+}
+
+// Get returns the metric with the given name
+func (l MetricList) Get(name string) (Metric, bool) {
+	for _, m := range l {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return Metric{}, false
+}
+
+// Missing returns the names that are not defined in the list
+func (l MetricList) Missing(names ...string) []string {
+	var missing []string
+	for _, name := range names {
+		if _, ok := l.Get(name); !ok {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,251 @@
+// Copyright © 2019 The OpenEBS Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"strings"
+
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+)
+
+const (
+	// ruleAPIVersion is the api version of PrometheusRule
+	// of prometheus operator
+	ruleAPIVersion = "monitoring.coreos.com/v1"
+	// ruleKind is the kind of PrometheusRule
+	ruleKind = "PrometheusRule"
+
+	severityWarning  = "warning"
+	severityCritical = "critical"
+)
+
+// PrometheusRule is the PrometheusRule custom resource of
+// prometheus operator. Its spec can also be used as a rule file
+// of prometheus.
+type PrometheusRule struct {
+	APIVersion string             `json:"apiVersion"`
+	Kind       string             `json:"kind"`
+	Metadata   RuleMetadata       `json:"metadata"`
+	Spec       PrometheusRuleSpec `json:"spec"`
+}
+
+// RuleMetadata is the metadata of PrometheusRule
+type RuleMetadata struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// PrometheusRuleSpec contains the rule groups
+type PrometheusRuleSpec struct {
+	Groups []RuleGroup `json:"groups"`
+}
+
+// RuleGroup is a group of rules evaluated together
+type RuleGroup struct {
+	Name  string `json:"name"`
+	Rules []Rule `json:"rules"`
+}
+
+// Rule is an alerting rule
+type Rule struct {
+	Alert       string            `json:"alert"`
+	Expr        string            `json:"expr"`
+	For         string            `json:"for,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// alert is the definition of an alerting rule along with the
+// metrics used in its expression
+type alert struct {
+	Rule
+	metrics []string
+}
+
+// alerts are the alerting rules of openebs. The metrics of each
+// alert are verified against the metrics of the collectors so that
+// the rules fail to generate if a metric is renamed or removed.
+var alerts = []alert{
+	{
+		Rule: Rule{
+			Alert: "OpenEBSVolumeOffline",
+			// 1 is Offline as per openebs_volume_status
+			Expr: `openebs_volume_status == 1`,
+			For:  "5m",
+			Labels: map[string]string{
+				"severity": severityCritical,
+			},
+			Annotations: map[string]string{
+				"summary":     "Volume {{ $labels.instance }} is offline",
+				"description": "Target of volume {{ $labels.instance }} has not been serving io's for 5 minutes.",
+			},
+		},
+		metrics: []string{"openebs_volume_status"},
+	},
+	{
+		Rule: Rule{
+			Alert: "OpenEBSCStorVolumeOffline",
+			Expr:  `openebs_cstor_volume_phase{phase="Offline"} == 1`,
+			For:   "5m",
+			Labels: map[string]string{
+				"severity": severityCritical,
+			},
+			Annotations: map[string]string{
+				"summary":     "CStor volume {{ $labels.namespace }}/{{ $labels.volume }} is offline",
+				"description": "CStor volume {{ $labels.namespace }}/{{ $labels.volume }} has been offline for 5 minutes.",
+			},
+		},
+		metrics: []string{"openebs_cstor_volume_phase"},
+	},
+	{
+		Rule: Rule{
+			Alert: "OpenEBSVolumeReplicaDegraded",
+			Expr:  `openebs_degraded_replica_count > 0`,
+			For:   "10m",
+			Labels: map[string]string{
+				"severity": severityWarning,
+			},
+			Annotations: map[string]string{
+				"summary":     "Volume {{ $labels.instance }} has degraded replicas",
+				"description": "{{ $value }} replicas of volume {{ $labels.instance }} have been degraded for 10 minutes.",
+			},
+		},
+		metrics: []string{"openebs_degraded_replica_count"},
+	},
+	{
+		Rule: Rule{
+			Alert: "OpenEBSCStorVolumeReplicaDegraded",
+			Expr: `openebs_cstor_volume_healthy_replicas < ` +
+				`on(namespace, volume) openebs_cstor_volume_replication_factor`,
+			For: "10m",
+			Labels: map[string]string{
+				"severity": severityWarning,
+			},
+			Annotations: map[string]string{
+				"summary":     "CStor volume {{ $labels.namespace }}/{{ $labels.volume }} has degraded replicas",
+				"description": "Only {{ $value }} replicas of cstor volume {{ $labels.namespace }}/{{ $labels.volume }} have been healthy for 10 minutes.",
+			},
+		},
+		metrics: []string{
+			"openebs_cstor_volume_healthy_replicas",
+			"openebs_cstor_volume_replication_factor",
+		},
+	},
+	{
+		Rule: Rule{
+			Alert: "OpenEBSPoolNearFull",
+			Expr:  `openebs_used_pool_capacity_percent > 80`,
+			For:   "10m",
+			Labels: map[string]string{
+				"severity": severityWarning,
+			},
+			Annotations: map[string]string{
+				"summary":     "Pool {{ $labels.instance }} is nearly full",
+				"description": "Pool {{ $labels.instance }} is {{ $value }}% full.",
+			},
+		},
+		metrics: []string{"openebs_used_pool_capacity_percent"},
+	},
+	{
+		Rule: Rule{
+			Alert: "OpenEBSPoolFull",
+			Expr:  `openebs_used_pool_capacity_percent > 90`,
+			For:   "5m",
+			Labels: map[string]string{
+				"severity": severityCritical,
+			},
+			Annotations: map[string]string{
+				"summary":     "Pool {{ $labels.instance }} is full",
+				"description": "Pool {{ $labels.instance }} is {{ $value }}% full, writes to its volumes may fail.",
+			},
+		},
+		metrics: []string{"openebs_used_pool_capacity_percent"},
+	},
+	{
+		Rule: Rule{
+			Alert: "OpenEBSPoolVdevUnhealthy",
+			// 1 is Online as per openebs_pool_vdev_status
+			Expr: `openebs_pool_vdev_status != 1`,
+			For:  "5m",
+			Labels: map[string]string{
+				"severity": severityWarning,
+			},
+			Annotations: map[string]string{
+				"summary":     "Vdev {{ $labels.vdev }} of pool {{ $labels.pool }} is not online",
+				"description": "Vdev {{ $labels.vdev }} of pool {{ $labels.pool }} has not been online for 5 minutes.",
+			},
+		},
+		metrics: []string{"openebs_pool_vdev_status"},
+	},
+	{
+		Rule: Rule{
+			Alert: "OpenEBSPoolVdevErrors",
+			Expr: `increase(openebs_pool_vdev_read_errors[1h]) > 0 or ` +
+				`increase(openebs_pool_vdev_write_errors[1h]) > 0 or ` +
+				`increase(openebs_pool_vdev_checksum_errors[1h]) > 0`,
+			Labels: map[string]string{
+				"severity": severityWarning,
+			},
+			Annotations: map[string]string{
+				"summary":     "Vdev {{ $labels.vdev }} of pool {{ $labels.pool }} is reporting errors",
+				"description": "Vdev {{ $labels.vdev }} of pool {{ $labels.pool }} has reported io errors in the last hour, the disk may be failing.",
+			},
+		},
+		metrics: []string{
+			"openebs_pool_vdev_read_errors",
+			"openebs_pool_vdev_write_errors",
+			"openebs_pool_vdev_checksum_errors",
+		},
+	},
+	{
+		Rule: Rule{
+			Alert: "OpenEBSRebuildStuck",
+			// 2 & 3 are the in progress states as per openebs_rebuild_status
+			Expr: `(openebs_rebuild_status == 2 or openebs_rebuild_status == 3) ` +
+				`and on(vol, pool) delta(openebs_rebuild_bytes[30m]) == 0`,
+			For: "30m",
+			Labels: map[string]string{
+				"severity": severityWarning,
+			},
+			Annotations: map[string]string{
+				"summary":     "Rebuild of replica {{ $labels.vol }} is stuck",
+				"description": "Rebuild of replica {{ $labels.vol }} on pool {{ $labels.pool }} has not made progress for 30 minutes.",
+			},
+		},
+		metrics: []string{"openebs_rebuild_status", "openebs_rebuild_bytes"},
+	},
+}
+
+// Rules returns the alerting rules of openebs. It returns error
+// if any of the metrics used by the rules is not in the list.
+func Rules(metrics MetricList) (*PrometheusRule, error) {
+	group := RuleGroup{Name: "openebs.rules"}
+	for _, a := range alerts {
+		if missing := metrics.Missing(a.metrics...); len(missing) != 0 {
+			return nil, errors.Errorf("failed to generate alert {%s}: metrics {%s} are not exported",
+				a.Alert, strings.Join(missing, ", "))
+		}
+		group.Rules = append(group.Rules, a.Rule)
+	}
+	return &PrometheusRule{
+		APIVersion: ruleAPIVersion,
+		Kind:       ruleKind,
+		Metadata: RuleMetadata{
+			Name:   "openebs-alerts",
+			Labels: map[string]string{"app": "openebs"},
+		},
+		Spec: PrometheusRuleSpec{Groups: []RuleGroup{group}},
+	}, nil
+}
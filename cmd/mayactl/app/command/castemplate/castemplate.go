@@ -0,0 +1,66 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package castemplate
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var (
+	castemplateCommandHelpText = `
+Command provides operations related to cas templates.
+
+Usage: mayactl castemplate <subcommand> [options] [args]
+
+Examples:
+  # Renders a cas template without creating any resource:
+    $ mayactl castemplate dryrun --volname <vol> --sc <storageclass> --size <size>
+
+  # Verifies cas templates and runtasks without running them:
+    $ mayactl castemplate lint -f <file>
+`
+
+	options = &CmdCASTemplateOptions{}
+)
+
+// CmdCASTemplateOptions holds information of cas template being operated
+type CmdCASTemplateOptions struct {
+	castName      string
+	volName       string
+	namespace     string
+	scName        string
+	pvcName       string
+	size          string
+	casConfigFile string
+	showValues    bool
+	lintFiles     []string
+}
+
+// NewCmdCASTemplate adds command for operating on cas templates
+func NewCmdCASTemplate() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "castemplate",
+		Short: "Provides operations related to a cas template",
+		Long:  castemplateCommandHelpText,
+	}
+
+	cmd.AddCommand(
+		NewCmdCASTemplateDryRun(),
+		NewCmdCASTemplateLint(),
+	)
+	return cmd
+}
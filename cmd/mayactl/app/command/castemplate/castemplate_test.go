@@ -0,0 +1,114 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package castemplate
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestValidateDryRun(t *testing.T) {
+	tests := map[string]struct {
+		options *CmdCASTemplateOptions
+		err     error
+	}{
+		"ValidOptions": {
+			options: &CmdCASTemplateOptions{volName: "pvc-1", scName: "openebs-cstor"},
+			err:     nil,
+		},
+		"MissingVolumeName": {
+			options: &CmdCASTemplateOptions{scName: "openebs-cstor"},
+			err:     fmt.Errorf("error: --volname not specified"),
+		},
+		"MissingStorageClass": {
+			options: &CmdCASTemplateOptions{volName: "pvc-1"},
+			err:     fmt.Errorf("error: --sc not specified"),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := tt.options.validateDryRun()
+			if fmt.Sprint(got) != fmt.Sprint(tt.err) {
+				t.Fatalf("TestName: %v | validateDryRun() => Got: %v | Want: %v ", name, got, tt.err)
+			}
+		})
+	}
+}
+
+func TestLintDocs(t *testing.T) {
+	installed, err := installedDocs()
+	if err != nil {
+		t.Fatalf("failed to get installed docs: %v", err)
+	}
+	tests := map[string]struct {
+		docs           []string
+		expectedErrors int
+		isErr          bool
+	}{
+		"InstalledArtifacts": {
+			docs: installed,
+		},
+		"InvalidRunTask": {
+			docs: splitDocs(`
+apiVersion: openebs.io/v1alpha1
+kind: CASTemplate
+metadata:
+  name: cast-1
+spec:
+  run:
+    tasks:
+    - rt-1
+---
+apiVersion: openebs.io/v1alpha1
+kind: RunTask
+metadata:
+  name: rt-1
+spec:
+  meta: |
+    id: rt1
+    apiVersion: v1
+    kind: Service
+    action: get
+  post: |
+    {{- .JsonResult | unknownFunc -}}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm-1
+`),
+			expectedErrors: 1,
+		},
+		"InvalidYaml": {
+			docs:  []string{"kind: [RunTask"},
+			isErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			issues, err := lintDocs(tt.docs)
+			if tt.isErr != (err != nil) {
+				t.Fatalf("TestName: %v | lintDocs() => Got error: %v | Want error: %v ", name, err, tt.isErr)
+			}
+			if len(issues.Errors()) != tt.expectedErrors {
+				t.Fatalf("TestName: %v | lintDocs() => Got errors: %v | Want errors: %d ", name, issues.Errors(), tt.expectedErrors)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package castemplate
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	"github.com/openebs/maya/pkg/client/mapiserver"
+	"github.com/openebs/maya/pkg/util"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	castemplateDryRunCommandHelpText = `
+This command renders a cas template for a volume without creating any
+resource in the cluster. Each runtask is displayed along with its rendered
+yaml and whether it would be skipped. Read only runtasks i.e. get and list
+are executed against the cluster so that the runtasks that follow get
+rendered with actual values.
+
+Usage: mayactl castemplate dryrun --volname <vol> --sc <storageclass> --size <size> [--castemplate <name>]
+
+$ mayactl castemplate dryrun --volname pvc-1 --sc openebs-cstor --size 5G --show-values
+`
+)
+
+const castemplateDryRunTemplate = `
+CAS Template Dry Run :
+----------------------
+Volume             : {{ .ObjectMeta.Name }}
+Namespace          : {{ .ObjectMeta.Namespace }}
+CAS Template       : {{ if .Spec.CASTemplate }}{{ .Spec.CASTemplate }}{{ else }}<from storageclass>{{ end }}
+StorageClass       : {{ .Spec.StorageClass }}
+{{ range $i, $task := .Status.Tasks }}
+RunTask {{ $i }}          : {{ $task.Name }}
+-----------------------
+ID                 : {{ $task.ID }}
+Resource           : {{ $task.APIVersion }}/{{ $task.Kind }}
+Action             : {{ $task.Action }}
+Object Name        : {{ $task.ObjectName }}
+Run Namespace      : {{ $task.RunNamespace }}
+Skipped            : {{ $task.Skipped }}{{ if $task.Message }} ({{ $task.Message }}){{ end }}
+Executed           : {{ $task.Executed }}
+{{- if $task.Error }}
+Error              : {{ $task.Error }}
+{{- end }}
+{{- range $r := $task.Rendered }}
+Rendered :
+{{ $r }}
+{{- end }}
+{{- if $.ShowValues }}
+Template Values :
+{{ $task.Values }}
+{{- end }}
+{{ end }}
+{{- if .Status.Error }}
+Error :
+-------
+{{ .Status.Error }}
+{{ else }}
+Output :
+--------
+{{ .Status.Output }}
+{{ end }}`
+
+// castemplateDryRunView is the object used to print the result of a dry run
+type castemplateDryRunView struct {
+	*v1alpha1.CASTemplateDryRun
+	ShowValues bool
+}
+
+// NewCmdCASTemplateDryRun renders a cas template
+func NewCmdCASTemplateDryRun() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dryrun",
+		Short: "Renders a cas template without creating any resource",
+		Long:  castemplateDryRunCommandHelpText,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(options.runCASTemplateDryRun(cmd), util.Fatal)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.castName, "castemplate", "", options.castName,
+		"name of the cas template to render (default: create volume cas template of the storageclass)")
+	cmd.Flags().StringVarP(&options.volName, "volname", "", options.volName,
+		"name of the volume to render the cas template for.")
+	cmd.Flags().StringVarP(&options.namespace, "namespace", "n", options.namespace,
+		"namespace where the volume would run")
+	cmd.Flags().StringVarP(&options.scName, "sc", "", options.scName,
+		"name of the storageclass whose cas config is used")
+	cmd.Flags().StringVarP(&options.pvcName, "pvc", "", options.pvcName,
+		"name of the persistent volume claim of the volume")
+	cmd.Flags().StringVarP(&options.size, "size", "", "5G",
+		"volume capacity (example: 10G)")
+	cmd.Flags().StringVarP(&options.casConfigFile, "cas-config", "", options.casConfigFile,
+		"path to a file with cas config in yaml format that overrides the storageclass cas config")
+	cmd.Flags().BoolVarP(&options.showValues, "show-values", "", options.showValues,
+		"display the template values used to render each runtask")
+	return cmd
+}
+
+// validateDryRun validates the flags passed to dryrun command
+func (c *CmdCASTemplateOptions) validateDryRun() error {
+	if len(c.volName) == 0 {
+		return fmt.Errorf("error: --volname not specified")
+	}
+	if len(c.scName) == 0 {
+		return fmt.Errorf("error: --sc not specified")
+	}
+	return nil
+}
+
+// runCASTemplateDryRun makes castemplate dry run API request to
+// maya-apiserver
+func (c *CmdCASTemplateOptions) runCASTemplateDryRun(cmd *cobra.Command) error {
+	err := c.validateDryRun()
+	if err != nil {
+		return err
+	}
+
+	var casConfig []byte
+	if len(c.casConfigFile) != 0 {
+		casConfig, err = ioutil.ReadFile(c.casConfigFile)
+		if err != nil {
+			return fmt.Errorf("Error reading cas config: %v", err)
+		}
+	}
+
+	resp, err := mapiserver.DryRunCASTemplate(&v1alpha1.CASTemplateDryRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      c.volName,
+			Namespace: c.namespace,
+		},
+		Spec: v1alpha1.CASTemplateDryRunSpec{
+			CASTemplate:  c.castName,
+			StorageClass: c.scName,
+			Capacity:     c.size,
+			PVC:          c.pvcName,
+			CASConfig:    string(casConfig),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("Error rendering cas template: %v", err)
+	}
+	return mapiserver.Print(castemplateDryRunTemplate, castemplateDryRunView{
+		CASTemplateDryRun: resp,
+		ShowValues:        c.showValues,
+	})
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package castemplate
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	castemplate "github.com/openebs/maya/pkg/castemplate/v1alpha1"
+	install "github.com/openebs/maya/pkg/install/v1alpha1"
+	template "github.com/openebs/maya/pkg/template/v1alpha1"
+	"github.com/openebs/maya/pkg/util"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	castemplateLintCommandHelpText = `
+This command verifies cas templates and runtasks without running them. It
+reports invalid templates, unknown template functions, unsupported runtask
+kinds and actions as well as references to task results that are never
+saved. No connection to the cluster is required.
+
+If no file is provided, the cas templates and runtasks that are installed
+by maya-apiserver are verified.
+
+Usage: mayactl castemplate lint [-f <file>]...
+
+$ mayactl castemplate lint -f cast.yaml -f runtasks.yaml
+`
+)
+
+// NewCmdCASTemplateLint verifies cas templates & runtasks
+func NewCmdCASTemplateLint() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Verifies cas templates and runtasks without running them",
+		Long:  castemplateLintCommandHelpText,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(options.runCASTemplateLint(cmd), util.Fatal)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&options.lintFiles, "filename", "f", options.lintFiles,
+		"path to a file with cas templates and runtasks in yaml format (default: installed cas templates and runtasks)")
+	return cmd
+}
+
+// runCASTemplateLint verifies the cas templates & runtasks found in the
+// provided files or the installed ones
+func (c *CmdCASTemplateOptions) runCASTemplateLint(cmd *cobra.Command) error {
+	var docs []string
+	if len(c.lintFiles) == 0 {
+		d, err := installedDocs()
+		if err != nil {
+			return err
+		}
+		docs = d
+	}
+	for _, file := range c.lintFiles {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("Error reading file: %v", err)
+		}
+		docs = append(docs, splitDocs(string(b))...)
+	}
+
+	issues, err := lintDocs(docs)
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		fmt.Println("No issues found")
+		return nil
+	}
+	fmt.Println(issues)
+	if issues.HasErrors() {
+		return fmt.Errorf("Error linting: found %d error(s)", len(issues.Errors()))
+	}
+	return nil
+}
+
+// installedDocs returns the yaml documents of the artifacts installed by
+// maya-apiserver. These are templated the same way they get templated
+// during install.
+func installedDocs() (docs []string, err error) {
+	t := install.ArtifactTemplater(map[string]interface{}{}, template.TextTemplate)
+	list, errs := install.RegisteredArtifacts().MapIf(t, install.IsNotRunTask)
+	if len(errs) != 0 {
+		return nil, fmt.Errorf("Error templating installed artifacts: %v", errs)
+	}
+	for _, artifact := range list.Items {
+		docs = append(docs, artifact.Doc)
+	}
+	return
+}
+
+// splitDocs splits multiple yaml documents separated via "---"
+func splitDocs(yml string) (docs []string) {
+	for _, doc := range strings.Split(yml, "\n---") {
+		doc = strings.TrimSpace(strings.TrimPrefix(doc, "---"))
+		if len(doc) == 0 {
+			continue
+		}
+		docs = append(docs, doc)
+	}
+	return
+}
+
+// lintDocs verifies the cas templates & runtasks present in the given yaml
+// documents. Documents of any other kind are ignored.
+func lintDocs(docs []string) (castemplate.LintIssueList, error) {
+	var casts []*v1alpha1.CASTemplate
+	var runtasks []*v1alpha1.RunTask
+	for _, doc := range docs {
+		var tm metav1.TypeMeta
+		err := yaml.Unmarshal([]byte(doc), &tm)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing yaml: %v", err)
+		}
+		switch tm.Kind {
+		case "CASTemplate":
+			cast := &v1alpha1.CASTemplate{}
+			err = yaml.Unmarshal([]byte(doc), cast)
+			casts = append(casts, cast)
+		case "RunTask":
+			rt := &v1alpha1.RunTask{}
+			err = yaml.Unmarshal([]byte(doc), rt)
+			runtasks = append(runtasks, rt)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing %s: %v", tm.Kind, err)
+		}
+	}
+	return castemplate.LintAll(casts, runtasks), nil
+}
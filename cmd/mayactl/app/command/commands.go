@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/openebs/maya/cmd/mayactl/app/command/castemplate"
 	"github.com/openebs/maya/cmd/mayactl/app/command/pool"
 	//"github.com/openebs/maya/cmd/mayactl/app/command/snapshot"
 	"github.com/openebs/maya/pkg/client/mapiserver"
@@ -57,6 +58,7 @@ func NewMayaCommand() *cobra.Command {
 		NewCmdVolume(),
 		//snapshot.NewCmdSnapshot(),
 		pool.NewCmdPool(),
+		castemplate.NewCmdCASTemplate(),
 	)
 
 	// add the glog flags
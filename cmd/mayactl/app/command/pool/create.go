@@ -0,0 +1,115 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"fmt"
+	"strings"
+
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
+	"github.com/openebs/maya/pkg/client/mapiserver"
+	"github.com/openebs/maya/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	poolCreateCommandHelpText = `
+This command creates a cstor pool cluster i.e. a pool on each of the
+given nodes. Each --raidgroup adds a raid group of the given block
+devices to the pool of the node.
+
+Usage: mayactl pool create --poolname <PoolName> --raidgroup <NodeName>=<BlockDevice>,... [options]
+
+$ mayactl pool create --poolname cstor-pool --raidgrouptype mirror \
+    --raidgroup node-1=bd-1,bd-2 --raidgroup node-2=bd-3,bd-4
+`
+)
+
+// NewCmdPoolCreate creates a pool
+func NewCmdPoolCreate() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Creates a pool",
+		Long:  poolCreateCommandHelpText,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(options.runPoolCreate(cmd), util.Fatal)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.poolName, "poolname", "", options.poolName,
+		"a unique pool name.")
+	cmd.Flags().StringVarP(&options.raidGroupType, "raidgrouptype", "", "stripe",
+		"type of raid groups i.e. stripe, mirror, raidz or raidz2.")
+	cmd.Flags().StringVarP(&options.compression, "compression", "", options.compression,
+		"compression algorithm of pool e.g. lz4.")
+	cmd.Flags().BoolVarP(&options.overProvisioning, "overprovisioning", "", false,
+		"allow volumes to be provisioned beyond the capacity of pool.")
+	cmd.Flags().StringArrayVarP(&options.raidGroups, "raidgroup", "", options.raidGroups,
+		"raid group of a node as <NodeName>=<BlockDevice>,... Can be repeated.")
+	return cmd
+}
+
+// runPoolCreate makes pool-create API request to maya-apiserver
+func (c *CmdPoolOptions) runPoolCreate(cmd *cobra.Command) error {
+	if len(c.poolName) == 0 {
+		return fmt.Errorf("error: --poolname not specified")
+	}
+	nodes, err := parseRaidGroups(c.raidGroups)
+	if err != nil {
+		return err
+	}
+	req := &apis.PoolCreateRequest{
+		Name:             c.poolName,
+		RaidGroupType:    c.raidGroupType,
+		Compression:      c.compression,
+		OverProvisioning: c.overProvisioning,
+		Nodes:            nodes,
+	}
+	_, err = mapiserver.CreatePool(req)
+	if err != nil {
+		return fmt.Errorf("Error creating pool: %v", err)
+	}
+	fmt.Printf("Pool %s created\n", c.poolName)
+	return nil
+}
+
+// parseRaidGroups returns the pools of nodes of the given raid
+// groups. A raid group is specified as
+// <NodeName>=<BlockDevice>,<BlockDevice>...
+func parseRaidGroups(raidGroups []string) ([]apis.PoolNode, error) {
+	if len(raidGroups) == 0 {
+		return nil, fmt.Errorf("error: --raidgroup not specified")
+	}
+	nodes := []apis.PoolNode{}
+	index := map[string]int{}
+	for _, rg := range raidGroups {
+		kv := strings.SplitN(rg, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return nil, fmt.Errorf("error: invalid --raidgroup {%s}: expected <NodeName>=<BlockDevice>,...", rg)
+		}
+		i, ok := index[kv[0]]
+		if !ok {
+			i = len(nodes)
+			index[kv[0]] = i
+			nodes = append(nodes, apis.PoolNode{NodeName: kv[0]})
+		}
+		nodes[i].RaidGroups = append(nodes[i].RaidGroups, apis.PoolRaidGroup{
+			BlockDevices: strings.Split(kv[1], ","),
+		})
+	}
+	return nodes, nil
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"errors"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
+	utiltesting "k8s.io/client-go/util/testing"
+)
+
+func TestParseRaidGroups(t *testing.T) {
+	tests := map[string]*struct {
+		raidGroups []string
+		expected   []apis.PoolNode
+		isErr      bool
+	}{
+		"raid groups of nodes": {
+			raidGroups: []string{"node-1=bd-1,bd-2", "node-2=bd-3,bd-4", "node-1=bd-5,bd-6"},
+			expected: []apis.PoolNode{
+				{
+					NodeName: "node-1",
+					RaidGroups: []apis.PoolRaidGroup{
+						{BlockDevices: []string{"bd-1", "bd-2"}},
+						{BlockDevices: []string{"bd-5", "bd-6"}},
+					},
+				},
+				{
+					NodeName: "node-2",
+					RaidGroups: []apis.PoolRaidGroup{
+						{BlockDevices: []string{"bd-3", "bd-4"}},
+					},
+				},
+			},
+		},
+		"no raid groups": {
+			isErr: true,
+		},
+		"missing block devices": {
+			raidGroups: []string{"node-1="},
+			isErr:      true,
+		},
+		"missing node name": {
+			raidGroups: []string{"bd-1,bd-2"},
+			isErr:      true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseRaidGroups(tt.raidGroups)
+			if tt.isErr != (err != nil) {
+				t.Fatalf("TestName: %v | parseRaidGroups() => Got error: %v | Want error: %v", name, err, tt.isErr)
+			}
+			if !tt.isErr && !reflect.DeepEqual(got, tt.expected) {
+				t.Fatalf("TestName: %v | parseRaidGroups() => Got: %+v | Want: %+v", name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRunPoolCreate(t *testing.T) {
+	tests := map[string]*struct {
+		cmdPoolOptions *CmdPoolOptions
+		fakeHandler    utiltesting.FakeHandler
+		err            error
+		addr           string
+	}{
+		"StatusOK": {
+			cmdPoolOptions: &CmdPoolOptions{
+				poolName:      "cstor-pool",
+				raidGroupType: "mirror",
+				raidGroups:    []string{"node-1=bd-1,bd-2"},
+			},
+			fakeHandler: utiltesting.FakeHandler{
+				StatusCode:   200,
+				ResponseBody: `{"metadata":{"name":"cstor-pool","namespace":"openebs"}}`,
+				T:            t,
+			},
+			err:  nil,
+			addr: "MAPI_ADDR",
+		},
+		"Invalid block device count": {
+			cmdPoolOptions: &CmdPoolOptions{
+				poolName:      "cstor-pool",
+				raidGroupType: "mirror",
+				raidGroups:    []string{"node-1=bd-1"},
+			},
+			fakeHandler: utiltesting.FakeHandler{
+				StatusCode:   400,
+				ResponseBody: `{"code":400,"reason":"BadRequest","message":"failed to create pool","details":["invalid raid group {0} of node {node-1}: {mirror} raid group expects a multiple of {2} block devices: got {1}"]}`,
+				T:            t,
+			},
+			err:  errors.New("Error creating pool: failed to create pool: invalid raid group {0} of node {node-1}: {mirror} raid group expects a multiple of {2} block devices: got {1}"),
+			addr: "MAPI_ADDR",
+		},
+		"Response code 500": {
+			cmdPoolOptions: &CmdPoolOptions{
+				poolName:      "cstor-pool",
+				raidGroupType: "stripe",
+				raidGroups:    []string{"node-1=bd-1"},
+			},
+			fakeHandler: utiltesting.FakeHandler{
+				StatusCode:   500,
+				ResponseBody: "",
+				T:            t,
+			},
+			err:  errors.New("Error creating pool: Server status error: Internal Server Error"),
+			addr: "MAPI_ADDR",
+		},
+		"When poolname is not specified": {
+			cmdPoolOptions: &CmdPoolOptions{},
+			fakeHandler: utiltesting.FakeHandler{
+				StatusCode: 500,
+				T:          t,
+			},
+			err:  errors.New("error: --poolname not specified"),
+			addr: "MAPI_ADDR",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(&tt.fakeHandler)
+			os.Setenv(tt.addr, server.URL)
+			defer os.Unsetenv(tt.addr)
+			defer server.Close()
+			got := tt.cmdPoolOptions.runPoolCreate(nil)
+			if !checkErr(got, tt.err) {
+				t.Fatalf("TestName: %v | runPoolCreate() => Got: %v | Want: %v \n", name, got, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"fmt"
+
+	"github.com/openebs/maya/pkg/client/mapiserver"
+	"github.com/openebs/maya/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	poolDeleteCommandHelpText = `
+This command deletes a cstor pool cluster. The pool is not deleted
+if any volume has its replica on the pool.
+
+Usage: mayactl pool delete --poolname <PoolName>
+
+$ mayactl pool delete --poolname cstor-pool
+`
+)
+
+// NewCmdPoolDelete deletes a pool
+func NewCmdPoolDelete() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Deletes a pool",
+		Long:  poolDeleteCommandHelpText,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(options.runPoolDelete(cmd), util.Fatal)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.poolName, "poolname", "", options.poolName,
+		"a unique pool name.")
+	return cmd
+}
+
+// runPoolDelete makes pool-delete API request to maya-apiserver
+func (c *CmdPoolOptions) runPoolDelete(cmd *cobra.Command) error {
+	if len(c.poolName) == 0 {
+		return fmt.Errorf("error: --poolname not specified")
+	}
+	err := mapiserver.DeletePool(c.poolName)
+	if err != nil {
+		return fmt.Errorf("Error deleting pool: %v", err)
+	}
+	fmt.Printf("Pool %s deleted\n", c.poolName)
+	return nil
+}
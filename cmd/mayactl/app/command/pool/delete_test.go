@@ -0,0 +1,81 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"errors"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	utiltesting "k8s.io/client-go/util/testing"
+)
+
+func TestRunPoolDelete(t *testing.T) {
+	tests := map[string]*struct {
+		cmdPoolOptions *CmdPoolOptions
+		fakeHandler    utiltesting.FakeHandler
+		err            error
+		addr           string
+	}{
+		"StatusOK": {
+			cmdPoolOptions: &CmdPoolOptions{
+				poolName: "cstor-pool",
+			},
+			fakeHandler: utiltesting.FakeHandler{
+				StatusCode: 200,
+				T:          t,
+			},
+			err:  nil,
+			addr: "MAPI_ADDR",
+		},
+		"Pool has replicas": {
+			cmdPoolOptions: &CmdPoolOptions{
+				poolName: "cstor-pool",
+			},
+			fakeHandler: utiltesting.FakeHandler{
+				StatusCode:   409,
+				ResponseBody: `{"code":409,"reason":"Conflict","message":"failed to delete storage pool 'cstor-pool': pool has volume replicas {pvc-1-cstor-pool-a1b2}"}`,
+				T:            t,
+			},
+			err:  errors.New("Error deleting pool: failed to delete storage pool 'cstor-pool': pool has volume replicas {pvc-1-cstor-pool-a1b2}"),
+			addr: "MAPI_ADDR",
+		},
+		"When poolname is not specified": {
+			cmdPoolOptions: &CmdPoolOptions{},
+			fakeHandler: utiltesting.FakeHandler{
+				StatusCode: 500,
+				T:          t,
+			},
+			err:  errors.New("error: --poolname not specified"),
+			addr: "MAPI_ADDR",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(&tt.fakeHandler)
+			os.Setenv(tt.addr, server.URL)
+			defer os.Unsetenv(tt.addr)
+			defer server.Close()
+			got := tt.cmdPoolOptions.runPoolDelete(nil)
+			if !checkErr(got, tt.err) {
+				t.Fatalf("TestName: %v | runPoolDelete() => Got: %v | Want: %v \n", name, got, tt.err)
+			}
+		})
+	}
+}
@@ -45,6 +45,10 @@ StoragePoolClaim   : {{ index .ObjectMeta.Labels "openebs.io/storage-pool-claim"
 UID                : {{ .ObjectMeta.UID }}
 Pool Type          : {{ .Spec.PoolSpec.PoolType }}
 Over Provisioning  : {{ .Spec.PoolSpec.OverProvisioning }}
+Over Commit Ratio  : {{ with .Spec.PoolSpec.OverCommitRatio }}{{ . }}{{ else }}-{{ end }}
+Size               : {{ .Status.Capacity.Total }}
+Used               : {{ .Status.Capacity.Used }}
+Provisioned        : {{ with .Status.Capacity.Provisioned }}{{ . }}{{ else }}-{{ end }}
 
 Disk List :
 -----------
@@ -0,0 +1,82 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"fmt"
+
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
+	"github.com/openebs/maya/pkg/client/mapiserver"
+	"github.com/openebs/maya/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	poolExpandCommandHelpText = `
+This command adds raid groups to the pool of a node of cstor pool
+cluster. The raid groups are of the default raid group type of the
+pool unless --raidgrouptype is specified.
+
+Usage: mayactl pool expand --poolname <PoolName> --raidgroup <NodeName>=<BlockDevice>,... [options]
+
+$ mayactl pool expand --poolname cstor-pool --raidgroup node-1=bd-5,bd-6
+`
+)
+
+// NewCmdPoolExpand adds raid groups to a pool
+func NewCmdPoolExpand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "expand",
+		Short: "Adds raid groups to a pool",
+		Long:  poolExpandCommandHelpText,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(options.runPoolExpand(cmd), util.Fatal)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.poolName, "poolname", "", options.poolName,
+		"a unique pool name.")
+	cmd.Flags().StringVarP(&options.raidGroupType, "raidgrouptype", "", options.raidGroupType,
+		"type of raid groups i.e. stripe, mirror, raidz or raidz2.")
+	cmd.Flags().StringArrayVarP(&options.raidGroups, "raidgroup", "", options.raidGroups,
+		"raid group of the node as <NodeName>=<BlockDevice>,... Can be repeated.")
+	return cmd
+}
+
+// runPoolExpand makes pool-expand API request to maya-apiserver
+func (c *CmdPoolOptions) runPoolExpand(cmd *cobra.Command) error {
+	if len(c.poolName) == 0 {
+		return fmt.Errorf("error: --poolname not specified")
+	}
+	nodes, err := parseRaidGroups(c.raidGroups)
+	if err != nil {
+		return err
+	}
+	if len(nodes) != 1 {
+		return fmt.Errorf("error: --raidgroup of a pool can be added to only one node at a time")
+	}
+	req := &apis.PoolExpandRequest{PoolNode: nodes[0]}
+	for i := range req.RaidGroups {
+		req.RaidGroups[i].Type = c.raidGroupType
+	}
+	_, err = mapiserver.ExpandPool(c.poolName, req)
+	if err != nil {
+		return fmt.Errorf("Error expanding pool: %v", err)
+	}
+	fmt.Printf("Pool %s expanded\n", c.poolName)
+	return nil
+}
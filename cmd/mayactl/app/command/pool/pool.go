@@ -29,6 +29,15 @@ Usage: mayactl pool <subcommand> [options] [args]
 Examples:
   # Lists pool:
     $ mayactl pool list 
+
+  # Creates pool:
+    $ mayactl pool create --poolname <PoolName> --raidgroup <NodeName>=<BlockDevice>,...
+
+  # Expands pool:
+    $ mayactl pool expand --poolname <PoolName> --raidgroup <NodeName>=<BlockDevice>,...
+
+  # Deletes pool:
+    $ mayactl pool delete --poolname <PoolName>
 `
 
 	options = &CmdPoolOptions{}
@@ -36,7 +45,11 @@ Examples:
 
 // CmdPoolOptions holds information of pool being operated
 type CmdPoolOptions struct {
-	poolName string
+	poolName         string
+	raidGroupType    string
+	compression      string
+	overProvisioning bool
+	raidGroups       []string
 }
 
 // NewCmdPool adds command for operating on snapshot
@@ -50,6 +63,9 @@ func NewCmdPool() *cobra.Command {
 	cmd.AddCommand(
 		NewCmdPoolList(),
 		NewCmdPoolDescribe(),
+		NewCmdPoolCreate(),
+		NewCmdPoolExpand(),
+		NewCmdPoolDelete(),
 	)
 	return cmd
 }
@@ -29,6 +29,7 @@ import (
 	"time"
 
 	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
 	"github.com/openebs/maya/pkg/client/mapiserver"
 	"github.com/openebs/maya/pkg/util"
 	"github.com/openebs/maya/types/v1"
@@ -55,7 +56,7 @@ type CASType string
 
 const (
 	// VolumeAPIPath is the api path to get volume information
-	VolumeAPIPath      = "/latest/volumes/"
+	VolumeAPIPath      = apis.APIPrefix + "volumes/"
 	controllerStatusOk = "running"
 	volumeStatusOK     = "Running"
 	// JivaStorageEngine is constant for jiva engine
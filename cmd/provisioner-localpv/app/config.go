@@ -44,6 +44,13 @@ const (
 	//KeyPVFSType defines filesystem type to be used with devices
 	// and can be configured via the StorageClass annotations.
 	KeyPVFSType = "FSType"
+	//KeyPVMkfsOptions defines the additional options that are passed
+	// to mkfs while formatting a device that has no filesystem.
+	// The options are specified as a single space separated string.
+	KeyPVMkfsOptions = "MkfsOptions"
+	//KeyPVMountOptions defines the comma separated list of options
+	// that will be set on the PV and used while mounting the device.
+	KeyPVMountOptions = "MountOptions"
 	//KeyPVRelativePath defines the alternate folder name under the BasePath
 	// By default, the pv name will be used as the folder name.
 	// KeyPVBasePath can be useful for providing the same underlying folder
@@ -136,6 +143,24 @@ func (c *VolumeConfig) GetFSType() string {
 	return fsType
 }
 
+//GetMkfsOptions returns the options to be passed to mkfs
+// while formatting a device. Default is an empty list.
+func (c *VolumeConfig) GetMkfsOptions() []string {
+	return strings.Fields(c.getValue(KeyPVMkfsOptions))
+}
+
+//GetMountOptions returns the list of mount options configured
+// in StorageClass. Default is an empty list.
+func (c *VolumeConfig) GetMountOptions() []string {
+	mountOptions := []string{}
+	for _, opt := range strings.Split(c.getValue(KeyPVMountOptions), ",") {
+		if len(strings.TrimSpace(opt)) != 0 {
+			mountOptions = append(mountOptions, strings.TrimSpace(opt))
+		}
+	}
+	return mountOptions
+}
+
 //GetPath returns a valid PV path based on the configuration
 // or an error. The Path is constructed using the following rules:
 // If AbsolutePath is specified return it. (Future)
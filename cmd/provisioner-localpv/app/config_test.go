@@ -0,0 +1,95 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func fakeVolumeConfig(key, value string) *VolumeConfig {
+	return &VolumeConfig{
+		pvName:  "pvName",
+		pvcName: "pvcName",
+		scName:  "scName",
+		options: map[string]interface{}{
+			key: map[string]string{
+				"enabled": "true",
+				"value":   value,
+			},
+		},
+	}
+}
+
+func TestGetMountOptions(t *testing.T) {
+	testCases := map[string]struct {
+		value       string
+		expectValue []string
+	}{
+		"Missing mount options": {
+			value:       "",
+			expectValue: []string{},
+		},
+		"Single mount option": {
+			value:       "noatime",
+			expectValue: []string{"noatime"},
+		},
+		"Multiple mount options with whitespaces": {
+			value:       " noatime, discard ,,",
+			expectValue: []string{"noatime", "discard"},
+		},
+	}
+
+	for k, v := range testCases {
+		v := v
+		t.Run(k, func(t *testing.T) {
+			actualValue := fakeVolumeConfig(KeyPVMountOptions, v.value).GetMountOptions()
+			if !reflect.DeepEqual(actualValue, v.expectValue) {
+				t.Errorf("expected %v got %v", v.expectValue, actualValue)
+			}
+		})
+	}
+}
+
+func TestGetMkfsOptions(t *testing.T) {
+	testCases := map[string]struct {
+		value       string
+		expectValue []string
+	}{
+		"Missing mkfs options": {
+			value:       "",
+			expectValue: []string{},
+		},
+		"Multiple mkfs options": {
+			value:       "-m 0  -E lazy_itable_init=0",
+			expectValue: []string{"-m", "0", "-E", "lazy_itable_init=0"},
+		},
+	}
+
+	for k, v := range testCases {
+		v := v
+		t.Run(k, func(t *testing.T) {
+			actualValue := fakeVolumeConfig(KeyPVMkfsOptions, v.value).GetMkfsOptions()
+			if len(actualValue) == 0 && len(v.expectValue) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(actualValue, v.expectValue) {
+				t.Errorf("expected %v got %v", v.expectValue, actualValue)
+			}
+		})
+	}
+}
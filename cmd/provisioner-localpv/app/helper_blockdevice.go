@@ -21,8 +21,8 @@ package app
 
 import (
 	//"fmt"
-	//"path/filepath"
-	//"strings"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/golang/glog"
@@ -31,18 +31,29 @@ import (
 
 	//hostpath "github.com/openebs/maya/pkg/hostpath/v1alpha1"
 
-	//container "github.com/openebs/maya/pkg/kubernetes/container/v1alpha1"
-	//pod "github.com/openebs/maya/pkg/kubernetes/pod/v1alpha1"
-	//volume "github.com/openebs/maya/pkg/kubernetes/volume/v1alpha1"
+	ndmv1alpha1 "github.com/openebs/maya/pkg/apis/openebs.io/ndm/v1alpha1"
 	blockdevice "github.com/openebs/maya/pkg/blockdevice/v1alpha2"
 	blockdeviceclaim "github.com/openebs/maya/pkg/blockdeviceclaim/v1alpha1"
+	container "github.com/openebs/maya/pkg/kubernetes/container/v1alpha1"
+	pod "github.com/openebs/maya/pkg/kubernetes/pod/v1alpha1"
+	volume "github.com/openebs/maya/pkg/kubernetes/volume/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
-	//ndmv1alpha1 "github.com/openebs/maya/pkg/apis/openebs.io/ndm/v1alpha1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
 )
 
 const (
 	bdcStorageClassAnnotation = "local.openebs.io/blockdeviceclaim"
+
+	//defaultBlockDeviceFSType is the filesystem used to format
+	// a claimed device that has no filesystem, when FSType is not
+	// specified in the StorageClass.
+	defaultBlockDeviceFSType = "ext4"
+
+	//mountedDataDir is the directory in the helper pod where the
+	// mount path of a mounted device is mounted.
+	mountedDataDir = "/data"
 )
 
 //TODO
@@ -50,6 +61,31 @@ var (
 	//WaitForBDTimeoutCounts specifies the duration to wait for BDC to be associated with a BD
 	//The duration is the value specified here multiplied by 5
 	WaitForBDTimeoutCounts = 12
+
+	//FormatTimeoutCounts specifies the duration in seconds to wait
+	//for the format pod to complete.
+	FormatTimeoutCounts = 300
+
+	//WipeTimeoutCounts specifies the duration in seconds to wait
+	//for the wipe pod to complete, in addition to the time taken
+	//to zero the device at WipeMinThroughputMiB.
+	WipeTimeoutCounts = 300
+
+	//WipeMinThroughputMiB specifies the minimum rate in MiB per
+	//second at which a device is expected to be zeroed. The whole
+	//device is zeroed, so the wipe timeout grows with its size.
+	WipeMinThroughputMiB = 50
+
+	//CleanupTimeoutCounts specifies the duration in seconds to wait
+	//for the pod that cleans up a mounted device to complete.
+	CleanupTimeoutCounts = 300
+
+	//supportedBlockDeviceFSTypes are the filesystems that can be
+	//specified via FSType, to format a claimed device.
+	supportedBlockDeviceFSTypes = map[string]bool{
+		"ext4": true,
+		"xfs":  true,
+	}
 )
 
 // HelperBlockDeviceOptions contains the options that
@@ -144,14 +180,15 @@ func (p *Provisioner) createBlockDeviceClaim(blkDevOpts *HelperBlockDeviceOption
 }
 
 // getBlockDevicePath fetches the BDC associated with this Local PV
-// or creates one. From the BDC, fetch the BD and get the path
-func (p *Provisioner) getBlockDevicePath(blkDevOpts *HelperBlockDeviceOptions) (string, string, error) {
+// or creates one. From the BDC, fetch the BD and get the path and
+// the filesystem already present on the device.
+func (p *Provisioner) getBlockDevicePath(blkDevOpts *HelperBlockDeviceOptions) (string, string, string, error) {
 
 	glog.Infof("Getting Block Device Path")
 	if !blkDevOpts.hasBDC() {
 		err := p.createBlockDeviceClaim(blkDevOpts)
 		if err != nil {
-			return "", "", err
+			return "", "", "", err
 		}
 	}
 
@@ -167,7 +204,7 @@ func (p *Provisioner) getBlockDevicePath(blkDevOpts *HelperBlockDeviceOptions) (
 		if err != nil {
 			//TODO : Need to relook at this error
 			//If the error is about BDC being already present, then return nil
-			return "", "", errors.Errorf("unable to get BDC %v associated with PV:%v %v", blkDevOpts.bdcName, blkDevOpts.name, err)
+			return "", "", "", errors.Errorf("unable to get BDC %v associated with PV:%v %v", blkDevOpts.bdcName, blkDevOpts.name, err)
 		}
 
 		bdName = bdc.Spec.BlockDeviceName
@@ -186,7 +223,7 @@ func (p *Provisioner) getBlockDevicePath(blkDevOpts *HelperBlockDeviceOptions) (
 	if err != nil {
 		//TODO : Need to relook at this error
 		//If the error is about BDC being already present, then return nil
-		return "", "", errors.Errorf("unable to find BD:%v for BDC:%v associated with PV:%v", bdName, blkDevOpts.bdcName, blkDevOpts.name)
+		return "", "", "", errors.Errorf("unable to find BD:%v for BDC:%v associated with PV:%v", bdName, blkDevOpts.bdcName, blkDevOpts.name)
 	}
 
 	path := bd.Spec.FileSystem.Mountpoint
@@ -196,7 +233,7 @@ func (p *Provisioner) getBlockDevicePath(blkDevOpts *HelperBlockDeviceOptions) (
 		blkPath = bd.Spec.DevLinks[0].Links[0]
 	}
 
-	return path, blkPath, nil
+	return path, blkPath, bd.Spec.FileSystem.Type, nil
 }
 
 // deleteBlockDeviceClaim deletes the BlockDeviceClaim associated with the
@@ -212,9 +249,235 @@ func (p *Provisioner) deleteBlockDeviceClaim(blkDevOpts *HelperBlockDeviceOption
 		WithNamespace(p.namespace).
 		Delete(blkDevOpts.bdcName, &metav1.DeleteOptions{})
 
-	if err != nil {
+	if err != nil && !k8serror.IsNotFound(err) {
 		//TODO : Need to relook at this error
 		return errors.Errorf("unable to delete BDC %v associated with PV:%v", blkDevOpts.bdcName, blkDevOpts.name)
 	}
 	return nil
 }
+
+// getBlockDeviceFromBDC fetches the BD bound to the BDC associated
+//  with the Local PV. Unlike getBlockDevicePath, it will not create
+//  the BDC or wait for the BDC to be bound. The returned error is the
+//  error of the BDC get request as is, if the BDC is not found.
+func (p *Provisioner) getBlockDeviceFromBDC(blkDevOpts *HelperBlockDeviceOptions) (*ndmv1alpha1.BlockDevice, error) {
+	bdc, err := blockdeviceclaim.NewKubeClient().
+		WithNamespace(p.namespace).
+		Get(blkDevOpts.bdcName, metav1.GetOptions{})
+	if k8serror.IsNotFound(err) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get BDC %v associated with PV:%v", blkDevOpts.bdcName, blkDevOpts.name)
+	}
+
+	bdName := bdc.Spec.BlockDeviceName
+	if bdName == "" {
+		return nil, errors.Errorf("BDC %v associated with PV:%v is not bound to any BD", blkDevOpts.bdcName, blkDevOpts.name)
+	}
+
+	bd, err := blockdevice.NewKubeClient().
+		WithNamespace(p.namespace).
+		Get(bdName, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to find BD:%v for BDC:%v associated with PV:%v", bdName, blkDevOpts.bdcName, blkDevOpts.name)
+	}
+	return bd, nil
+}
+
+// getBlockDevicePathFromBD returns the path of the device, the first
+//  of its links is preferred as it is stable across node reboots.
+func getBlockDevicePathFromBD(bd *ndmv1alpha1.BlockDevice) string {
+	if len(bd.Spec.DevLinks) > 0 && len(bd.Spec.DevLinks[0].Links) > 0 {
+		return bd.Spec.DevLinks[0].Links[0]
+	}
+	return bd.Spec.Path
+}
+
+// HelperDevicePodOptions contains the options that
+// will launch a privileged Pod on a specific node (nodeName)
+// to execute a command (cmdsForDevice) on a given block
+// device (devicePath)
+type HelperDevicePodOptions struct {
+	//nodeName represents the host where pod should be launched.
+	nodeName string
+	//name is the name of the PV for which the pod is being launched
+	name string
+	//cmdsForDevice represent either the format (mkfs), the wipe
+	//(blkdiscard/dd) or the cleanup (find -delete) command that
+	//need to be executed on the device.
+	cmdsForDevice []string
+	//devicePath is the path of the block device on the node
+	devicePath string
+	//mountPath is the path where the block device is mounted on
+	//the node. If set, it is mounted in the pod at mountedDataDir
+	//instead of the host /dev.
+	mountPath string
+	//timeoutCounts is the duration in seconds to wait for the
+	//pod to complete
+	timeoutCounts int
+}
+
+// validate checks that the required fields to launch
+// the device helper pods are valid.
+func (pOpts *HelperDevicePodOptions) validate() error {
+	if pOpts.name == "" || pOpts.devicePath == "" || pOpts.nodeName == "" {
+		return errors.Errorf("invalid empty name or device path or node")
+	}
+	if !strings.HasPrefix(pOpts.devicePath, "/dev/") {
+		return errors.Errorf("invalid device path {%v}: should be under /dev/", pOpts.devicePath)
+	}
+	if len(pOpts.cmdsForDevice) == 0 {
+		return errors.Errorf("invalid empty command for device {%v}", pOpts.devicePath)
+	}
+	if pOpts.mountPath != "" && (!filepath.IsAbs(pOpts.mountPath) || filepath.Clean(pOpts.mountPath) == "/") {
+		return errors.Errorf("invalid mount path {%v} of device {%v}", pOpts.mountPath, pOpts.devicePath)
+	}
+	return nil
+}
+
+// validateBlockDeviceFSType returns error if the filesystem
+//  cannot be used to format a claimed device.
+func validateBlockDeviceFSType(fsType string) error {
+	if fsType == "" || supportedBlockDeviceFSTypes[fsType] {
+		return nil
+	}
+	return errors.Errorf("invalid FSType {%v}: supported types are ext4 and xfs", fsType)
+}
+
+// getFormatCmdsForDevice returns the command that formats the device
+//  with the given filesystem. The mkfs options are passed as separate
+//  arguments and the command is not run via a shell, as the options
+//  are read from the StorageClass.
+func getFormatCmdsForDevice(fsType string, mkfsOptions []string, devicePath string) ([]string, error) {
+	if fsType == "" {
+		fsType = defaultBlockDeviceFSType
+	}
+	if err := validateBlockDeviceFSType(fsType); err != nil {
+		return nil, err
+	}
+	cmds := []string{"mkfs." + fsType}
+	cmds = append(cmds, mkfsOptions...)
+	return append(cmds, devicePath), nil
+}
+
+// getWipeCmdsForDevice returns the command that wipes the device
+//  by zeroing all of its blocks. The zeroing is offloaded to the
+//  device using blkdiscard if it is supported, else the device is
+//  overwritten using dd. The device path is passed as a positional
+//  parameter to avoid having to quote it.
+func getWipeCmdsForDevice(devicePath string) []string {
+	script := "dev=\"$1\"; " +
+		"blkdiscard -z \"$dev\" && exit 0; " +
+		"size=$(blockdev --getsize64 \"$dev\") || exit 1; " +
+		"dd if=/dev/zero of=\"$dev\" bs=1M count=$(( size / 1048576 )) conv=fsync || exit 1; " +
+		"rem=$(( size % 1048576 / 512 )); " +
+		"if [ \"$rem\" -gt 0 ]; then " +
+		"dd if=/dev/zero of=\"$dev\" bs=512 seek=$(( size / 512 - rem )) count=\"$rem\" conv=fsync || exit 1; fi"
+	return []string{"sh", "-c", script, "wipe", devicePath}
+}
+
+// getWipeTimeoutCounts returns the duration in seconds to wait for
+//  the wipe of a device of the given capacity in bytes.
+func getWipeTimeoutCounts(capacity uint64) int {
+	return WipeTimeoutCounts + int(capacity/uint64(WipeMinThroughputMiB*1024*1024))
+}
+
+// getCleanupCmdsForMount returns the command that deletes the contents
+//  of the mounted device, leaving the filesystem and the mount point
+//  in place.
+func getCleanupCmdsForMount() []string {
+	return []string{"find", mountedDataDir, "-mindepth", "1", "-delete"}
+}
+
+// createFormatPod launches a privileged helper pod, to format the claimed
+//  block device if it does not have a filesystem.
+func (p *Provisioner) createFormatPod(pOpts *HelperDevicePodOptions) error {
+	return p.launchDevicePod("format-", "local-device-format", pOpts)
+}
+
+// createWipePod launches a privileged helper pod, to wipe the data
+//  from the block device before the BDC is released.
+func (p *Provisioner) createWipePod(pOpts *HelperDevicePodOptions) error {
+	return p.launchDevicePod("wipe-", "local-device-wipe", pOpts)
+}
+
+// createMountCleanupPod launches a privileged helper pod, to delete the
+//  data from the mounted block device before the BDC is released.
+func (p *Provisioner) createMountCleanupPod(pOpts *HelperDevicePodOptions) error {
+	return p.launchDevicePod("cleanup-", "local-device-cleanup", pOpts)
+}
+
+// launchDevicePod launches a privileged pod on the node of the device
+//  with the host /dev (or the mount path of the device) mounted, and
+//  waits for the command to complete. The pod name has a random suffix,
+//  so that a retry does not collide with a pod left over by an earlier
+//  attempt.
+func (p *Provisioner) launchDevicePod(prefix, containerName string, pOpts *HelperDevicePodOptions) error {
+	if err := pOpts.validate(); err != nil {
+		return err
+	}
+
+	hostDir, podDir := "/dev", "/dev"
+	if pOpts.mountPath != "" {
+		hostDir, podDir = pOpts.mountPath, mountedDataDir
+	}
+
+	privileged := true
+	devicePod, err := pod.NewBuilder().
+		WithName(prefix + pOpts.name + "-" + rand.String(5)).
+		WithRestartPolicy(corev1.RestartPolicyNever).
+		WithNodeName(pOpts.nodeName).
+		WithContainerBuilder(
+			container.NewBuilder().
+				WithName(containerName).
+				WithImage(p.helperImage).
+				WithCommandNew(pOpts.cmdsForDevice).
+				WithPrivilegedSecurityContext(&privileged).
+				WithVolumeMountsNew([]corev1.VolumeMount{
+					{
+						Name:      "dev",
+						ReadOnly:  false,
+						MountPath: podDir,
+					},
+				}),
+		).
+		WithVolumeBuilder(
+			volume.NewBuilder().
+				WithName("dev").
+				WithHostDirectory(hostDir),
+		).
+		Build()
+	if err != nil {
+		return err
+	}
+
+	//Launch the device pod.
+	dPod, err := p.kubeClient.CoreV1().Pods(p.namespace).Create(devicePod)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		e := p.kubeClient.CoreV1().Pods(p.namespace).Delete(dPod.Name, &metav1.DeleteOptions{})
+		if e != nil {
+			glog.Errorf("unable to delete the helper pod: %v", e)
+		}
+	}()
+
+	//Wait for the device pod to complete it job and exit
+	for i := 0; i < pOpts.timeoutCounts; i++ {
+		checkPod, err := p.kubeClient.CoreV1().Pods(p.namespace).Get(dPod.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		switch checkPod.Status.Phase {
+		case corev1.PodSucceeded:
+			return nil
+		case corev1.PodFailed:
+			return errors.Errorf("helper pod %v failed for device %v", dPod.Name, pOpts.devicePath)
+		}
+		time.Sleep(1 * time.Second)
+	}
+	return errors.Errorf("%v process timeout after %v seconds", strings.TrimSuffix(prefix, "-"), pOpts.timeoutCounts)
+}
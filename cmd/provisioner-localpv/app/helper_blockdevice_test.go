@@ -0,0 +1,187 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHelperDevicePodOptionsValidate(t *testing.T) {
+	testCases := map[string]struct {
+		opts      *HelperDevicePodOptions
+		expectErr bool
+	}{
+		"Valid options": {
+			opts: &HelperDevicePodOptions{
+				name:          "pv1",
+				nodeName:      "node1",
+				devicePath:    "/dev/sdb",
+				cmdsForDevice: getWipeCmdsForDevice("/dev/sdb"),
+			},
+			expectErr: false,
+		},
+		"Missing node name": {
+			opts: &HelperDevicePodOptions{
+				name:          "pv1",
+				devicePath:    "/dev/sdb",
+				cmdsForDevice: getWipeCmdsForDevice("/dev/sdb"),
+			},
+			expectErr: true,
+		},
+		"Device path outside /dev": {
+			opts: &HelperDevicePodOptions{
+				name:          "pv1",
+				nodeName:      "node1",
+				devicePath:    "/var/openebs/local",
+				cmdsForDevice: getWipeCmdsForDevice("/var/openebs/local"),
+			},
+			expectErr: true,
+		},
+		"Valid mount path": {
+			opts: &HelperDevicePodOptions{
+				name:          "pv1",
+				nodeName:      "node1",
+				devicePath:    "/dev/sdb",
+				mountPath:     "/mnt/disk1",
+				cmdsForDevice: getCleanupCmdsForMount(),
+			},
+			expectErr: false,
+		},
+		"Mount path at root": {
+			opts: &HelperDevicePodOptions{
+				name:          "pv1",
+				nodeName:      "node1",
+				devicePath:    "/dev/sdb",
+				mountPath:     "/",
+				cmdsForDevice: getCleanupCmdsForMount(),
+			},
+			expectErr: true,
+		},
+		"Relative mount path": {
+			opts: &HelperDevicePodOptions{
+				name:          "pv1",
+				nodeName:      "node1",
+				devicePath:    "/dev/sdb",
+				mountPath:     "mnt/disk1",
+				cmdsForDevice: getCleanupCmdsForMount(),
+			},
+			expectErr: true,
+		},
+		"Missing command": {
+			opts: &HelperDevicePodOptions{
+				name:       "pv1",
+				nodeName:   "node1",
+				devicePath: "/dev/sdb",
+			},
+			expectErr: true,
+		},
+	}
+
+	for k, v := range testCases {
+		v := v
+		t.Run(k, func(t *testing.T) {
+			err := v.opts.validate()
+			if v.expectErr && err == nil {
+				t.Errorf("expected error got nil")
+			}
+			if !v.expectErr && err != nil {
+				t.Errorf("expected no error got %v", err)
+			}
+		})
+	}
+}
+
+func TestGetFormatCmdsForDevice(t *testing.T) {
+	testCases := map[string]struct {
+		fsType      string
+		mkfsOptions []string
+		expectCmds  []string
+		expectErr   bool
+	}{
+		"Default fs type": {
+			fsType:     "",
+			expectCmds: []string{"mkfs.ext4", "/dev/sdb"},
+		},
+		"Custom fs type with options": {
+			fsType:      "xfs",
+			mkfsOptions: []string{"-f", "-K"},
+			expectCmds:  []string{"mkfs.xfs", "-f", "-K", "/dev/sdb"},
+		},
+		"Options are not interpreted by a shell": {
+			fsType:      "ext4",
+			mkfsOptions: []string{";", "rm", "-rf", "/"},
+			expectCmds:  []string{"mkfs.ext4", ";", "rm", "-rf", "/", "/dev/sdb"},
+		},
+		"Unsupported fs type": {
+			fsType:    "ext4 /dev/sda; true",
+			expectErr: true,
+		},
+	}
+
+	for k, v := range testCases {
+		v := v
+		t.Run(k, func(t *testing.T) {
+			cmds, err := getFormatCmdsForDevice(v.fsType, v.mkfsOptions, "/dev/sdb")
+			if v.expectErr && err == nil {
+				t.Errorf("expected error got nil")
+			}
+			if !v.expectErr && err != nil {
+				t.Errorf("expected no error got %v", err)
+			}
+			if !reflect.DeepEqual(cmds, v.expectCmds) {
+				t.Errorf("expected format command %v got %v", v.expectCmds, cmds)
+			}
+		})
+	}
+}
+
+func TestGetWipeCmdsForDevice(t *testing.T) {
+	cmds := getWipeCmdsForDevice("/dev/sdb")
+	if len(cmds) != 5 || cmds[4] != "/dev/sdb" {
+		t.Fatalf("expected device path as argument got %v", cmds)
+	}
+	if strings.Contains(cmds[2], "/dev/sdb") {
+		t.Fatalf("expected device path to be passed as argument got %q", cmds[2])
+	}
+}
+
+func TestGetWipeTimeoutCounts(t *testing.T) {
+	testCases := map[string]struct {
+		capacity uint64
+		expect   int
+	}{
+		"Unknown capacity": {
+			capacity: 0,
+			expect:   WipeTimeoutCounts,
+		},
+		"Timeout grows with capacity": {
+			capacity: 100 * 1024 * 1024 * 1024,
+			expect:   WipeTimeoutCounts + 100*1024/WipeMinThroughputMiB,
+		},
+	}
+
+	for k, v := range testCases {
+		v := v
+		t.Run(k, func(t *testing.T) {
+			if got := getWipeTimeoutCounts(v.capacity); got != v.expect {
+				t.Errorf("expected timeout %v got %v", v.expect, got)
+			}
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	mconfig "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
 	mPV "github.com/openebs/maya/pkg/kubernetes/persistentvolume/v1alpha1"
 	"k8s.io/api/core/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
 	//metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -44,15 +45,50 @@ func (p *Provisioner) ProvisionBlockDevice(opts pvController.VolumeOptions, volu
 		capacity: capacity.String(),
 	}
 
-	path, blkPath, err := p.getBlockDevicePath(blkDevOpts)
+	if err := validateBlockDeviceFSType(fsType); err != nil {
+		glog.Infof("Initialize volume %v failed: %v", name, err)
+		return nil, err
+	}
+
+	path, blkPath, blkFSType, err := p.getBlockDevicePath(blkDevOpts)
 	if err != nil {
 		glog.Infof("Initialize volume %v failed: %v", name, err)
 		return nil, err
 	}
 	glog.Infof("Creating volume %v on %v at %v(%v)", name, node.Name, path, blkPath)
-	if path == "" {
+	if path == "" && blkFSType != "" {
+		//The claimed device is not mounted on the node, but already
+		//has a filesystem. Use it as is, to avoid losing its data.
+		path = blkPath
+		if fsType != "" && fsType != blkFSType {
+			glog.Infof("Using existing fs{%v} of block device{%v} instead of fs{%v}", blkFSType, blkPath, fsType)
+		}
+		fsType = blkFSType
+	} else if path == "" {
 		path = blkPath
+		cmds, err := getFormatCmdsForDevice(fsType, volumeConfig.GetMkfsOptions(), blkPath)
+		if err != nil {
+			glog.Infof("Format device %v for volume %v failed: %v", blkPath, name, err)
+			return nil, err
+		}
+		if fsType == "" {
+			fsType = defaultBlockDeviceFSType
+		}
 		glog.Infof("Using block device{%v} with fs{%v}", blkPath, fsType)
+
+		//The claimed device is not mounted on the node and has no
+		//filesystem. Format it, before it is handed over as Local PV.
+		podOpts := &HelperDevicePodOptions{
+			cmdsForDevice: cmds,
+			name:          name,
+			devicePath:    blkPath,
+			nodeName:      node.Name,
+			timeoutCounts: FormatTimeoutCounts,
+		}
+		if err := p.createFormatPod(podOpts); err != nil {
+			glog.Infof("Format device %v for volume %v failed: %v", blkPath, name, err)
+			return nil, err
+		}
 	}
 
 	// TODO
@@ -84,6 +120,7 @@ func (p *Provisioner) ProvisionBlockDevice(opts pvController.VolumeOptions, volu
 		WithVolumeMode(fs).
 		WithCapacityQty(pvc.Spec.Resources.Requests[v1.ResourceName(v1.ResourceStorage)]).
 		WithLocalHostPathFormat(path, fsType).
+		WithMountOptions(volumeConfig.GetMountOptions()).
 		WithNodeAffinity(node.Name).
 		Build()
 
@@ -113,6 +150,13 @@ func (p *Provisioner) DeleteBlockDevice(pv *v1.PersistentVolume) (err error) {
 	//Initiate clean up only when reclaim policy is not retain.
 	//TODO: this part of the code could be eliminated by setting up
 	// BDC owner reference to PVC.
+	if blkDevOpts.hasBDC() {
+		if err := p.wipeBlockDevice(pv, blkDevOpts); err != nil {
+			glog.Infof("wipe volume %v failed: %v", pv.Name, err)
+			return err
+		}
+	}
+
 	glog.Infof("Release the Block Device Claim %v for PV %v", blkDevOpts.bdcName, pv.Name)
 
 	if err := p.deleteBlockDeviceClaim(blkDevOpts); err != nil {
@@ -121,3 +165,50 @@ func (p *Provisioner) DeleteBlockDevice(pv *v1.PersistentVolume) (err error) {
 	}
 	return nil
 }
+
+// wipeBlockDevice launches a helper pod to zero all the blocks of the
+//  device associated with the Local PV, so that the data is not exposed
+//  to the next claim of the device. Devices that are mounted on the node
+//  are not formatted or managed by the provisioner, so only the contents
+//  of their mount path are deleted.
+func (p *Provisioner) wipeBlockDevice(pv *v1.PersistentVolume, blkDevOpts *HelperBlockDeviceOptions) error {
+	bd, err := p.getBlockDeviceFromBDC(blkDevOpts)
+	if k8serror.IsNotFound(err) {
+		//The BDC is released once the device is wiped. A missing
+		//BDC implies that an earlier attempt completed the clean up.
+		glog.Infof("Skip wipe for PV %v: BDC %v not found", pv.Name, blkDevOpts.bdcName)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	blkPath := getBlockDevicePathFromBD(bd)
+
+	node := mPV.NewForAPIObject(pv).GetAffinitedNode()
+	if node == "" {
+		return errors.Errorf("cannot find affinited node")
+	}
+
+	if mountPath := bd.Spec.FileSystem.Mountpoint; mountPath != "" {
+		glog.Infof("Cleaning up device %v of volume %v on %v at %v", blkPath, pv.Name, node, mountPath)
+		podOpts := &HelperDevicePodOptions{
+			cmdsForDevice: getCleanupCmdsForMount(),
+			name:          pv.Name,
+			devicePath:    blkPath,
+			mountPath:     mountPath,
+			nodeName:      node,
+			timeoutCounts: CleanupTimeoutCounts,
+		}
+		return p.createMountCleanupPod(podOpts)
+	}
+
+	glog.Infof("Wiping device %v of volume %v on %v", blkPath, pv.Name, node)
+	podOpts := &HelperDevicePodOptions{
+		cmdsForDevice: getWipeCmdsForDevice(blkPath),
+		name:          pv.Name,
+		devicePath:    blkPath,
+		nodeName:      node,
+		timeoutCounts: getWipeTimeoutCounts(bd.Spec.Capacity.Storage),
+	}
+	return p.createWipePod(podOpts)
+}
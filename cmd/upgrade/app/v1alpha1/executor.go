@@ -17,14 +17,20 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
 	"os"
+	"sync"
 
+	"github.com/golang/glog"
 	apis "github.com/openebs/maya/pkg/apis/openebs.io/upgrade/v1alpha1"
+	castapis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
 	cast "github.com/openebs/maya/pkg/castemplate/v1alpha1"
 	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
 	pod "github.com/openebs/maya/pkg/kubernetes/pod/v1alpha1"
+	upgraderesult "github.com/openebs/maya/pkg/upgrade/result/v1alpha1"
 	upgrade "github.com/openebs/maya/pkg/upgrade/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
 )
 
 const (
@@ -36,9 +42,82 @@ const (
 	envPodNamespace = "OPENEBS_IO_POD_NAMESPACE"
 )
 
-// Executor contains list of castEngine
+// Steps of upgrading a unit of upgrade that are
+// recorded as decisions in its upgrade result
+const (
+	stepPreFlight = "PreFlight"
+	stepDryRun    = "DryRun"
+	stepUpgrade   = "Upgrade"
+	stepRollback  = "Rollback"
+)
+
+// Decisions taken in a step of the upgrade
+const (
+	decisionPassed    = "Passed"
+	decisionFailed    = "Failed"
+	decisionSkipped   = "Skipped"
+	decisionSucceeded = "Succeeded"
+)
+
+// States of a unit of upgrade recorded as the
+// pre & post state in its upgrade result
+const (
+	stateHealthy        = "Healthy"
+	stateUnhealthy      = "Unhealthy"
+	stateUpgraded       = "Upgraded"
+	stateFailed         = "Failed"
+	stateRolledBack     = "RolledBack"
+	stateRollbackFailed = "RollbackFailed"
+)
+
+// unitOfUpgrade is a resource that is upgraded along
+// with the engines to upgrade & rollback the resource
+type unitOfUpgrade struct {
+	resource apis.ResourceDetails
+	result   *apis.UpgradeResult
+	engine   cast.Interface
+	// rollback reverts the resource if its upgrade
+	// failed; the resource is reverted to its captured
+	// versions if this is nil
+	rollback cast.Interface
+	// versions are the versions & the container images
+	// of the resource captured before upgrade
+	versions []apis.ResourceVersion
+}
+
+// resultUpdater applies the given changes to the
+// upgrade result
+type resultUpdater func(
+	result *apis.UpgradeResult, update func(*apis.UpgradeResultStatus)) error
+
+// Executor contains list of units of upgrade
+// and the options to upgrade these units
 type Executor struct {
-	engines []cast.Interface
+	units []*unitOfUpgrade
+
+	// dryRun runs the pre-flight checks & renders
+	// the castemplates without upgrading
+	dryRun bool
+
+	// parallelism is the no of units that are
+	// upgraded at the same time
+	parallelism int
+
+	// checkers are the pre-flight checks for each
+	// kind of unit of upgrade
+	checkers map[string]healthChecker
+
+	// capturers capture the versions of each kind
+	// of unit of upgrade before it is upgraded
+	capturers map[string]versionCapturer
+
+	// restore reverts a unit whose upgrade failed to
+	// its captured versions
+	restore versionRestorer
+
+	// updateResult records the decisions in the
+	// upgrade result of a unit
+	updateResult resultUpdater
 }
 
 // ExecutorBuilder helps to build Executor instance
@@ -101,7 +180,18 @@ func ExecutorBuilderForConfig(cfg *apis.UpgradeConfig) *ExecutorBuilder {
 		tasks = append(tasks, task)
 	}
 
-	engines := []cast.Interface{}
+	var rollbackObj *castapis.CASTemplate
+	if cfg.RollbackCASTemplate != "" {
+		rollbackObj, err = cast.KubeClient().
+			Get(cfg.RollbackCASTemplate, metav1.GetOptions{})
+		if err != nil {
+			executorBuilder.Errors = append(executorBuilder.Errors,
+				errors.Wrapf(err, "failed to instantiate executor builder: %s", cfg))
+			return executorBuilder
+		}
+	}
+
+	units := []*unitOfUpgrade{}
 	for _, resource := range cfg.Resources {
 		resource := resource // pin it
 		upgradeResult, err := NewUpgradeResultGetOrCreateBuilder().
@@ -130,9 +220,36 @@ func ExecutorBuilderForConfig(cfg *apis.UpgradeConfig) *ExecutorBuilder {
 			return executorBuilder
 		}
 
-		engines = append(engines, e)
+		unit := &unitOfUpgrade{
+			resource: resource,
+			result:   upgradeResult,
+			engine:   e,
+		}
+		if rollbackObj != nil {
+			unit.rollback, err = upgrade.NewCASTEngineBuilder().
+				WithCASTemplate(rollbackObj).
+				WithUnitOfUpgrade(&resource).
+				WithRuntimeConfig(cfg.Data).
+				WithUpgradeResult(upgradeResult).
+				Build()
+			if err != nil {
+				executorBuilder.Errors = append(executorBuilder.Errors,
+					errors.Wrapf(err,
+						"failed to instantiate executor builder: rollback: %s: %s", resource, cfg))
+				return executorBuilder
+			}
+		}
+		units = append(units, unit)
+	}
+	executorBuilder.object = &Executor{
+		units:        units,
+		dryRun:       cfg.DryRun,
+		parallelism:  cfg.Parallelism,
+		checkers:     defaultHealthCheckers,
+		capturers:    defaultVersionCapturers,
+		restore:      restoreVersions,
+		updateResult: updateUpgradeResult,
 	}
-	executorBuilder.object = &Executor{engines: engines}
 	return executorBuilder
 }
 
@@ -145,14 +262,235 @@ func (eb *ExecutorBuilder) Build() (*Executor, error) {
 	return eb.object, nil
 }
 
-// Execute runs list of castEngines. It returns error
-// if there is any while running these engines
+// Execute upgrades the units of upgrade. The units are
+// upgraded by as many workers as the parallelism. A unit
+// is upgraded only if its pre-flight check passes and is
+// rolled back if its upgrade fails. No more units are
+// upgraded once a unit fails. It returns error if any
+// of the units could not be upgraded.
 func (e *Executor) Execute() error {
-	for _, engine := range e.engines {
-		_, err := engine.Run()
-		if err != nil {
-			return errors.Wrap(err, "failed to run upgrade engine")
+	parallelism := e.parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	failed := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(errs) != 0
+	}
+
+	queue := make(chan *unitOfUpgrade)
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for unit := range queue {
+				err := e.upgrade(unit)
+				if err != nil {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for _, unit := range e.units {
+		if failed() {
+			e.record(unit, stepUpgrade, decisionSkipped,
+				"upgrade of another resource failed", nil)
+			continue
 		}
+		queue <- unit
+	}
+	close(queue)
+	wg.Wait()
+
+	if len(errs) != 0 {
+		return (&errors.ErrorList{Errors: errs}).
+			WithStack("failed to run upgrade engine")
+	}
+	return nil
+}
+
+// upgrade runs the pre-flight check & upgrades the unit.
+// The unit is rolled back if its upgrade fails.
+func (e *Executor) upgrade(unit *unitOfUpgrade) error {
+	healthy, reason, err := e.preflight(&unit.resource)
+	if err != nil {
+		e.record(unit, stepPreFlight, decisionFailed, err.Error(), nil)
+		return err
+	}
+	if !healthy {
+		e.record(unit, stepPreFlight, decisionFailed, reason,
+			func(s *apis.UpgradeResultStatus) {
+				s.Resource.PreState = newResourceState(stateUnhealthy, reason)
+			})
+		return errors.Errorf(
+			"failed to upgrade: pre-flight check failed: %s: %s", reason, unit.resource)
+	}
+	unit.versions, err = e.capture(unit)
+	if err != nil {
+		e.record(unit, stepPreFlight, decisionFailed, err.Error(), nil)
+		return err
 	}
+	e.record(unit, stepPreFlight, decisionPassed, "resource is healthy",
+		func(s *apis.UpgradeResultStatus) {
+			s.Resource.PreState = newResourceState(stateHealthy, "")
+			s.Resource.PreState.Versions = unit.versions
+		})
+
+	if e.dryRun {
+		return e.dryRunUnit(unit)
+	}
+
+	_, err = unit.engine.Run()
+	if err == nil {
+		e.record(unit, stepUpgrade, decisionSucceeded, "resource upgraded",
+			func(s *apis.UpgradeResultStatus) {
+				s.ActualCount = 1
+				s.Resource.PostState = newResourceState(stateUpgraded, "")
+			})
+		return nil
+	}
+
+	upgradeErr := errors.Wrapf(err, "failed to upgrade: %s", unit.resource)
+	e.record(unit, stepUpgrade, decisionFailed, err.Error(),
+		func(s *apis.UpgradeResultStatus) {
+			s.FailedCount = 1
+			s.Resource.PostState = newResourceState(stateFailed, err.Error())
+		})
+	e.rollback(unit)
+	return upgradeErr
+}
+
+// dryRunUnit renders the castemplate of the unit
+// without upgrading it
+func (e *Executor) dryRunUnit(unit *unitOfUpgrade) error {
+	status, err := unit.engine.DryRun()
+	if err == nil && status.Error != "" {
+		err = errors.New(status.Error)
+	}
+	if err != nil {
+		e.record(unit, stepDryRun, decisionFailed, err.Error(), nil)
+		return errors.Wrapf(err, "failed to dry run upgrade: %s", unit.resource)
+	}
+	e.record(unit, stepDryRun, decisionPassed,
+		fmt.Sprintf("rendered %d task(s)", len(status.Tasks)), nil)
 	return nil
 }
+
+// capture returns the versions & the snapshots of the
+// unit before upgrade. Versions recorded by an earlier
+// attempt to upgrade the unit are reused since the unit
+// may have been partially upgraded by that attempt.
+// Nothing is captured for the kinds without a capturer,
+// hence they are rolled back only by a rollback
+// castemplate.
+func (e *Executor) capture(unit *unitOfUpgrade) ([]apis.ResourceVersion, error) {
+	recorded := unit.result.Status.Resource.PreState.Versions
+	if len(recorded) != 0 {
+		return recorded, nil
+	}
+	capture, ok := e.capturers[unit.resource.Kind]
+	if !ok {
+		return nil, nil
+	}
+	versions, err := capture(&unit.resource)
+	if err != nil {
+		return nil, errors.Wrapf(err,
+			"failed to capture versions: %s", unit.resource)
+	}
+	return versions, nil
+}
+
+// rollback reverts the unit whose upgrade failed. The
+// rollback castemplate is run if it is configured else
+// the unit is reverted to the labels & the spec captured
+// before upgrade.
+func (e *Executor) rollback(unit *unitOfUpgrade) {
+	var err error
+	switch {
+	case unit.rollback != nil:
+		_, err = unit.rollback.Run()
+	case len(unit.versions) != 0:
+		err = e.restore(unit.versions)
+	default:
+		e.record(unit, stepRollback, decisionSkipped,
+			"no versions were captured before upgrade", nil)
+		return
+	}
+	if err != nil {
+		glog.Errorf("failed to rollback upgrade: %s: %v", unit.resource.Name, err)
+		e.record(unit, stepRollback, decisionFailed, err.Error(),
+			func(s *apis.UpgradeResultStatus) {
+				s.Resource.PostState = newResourceState(stateRollbackFailed, err.Error())
+			})
+		return
+	}
+	e.record(unit, stepRollback, decisionSucceeded, "resource rolled back",
+		func(s *apis.UpgradeResultStatus) {
+			s.Resource.PostState = newResourceState(stateRolledBack, "")
+		})
+}
+
+// record adds the decision to the upgrade result of
+// the unit along with any other changes to its status.
+// Failure to record is logged since it should not
+// change the outcome of the upgrade.
+func (e *Executor) record(
+	unit *unitOfUpgrade,
+	step, decision, message string,
+	update func(*apis.UpgradeResultStatus),
+) {
+	glog.Infof("upgrade of %s {%s/%s}: %s: %s: %s", unit.resource.Kind,
+		unit.resource.Namespace, unit.resource.Name, step, decision, message)
+	err := e.updateResult(unit.result, func(s *apis.UpgradeResultStatus) {
+		s.DesiredCount = 1
+		s.Decisions = append(s.Decisions, apis.UpgradeDecision{
+			Step:     step,
+			Decision: decision,
+			Message:  message,
+			Time:     metav1.Now(),
+		})
+		if update != nil {
+			update(s)
+		}
+	})
+	if err != nil {
+		glog.Errorf("failed to record upgrade decision: %s: %v", unit.resource.Name, err)
+	}
+}
+
+// newResourceState returns a resource state with the
+// given status
+func newResourceState(status, message string) apis.ResourceState {
+	return apis.ResourceState{
+		Status:             status,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	}
+}
+
+// updateUpgradeResult applies the changes to the latest
+// version of the upgrade result. Runtasks of the upgrade
+// update the same upgrade result; hence the update is
+// retried on conflict.
+func updateUpgradeResult(
+	result *apis.UpgradeResult, update func(*apis.UpgradeResultStatus)) error {
+	client := upgraderesult.NewKubeClient().WithNamespace(result.Namespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest, err := client.Get(result.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		update(&latest.Status)
+		_, err = client.Update(latest)
+		return err
+	})
+}
@@ -0,0 +1,394 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/upgrade/v1alpha1"
+	castapis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+)
+
+type fakeEngine struct {
+	mu       sync.Mutex
+	runErr   error
+	dryRuns  int
+	runs     int
+	dryRunOK bool
+}
+
+func (f *fakeEngine) SetConfig(values map[string]interface{})             {}
+func (f *fakeEngine) SetValues(key string, values map[string]interface{}) {}
+func (f *fakeEngine) SetContext(ctx context.Context)                      {}
+
+func (f *fakeEngine) Run() ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.runs++
+	return nil, f.runErr
+}
+
+func (f *fakeEngine) DryRun() (*castapis.CASTemplateDryRunStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.dryRuns++
+	if !f.dryRunOK {
+		return &castapis.CASTemplateDryRunStatus{Error: "failed to render"}, nil
+	}
+	return &castapis.CASTemplateDryRunStatus{Tasks: []castapis.RunTaskDryRunResult{{Name: "task-1"}}}, nil
+}
+
+type fakeResults struct {
+	mu     sync.Mutex
+	status map[string]*apis.UpgradeResultStatus
+}
+
+func (f *fakeResults) update(
+	result *apis.UpgradeResult, update func(*apis.UpgradeResultStatus)) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, ok := f.status[result.Name]
+	if !ok {
+		s = &apis.UpgradeResultStatus{}
+		f.status[result.Name] = s
+	}
+	update(s)
+	return nil
+}
+
+func (f *fakeResults) decisions(name string) []string {
+	var d []string
+	if s, ok := f.status[name]; ok {
+		for _, decision := range s.Decisions {
+			d = append(d, decision.Step+"/"+decision.Decision)
+		}
+	}
+	return d
+}
+
+func fakeUnit(name string, engine, rollback *fakeEngine) *unitOfUpgrade {
+	unit := &unitOfUpgrade{
+		resource: apis.ResourceDetails{Name: name, Kind: cstorPoolKind},
+		result:   &apis.UpgradeResult{},
+		engine:   engine,
+	}
+	unit.result.Name = name
+	if rollback != nil {
+		unit.rollback = rollback
+	}
+	return unit
+}
+
+func fakeChecker(unhealthy ...string) map[string]healthChecker {
+	return map[string]healthChecker{
+		cstorPoolKind: func(r *apis.ResourceDetails) (bool, string, error) {
+			for _, name := range unhealthy {
+				if r.Name == name {
+					return false, "pool is offline", nil
+				}
+			}
+			return true, "", nil
+		},
+	}
+}
+
+type fakeVersions struct {
+	mu         sync.Mutex
+	versions   []apis.ResourceVersion
+	captureErr error
+	restoreErr error
+	captures   int
+	restored   [][]apis.ResourceVersion
+}
+
+func (f *fakeVersions) capturers() map[string]versionCapturer {
+	return map[string]versionCapturer{
+		cstorPoolKind: func(r *apis.ResourceDetails) ([]apis.ResourceVersion, error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			f.captures++
+			return f.versions, f.captureErr
+		},
+	}
+}
+
+func (f *fakeVersions) restore(versions []apis.ResourceVersion) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.restored = append(f.restored, versions)
+	return f.restoreErr
+}
+
+func fakePoolVersions(version string) []apis.ResourceVersion {
+	return []apis.ResourceVersion{
+		{Kind: cstorPoolCRKind, Name: "pool-1", Version: version},
+		{
+			Kind:      deploymentKind,
+			Name:      "pool-1-deploy",
+			Namespace: "openebs",
+			Version:   version,
+			Images:    map[string]string{"cstor-pool": "openebs/cstor-pool:" + version},
+		},
+	}
+}
+
+func TestExecute(t *testing.T) {
+	tests := map[string]struct {
+		dryRun            bool
+		kind              string
+		unhealthy         []string
+		engines           map[string]*fakeEngine
+		rollbacks         map[string]*fakeEngine
+		versions          *fakeVersions
+		recordedVersions  []apis.ResourceVersion
+		isErr             bool
+		expectedDecisions map[string][]string
+		expectedRuns      map[string]int
+		expectedRollbacks map[string]int
+		expectedPostState map[string]string
+		expectedCaptures  int
+		expectedRestored  [][]apis.ResourceVersion
+	}{
+		"all resources are upgraded": {
+			engines: map[string]*fakeEngine{"pool-1": {}, "pool-2": {}},
+			expectedDecisions: map[string][]string{
+				"pool-1": {"PreFlight/Passed", "Upgrade/Succeeded"},
+				"pool-2": {"PreFlight/Passed", "Upgrade/Succeeded"},
+			},
+			expectedRuns:      map[string]int{"pool-1": 1, "pool-2": 1},
+			expectedPostState: map[string]string{"pool-1": stateUpgraded, "pool-2": stateUpgraded},
+			expectedCaptures:  2,
+		},
+		"unhealthy resource is not upgraded": {
+			unhealthy: []string{"pool-1"},
+			engines:   map[string]*fakeEngine{"pool-1": {}, "pool-2": {}},
+			isErr:     true,
+			expectedDecisions: map[string][]string{
+				"pool-1": {"PreFlight/Failed"},
+				"pool-2": {"Upgrade/Skipped"},
+			},
+			expectedRuns: map[string]int{"pool-1": 0, "pool-2": 0},
+		},
+		"dry run does not upgrade": {
+			dryRun:  true,
+			engines: map[string]*fakeEngine{"pool-1": {dryRunOK: true}},
+			expectedDecisions: map[string][]string{
+				"pool-1": {"PreFlight/Passed", "DryRun/Passed"},
+			},
+			expectedRuns:     map[string]int{"pool-1": 0},
+			expectedCaptures: 1,
+		},
+		"dry run reports render failure": {
+			dryRun:  true,
+			engines: map[string]*fakeEngine{"pool-1": {}},
+			isErr:   true,
+			expectedDecisions: map[string][]string{
+				"pool-1": {"PreFlight/Passed", "DryRun/Failed"},
+			},
+			expectedRuns:     map[string]int{"pool-1": 0},
+			expectedCaptures: 1,
+		},
+		"failed upgrade is rolled back": {
+			engines:   map[string]*fakeEngine{"pool-1": {runErr: errors.New("task failed")}},
+			rollbacks: map[string]*fakeEngine{"pool-1": {}},
+			isErr:     true,
+			expectedDecisions: map[string][]string{
+				"pool-1": {"PreFlight/Passed", "Upgrade/Failed", "Rollback/Succeeded"},
+			},
+			expectedRuns:      map[string]int{"pool-1": 1},
+			expectedRollbacks: map[string]int{"pool-1": 1},
+			expectedPostState: map[string]string{"pool-1": stateRolledBack},
+			expectedCaptures:  1,
+		},
+		"failed upgrade is restored to captured versions": {
+			engines:  map[string]*fakeEngine{"pool-1": {runErr: errors.New("task failed")}},
+			versions: &fakeVersions{versions: fakePoolVersions("1.0.0")},
+			isErr:    true,
+			expectedDecisions: map[string][]string{
+				"pool-1": {"PreFlight/Passed", "Upgrade/Failed", "Rollback/Succeeded"},
+			},
+			expectedRuns:      map[string]int{"pool-1": 1},
+			expectedPostState: map[string]string{"pool-1": stateRolledBack},
+			expectedCaptures:  1,
+			expectedRestored:  [][]apis.ResourceVersion{fakePoolVersions("1.0.0")},
+		},
+		"versions recorded by earlier attempt are restored": {
+			engines:          map[string]*fakeEngine{"pool-1": {runErr: errors.New("task failed")}},
+			versions:         &fakeVersions{versions: fakePoolVersions("1.1.0")},
+			recordedVersions: fakePoolVersions("1.0.0"),
+			isErr:            true,
+			expectedDecisions: map[string][]string{
+				"pool-1": {"PreFlight/Passed", "Upgrade/Failed", "Rollback/Succeeded"},
+			},
+			expectedRuns:      map[string]int{"pool-1": 1},
+			expectedPostState: map[string]string{"pool-1": stateRolledBack},
+			expectedCaptures:  0,
+			expectedRestored:  [][]apis.ResourceVersion{fakePoolVersions("1.0.0")},
+		},
+		"failed restore is recorded": {
+			engines: map[string]*fakeEngine{"pool-1": {runErr: errors.New("task failed")}},
+			versions: &fakeVersions{
+				versions:   fakePoolVersions("1.0.0"),
+				restoreErr: errors.New("patch failed"),
+			},
+			isErr: true,
+			expectedDecisions: map[string][]string{
+				"pool-1": {"PreFlight/Passed", "Upgrade/Failed", "Rollback/Failed"},
+			},
+			expectedRuns:      map[string]int{"pool-1": 1},
+			expectedPostState: map[string]string{"pool-1": stateRollbackFailed},
+			expectedCaptures:  1,
+			expectedRestored:  [][]apis.ResourceVersion{fakePoolVersions("1.0.0")},
+		},
+		"rollback is skipped if no versions were captured": {
+			engines: map[string]*fakeEngine{"pool-1": {runErr: errors.New("task failed")}},
+			isErr:   true,
+			expectedDecisions: map[string][]string{
+				"pool-1": {"PreFlight/Passed", "Upgrade/Failed", "Rollback/Skipped"},
+			},
+			expectedRuns:      map[string]int{"pool-1": 1},
+			expectedPostState: map[string]string{"pool-1": stateFailed},
+			expectedCaptures:  1,
+		},
+		"failure to capture versions fails pre-flight": {
+			engines:  map[string]*fakeEngine{"pool-1": {}},
+			versions: &fakeVersions{captureErr: errors.New("pool not found")},
+			isErr:    true,
+			expectedDecisions: map[string][]string{
+				"pool-1": {"PreFlight/Failed"},
+			},
+			expectedRuns:     map[string]int{"pool-1": 0},
+			expectedCaptures: 1,
+		},
+		"kind without checks is upgraded without snapshot": {
+			kind:    "CStorPool",
+			engines: map[string]*fakeEngine{"pool-1": {runErr: errors.New("task failed")}},
+			isErr:   true,
+			expectedDecisions: map[string][]string{
+				"pool-1": {"PreFlight/Passed", "Upgrade/Failed", "Rollback/Skipped"},
+			},
+			expectedRuns:      map[string]int{"pool-1": 1},
+			expectedPostState: map[string]string{"pool-1": stateFailed},
+		},
+		"failed rollback is recorded": {
+			engines:   map[string]*fakeEngine{"pool-1": {runErr: errors.New("task failed")}},
+			rollbacks: map[string]*fakeEngine{"pool-1": {runErr: errors.New("rollback failed")}},
+			isErr:     true,
+			expectedDecisions: map[string][]string{
+				"pool-1": {"PreFlight/Passed", "Upgrade/Failed", "Rollback/Failed"},
+			},
+			expectedRuns:      map[string]int{"pool-1": 1},
+			expectedRollbacks: map[string]int{"pool-1": 1},
+			expectedPostState: map[string]string{"pool-1": stateRollbackFailed},
+			expectedCaptures:  1,
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			results := &fakeResults{status: map[string]*apis.UpgradeResultStatus{}}
+			versions := mock.versions
+			if versions == nil {
+				versions = &fakeVersions{}
+			}
+			e := &Executor{
+				dryRun:       mock.dryRun,
+				checkers:     fakeChecker(mock.unhealthy...),
+				capturers:    versions.capturers(),
+				restore:      versions.restore,
+				updateResult: results.update,
+			}
+			for _, resource := range []string{"pool-1", "pool-2"} {
+				engine, ok := mock.engines[resource]
+				if !ok {
+					continue
+				}
+				unit := fakeUnit(resource, engine, mock.rollbacks[resource])
+				if mock.kind != "" {
+					unit.resource.Kind = mock.kind
+				}
+				unit.result.Status.Resource.PreState.Versions = mock.recordedVersions
+				e.units = append(e.units, unit)
+			}
+
+			err := e.Execute()
+			if mock.isErr != (err != nil) {
+				t.Fatalf("Test %q failed: expected error '%t': actual error '%v'", name, mock.isErr, err)
+			}
+			for resource, expected := range mock.expectedDecisions {
+				if got := results.decisions(resource); !reflect.DeepEqual(got, expected) {
+					t.Fatalf("Test %q failed: %s: expected decisions '%v': actual '%v'", name, resource, expected, got)
+				}
+			}
+			for resource, expected := range mock.expectedRuns {
+				if got := mock.engines[resource].runs; got != expected {
+					t.Fatalf("Test %q failed: %s: expected runs '%d': actual '%d'", name, resource, expected, got)
+				}
+			}
+			for resource, expected := range mock.expectedRollbacks {
+				if got := mock.rollbacks[resource].runs; got != expected {
+					t.Fatalf("Test %q failed: %s: expected rollbacks '%d': actual '%d'", name, resource, expected, got)
+				}
+			}
+			for resource, expected := range mock.expectedPostState {
+				if got := results.status[resource].Resource.PostState.Status; got != expected {
+					t.Fatalf("Test %q failed: %s: expected post state '%s': actual '%s'", name, resource, expected, got)
+				}
+			}
+			if versions.captures != mock.expectedCaptures {
+				t.Fatalf("Test %q failed: expected captures '%d': actual '%d'", name, mock.expectedCaptures, versions.captures)
+			}
+			if !reflect.DeepEqual(versions.restored, mock.expectedRestored) {
+				t.Fatalf("Test %q failed: expected restored versions '%v': actual '%v'", name, mock.expectedRestored, versions.restored)
+			}
+		})
+	}
+}
+
+func TestExecuteParallelism(t *testing.T) {
+	results := &fakeResults{status: map[string]*apis.UpgradeResultStatus{}}
+	versions := &fakeVersions{}
+	e := &Executor{
+		parallelism:  3,
+		checkers:     fakeChecker(),
+		capturers:    versions.capturers(),
+		restore:      versions.restore,
+		updateResult: results.update,
+	}
+	engines := map[string]*fakeEngine{}
+	for _, name := range []string{"pool-1", "pool-2", "pool-3", "pool-4", "pool-5"} {
+		engines[name] = &fakeEngine{}
+		e.units = append(e.units, fakeUnit(name, engines[name], nil))
+	}
+
+	err := e.Execute()
+	if err != nil {
+		t.Fatalf("Test failed: expected no error: actual error '%v'", err)
+	}
+	for name, engine := range engines {
+		if engine.runs != 1 {
+			t.Fatalf("Test failed: %s: expected runs '1': actual '%d'", name, engine.runs)
+		}
+		if results.status[name].ActualCount != 1 {
+			t.Fatalf("Test failed: %s: expected actual count '1': actual '%d'", name, results.status[name].ActualCount)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"path"
+
+	"github.com/ghodss/yaml"
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/upgrade/v1alpha1"
+	csp "github.com/openebs/maya/pkg/cstor/pool/v1alpha2"
+	cv "github.com/openebs/maya/pkg/cstor/volume/v1alpha1"
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+	kclient "github.com/openebs/maya/pkg/kubernetes/client/v1alpha1"
+	upgraderesult "github.com/openebs/maya/pkg/upgrade/result/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+)
+
+const (
+	// jobNamePrefix is the prefix of the name of the
+	// upgrade jobs launched by the planner
+	jobNamePrefix = "openebs-upgrade-"
+	// labelPlannedBy label is set on the upgrade jobs
+	// launched by the planner
+	labelPlannedBy = "upgrade.openebs.io/planned-by"
+	// labelTargetVersion label contains the version an
+	// upgrade job upgrades its resources to
+	labelTargetVersion = "upgrade.openebs.io/target-version"
+	// configMountPath is the directory in which the
+	// upgrade config of the job is mounted
+	configMountPath = "/etc/config/upgrade"
+	// configKey is the key of the upgrade config in
+	// the config map of the job
+	configKey = "upgrade"
+	// labelJivaController label is set on the target
+	// deployments of jiva volumes
+	labelJivaController = "openebs.io/controller=jiva-controller"
+)
+
+// jobClient discovers the upgradable resources & runs
+// the upgrade jobs in the cluster
+type jobClient struct {
+	config PlannerConfig
+}
+
+// newJobClient returns a new instance of jobClient
+func newJobClient(cfg PlannerConfig) *jobClient {
+	return &jobClient{config: cfg}
+}
+
+// discover returns the cstor pools, cstor volumes &
+// jiva volumes of the cluster along with their
+// current versions
+func (c *jobClient) discover() ([]versionedResource, error) {
+	resources := []versionedResource{}
+
+	pools, err := csp.NewKubeClient().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to discover cstor pools")
+	}
+	for _, pool := range pools.Items {
+		resources = append(resources, versionedResource{
+			ResourceDetails: apis.ResourceDetails{
+				Name: pool.Name,
+				Kind: cstorPoolKind,
+				// pools are cluster scoped; the pool
+				// deployments are in openebs namespace
+				Namespace: c.config.Namespace,
+			},
+			version: pool.Labels[labelVersion],
+		})
+	}
+
+	vols, err := cv.NewKubeclient(cv.WithNamespace("")).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to discover cstor volumes")
+	}
+	for _, vol := range vols.Items {
+		resources = append(resources, versionedResource{
+			ResourceDetails: apis.ResourceDetails{
+				Name:      vol.Name,
+				Kind:      cstorVolumeKind,
+				Namespace: vol.Namespace,
+			},
+			version: vol.Labels[labelVersion],
+		})
+	}
+
+	cs, err := kclient.New().Clientset()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to discover jiva volumes")
+	}
+	targets, err := cs.AppsV1().Deployments("").List(metav1.ListOptions{
+		LabelSelector: labelJivaController,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to discover jiva volumes")
+	}
+	for _, target := range targets.Items {
+		name := target.Labels[labelPersistentVolume]
+		if name == "" {
+			continue
+		}
+		resources = append(resources, versionedResource{
+			ResourceDetails: apis.ResourceDetails{
+				Name:      name,
+				Kind:      jivaVolumeKind,
+				Namespace: target.Namespace,
+			},
+			version: target.Labels[labelVersion],
+		})
+	}
+	return resources, nil
+}
+
+// launch creates an upgrade job & the config map with
+// the upgrade config of the job. The config map is
+// owned by the job & is deleted along with the job.
+func (c *jobClient) launch(cfg *apis.UpgradeConfig) (string, error) {
+	cs, err := kclient.New().Clientset()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to launch upgrade job")
+	}
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to launch upgrade job: %s", cfg)
+	}
+
+	name := jobNamePrefix + cfg.Resources[0].Kind + "-" + rand.String(5)
+	job, err := cs.BatchV1().Jobs(c.config.Namespace).Create(c.buildJob(name, cfg))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to launch upgrade job: %s", cfg)
+	}
+
+	isController := true
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			// pods of the job wait for the config map
+			// till it is created
+			Name:      job.Name,
+			Namespace: job.Namespace,
+			Labels:    job.Labels,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: "batch/v1",
+					Kind:       "Job",
+					Name:       job.Name,
+					UID:        job.UID,
+					Controller: &isController,
+				},
+			},
+		},
+		Data: map[string]string{configKey: string(data)},
+	}
+	_, err = cs.CoreV1().ConfigMaps(job.Namespace).Create(cm)
+	if err != nil {
+		return "", errors.Wrapf(err,
+			"failed to launch upgrade job {%s}: failed to create config map", job.Name)
+	}
+	return job.Name, nil
+}
+
+// buildJob returns the upgrade job that upgrades the
+// resources of the given config
+func (c *jobClient) buildJob(name string, cfg *apis.UpgradeConfig) *batchv1.Job {
+	labels := map[string]string{
+		labelPlannedBy:     "upgrade-planner",
+		labelTargetVersion: c.config.TargetVersion,
+	}
+	backoffLimit := int32(0)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: c.config.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			// upgrade of a resource is resumed from its
+			// upgrade result; failed jobs are reported
+			// instead of being retried
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: c.config.ServiceAccount,
+					RestartPolicy:      corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:  "upgrade",
+							Image: c.config.Image,
+							Args: []string{
+								"--config-path=" + path.Join(configMountPath, configKey),
+							},
+							Env: []corev1.EnvVar{
+								fieldRefEnv(envPodName, "metadata.name"),
+								fieldRefEnv(envPodNamespace, "metadata.namespace"),
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "config", MountPath: configMountPath},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "config",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: name,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// fieldRefEnv returns an env whose value is the given
+// field of the pod
+func fieldRefEnv(name, fieldPath string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: fieldPath},
+		},
+	}
+}
+
+// jobStatus returns the current state of the upgrade
+// job from its conditions
+func (c *jobClient) jobStatus(name string) (jobState, error) {
+	cs, err := kclient.New().Clientset()
+	if err != nil {
+		return jobState{}, errors.Wrapf(err, "failed to get upgrade job {%s}", name)
+	}
+	job, err := cs.BatchV1().Jobs(c.config.Namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return jobState{}, errors.Wrapf(err, "failed to get upgrade job {%s}", name)
+	}
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			return jobState{done: true}, nil
+		case batchv1.JobFailed:
+			return jobState{done: true, failed: true, message: cond.Message}, nil
+		}
+	}
+	return jobState{}, nil
+}
+
+// listResults returns the upgrade results created by
+// the pods of the upgrade job
+func (c *jobClient) listResults(job string) ([]apis.UpgradeResult, error) {
+	results, err := upgraderesult.NewKubeClient().
+		WithNamespace(c.config.Namespace).
+		List(metav1.ListOptions{LabelSelector: labelJobName + "=" + job})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list upgrade results of job {%s}", job)
+	}
+	return results.Items, nil
+}
@@ -0,0 +1,486 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Masterminds/semver"
+	"github.com/ghodss/yaml"
+	"github.com/golang/glog"
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/upgrade/v1alpha1"
+	stringer "github.com/openebs/maya/pkg/apis/stringer/v1alpha1"
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+	"github.com/openebs/maya/pkg/version"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// labelVersion label contains the openebs version
+	// a resource was created or last upgraded with
+	labelVersion = "openebs.io/version"
+
+	// defaultBatchSize is the default no of resources
+	// upgraded by a single upgrade job
+	defaultBatchSize = 10
+	// defaultConcurrency is the default no of upgrade
+	// jobs that run at the same time
+	defaultConcurrency = 1
+	// defaultPollInterval is the default interval at
+	// which the upgrade jobs are checked for completion
+	defaultPollInterval = 10 * time.Second
+	// defaultJobTimeout is the default time an upgrade
+	// job is waited for before it is considered failed
+	defaultJobTimeout = time.Hour
+)
+
+// kindOrder is the order in which the kinds of units
+// of upgrade are upgraded. Pools are upgraded before
+// the volumes whose replicas are placed on these pools.
+var kindOrder = []string{cstorPoolKind, cstorVolumeKind, jivaVolumeKind}
+
+// PlannerConfig contains the options to plan & run
+// the upgrade of all the outdated resources of the
+// cluster
+type PlannerConfig struct {
+	// TargetVersion is the version resources are
+	// upgraded to. Resources whose version is behind
+	// this version are upgraded.
+	TargetVersion string `json:"targetVersion"`
+	// CASTemplates contains the castemplate name used
+	// to upgrade each kind of resource. Kinds without
+	// a castemplate are not upgraded.
+	CASTemplates map[string]string `json:"casTemplates"`
+	// RollbackCASTemplates contains the castemplate
+	// name used to revert each kind of resource
+	RollbackCASTemplates map[string]string `json:"rollbackCasTemplates"`
+	// Data is copied to the upgrade config of every
+	// batch
+	Data []apis.DataItem `json:"data"`
+	// Namespace is the namespace in which upgrade jobs
+	// are launched & openebs components are installed
+	Namespace string `json:"namespace"`
+	// Image is the upgrade image run by upgrade jobs
+	Image string `json:"image"`
+	// ServiceAccount is the service account of the
+	// upgrade jobs
+	ServiceAccount string `json:"serviceAccount"`
+	// BatchSize is the max no of resources upgraded by
+	// a single upgrade job
+	BatchSize int `json:"batchSize"`
+	// Concurrency is the max no of upgrade jobs that
+	// run at the same time
+	Concurrency int `json:"concurrency"`
+	// Parallelism is the no of resources upgraded at
+	// the same time by an upgrade job
+	Parallelism int `json:"parallelism"`
+	// DryRun when set plans & launches the upgrade jobs
+	// in dry run mode
+	DryRun bool `json:"dryRun"`
+	// PollInterval is the interval at which upgrade jobs
+	// are checked for completion
+	PollInterval time.Duration `json:"-"`
+	// JobTimeout is the time an upgrade job is waited
+	// for before it is considered failed
+	JobTimeout time.Duration `json:"-"`
+}
+
+// String implements Stringer interface
+func (pc PlannerConfig) String() string {
+	return stringer.Yaml("planner config", pc)
+}
+
+// GoString implements GoStringer interface
+func (pc PlannerConfig) GoString() string {
+	return pc.String()
+}
+
+// versionedResource is a unit of upgrade along with
+// the version it is currently at
+type versionedResource struct {
+	apis.ResourceDetails
+	version string
+}
+
+// Batch is a set of resources of the same kind that
+// are upgraded by a single upgrade job
+type Batch struct {
+	Kind   string
+	Config *apis.UpgradeConfig
+}
+
+// JobSummary is the outcome of an upgrade job
+type JobSummary struct {
+	Name      string `json:"name"`
+	Kind      string `json:"kind"`
+	Resources int    `json:"resources"`
+	// Status is the final status of the job i.e.
+	// Succeeded, Failed or Skipped
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// PlanSummary is the outcome of all the upgrade
+// jobs launched by the planner
+type PlanSummary struct {
+	TargetVersion string       `json:"targetVersion"`
+	Jobs          []JobSummary `json:"jobs"`
+	// counts of resources by their post state
+	Upgraded   int `json:"upgraded"`
+	Failed     int `json:"failed"`
+	RolledBack int `json:"rolledBack"`
+	Skipped    int `json:"skipped"`
+	Pending    int `json:"pending"`
+}
+
+// String implements Stringer interface
+func (ps PlanSummary) String() string {
+	return stringer.Yaml("upgrade plan summary", ps)
+}
+
+// GoString implements GoStringer interface
+func (ps PlanSummary) GoString() string {
+	return ps.String()
+}
+
+// jobState is the state of an upgrade job
+type jobState struct {
+	done    bool
+	failed  bool
+	message string
+}
+
+// Planner discovers the outdated resources of the
+// cluster and upgrades these in batches via upgrade
+// jobs
+type Planner struct {
+	config PlannerConfig
+
+	// discover returns the upgradable resources of
+	// the cluster along with their current versions
+	discover func() ([]versionedResource, error)
+
+	// launch starts an upgrade job for the given
+	// batch and returns the name of the job
+	launch func(cfg *apis.UpgradeConfig) (string, error)
+
+	// jobStatus returns the current state of the
+	// upgrade job
+	jobStatus func(name string) (jobState, error)
+
+	// listResults returns the upgrade results
+	// recorded by the upgrade job
+	listResults func(job string) ([]apis.UpgradeResult, error)
+}
+
+// NewPlannerForConfigPath reads the planner config from
+// the given file & returns a new instance of Planner.
+// Upgrade jobs are launched in the namespace of this
+// pod & resources are upgraded to the version of this
+// binary unless these are set in the config.
+func NewPlannerForConfigPath(filePath string) (*Planner, error) {
+	data, err := ioutil.ReadFile(path.Clean(filePath))
+	if err != nil {
+		return nil, errors.Wrapf(err,
+			"failed to instantiate planner: failed to read config: %s", filePath)
+	}
+	cfg := PlannerConfig{}
+	err = yaml.Unmarshal(data, &cfg)
+	if err != nil {
+		return nil, errors.Wrapf(err,
+			"failed to instantiate planner: failed to unmarshal config: %s", filePath)
+	}
+	if cfg.Namespace == "" {
+		cfg.Namespace = os.Getenv(envPodNamespace)
+	}
+	if cfg.TargetVersion == "" {
+		cfg.TargetVersion = version.Current()
+	}
+	return NewPlanner(cfg)
+}
+
+// NewPlanner returns a new instance of Planner that
+// runs upgrade jobs in the cluster
+func NewPlanner(cfg PlannerConfig) (*Planner, error) {
+	if cfg.TargetVersion == "" {
+		return nil, errors.New("failed to instantiate planner: missing target version")
+	}
+	if _, err := semver.NewVersion(cfg.TargetVersion); err != nil {
+		return nil, errors.Wrapf(err,
+			"failed to instantiate planner: invalid target version {%s}", cfg.TargetVersion)
+	}
+	if len(cfg.CASTemplates) == 0 {
+		return nil, errors.New("failed to instantiate planner: missing castemplates")
+	}
+	if cfg.Namespace == "" {
+		return nil, errors.New("failed to instantiate planner: missing namespace")
+	}
+	if cfg.Image == "" {
+		return nil, errors.New("failed to instantiate planner: missing upgrade image")
+	}
+	p := &Planner{config: cfg}
+	p.withDefaults()
+	c := newJobClient(p.config)
+	p.discover = c.discover
+	p.launch = c.launch
+	p.jobStatus = c.jobStatus
+	p.listResults = c.listResults
+	return p, nil
+}
+
+// withDefaults sets the default options of the
+// planner if these were not provided
+func (p *Planner) withDefaults() {
+	if p.config.BatchSize <= 0 {
+		p.config.BatchSize = defaultBatchSize
+	}
+	if p.config.Concurrency <= 0 {
+		p.config.Concurrency = defaultConcurrency
+	}
+	if p.config.PollInterval <= 0 {
+		p.config.PollInterval = defaultPollInterval
+	}
+	if p.config.JobTimeout <= 0 {
+		p.config.JobTimeout = defaultJobTimeout
+	}
+}
+
+// isOutdated returns true if the given version is
+// behind the target version
+func isOutdated(version, target string) (bool, error) {
+	current, err := semver.NewVersion(version)
+	if err != nil {
+		return false, errors.Wrapf(err, "invalid version {%s}", version)
+	}
+	desired, err := semver.NewVersion(target)
+	if err != nil {
+		return false, errors.Wrapf(err, "invalid target version {%s}", target)
+	}
+	return current.LessThan(desired), nil
+}
+
+// Plan returns the batches of outdated resources in
+// the order these should be upgraded. Each batch has
+// resources of the same kind & all the batches of a
+// kind come before the batches of the next kind.
+func (p *Planner) Plan(resources []versionedResource) []Batch {
+	byKind := map[string][]apis.ResourceDetails{}
+	for _, r := range resources {
+		if _, ok := p.config.CASTemplates[r.Kind]; !ok {
+			glog.V(4).Infof("skipping %s {%s/%s}: no castemplate to upgrade this kind",
+				r.Kind, r.Namespace, r.Name)
+			continue
+		}
+		if r.version == "" {
+			glog.Warningf("skipping %s {%s/%s}: missing label {%s}",
+				r.Kind, r.Namespace, r.Name, labelVersion)
+			continue
+		}
+		outdated, err := isOutdated(r.version, p.config.TargetVersion)
+		if err != nil {
+			glog.Warningf("skipping %s {%s/%s}: %v", r.Kind, r.Namespace, r.Name, err)
+			continue
+		}
+		if outdated {
+			byKind[r.Kind] = append(byKind[r.Kind], r.ResourceDetails)
+		}
+	}
+
+	var batches []Batch
+	for _, kind := range kindOrder {
+		items := byKind[kind]
+		sort.Slice(items, func(i, j int) bool {
+			if items[i].Namespace != items[j].Namespace {
+				return items[i].Namespace < items[j].Namespace
+			}
+			return items[i].Name < items[j].Name
+		})
+		for start := 0; start < len(items); start += p.config.BatchSize {
+			end := start + p.config.BatchSize
+			if end > len(items) {
+				end = len(items)
+			}
+			batches = append(batches, Batch{
+				Kind: kind,
+				Config: &apis.UpgradeConfig{
+					CASTemplate:         p.config.CASTemplates[kind],
+					RollbackCASTemplate: p.config.RollbackCASTemplates[kind],
+					Data:                p.config.Data,
+					Resources:           items[start:end],
+					DryRun:              p.config.DryRun,
+					Parallelism:         p.config.Parallelism,
+				},
+			})
+		}
+	}
+	return batches
+}
+
+// Run discovers the outdated resources of the cluster
+// and upgrades these via upgrade jobs. Batches of a
+// kind are upgraded only after all the batches of the
+// previous kind have succeeded.
+func (p *Planner) Run() (*PlanSummary, error) {
+	resources, err := p.discover()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to run upgrade plan: %s", p.config)
+	}
+	batches := p.Plan(resources)
+	glog.Infof("planned %d upgrade job(s) for version {%s}",
+		len(batches), p.config.TargetVersion)
+
+	summary := &PlanSummary{TargetVersion: p.config.TargetVersion}
+	failed := false
+	for _, kind := range kindOrder {
+		var kindBatches []Batch
+		for _, b := range batches {
+			if b.Kind == kind {
+				kindBatches = append(kindBatches, b)
+			}
+		}
+		if len(kindBatches) == 0 {
+			continue
+		}
+		if failed {
+			for _, b := range kindBatches {
+				summary.Jobs = append(summary.Jobs, JobSummary{
+					Kind:      kind,
+					Resources: len(b.Config.Resources),
+					Status:    decisionSkipped,
+					Message:   "upgrade of a previous kind failed",
+				})
+				summary.Skipped += len(b.Config.Resources)
+			}
+			continue
+		}
+		jobs := p.runBatches(kindBatches)
+		for _, job := range jobs {
+			if job.Status != decisionSucceeded {
+				failed = true
+			}
+		}
+		summary.Jobs = append(summary.Jobs, jobs...)
+	}
+
+	p.summarize(summary)
+	if failed {
+		return summary, errors.Errorf("failed to run upgrade plan: %s", summary)
+	}
+	return summary, nil
+}
+
+// runBatches runs the upgrade jobs of the given
+// batches with at most Concurrency jobs at a time
+func (p *Planner) runBatches(batches []Batch) []JobSummary {
+	jobs := make([]JobSummary, len(batches))
+	sem := make(chan struct{}, p.config.Concurrency)
+	var wg sync.WaitGroup
+	for i := range batches {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			jobs[i] = p.runBatch(batches[i])
+		}(i)
+	}
+	wg.Wait()
+	return jobs
+}
+
+// runBatch launches the upgrade job of the batch and
+// waits for the job to complete
+func (p *Planner) runBatch(b Batch) JobSummary {
+	job := JobSummary{Kind: b.Kind, Resources: len(b.Config.Resources)}
+	name, err := p.launch(b.Config)
+	if err != nil {
+		job.Status = decisionFailed
+		job.Message = err.Error()
+		glog.Errorf("failed to launch upgrade job for %s: %+v", b.Config, err)
+		return job
+	}
+	job.Name = name
+	glog.Infof("launched upgrade job {%s} for %d %s(s)", name, job.Resources, b.Kind)
+
+	var state jobState
+	err = wait.PollImmediate(p.config.PollInterval, p.config.JobTimeout,
+		func() (bool, error) {
+			state, err = p.jobStatus(name)
+			if err != nil {
+				glog.Warningf("failed to get status of upgrade job {%s}: %v", name, err)
+				return false, nil
+			}
+			return state.done, nil
+		})
+	switch {
+	case err != nil:
+		job.Status = decisionFailed
+		job.Message = "timed out waiting for job to complete"
+	case state.failed:
+		job.Status = decisionFailed
+		job.Message = state.message
+	default:
+		job.Status = decisionSucceeded
+	}
+	glog.Infof("upgrade job {%s} %s", name, job.Status)
+	return job
+}
+
+// summarize counts the resources of the launched jobs
+// by the post state recorded in their upgrade results
+func (p *Planner) summarize(summary *PlanSummary) {
+	for _, job := range summary.Jobs {
+		if job.Name == "" {
+			// job was either skipped or could not be
+			// launched
+			if job.Status == decisionFailed {
+				summary.Failed += job.Resources
+			}
+			continue
+		}
+		results, err := p.listResults(job.Name)
+		if err != nil {
+			glog.Errorf("failed to list upgrade results of job {%s}: %+v", job.Name, err)
+			summary.Pending += job.Resources
+			continue
+		}
+		recorded := 0
+		for _, r := range results {
+			recorded++
+			switch r.Status.Resource.PostState.Status {
+			case stateUpgraded:
+				summary.Upgraded++
+			case stateFailed, stateRollbackFailed:
+				summary.Failed++
+			case stateRolledBack:
+				summary.RolledBack++
+			default:
+				summary.Pending++
+			}
+		}
+		// resources of the job without an upgrade result
+		// were never picked up by the job
+		if recorded < job.Resources {
+			summary.Pending += job.Resources - recorded
+		}
+	}
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/upgrade/v1alpha1"
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+)
+
+func fakeResource(kind, name, version string) versionedResource {
+	return versionedResource{
+		ResourceDetails: apis.ResourceDetails{Kind: kind, Name: name, Namespace: "openebs"},
+		version:         version,
+	}
+}
+
+func fakePlanner(batchSize int) *Planner {
+	p := &Planner{
+		config: PlannerConfig{
+			TargetVersion: "1.0.0",
+			CASTemplates: map[string]string{
+				cstorPoolKind:   "cstor-pool-upgrade",
+				cstorVolumeKind: "cstor-volume-upgrade",
+			},
+			BatchSize:    batchSize,
+			PollInterval: time.Millisecond,
+			JobTimeout:   time.Second,
+		},
+	}
+	p.withDefaults()
+	return p
+}
+
+func TestIsOutdated(t *testing.T) {
+	tests := map[string]struct {
+		version  string
+		target   string
+		expected bool
+		isErr    bool
+	}{
+		"older version":       {version: "0.9.0", target: "1.0.0", expected: true},
+		"same version":        {version: "1.0.0", target: "1.0.0"},
+		"newer version":       {version: "1.1.0", target: "1.0.0"},
+		"release candidate":   {version: "1.0.0-RC1", target: "1.0.0", expected: true},
+		"invalid version":     {version: "latest", target: "1.0.0", isErr: true},
+		"invalid target":      {version: "0.9.0", target: "latest", isErr: true},
+		"older minor version": {version: "0.8.2", target: "0.9.0", expected: true},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			got, err := isOutdated(mock.version, mock.target)
+			if mock.isErr != (err != nil) {
+				t.Fatalf("Test %q failed: expected error '%t': actual error '%v'", name, mock.isErr, err)
+			}
+			if got != mock.expected {
+				t.Fatalf("Test %q failed: expected '%t': actual '%t'", name, mock.expected, got)
+			}
+		})
+	}
+}
+
+func TestPlan(t *testing.T) {
+	tests := map[string]struct {
+		batchSize int
+		resources []versionedResource
+		expected  [][]string
+	}{
+		"pools are upgraded before volumes": {
+			batchSize: 10,
+			resources: []versionedResource{
+				fakeResource(cstorVolumeKind, "pv-1", "0.9.0"),
+				fakeResource(cstorPoolKind, "pool-1", "0.9.0"),
+			},
+			expected: [][]string{{"cstor-pool/pool-1"}, {"cstor-volume/pv-1"}},
+		},
+		"up to date resources are not upgraded": {
+			batchSize: 10,
+			resources: []versionedResource{
+				fakeResource(cstorPoolKind, "pool-1", "1.0.0"),
+				fakeResource(cstorPoolKind, "pool-2", "0.9.0"),
+			},
+			expected: [][]string{{"cstor-pool/pool-2"}},
+		},
+		"resources without version or castemplate are not upgraded": {
+			batchSize: 10,
+			resources: []versionedResource{
+				fakeResource(cstorPoolKind, "pool-1", ""),
+				fakeResource(cstorPoolKind, "pool-2", "latest"),
+				fakeResource(jivaVolumeKind, "pv-1", "0.9.0"),
+			},
+		},
+		"resources are split as per batch size": {
+			batchSize: 2,
+			resources: []versionedResource{
+				fakeResource(cstorPoolKind, "pool-3", "0.9.0"),
+				fakeResource(cstorPoolKind, "pool-1", "0.9.0"),
+				fakeResource(cstorPoolKind, "pool-2", "0.9.0"),
+			},
+			expected: [][]string{
+				{"cstor-pool/pool-1", "cstor-pool/pool-2"},
+				{"cstor-pool/pool-3"},
+			},
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			var got [][]string
+			for _, b := range fakePlanner(mock.batchSize).Plan(mock.resources) {
+				var items []string
+				for _, r := range b.Config.Resources {
+					if r.Kind != b.Kind {
+						t.Fatalf("Test %q failed: expected kind '%s': actual '%s'", name, b.Kind, r.Kind)
+					}
+					items = append(items, r.Kind+"/"+r.Name)
+				}
+				got = append(got, items)
+			}
+			if !reflect.DeepEqual(got, mock.expected) {
+				t.Fatalf("Test %q failed: expected batches '%v': actual '%v'", name, mock.expected, got)
+			}
+		})
+	}
+}
+
+func TestPlannerRun(t *testing.T) {
+	tests := map[string]struct {
+		failedKind       string
+		isErr            bool
+		expectedStatus   map[string]string
+		expectedUpgraded int
+		expectedFailed   int
+		expectedSkipped  int
+	}{
+		"all jobs succeed": {
+			expectedStatus: map[string]string{
+				cstorPoolKind:   decisionSucceeded,
+				cstorVolumeKind: decisionSucceeded,
+			},
+			expectedUpgraded: 4,
+		},
+		"volumes are skipped if pool upgrade fails": {
+			failedKind: cstorPoolKind,
+			isErr:      true,
+			expectedStatus: map[string]string{
+				cstorPoolKind:   decisionFailed,
+				cstorVolumeKind: decisionSkipped,
+			},
+			expectedFailed:  2,
+			expectedSkipped: 2,
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			p := fakePlanner(1)
+			p.config.Concurrency = 2
+			p.discover = func() ([]versionedResource, error) {
+				return []versionedResource{
+					fakeResource(cstorPoolKind, "pool-1", "0.9.0"),
+					fakeResource(cstorPoolKind, "pool-2", "0.9.0"),
+					fakeResource(cstorVolumeKind, "pv-1", "0.9.0"),
+					fakeResource(cstorVolumeKind, "pv-2", "0.9.0"),
+				}, nil
+			}
+			var mu sync.Mutex
+			jobs := map[string]*apis.UpgradeConfig{}
+			p.launch = func(cfg *apis.UpgradeConfig) (string, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				job := fmt.Sprintf("job-%d", len(jobs))
+				jobs[job] = cfg
+				return job, nil
+			}
+			p.jobStatus = func(job string) (jobState, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				if jobs[job].Resources[0].Kind == mock.failedKind {
+					return jobState{done: true, failed: true, message: "pod failed"}, nil
+				}
+				return jobState{done: true}, nil
+			}
+			p.listResults = func(job string) ([]apis.UpgradeResult, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				cfg, ok := jobs[job]
+				if !ok {
+					return nil, errors.Errorf("job {%s} not found", job)
+				}
+				state := stateUpgraded
+				if cfg.Resources[0].Kind == mock.failedKind {
+					state = stateFailed
+				}
+				r := apis.UpgradeResult{}
+				r.Status.Resource.PostState.Status = state
+				return []apis.UpgradeResult{r}, nil
+			}
+
+			summary, err := p.Run()
+			if mock.isErr != (err != nil) {
+				t.Fatalf("Test %q failed: expected error '%t': actual error '%v'", name, mock.isErr, err)
+			}
+			for _, job := range summary.Jobs {
+				if job.Status != mock.expectedStatus[job.Kind] {
+					t.Fatalf("Test %q failed: %s: expected status '%s': actual '%s'",
+						name, job.Kind, mock.expectedStatus[job.Kind], job.Status)
+				}
+			}
+			if summary.Upgraded != mock.expectedUpgraded ||
+				summary.Failed != mock.expectedFailed ||
+				summary.Skipped != mock.expectedSkipped {
+				t.Fatalf("Test %q failed: expected upgraded/failed/skipped '%d/%d/%d': actual '%d/%d/%d'",
+					name, mock.expectedUpgraded, mock.expectedFailed, mock.expectedSkipped,
+					summary.Upgraded, summary.Failed, summary.Skipped)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	upgrade "github.com/openebs/maya/pkg/apis/openebs.io/upgrade/v1alpha1"
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	csp "github.com/openebs/maya/pkg/cstor/pool/v1alpha2"
+	cv "github.com/openebs/maya/pkg/cstor/volume/v1alpha1"
+	cvr "github.com/openebs/maya/pkg/cstor/volumereplica/v1alpha1"
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+	deploy "github.com/openebs/maya/pkg/kubernetes/deployment/appsv1/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// cstorPoolKind is the kind of unit of upgrade
+	// for a cstor pool
+	cstorPoolKind = "cstor-pool"
+	// cstorVolumeKind is the kind of unit of upgrade
+	// for a cstor volume
+	cstorVolumeKind = "cstor-volume"
+	// jivaVolumeKind is the kind of unit of upgrade
+	// for a jiva volume
+	jivaVolumeKind = "jiva-volume"
+
+	// labelPersistentVolume label contains name of
+	// the volume a resource belongs to
+	labelPersistentVolume = "openebs.io/persistent-volume"
+	// cstorVolumeHealthy is the phase of a cstor volume
+	// whose target is connected to its replicas
+	cstorVolumeHealthy = "Healthy"
+)
+
+// healthChecker checks if a unit of upgrade is healthy
+// enough to be upgraded. It returns the reason if the
+// resource is not healthy.
+type healthChecker func(
+	resource *upgrade.ResourceDetails) (healthy bool, reason string, err error)
+
+// defaultHealthCheckers are the pre-flight checks run
+// for each kind of unit of upgrade
+var defaultHealthCheckers = map[string]healthChecker{
+	cstorPoolKind:   isCStorPoolHealthy,
+	cstorVolumeKind: isCStorVolumeHealthy,
+	jivaVolumeKind:  isJivaVolumeHealthy,
+}
+
+// isCStorPoolHealthy returns true if the cstor pool
+// is online
+func isCStorPoolHealthy(
+	resource *upgrade.ResourceDetails) (bool, string, error) {
+	pool, err := csp.NewKubeClient().Get(resource.Name, metav1.GetOptions{})
+	if err != nil {
+		return false, "", err
+	}
+	if pool.Status.Phase != apis.CStorPoolStatusOnline {
+		return false,
+			fmt.Sprintf("cstor pool {%s} is not online: phase {%s}",
+				pool.Name, pool.Status.Phase), nil
+	}
+	return true, "", nil
+}
+
+// isCStorVolumeHealthy returns true if the cstor volume
+// and all of its replicas are healthy
+func isCStorVolumeHealthy(
+	resource *upgrade.ResourceDetails) (bool, string, error) {
+	vol, err := cv.NewKubeclient(cv.WithNamespace(resource.Namespace)).
+		Get(resource.Name, metav1.GetOptions{})
+	if err != nil {
+		return false, "", err
+	}
+	if vol.Status.Phase != cstorVolumeHealthy {
+		return false,
+			fmt.Sprintf("cstor volume {%s} is not healthy: phase {%s}",
+				vol.Name, vol.Status.Phase), nil
+	}
+
+	replicas, err := cvr.NewKubeclient(cvr.WithNamespace(resource.Namespace)).
+		List(metav1.ListOptions{
+			LabelSelector: labelPersistentVolume + "=" + resource.Name,
+		})
+	if err != nil {
+		return false, "", err
+	}
+	if len(replicas.Items) == 0 {
+		return false,
+			fmt.Sprintf("cstor volume {%s} has no replicas", vol.Name), nil
+	}
+	for _, replica := range replicas.Items {
+		if replica.Status.Phase != apis.CVRStatusOnline {
+			return false,
+				fmt.Sprintf("cstor volume replica {%s} is not healthy: phase {%s}",
+					replica.Name, replica.Status.Phase), nil
+		}
+	}
+	return true, "", nil
+}
+
+// isJivaVolumeHealthy returns true if all the pods of
+// the controller and replica deployments of the jiva
+// volume are ready
+func isJivaVolumeHealthy(
+	resource *upgrade.ResourceDetails) (bool, string, error) {
+	client := deploy.NewKubeClient(deploy.WithNamespace(resource.Namespace))
+	for _, name := range []string{resource.Name + "-ctrl", resource.Name + "-rep"} {
+		d, err := client.Get(name)
+		if err != nil {
+			return false, "", err
+		}
+		if !isDeploymentReady(d) {
+			return false,
+				fmt.Sprintf("jiva deployment {%s} is not ready: ready replicas {%d}",
+					d.Name, d.Status.ReadyReplicas), nil
+		}
+	}
+	return true, "", nil
+}
+
+// isDeploymentReady returns true if all the desired
+// pods of the deployment are ready
+func isDeploymentReady(d *appsv1.Deployment) bool {
+	desired := int32(1)
+	if d.Spec.Replicas != nil {
+		desired = *d.Spec.Replicas
+	}
+	return d.Status.ReadyReplicas >= desired
+}
+
+// preflight runs the pre-flight check of the unit of
+// upgrade. Kinds without any check are considered
+// healthy.
+func (e *Executor) preflight(
+	resource *upgrade.ResourceDetails) (bool, string, error) {
+	check, ok := e.checkers[resource.Kind]
+	if !ok {
+		return true, "", nil
+	}
+	healthy, reason, err := check(resource)
+	if err != nil {
+		return false, "",
+			errors.Wrapf(err, "failed to run pre-flight check: %s", resource)
+	}
+	return healthy, reason, nil
+}
@@ -0,0 +1,293 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	upgrade "github.com/openebs/maya/pkg/apis/openebs.io/upgrade/v1alpha1"
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	clientset "github.com/openebs/maya/pkg/client/generated/clientset/versioned"
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+	kclient "github.com/openebs/maya/pkg/kubernetes/client/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	typedappsv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
+)
+
+// Kinds of the resources whose versions & snapshots are
+// captured before upgrade
+const (
+	deploymentKind         = "Deployment"
+	cstorPoolCRKind        = "CStorPool"
+	cstorVolumeCRKind      = "CStorVolume"
+	cstorVolumeReplicaKind = "CStorVolumeReplica"
+
+	// labelCStorPool label contains name of the cstor
+	// pool a pool deployment belongs to
+	labelCStorPool = "openebs.io/cstor-pool"
+)
+
+// versionCapturer returns the versions, the container
+// images & the snapshots of a unit of upgrade & its
+// related resources
+type versionCapturer func(
+	resource *upgrade.ResourceDetails) ([]upgrade.ResourceVersion, error)
+
+// versionRestorer reverts the resources to the given
+// snapshots
+type versionRestorer func(versions []upgrade.ResourceVersion) error
+
+// defaultVersionCapturers capture the versions of each
+// kind of unit of upgrade before it is upgraded
+var defaultVersionCapturers = map[string]versionCapturer{
+	cstorPoolKind:   captureCStorPoolVersions,
+	cstorVolumeKind: captureCStorVolumeVersions,
+	jivaVolumeKind:  captureJivaVolumeVersions,
+}
+
+// captureCStorPoolVersions returns the versions of the
+// cstor pool & its pool deployment
+func captureCStorPoolVersions(
+	resource *upgrade.ResourceDetails) ([]upgrade.ResourceVersion, error) {
+	cs, err := getOpenebsClientset()
+	if err != nil {
+		return nil, err
+	}
+	pool, err := cs.OpenebsV1alpha1().CStorPools().
+		Get(resource.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	version, err := newResourceVersion(cstorPoolCRKind, pool.ObjectMeta, pool.Spec)
+	if err != nil {
+		return nil, err
+	}
+	versions := []upgrade.ResourceVersion{version}
+	deploys, err := captureDeploymentVersions(resource.Namespace,
+		labelCStorPool+"="+resource.Name)
+	if err != nil {
+		return nil, err
+	}
+	return append(versions, deploys...), nil
+}
+
+// captureCStorVolumeVersions returns the versions of the
+// cstor volume, its replicas & its target deployment
+func captureCStorVolumeVersions(
+	resource *upgrade.ResourceDetails) ([]upgrade.ResourceVersion, error) {
+	cs, err := getOpenebsClientset()
+	if err != nil {
+		return nil, err
+	}
+	vol, err := cs.OpenebsV1alpha1().CStorVolumes(resource.Namespace).
+		Get(resource.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	version, err := newResourceVersion(cstorVolumeCRKind, vol.ObjectMeta, vol.Spec)
+	if err != nil {
+		return nil, err
+	}
+	versions := []upgrade.ResourceVersion{version}
+	selector := labelPersistentVolume + "=" + resource.Name
+	replicas, err := cs.OpenebsV1alpha1().CStorVolumeReplicas(resource.Namespace).
+		List(metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	for _, replica := range replicas.Items {
+		version, err := newResourceVersion(cstorVolumeReplicaKind, replica.ObjectMeta, replica.Spec)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+	deploys, err := captureDeploymentVersions(resource.Namespace, selector)
+	if err != nil {
+		return nil, err
+	}
+	return append(versions, deploys...), nil
+}
+
+// captureJivaVolumeVersions returns the versions of the
+// controller & replica deployments of the jiva volume
+func captureJivaVolumeVersions(
+	resource *upgrade.ResourceDetails) ([]upgrade.ResourceVersion, error) {
+	return captureDeploymentVersions(resource.Namespace,
+		labelPersistentVolume+"="+resource.Name)
+}
+
+// captureDeploymentVersions returns the versions, the
+// container images & the snapshots of the deployments
+// matching the label selector
+func captureDeploymentVersions(
+	namespace, selector string) ([]upgrade.ResourceVersion, error) {
+	cs, err := kclient.New().Clientset()
+	if err != nil {
+		return nil, err
+	}
+	deploys, err := cs.AppsV1().Deployments(namespace).
+		List(metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	versions := []upgrade.ResourceVersion{}
+	for _, d := range deploys.Items {
+		version, err := newDeploymentVersion(&d)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
+
+// newResourceVersion returns the version of a resource
+// from its version label along with the snapshot of its
+// labels & spec
+func newResourceVersion(
+	kind string, meta metav1.ObjectMeta, spec interface{}) (upgrade.ResourceVersion, error) {
+	raw, err := json.Marshal(spec)
+	if err != nil {
+		return upgrade.ResourceVersion{}, errors.Wrapf(err,
+			"failed to capture spec of %s {%s/%s}", kind, meta.Namespace, meta.Name)
+	}
+	return upgrade.ResourceVersion{
+		Kind:      kind,
+		Name:      meta.Name,
+		Namespace: meta.Namespace,
+		Version:   meta.Labels[labelVersion],
+		Labels:    meta.Labels,
+		Spec:      runtime.RawExtension{Raw: raw},
+	}, nil
+}
+
+// newDeploymentVersion returns the version, the container
+// images & the snapshot of the deployment
+func newDeploymentVersion(d *appsv1.Deployment) (upgrade.ResourceVersion, error) {
+	version, err := newResourceVersion(deploymentKind, d.ObjectMeta, d.Spec)
+	if err != nil {
+		return version, err
+	}
+	version.Images = map[string]string{}
+	for _, c := range d.Spec.Template.Spec.Containers {
+		version.Images[c.Name] = c.Image
+	}
+	return version, nil
+}
+
+// restoreVersions reverts the resources to the labels &
+// the spec captured in the given versions. The changes
+// made by the upgrade e.g. to the env or volumes of the
+// containers are reverted along with their images.
+func restoreVersions(versions []upgrade.ResourceVersion) error {
+	cs, err := getOpenebsClientset()
+	if err != nil {
+		return err
+	}
+	kcs, err := kclient.New().Clientset()
+	if err != nil {
+		return err
+	}
+	for _, v := range versions {
+		switch v.Kind {
+		case deploymentKind:
+			err = restoreDeployment(kcs.AppsV1().Deployments(v.Namespace), v)
+		case cstorPoolCRKind:
+			var pool *apis.CStorPool
+			pool, err = cs.OpenebsV1alpha1().CStorPools().Get(v.Name, metav1.GetOptions{})
+			if err == nil {
+				pool.Spec = apis.CStorPoolSpec{}
+				err = restoreSnapshot(v, &pool.ObjectMeta, &pool.Spec)
+			}
+			if err == nil {
+				_, err = cs.OpenebsV1alpha1().CStorPools().Update(pool)
+			}
+		case cstorVolumeCRKind:
+			var vol *apis.CStorVolume
+			vol, err = cs.OpenebsV1alpha1().CStorVolumes(v.Namespace).Get(v.Name, metav1.GetOptions{})
+			if err == nil {
+				vol.Spec = apis.CStorVolumeSpec{}
+				err = restoreSnapshot(v, &vol.ObjectMeta, &vol.Spec)
+			}
+			if err == nil {
+				_, err = cs.OpenebsV1alpha1().CStorVolumes(v.Namespace).Update(vol)
+			}
+		case cstorVolumeReplicaKind:
+			var replica *apis.CStorVolumeReplica
+			replica, err = cs.OpenebsV1alpha1().CStorVolumeReplicas(v.Namespace).Get(v.Name, metav1.GetOptions{})
+			if err == nil {
+				replica.Spec = apis.CStorVolumeReplicaSpec{}
+				err = restoreSnapshot(v, &replica.ObjectMeta, &replica.Spec)
+			}
+			if err == nil {
+				_, err = cs.OpenebsV1alpha1().CStorVolumeReplicas(v.Namespace).Update(replica)
+			}
+		default:
+			err = errors.Errorf("unsupported kind {%s}", v.Kind)
+		}
+		if err != nil {
+			return errors.Wrapf(err,
+				"failed to restore %s {%s/%s}", v.Kind, v.Namespace, v.Name)
+		}
+	}
+	return nil
+}
+
+// restoreDeployment reverts the deployment to the labels
+// & the spec captured in the given version
+func restoreDeployment(
+	client typedappsv1.DeploymentInterface, v upgrade.ResourceVersion) error {
+	d, err := client.Get(v.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	d.Spec = appsv1.DeploymentSpec{}
+	if err := restoreSnapshot(v, &d.ObjectMeta, &d.Spec); err != nil {
+		return err
+	}
+	_, err = client.Update(d)
+	return err
+}
+
+// restoreSnapshot sets the labels & the spec of a resource
+// to the ones captured in the given version. The spec is
+// expected to be reset by the caller. Versions recorded
+// without a snapshot can not be restored.
+func restoreSnapshot(
+	v upgrade.ResourceVersion, meta *metav1.ObjectMeta, spec interface{}) error {
+	if len(v.Spec.Raw) == 0 {
+		return errors.New("no snapshot was captured before upgrade")
+	}
+	if err := json.Unmarshal(v.Spec.Raw, spec); err != nil {
+		return errors.Wrap(err, "failed to decode snapshot")
+	}
+	meta.Labels = v.Labels
+	return nil
+}
+
+// getOpenebsClientset returns the clientset of the
+// openebs custom resources
+func getOpenebsClientset() (*clientset.Clientset, error) {
+	config, err := kclient.New().Config()
+	if err != nil {
+		return nil, err
+	}
+	return clientset.NewForConfig(config)
+}
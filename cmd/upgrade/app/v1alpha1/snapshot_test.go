@@ -0,0 +1,111 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/upgrade/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func fakeDeployment(version string, env ...corev1.EnvVar) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pool-1-deploy",
+			Namespace: "openebs",
+			Labels:    map[string]string{labelVersion: version},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{labelVersion: version},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "cstor-pool", Image: "openebs/cstor-pool:" + version, Env: env},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestNewDeploymentVersion(t *testing.T) {
+	version, err := newDeploymentVersion(fakeDeployment("1.0.0"))
+	if err != nil {
+		t.Fatalf("Test failed: expected no error: actual error '%v'", err)
+	}
+	if version.Kind != deploymentKind || version.Version != "1.0.0" ||
+		version.Images["cstor-pool"] != "openebs/cstor-pool:1.0.0" ||
+		version.Labels[labelVersion] != "1.0.0" || len(version.Spec.Raw) == 0 {
+		t.Fatalf("Test failed: unexpected version '%+v'", version)
+	}
+}
+
+func TestRestoreDeployment(t *testing.T) {
+	before := fakeDeployment("1.0.0")
+	version, err := newDeploymentVersion(before)
+	if err != nil {
+		t.Fatalf("Test failed: expected no error: actual error '%v'", err)
+	}
+
+	// upgrade changes the env & the labels besides the images
+	upgraded := fakeDeployment("1.1.0", corev1.EnvVar{Name: "NEW_ENV", Value: "on"})
+	upgraded.Labels["openebs.io/upgraded"] = "true"
+	client := fake.NewSimpleClientset(upgraded).AppsV1().Deployments("openebs")
+
+	if err := restoreDeployment(client, version); err != nil {
+		t.Fatalf("Test failed: expected no error: actual error '%v'", err)
+	}
+	got, err := client.Get("pool-1-deploy", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Test failed: expected no error: actual error '%v'", err)
+	}
+	if !reflect.DeepEqual(got.Labels, before.Labels) {
+		t.Fatalf("Test failed: expected labels '%v': actual '%v'", before.Labels, got.Labels)
+	}
+	if !reflect.DeepEqual(got.Spec, before.Spec) {
+		t.Fatalf("Test failed: expected spec '%+v': actual '%+v'", before.Spec, got.Spec)
+	}
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	tests := map[string]struct {
+		version apis.ResourceVersion
+		isErr   bool
+	}{
+		"version without snapshot is not restored": {
+			version: apis.ResourceVersion{Kind: deploymentKind, Name: "pool-1-deploy", Version: "1.0.0"},
+			isErr:   true,
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			d := fakeDeployment("1.1.0")
+			err := restoreSnapshot(mock.version, &d.ObjectMeta, &d.Spec)
+			if mock.isErr != (err != nil) {
+				t.Fatalf("Test %q failed: expected error '%t': actual error '%v'", name, mock.isErr, err)
+			}
+		})
+	}
+}
@@ -32,14 +32,23 @@ func main() {
 		os.Exit(1)
 	}
 	configPath := flag.String("config-path", "/etc/config/upgrade", "path to upgrade config file.")
+	dryRun := flag.Bool("dry-run", false, "run pre-flight checks and render the castemplate without upgrading.")
+	plan := flag.Bool("plan", false, "discover outdated resources of the cluster and upgrade these via upgrade jobs; config path refers to the planner config.")
 	defer log.Flush()
 	flag.Parse()
 
+	if *plan {
+		runPlan(*configPath)
+	}
+
 	u, err := upgrade.NewUpgradeForConfigPath(*configPath)
 	if err != nil {
 		log.Errorf("failed to upgrade: %+v", err)
 		os.Exit(1)
 	}
+	if *dryRun {
+		u.Config.DryRun = true
+	}
 
 	err = u.Run()
 	if err != nil {
@@ -48,3 +57,22 @@ func main() {
 	}
 	os.Exit(0)
 }
+
+// runPlan upgrades all the outdated resources of the
+// cluster as per the planner config & exits
+func runPlan(configPath string) {
+	p, err := upgrade.NewPlannerForConfigPath(configPath)
+	if err != nil {
+		log.Errorf("failed to plan upgrade: %+v", err)
+		os.Exit(1)
+	}
+	summary, err := p.Run()
+	if summary != nil {
+		log.Infof("%s", summary)
+	}
+	if err != nil {
+		log.Errorf("failed to run upgrade plan: %+v", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
@@ -17,13 +17,19 @@ limitations under the License.
 package v1alpha2
 
 import (
+	"encoding/json"
+	"reflect"
+	"time"
+
 	"github.com/golang/glog"
 	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
 	apiscsp "github.com/openebs/maya/pkg/cstor/newpool/v1alpha3"
+	cstorpool "github.com/openebs/maya/pkg/cstor/pool/v1alpha1"
 	deploy "github.com/openebs/maya/pkg/kubernetes/deployment/appsv1/v1alpha1"
 	"github.com/pkg/errors"
 	k8serror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // GetPendingPoolCount returns the pending pool count that should be created for the
@@ -80,3 +86,120 @@ func (c *Config) GetCSPWithoutDeployment() ([]apis.NewTestCStorPool, error) {
 	}
 	return cspList, nil
 }
+
+// SyncScrubInterval updates the scrub interval of the CSPs whose scrub
+// interval differs from the one present in their pool spec on CSPC.
+func (c *Config) SyncScrubInterval() error {
+	cspList, err := apiscsp.NewKubeClient().WithNamespace(c.Namespace).List(metav1.ListOptions{LabelSelector: string(apis.CStorPoolClusterCPK) + "=" + c.CSPC.Name})
+	if err != nil {
+		return errors.Wrapf(err, "could not list csp for cspc {%s}", c.CSPC.Name)
+	}
+	updates, err := c.getScrubIntervalUpdates(cspList.Items)
+	if err != nil {
+		return err
+	}
+	for name, interval := range updates {
+		err = c.patchPoolConfig(name, "scrubInterval", interval)
+		if err != nil {
+			return errors.Wrapf(err, "could not update scrub interval of csp {%s}", name)
+		}
+		glog.Infof("Updated scrub interval of csp {%s} to '%s' for cspc {%s}", name, interval, c.CSPC.Name)
+	}
+	return nil
+}
+
+// SyncOverCommitRatio updates the over commit ratio of the CSPs whose
+// over commit ratio differs from the one present in their pool spec on
+// CSPC.
+func (c *Config) SyncOverCommitRatio() error {
+	cspList, err := apiscsp.NewKubeClient().WithNamespace(c.Namespace).List(metav1.ListOptions{LabelSelector: string(apis.CStorPoolClusterCPK) + "=" + c.CSPC.Name})
+	if err != nil {
+		return errors.Wrapf(err, "could not list csp for cspc {%s}", c.CSPC.Name)
+	}
+	updates, err := c.getOverCommitRatioUpdates(cspList.Items)
+	if err != nil {
+		return err
+	}
+	for name, ratio := range updates {
+		err = c.patchPoolConfig(name, "overCommitRatio", ratio)
+		if err != nil {
+			return errors.Wrapf(err, "could not update over commit ratio of csp {%s}", name)
+		}
+		glog.Infof("Updated over commit ratio of csp {%s} to '%s' for cspc {%s}", name, ratio, c.CSPC.Name)
+	}
+	return nil
+}
+
+// patchPoolConfig sets the given field of the pool config of the CSP
+func (c *Config) patchPoolConfig(name, field, value string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"poolConfig": map[string]interface{}{
+				field: value,
+			},
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "could not build pool config patch for csp {%s}", name)
+	}
+	_, err = apiscsp.NewKubeClient().WithNamespace(c.Namespace).Patch(name, types.MergePatchType, patch)
+	return err
+}
+
+// getScrubIntervalUpdates returns the scrub interval that should be set
+// on each of the given CSPs whose scrub interval differs from the one
+// present in their pool spec on CSPC.
+func (c *Config) getScrubIntervalUpdates(cspList []apis.NewTestCStorPool) (map[string]string, error) {
+	updates := map[string]string{}
+	for _, cspObj := range cspList {
+		poolSpec := c.getPoolSpecForCSP(&cspObj)
+		if poolSpec == nil {
+			continue
+		}
+		interval := poolSpec.PoolConfig.ScrubInterval
+		if cspObj.Spec.PoolConfig.ScrubInterval == interval {
+			continue
+		}
+		if interval != "" {
+			d, err := time.ParseDuration(interval)
+			if err != nil || d <= 0 {
+				return nil, errors.Errorf("invalid scrubInterval '%s' for node selector {%v}", interval, poolSpec.NodeSelector)
+			}
+		}
+		updates[cspObj.Name] = interval
+	}
+	return updates, nil
+}
+
+// getOverCommitRatioUpdates returns the over commit ratio that should be
+// set on each of the given CSPs whose over commit ratio differs from the
+// one present in their pool spec on CSPC.
+func (c *Config) getOverCommitRatioUpdates(cspList []apis.NewTestCStorPool) (map[string]string, error) {
+	updates := map[string]string{}
+	for _, cspObj := range cspList {
+		poolSpec := c.getPoolSpecForCSP(&cspObj)
+		if poolSpec == nil {
+			continue
+		}
+		ratio := poolSpec.PoolConfig.OverCommitRatio
+		if cspObj.Spec.PoolConfig.OverCommitRatio == ratio {
+			continue
+		}
+		if _, err := cstorpool.ParseOverCommitRatio(ratio); err != nil {
+			return nil, errors.Wrapf(err, "invalid pool config for node selector {%v}", poolSpec.NodeSelector)
+		}
+		updates[cspObj.Name] = ratio
+	}
+	return updates, nil
+}
+
+// getPoolSpecForCSP returns the pool spec on CSPC that the given CSP
+// was provisioned from.
+func (c *Config) getPoolSpecForCSP(csp *apis.NewTestCStorPool) *apis.PoolSpec {
+	for i, poolSpec := range c.CSPC.Spec.Pools {
+		if reflect.DeepEqual(poolSpec.NodeSelector, csp.Spec.NodeSelector) {
+			return &c.CSPC.Spec.Pools[i]
+		}
+	}
+	return nil
+}
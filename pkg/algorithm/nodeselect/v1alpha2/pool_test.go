@@ -0,0 +1,141 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"reflect"
+	"testing"
+
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func fakeCSP(name, node, interval string) apis.NewTestCStorPool {
+	return apis.NewTestCStorPool{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: apis.NewCStorPoolSpec{
+			NodeSelector: map[string]string{HostName: node},
+			PoolConfig:   apis.PoolConfig{ScrubInterval: interval},
+		},
+	}
+}
+
+func fakePoolSpec(node, interval string) apis.PoolSpec {
+	return apis.PoolSpec{
+		NodeSelector: map[string]string{HostName: node},
+		PoolConfig:   apis.PoolConfig{ScrubInterval: interval},
+	}
+}
+
+func TestGetScrubIntervalUpdates(t *testing.T) {
+	tests := map[string]struct {
+		pools           []apis.PoolSpec
+		csps            []apis.NewTestCStorPool
+		expectedUpdates map[string]string
+		expectedErr     bool
+	}{
+		"scrub interval changed on cspc": {
+			pools:           []apis.PoolSpec{fakePoolSpec("node-1", "24h"), fakePoolSpec("node-2", "168h")},
+			csps:            []apis.NewTestCStorPool{fakeCSP("csp-1", "node-1", "168h"), fakeCSP("csp-2", "node-2", "168h")},
+			expectedUpdates: map[string]string{"csp-1": "24h"},
+		},
+		"scrub interval removed from cspc": {
+			pools:           []apis.PoolSpec{fakePoolSpec("node-1", "")},
+			csps:            []apis.NewTestCStorPool{fakeCSP("csp-1", "node-1", "168h")},
+			expectedUpdates: map[string]string{"csp-1": ""},
+		},
+		"csp without pool spec on cspc": {
+			pools:           []apis.PoolSpec{fakePoolSpec("node-1", "24h")},
+			csps:            []apis.NewTestCStorPool{fakeCSP("csp-2", "node-2", "168h")},
+			expectedUpdates: map[string]string{},
+		},
+		"invalid scrub interval on cspc": {
+			pools:       []apis.PoolSpec{fakePoolSpec("node-1", "weekly")},
+			csps:        []apis.NewTestCStorPool{fakeCSP("csp-1", "node-1", "168h")},
+			expectedErr: true,
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			ac := &Config{
+				CSPC: &apis.CStorPoolCluster{
+					Spec: apis.CStorPoolClusterSpec{Pools: mock.pools},
+				},
+			}
+			updates, err := ac.getScrubIntervalUpdates(mock.csps)
+			if mock.expectedErr != (err != nil) {
+				t.Fatalf("Test %q failed: expected error %t: actual error '%v'", name, mock.expectedErr, err)
+			}
+			if !mock.expectedErr && !reflect.DeepEqual(updates, mock.expectedUpdates) {
+				t.Fatalf("Test %q failed: expected updates %v: actual %v", name, mock.expectedUpdates, updates)
+			}
+		})
+	}
+}
+
+func TestGetOverCommitRatioUpdates(t *testing.T) {
+	fakeRatioCSP := func(name, node, ratio string) apis.NewTestCStorPool {
+		csp := fakeCSP(name, node, "")
+		csp.Spec.PoolConfig.OverCommitRatio = ratio
+		return csp
+	}
+	fakeRatioPoolSpec := func(node, ratio string) apis.PoolSpec {
+		poolSpec := fakePoolSpec(node, "")
+		poolSpec.PoolConfig.OverCommitRatio = ratio
+		return poolSpec
+	}
+	tests := map[string]struct {
+		pools           []apis.PoolSpec
+		csps            []apis.NewTestCStorPool
+		expectedUpdates map[string]string
+		expectedErr     bool
+	}{
+		"over commit ratio changed on cspc": {
+			pools:           []apis.PoolSpec{fakeRatioPoolSpec("node-1", "2"), fakeRatioPoolSpec("node-2", "1.5")},
+			csps:            []apis.NewTestCStorPool{fakeRatioCSP("csp-1", "node-1", ""), fakeRatioCSP("csp-2", "node-2", "1.5")},
+			expectedUpdates: map[string]string{"csp-1": "2"},
+		},
+		"over commit ratio removed from cspc": {
+			pools:           []apis.PoolSpec{fakeRatioPoolSpec("node-1", "")},
+			csps:            []apis.NewTestCStorPool{fakeRatioCSP("csp-1", "node-1", "2")},
+			expectedUpdates: map[string]string{"csp-1": ""},
+		},
+		"invalid over commit ratio on cspc": {
+			pools:       []apis.PoolSpec{fakeRatioPoolSpec("node-1", "twice")},
+			csps:        []apis.NewTestCStorPool{fakeRatioCSP("csp-1", "node-1", "")},
+			expectedErr: true,
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			ac := &Config{
+				CSPC: &apis.CStorPoolCluster{
+					Spec: apis.CStorPoolClusterSpec{Pools: mock.pools},
+				},
+			}
+			updates, err := ac.getOverCommitRatioUpdates(mock.csps)
+			if mock.expectedErr != (err != nil) {
+				t.Fatalf("Test %q failed: expected error %t: actual error '%v'", name, mock.expectedErr, err)
+			}
+			if !mock.expectedErr && !reflect.DeepEqual(updates, mock.expectedUpdates) {
+				t.Fatalf("Test %q failed: expected updates %v: actual %v", name, mock.expectedUpdates, updates)
+			}
+		})
+	}
+}
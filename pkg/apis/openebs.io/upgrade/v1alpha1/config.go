@@ -33,6 +33,17 @@ type UpgradeConfig struct {
 	Data []DataItem `json:"data"`
 	// Resources contains list of resources which we are going to upgrade
 	Resources []ResourceDetails `json:"resources"`
+	// RollbackCASTemplate contains castemplate name which task executor
+	// will use to revert a single unit of resource whose upgrade failed.
+	// Failed resources are reverted to the versions & the images
+	// captured before upgrade if this is not set.
+	RollbackCASTemplate string `json:"rollbackCasTemplate"`
+	// DryRun when set runs the pre-flight checks and renders the
+	// castemplate for each resource without upgrading any resource
+	DryRun bool `json:"dryRun"`
+	// Parallelism is the no of resources that are upgraded at the
+	// same time. Resources are upgraded one at a time by default.
+	Parallelism int `json:"parallelism"`
 }
 
 // String implements Stringer interface
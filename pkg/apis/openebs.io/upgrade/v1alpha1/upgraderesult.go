@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	stringer "github.com/openebs/maya/pkg/apis/stringer/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func init() {
@@ -69,6 +70,27 @@ type UpgradeResultStatus struct {
 	// Resource is the resource that needs to
 	// be upgraded
 	Resource UpgradeResource `json:"resource"`
+	// Decisions are the decisions taken by the
+	// executor while upgrading the resource in
+	// the order these were taken
+	Decisions []UpgradeDecision `json:"decisions"`
+}
+
+// UpgradeDecision represents a decision taken by the
+// executor while upgrading a resource e.g. skipping the
+// upgrade of an unhealthy resource or rolling back a
+// failed upgrade
+type UpgradeDecision struct {
+	// Step of the upgrade where the decision was
+	// taken i.e. PreFlight, DryRun, Upgrade or Rollback
+	Step string `json:"step"`
+	// Decision is the outcome of the step
+	Decision string `json:"decision"`
+	// Message is a human readable message
+	// indicating details about the decision
+	Message string `json:"message"`
+	// Time when the decision was taken
+	Time metav1.Time `json:"time"`
 }
 
 // UpgradeResource represents a resource that needs to
@@ -162,6 +184,33 @@ type ResourceState struct {
 	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
 	// Message is a human readable message indicating details about the transition.
 	Message string `json:"message"`
+	// Versions are the versions & the container images of
+	// the resource & its related resources. The versions
+	// captured before upgrade are restored on rollback.
+	Versions []ResourceVersion `json:"versions,omitempty"`
+}
+
+// ResourceVersion represents the version, the container
+// images & the snapshot of a kubernetes resource
+type ResourceVersion struct {
+	// Kind of the resource e.g. Deployment, CStorPool
+	Kind string `json:"kind"`
+	// Name of the resource
+	Name string `json:"name"`
+	// Namespace of the resource; it is empty for
+	// cluster scoped resources
+	Namespace string `json:"namespace,omitempty"`
+	// Version is the value of the version label
+	// of the resource
+	Version string `json:"version,omitempty"`
+	// Images are the images of the containers of the
+	// resource keyed by the container name
+	Images map[string]string `json:"images,omitempty"`
+	// Labels are the labels of the resource
+	Labels map[string]string `json:"labels,omitempty"`
+	// Spec is the spec of the resource that is restored
+	// along with its labels on rollback
+	Spec runtime.RawExtension `json:"spec,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
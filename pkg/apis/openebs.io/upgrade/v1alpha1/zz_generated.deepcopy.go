@@ -67,6 +67,13 @@ func (in *ResourceDetails) DeepCopy() *ResourceDetails {
 func (in *ResourceState) DeepCopyInto(out *ResourceState) {
 	*out = *in
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]ResourceVersion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -80,6 +87,37 @@ func (in *ResourceState) DeepCopy() *ResourceState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceVersion) DeepCopyInto(out *ResourceVersion) {
+	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceVersion.
+func (in *ResourceVersion) DeepCopy() *ResourceVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UpgradeConfig) DeepCopyInto(out *UpgradeConfig) {
 	*out = *in
@@ -108,6 +146,23 @@ func (in *UpgradeConfig) DeepCopy() *UpgradeConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeDecision) DeepCopyInto(out *UpgradeDecision) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeDecision.
+func (in *UpgradeDecision) DeepCopy() *UpgradeDecision {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeDecision)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UpgradeResource) DeepCopyInto(out *UpgradeResource) {
 	*out = *in
@@ -230,6 +285,13 @@ func (in *UpgradeResultList) DeepCopyObject() runtime.Object {
 func (in *UpgradeResultStatus) DeepCopyInto(out *UpgradeResultStatus) {
 	*out = *in
 	in.Resource.DeepCopyInto(&out.Resource)
+	if in.Decisions != nil {
+		in, out := &in.Decisions, &out.Decisions
+		*out = make([]UpgradeDecision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -80,6 +80,7 @@ type SnapshotOptions struct {
 // CASSnapshotList is a list of CASSnapshot resources
 type CASSnapshotList struct {
 	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
 	// Items are the list of volumes
 	Items []CASSnapshot `json:"items"`
 }
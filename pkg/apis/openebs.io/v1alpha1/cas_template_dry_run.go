@@ -0,0 +1,119 @@
+/*
+Copyright 2019 The OpenEBS Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CASTemplateDryRun represents a request to render a cas template without
+// creating any resource in the cluster. The rendered runtasks are reported
+// in its status.
+//
+// NOTE:
+//  Name and namespace of this object are used as the name and the run
+// namespace of the volume that the cas template is rendered for
+type CASTemplateDryRun struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec i.e. the inputs to render the cas template
+	Spec CASTemplateDryRunSpec `json:"spec"`
+
+	// Status i.e. the result of rendering the cas template
+	Status CASTemplateDryRunStatus `json:"status"`
+}
+
+// CASTemplateDryRunSpec has the inputs to render a cas template
+type CASTemplateDryRunSpec struct {
+	// CASTemplate is the name of the cas template to render. The cas template
+	// used to create volumes of the storage class is rendered if this is
+	// empty.
+	CASTemplate string `json:"casTemplate"`
+
+	// StorageClass is the name of the storage class whose cas config is
+	// merged with the cas template defaults
+	StorageClass string `json:"storageClass"`
+
+	// Capacity is the capacity of the volume
+	Capacity string `json:"capacity"`
+
+	// PVC is the name of the persistent volume claim of the volume
+	PVC string `json:"pvc"`
+
+	// CASConfig is the cas config in yaml format that would have been set as
+	// an annotation of the persistent volume claim
+	CASConfig string `json:"casConfig"`
+}
+
+// CASTemplateDryRunStatus has the result of rendering a cas template
+type CASTemplateDryRunStatus struct {
+	// Tasks has the result of rendering each runtask of the cas template in
+	// the order of execution
+	Tasks []RunTaskDryRunResult `json:"tasks"`
+
+	// Output is the rendered output task of the cas template
+	Output string `json:"output"`
+
+	// Error is the error that stopped rendering the cas template if any
+	Error string `json:"error"`
+}
+
+// RunTaskDryRunResult is the result of rendering a runtask
+type RunTaskDryRunResult struct {
+	// Name of the runtask
+	Name string `json:"name"`
+
+	// ID is the identity of the runtask
+	ID string `json:"id"`
+
+	// APIVersion of the resource operated by the runtask
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the resource operated by the runtask
+	Kind string `json:"kind"`
+
+	// Action of the runtask e.g. get, list, put, etc
+	Action string `json:"action"`
+
+	// ObjectName is the name of the resource operated by the runtask
+	ObjectName string `json:"objectName"`
+
+	// RunNamespace is the namespace where the runtask would be executed
+	RunNamespace string `json:"runNamespace"`
+
+	// Skipped is true if the runtask would not be executed
+	Skipped bool `json:"skipped"`
+
+	// Executed is true if the runtask was executed against the cluster. Only
+	// read only runtasks i.e. get and list are executed during a dry run.
+	Executed bool `json:"executed"`
+
+	// Message has the reason for skipping the runtask if any
+	Message string `json:"message"`
+
+	// Rendered has the task specifications of the runtask rendered with the
+	// template values. There is one entry per repeat of the runtask.
+	Rendered []string `json:"rendered"`
+
+	// Values is the yaml representation of the template values used to
+	// render the runtask
+	Values string `json:"values"`
+
+	// Error is the error that occurred while rendering the runtask if any
+	Error string `json:"error"`
+}
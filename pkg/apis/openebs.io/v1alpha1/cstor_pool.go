@@ -70,6 +70,13 @@ type CStorPoolAttr struct {
 	CacheFile        string `json:"cacheFile"`        //optional, faster if specified
 	PoolType         string `json:"poolType"`         //mirrored, striped
 	OverProvisioning bool   `json:"overProvisioning"` //true or false
+	// ScrubInterval is the interval at which the pool is scrubbed
+	// e.g. 168h. The pool is not scrubbed on schedule if empty.
+	ScrubInterval string `json:"scrubInterval,omitempty"`
+	// OverCommitRatio is the ratio of the capacity that can be
+	// provisioned on the pool to the size of the pool e.g. 1.5.
+	// There is no limit if it is empty.
+	OverCommitRatio string `json:"overCommitRatio,omitempty"`
 }
 
 // CStorPoolPhase is a typed string for phase field of CStorPool.
@@ -115,6 +122,96 @@ type CStorPoolStatus struct {
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
 	LastUpdateTime     metav1.Time `json:"lastUpdateTime,omitempty"`
 	Message            string      `json:"message,omitempty"`
+	// Scrub is the status of the last scrub or resilver of the pool
+	Scrub CStorPoolScrubStatus `json:"scrub,omitempty"`
+	// Vdevs is the vdev tree of the pool as reported by zpool status.
+	// The first vdev is the pool itself followed by the logs, cache
+	// & spares vdevs if present.
+	Vdevs []CStorPoolVdevStatus `json:"vdevs,omitempty"`
+}
+
+// CStorPoolVdevStatus stores the status of a vdev of the pool i.e.
+// the pool, a raid group or a block device.
+type CStorPoolVdevStatus struct {
+	// Name of the vdev e.g. mirror-0 or the path of the block device
+	Name string `json:"name"`
+	// State of the vdev e.g. ONLINE, DEGRADED, FAULTED or AVAIL for a
+	// spare. It is empty for the logs, cache & spares vdevs.
+	State string `json:"state,omitempty"`
+	// ReadErrors is the number of read errors of the vdev
+	ReadErrors int64 `json:"readErrors"`
+	// WriteErrors is the number of write errors of the vdev
+	WriteErrors int64 `json:"writeErrors"`
+	// ChecksumErrors is the number of checksum errors of the vdev
+	ChecksumErrors int64 `json:"checksumErrors"`
+	// Resilvering is true if data is being copied to the vdev
+	Resilvering bool `json:"resilvering,omitempty"`
+	// Message is the note against the vdev e.g. too many errors
+	Message string `json:"message,omitempty"`
+	// Children are the vdevs that constitute this vdev
+	Children []CStorPoolVdevStatus `json:"children,omitempty"`
+}
+
+// CStorPoolScanFunction is a typed string for the function of a
+// zpool scan.
+type CStorPoolScanFunction string
+
+// Functions of zpool scan.
+const (
+	// CStorPoolScanScrub signifies that the pool is verified against
+	// checksums.
+	CStorPoolScanScrub CStorPoolScanFunction = "Scrub"
+	// CStorPoolScanResilver signifies that the data is copied to a
+	// replaced or reattached device.
+	CStorPoolScanResilver CStorPoolScanFunction = "Resilver"
+)
+
+// CStorPoolScanState is a typed string for the state of a zpool scan.
+type CStorPoolScanState string
+
+// States of zpool scan.
+const (
+	// CStorPoolScanStateNone signifies that the pool was never scanned.
+	CStorPoolScanStateNone CStorPoolScanState = "None"
+	// CStorPoolScanStateInProgress signifies that the scan is running.
+	CStorPoolScanStateInProgress CStorPoolScanState = "InProgress"
+	// CStorPoolScanStatePaused signifies that the scan is paused.
+	CStorPoolScanStatePaused CStorPoolScanState = "Paused"
+	// CStorPoolScanStateFinished signifies that the scan is complete.
+	CStorPoolScanStateFinished CStorPoolScanState = "Finished"
+	// CStorPoolScanStateCanceled signifies that the scan was stopped.
+	CStorPoolScanStateCanceled CStorPoolScanState = "Canceled"
+)
+
+// CStorPoolScrubStatus stores the status of the scrub & resilver of
+// the pool as reported by zpool status.
+type CStorPoolScrubStatus struct {
+	// Function of the last scan i.e. Scrub or Resilver
+	Function CStorPoolScanFunction `json:"function,omitempty"`
+	// State of the last scan
+	State CStorPoolScanState `json:"state,omitempty"`
+	// Progress is the percentage of the scan that is done e.g. 24.41%
+	Progress string `json:"progress,omitempty"`
+	// Repaired is the amount of data repaired by the scan e.g. 0B
+	Repaired string `json:"repaired,omitempty"`
+	// Errors is the number of errors found by the last finished scan
+	Errors int64 `json:"errors"`
+	// DataErrors is the number of known data errors of the pool
+	DataErrors int64 `json:"dataErrors"`
+	// StartTime refers to the time when the scan started
+	StartTime metav1.Time `json:"startTime,omitempty"`
+	// EndTime refers to the time when the scan finished or was
+	// canceled
+	EndTime metav1.Time `json:"endTime,omitempty"`
+	// LastScheduledTime refers to the time when a scrub was last
+	// started on schedule
+	LastScheduledTime metav1.Time `json:"lastScheduledTime,omitempty"`
+	// AutoPaused is true if the scrub was paused because the pool
+	// is resilvering or its replicas are rebuilding. Such a scrub
+	// is resumed once they are done.
+	AutoPaused bool `json:"autoPaused,omitempty"`
+	// Message is the human readable reason of the state
+	Message string `json:"message,omitempty"`
 }
 
 // CStorPoolCapacityAttr stores the pool capacity related attributes.
@@ -122,6 +219,10 @@ type CStorPoolCapacityAttr struct {
 	Total string `json:"total"`
 	Free  string `json:"free"`
 	Used  string `json:"used"`
+	// Provisioned is the sum of the capacities of the volume
+	// replicas on the pool which can exceed Total as the volume
+	// replicas are thin provisioned
+	Provisioned string `json:"provisioned,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -0,0 +1,110 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +resource:path=cstorreplicamigration
+
+// CStorReplicaMigration describes the migration of a replica of a cstor
+// volume from one cstor pool to another. A replica is created on the
+// target pool and the replica on the source pool is removed once the new
+// replica has been rebuilt.
+type CStorReplicaMigration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              CStorReplicaMigrationSpec   `json:"spec"`
+	Status            CStorReplicaMigrationStatus `json:"status"`
+}
+
+// CStorReplicaMigrationSpec is the spec for a CStorReplicaMigration resource
+type CStorReplicaMigrationSpec struct {
+	// VolumeName is the name of the cstor volume whose replica is migrated
+	VolumeName string `json:"volumeName"`
+	// SourcePool is the name of the cstor pool the replica is migrated from
+	SourcePool string `json:"sourcePool"`
+	// TargetPool is the name of the cstor pool the replica is migrated to
+	TargetPool string `json:"targetPool"`
+	// Abort stops the migration if the source replica has not yet been
+	// removed. The replica created on the target pool is removed.
+	Abort bool `json:"abort,omitempty"`
+}
+
+// CStorReplicaMigrationPhase is the phase of the replica migration
+type CStorReplicaMigrationPhase string
+
+// Phases of the replica migration
+const (
+	// CRMPhasePending means the replica on the target pool is yet to be
+	// created
+	CRMPhasePending CStorReplicaMigrationPhase = ""
+	// CRMPhaseRebuilding means the replica on the target pool has been
+	// created and is being rebuilt
+	CRMPhaseRebuilding CStorReplicaMigrationPhase = "Rebuilding"
+	// CRMPhaseCompleted means the replica on the source pool has been
+	// removed after the replica on the target pool became healthy
+	CRMPhaseCompleted CStorReplicaMigrationPhase = "Completed"
+	// CRMPhaseFailed means the migration can not be done
+	CRMPhaseFailed CStorReplicaMigrationPhase = "Failed"
+	// CRMPhaseAborted means the migration was stopped on request
+	CRMPhaseAborted CStorReplicaMigrationPhase = "Aborted"
+)
+
+// CStorReplicaMigrationStatus is the status of the replica migration
+type CStorReplicaMigrationStatus struct {
+	Phase CStorReplicaMigrationPhase `json:"phase,omitempty"`
+	// SourceReplica is the name of the cstor volume replica on the source
+	// pool
+	SourceReplica string `json:"sourceReplica,omitempty"`
+	// TargetReplica is the name of the cstor volume replica created on the
+	// target pool
+	TargetReplica string `json:"targetReplica,omitempty"`
+	// HealthyReplicas is the number of replicas reported healthy by the
+	// target of the volume
+	HealthyReplicas int `json:"healthyReplicas,omitempty"`
+	// Message is the human readable progress or failure of the migration
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime refers to the time when the phase changes
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	LastUpdateTime     metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// IsDone returns true if the migration has completed, failed or has been
+// aborted
+func (m *CStorReplicaMigration) IsDone() bool {
+	switch m.Status.Phase {
+	case CRMPhaseCompleted, CRMPhaseFailed, CRMPhaseAborted:
+		return true
+	}
+	return false
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +resource:path=cstorreplicamigration
+
+// CStorReplicaMigrationList is a list of CStorReplicaMigration resources
+type CStorReplicaMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []CStorReplicaMigration `json:"items"`
+}
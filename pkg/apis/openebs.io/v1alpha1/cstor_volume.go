@@ -57,6 +57,13 @@ type CStorVolumeStatus struct {
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
 	LastUpdateTime     metav1.Time `json:"lastUpdateTime,omitempty"`
 	Message            string      `json:"message,omitempty"`
+	// Capacity is the size of the LUN exposed by the target. It is
+	// updated by cstor-volume-mgmt once the target has been resized
+	// to the capacity in spec.
+	Capacity string `json:"capacity,omitempty"`
+	// LastResizeError is the error of the last attempt to resize the
+	// target. It is cleared once the target has been resized.
+	LastResizeError string `json:"lastResizeError,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -52,6 +52,10 @@ type CStorVolumeClaimSpec struct {
 	// CStorVolumeRef has the information about where CstorVolumeClaim
 	// is created from.
 	CStorVolumeRef *corev1.ObjectReference `json:"cstorVolumeRef,omitempty"`
+	// ReplicaCount is the desired number of replicas of the cstor volume.
+	// Replicas are added or removed when this is changed. The replica
+	// count of the storageclass is used if not set.
+	ReplicaCount int `json:"replicaCount,omitempty"`
 }
 
 // CStorVolumeClaimPublish contains info related to attachment of a volume to a node.
@@ -68,7 +72,9 @@ type CStorVolumeClaimPhase string
 // defines the observed state of CStorVolumeClaim
 type CStorVolumeClaimStatus struct {
 	// Phase represents the current phase of CStorVolumeClaim.
-	Phase     CStorVolumeClaimPhase       `json:"phase"`
+	Phase CStorVolumeClaimPhase `json:"phase"`
+	// Capacity the actual resources of the underlying volume.
+	Capacity  corev1.ResourceList         `json:"capacity,omitempty"`
 	Condition []CStorVolumeClaimCondition `json:"condition,omitempty"`
 }
 
@@ -77,6 +83,12 @@ type CStorVolumeClaimCondition struct {
 	// Current Condition of cstor volume claim. If underlying persistent volume is being
 	// resized then the Condition will be set to 'ResizeStarted' etc
 	Type CStorVolumeClaimConditionType `json:"type"`
+	// Last time we probed the condition.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// Last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
 	// Reason is a brief CamelCase string that describes any failure
 	Reason string `json:"reason"`
 	// Human-readable message indicating details about last transition.
@@ -86,6 +98,23 @@ type CStorVolumeClaimCondition struct {
 // CStorVolumeClaimConditionType is a valid value of CstorVolumeClaimCondition.Type
 type CStorVolumeClaimConditionType string
 
+const (
+	// CStorVolumeClaimResizing - a user trigger resize of pvc has been started
+	CStorVolumeClaimResizing CStorVolumeClaimConditionType = "Resizing"
+	// CStorVolumeClaimResizeFailed - a resize of the underlying cstor volume
+	// replicas or target has failed
+	CStorVolumeClaimResizeFailed CStorVolumeClaimConditionType = "VolumeResizeFailed"
+	// CStorVolumeClaimResizeSuccess - the underlying cstor volume replicas
+	// and target have been resized
+	CStorVolumeClaimResizeSuccess CStorVolumeClaimConditionType = "VolumeResizeSuccessful"
+	// CStorVolumeClaimResizePending - controller resize is complete and the
+	// filesystem on the node needs to be expanded
+	CStorVolumeClaimResizePending CStorVolumeClaimConditionType = "FileSystemResizePending"
+	// CStorVolumeClaimReplicaScaling - replicas of the underlying cstor volume
+	// are being added or removed
+	CStorVolumeClaimReplicaScaling CStorVolumeClaimConditionType = "ReplicaScaling"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +k8s:openapi-gen=true
 
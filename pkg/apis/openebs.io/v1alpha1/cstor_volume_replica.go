@@ -54,6 +54,22 @@ type CStorVolumeReplicaSpec struct {
 	Capacity string `json:"capacity"`
 	// ZvolWorkers represents number of threads that executes client IOs
 	ZvolWorkers string `json:"zvolWorkers"`
+	// BlockSize is the volblocksize of the zfs volume e.g. 4K. It is
+	// applied only while creating the volume as zfs does not allow
+	// changing it later. Defaults to 4K.
+	BlockSize string `json:"blockSize,omitempty"`
+	// Compression is the compression algorithm of the zfs volume i.e.
+	// on, off, lz4, lzjb, zle, gzip or gzip-1 to gzip-9. Defaults to on.
+	Compression string `json:"compression,omitempty"`
+	// Sync is the sync behavior of the zfs volume i.e. standard,
+	// always or disabled
+	Sync string `json:"sync,omitempty"`
+	// Checksum is the checksum algorithm of the zfs volume e.g.
+	// fletcher4, sha256 or sha512
+	Checksum string `json:"checksum,omitempty"`
+	// LogBias is the handling of synchronous requests of the zfs
+	// volume i.e. latency or throughput
+	LogBias string `json:"logBias,omitempty"`
 }
 
 // CStorVolumeReplicaPhase is to hold result of action.
@@ -99,6 +115,9 @@ type CStorVolumeReplicaStatus struct {
 type CStorVolumeCapacityAttr struct {
 	TotalAllocated string `json:"totalAllocated"`
 	Used           string `json:"used"`
+	// VolSize is the size of the zfs volume i.e. the capacity
+	// that is presently provisioned for this replica.
+	VolSize string `json:"volSize,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
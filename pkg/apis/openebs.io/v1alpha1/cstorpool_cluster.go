@@ -83,10 +83,19 @@ type PoolConfig struct {
 	// OverProvisioning to enable over provisioning
 	// Optional -- defaults to false
 	OverProvisioning bool `json:"overProvisioning"`
+	// OverCommitRatio is the ratio of the capacity that can be
+	// provisioned on the pool to the size of the pool
+	// Optional -- there is no limit if empty
+	// e.g. 1.5
+	OverCommitRatio string `json:"overCommitRatio,omitempty"`
 	// Compression to enable compression
 	// Optional -- defaults to off
 	// Possible values : lz, off
 	Compression string `json:"compression"`
+	// ScrubInterval is the interval at which the pool is scrubbed
+	// Optional -- pool is not scrubbed on schedule if empty
+	// e.g. 168h
+	ScrubInterval string `json:"scrubInterval,omitempty"`
 }
 
 // RaidGroup contains the details of a raid group for the pool
@@ -0,0 +1,128 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +resource:path=jivavolumehealth
+
+// JivaVolumeHealth describes the health of the replicas of a jiva
+// volume as reported by its controller. It is created & updated by
+// the jiva health reconciler which also restarts the replicas that
+// are stuck in error or write only mode.
+type JivaVolumeHealth struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              JivaVolumeHealthSpec   `json:"spec"`
+	Status            JivaVolumeHealthStatus `json:"status"`
+}
+
+// JivaVolumeHealthSpec is the spec for a JivaVolumeHealth resource
+type JivaVolumeHealthSpec struct {
+	// VolumeName is the name of the jiva volume
+	VolumeName string `json:"volumeName"`
+	// DisableRecovery stops the reconciler from restarting the
+	// replicas of the volume. Their health is still recorded.
+	DisableRecovery bool `json:"disableRecovery,omitempty"`
+}
+
+// JivaVolumeHealthPhase is the health of a jiva volume
+type JivaVolumeHealthPhase string
+
+// Phases of the jiva volume health
+const (
+	// JVHPhaseHealthy means all the replicas are in read write
+	// mode
+	JVHPhaseHealthy JivaVolumeHealthPhase = "Healthy"
+	// JVHPhaseRebuilding means one or more replicas are being
+	// rebuilt & the others are in read write mode
+	JVHPhaseRebuilding JivaVolumeHealthPhase = "Rebuilding"
+	// JVHPhaseDegraded means one or more replicas are in error
+	// mode or are stuck in write only mode
+	JVHPhaseDegraded JivaVolumeHealthPhase = "Degraded"
+	// JVHPhaseOffline means no replica is in read write mode or
+	// the controller is not reachable
+	JVHPhaseOffline JivaVolumeHealthPhase = "Offline"
+)
+
+// JivaReplicaMode is the mode of a jiva replica as reported by
+// its controller
+type JivaReplicaMode string
+
+// Modes of jiva replica
+const (
+	// JivaReplicaModeRW means the replica is healthy
+	JivaReplicaModeRW JivaReplicaMode = "RW"
+	// JivaReplicaModeWO means the replica is being rebuilt
+	JivaReplicaModeWO JivaReplicaMode = "WO"
+	// JivaReplicaModeERR means the replica has failed
+	JivaReplicaModeERR JivaReplicaMode = "ERR"
+)
+
+// JivaVolumeHealthStatus is the status of the jiva volume health
+type JivaVolumeHealthStatus struct {
+	Phase JivaVolumeHealthPhase `json:"phase,omitempty"`
+	// HealthyReplicas is the number of replicas in read write mode
+	HealthyReplicas int `json:"healthyReplicas"`
+	// Replicas is the health of each replica of the volume
+	Replicas []JivaReplicaHealth `json:"replicas,omitempty"`
+	// Message is the human readable reason of the phase
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime refers to the time when the phase changes
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	LastUpdateTime     metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// JivaReplicaHealth is the health of a replica of jiva volume
+type JivaReplicaHealth struct {
+	// Address of the replica e.g. tcp://10.1.0.2:9502
+	Address string `json:"address"`
+	// Mode of the replica i.e. RW, WO or ERR
+	Mode JivaReplicaMode `json:"mode"`
+	// Rebuilding is true if the replica is syncing from a
+	// healthy replica
+	Rebuilding bool `json:"rebuilding,omitempty"`
+	// RevisionCounter is the count of writes applied to the
+	// replica. A rebuilt replica catches up with the revision
+	// counter of the healthy replicas.
+	RevisionCounter string `json:"revisionCounter,omitempty"`
+	// LastModeTransitionTime refers to the time when the mode
+	// changes
+	LastModeTransitionTime metav1.Time `json:"lastModeTransitionTime,omitempty"`
+	// RecoveryAttempts is the number of times the replica has been
+	// restarted by the reconciler since it was last healthy
+	RecoveryAttempts int `json:"recoveryAttempts,omitempty"`
+	// LastRecoveryTime refers to the time when the replica was
+	// last restarted by the reconciler
+	LastRecoveryTime metav1.Time `json:"lastRecoveryTime,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +resource:path=jivavolumehealth
+
+// JivaVolumeHealthList is a list of JivaVolumeHealth resources
+type JivaVolumeHealthList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []JivaVolumeHealth `json:"items"`
+}
@@ -64,6 +64,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&CStorVolumeReplicaList{},
 		&CASTemplate{},
 		&CASTemplateList{},
+		&RunTask{},
+		&RunTaskList{},
 		&CStorVolume{},
 		&CStorVolumeList{},
 		&CStorBackup{},
@@ -74,6 +76,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&CStorRestoreList{},
 		&CStorVolumeClaim{},
 		&CStorVolumeClaimList{},
+		&CStorReplicaMigration{},
+		&CStorReplicaMigrationList{},
+		&JivaVolumeHealth{},
+		&JivaVolumeHealthList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
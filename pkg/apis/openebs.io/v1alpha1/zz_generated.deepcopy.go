@@ -90,6 +90,7 @@ func (in *CASSnapshot) DeepCopy() *CASSnapshot {
 func (in *CASSnapshotList) DeepCopyInto(out *CASSnapshotList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
 		*out = make([]CASSnapshot, len(*in))
@@ -137,6 +138,65 @@ func (in *CASTemplate) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CASTemplateDryRun) DeepCopyInto(out *CASTemplateDryRun) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CASTemplateDryRun.
+func (in *CASTemplateDryRun) DeepCopy() *CASTemplateDryRun {
+	if in == nil {
+		return nil
+	}
+	out := new(CASTemplateDryRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CASTemplateDryRunSpec) DeepCopyInto(out *CASTemplateDryRunSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CASTemplateDryRunSpec.
+func (in *CASTemplateDryRunSpec) DeepCopy() *CASTemplateDryRunSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CASTemplateDryRunSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CASTemplateDryRunStatus) DeepCopyInto(out *CASTemplateDryRunStatus) {
+	*out = *in
+	if in.Tasks != nil {
+		in, out := &in.Tasks, &out.Tasks
+		*out = make([]RunTaskDryRunResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CASTemplateDryRunStatus.
+func (in *CASTemplateDryRunStatus) DeepCopy() *CASTemplateDryRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CASTemplateDryRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CASTemplateList) DeepCopyInto(out *CASTemplateList) {
 	*out = *in
@@ -618,6 +678,25 @@ func (in *CStorPoolList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CStorPoolScrubStatus) DeepCopyInto(out *CStorPoolScrubStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.EndTime.DeepCopyInto(&out.EndTime)
+	in.LastScheduledTime.DeepCopyInto(&out.LastScheduledTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CStorPoolScrubStatus.
+func (in *CStorPoolScrubStatus) DeepCopy() *CStorPoolScrubStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CStorPoolScrubStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CStorPoolSpec) DeepCopyInto(out *CStorPoolSpec) {
 	*out = *in
@@ -648,6 +727,14 @@ func (in *CStorPoolStatus) DeepCopyInto(out *CStorPoolStatus) {
 	out.Capacity = in.Capacity
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	in.Scrub.DeepCopyInto(&out.Scrub)
+	if in.Vdevs != nil {
+		in, out := &in.Vdevs, &out.Vdevs
+		*out = make([]CStorPoolVdevStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -661,6 +748,124 @@ func (in *CStorPoolStatus) DeepCopy() *CStorPoolStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CStorPoolVdevStatus) DeepCopyInto(out *CStorPoolVdevStatus) {
+	*out = *in
+	if in.Children != nil {
+		in, out := &in.Children, &out.Children
+		*out = make([]CStorPoolVdevStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CStorPoolVdevStatus.
+func (in *CStorPoolVdevStatus) DeepCopy() *CStorPoolVdevStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CStorPoolVdevStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CStorReplicaMigration) DeepCopyInto(out *CStorReplicaMigration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CStorReplicaMigration.
+func (in *CStorReplicaMigration) DeepCopy() *CStorReplicaMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(CStorReplicaMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CStorReplicaMigration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CStorReplicaMigrationList) DeepCopyInto(out *CStorReplicaMigrationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CStorReplicaMigration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CStorReplicaMigrationList.
+func (in *CStorReplicaMigrationList) DeepCopy() *CStorReplicaMigrationList {
+	if in == nil {
+		return nil
+	}
+	out := new(CStorReplicaMigrationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CStorReplicaMigrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CStorReplicaMigrationSpec) DeepCopyInto(out *CStorReplicaMigrationSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CStorReplicaMigrationSpec.
+func (in *CStorReplicaMigrationSpec) DeepCopy() *CStorReplicaMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CStorReplicaMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CStorReplicaMigrationStatus) DeepCopyInto(out *CStorReplicaMigrationStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CStorReplicaMigrationStatus.
+func (in *CStorReplicaMigrationStatus) DeepCopy() *CStorReplicaMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CStorReplicaMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CStorRestore) DeepCopyInto(out *CStorRestore) {
 	*out = *in
@@ -813,6 +1018,8 @@ func (in *CStorVolumeClaim) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CStorVolumeClaimCondition) DeepCopyInto(out *CStorVolumeClaimCondition) {
 	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
@@ -906,10 +1113,19 @@ func (in *CStorVolumeClaimSpec) DeepCopy() *CStorVolumeClaimSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CStorVolumeClaimStatus) DeepCopyInto(out *CStorVolumeClaimStatus) {
 	*out = *in
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
 	if in.Condition != nil {
 		in, out := &in.Condition, &out.Condition
 		*out = make([]CStorVolumeClaimCondition, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -1238,6 +1454,126 @@ func (in *Gauge) DeepCopy() *Gauge {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JivaReplicaHealth) DeepCopyInto(out *JivaReplicaHealth) {
+	*out = *in
+	in.LastModeTransitionTime.DeepCopyInto(&out.LastModeTransitionTime)
+	in.LastRecoveryTime.DeepCopyInto(&out.LastRecoveryTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JivaReplicaHealth.
+func (in *JivaReplicaHealth) DeepCopy() *JivaReplicaHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(JivaReplicaHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JivaVolumeHealth) DeepCopyInto(out *JivaVolumeHealth) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JivaVolumeHealth.
+func (in *JivaVolumeHealth) DeepCopy() *JivaVolumeHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(JivaVolumeHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JivaVolumeHealth) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JivaVolumeHealthList) DeepCopyInto(out *JivaVolumeHealthList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]JivaVolumeHealth, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JivaVolumeHealthList.
+func (in *JivaVolumeHealthList) DeepCopy() *JivaVolumeHealthList {
+	if in == nil {
+		return nil
+	}
+	out := new(JivaVolumeHealthList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JivaVolumeHealthList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JivaVolumeHealthSpec) DeepCopyInto(out *JivaVolumeHealthSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JivaVolumeHealthSpec.
+func (in *JivaVolumeHealthSpec) DeepCopy() *JivaVolumeHealthSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JivaVolumeHealthSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JivaVolumeHealthStatus) DeepCopyInto(out *JivaVolumeHealthStatus) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make([]JivaReplicaHealth, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JivaVolumeHealthStatus.
+func (in *JivaVolumeHealthStatus) DeepCopy() *JivaVolumeHealthStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JivaVolumeHealthStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LabelItem) DeepCopyInto(out *LabelItem) {
 	*out = *in
@@ -1558,6 +1894,27 @@ func (in *RunTask) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunTaskDryRunResult) DeepCopyInto(out *RunTaskDryRunResult) {
+	*out = *in
+	if in.Rendered != nil {
+		in, out := &in.Rendered, &out.Rendered
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunTaskDryRunResult.
+func (in *RunTaskDryRunResult) DeepCopy() *RunTaskDryRunResult {
+	if in == nil {
+		return nil
+	}
+	out := new(RunTaskDryRunResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RunTaskList) DeepCopyInto(out *RunTaskList) {
 	*out = *in
@@ -0,0 +1,180 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha1 has the types of maya apiserver REST API that
+// are shared by the server & its clients
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+)
+
+const (
+	// APIVersion is the version of maya apiserver REST API
+	APIVersion = "v1"
+
+	// APIPrefix is the path prefix of the versioned REST API
+	APIPrefix = "/" + APIVersion + "/"
+
+	// LatestPrefix is the path prefix of the REST API that is
+	// an alias of the current version
+	LatestPrefix = "/latest/"
+)
+
+// Reason is the machine readable reason of an API error
+type Reason string
+
+const (
+	// ReasonBadRequest is the reason of an invalid request
+	ReasonBadRequest Reason = "BadRequest"
+	// ReasonUnauthorized is the reason of a request that
+	// could not be authenticated
+	ReasonUnauthorized Reason = "Unauthorized"
+	// ReasonForbidden is the reason of a request that is not
+	// authorized
+	ReasonForbidden Reason = "Forbidden"
+	// ReasonNotFound is the reason of a request whose resource
+	// does not exist
+	ReasonNotFound Reason = "NotFound"
+	// ReasonMethodNotAllowed is the reason of a request whose
+	// http method is not supported by the resource
+	ReasonMethodNotAllowed Reason = "MethodNotAllowed"
+	// ReasonConflict is the reason of a request that conflicts
+	// with the current state of the resource
+	ReasonConflict Reason = "Conflict"
+	// ReasonInternalError is the reason of a request that
+	// failed in the server
+	ReasonInternalError Reason = "InternalError"
+	// ReasonUnknown is the reason of an error whose code is
+	// not known
+	ReasonUnknown Reason = "Unknown"
+)
+
+// ReasonForCode returns the reason of the given http status code
+func ReasonForCode(code int) Reason {
+	switch code {
+	case http.StatusBadRequest:
+		return ReasonBadRequest
+	case http.StatusUnauthorized:
+		return ReasonUnauthorized
+	case http.StatusForbidden:
+		return ReasonForbidden
+	case http.StatusNotFound:
+		return ReasonNotFound
+	case http.StatusMethodNotAllowed:
+		return ReasonMethodNotAllowed
+	case http.StatusConflict:
+		return ReasonConflict
+	case http.StatusInternalServerError:
+		return ReasonInternalError
+	default:
+		return ReasonUnknown
+	}
+}
+
+// Error is the body of every failed response of the versioned
+// REST API
+type Error struct {
+	// Code is the http status code of the response
+	Code int `json:"code"`
+
+	// Reason is the machine readable reason of the error
+	Reason Reason `json:"reason"`
+
+	// Message is the human readable description of the error
+	Message string `json:"message"`
+
+	// Details has additional information about the error
+	// e.g. its cause
+	Details []string `json:"details,omitempty"`
+}
+
+// NewError returns a new instance of error for the given http
+// status code
+func NewError(code int, message string, details ...string) *Error {
+	return &Error{
+		Code:    code,
+		Reason:  ReasonForCode(code),
+		Message: message,
+		Details: details,
+	}
+}
+
+// Error implements error interface. The reason & code are left
+// out so that the error reads same as the message of the server.
+func (e *Error) Error() string {
+	if len(e.Details) == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Message, strings.Join(e.Details, ", "))
+}
+
+// DecodeError returns the error of the given failed response.
+// The body is decoded as error envelope of the versioned API or
+// else is used as the message of error e.g. the plain text body
+// of the legacy API.
+func DecodeError(code int, body []byte) *Error {
+	e := &Error{}
+	if err := json.Unmarshal(body, e); err == nil && e.Code != 0 {
+		return e
+	}
+	message := strings.TrimSpace(string(body))
+	if message == "" {
+		message = http.StatusText(code)
+	}
+	return NewError(code, message)
+}
+
+// IsUnknownPath returns true if the failed response is the not
+// found error of a server that does not serve the requested path
+// e.g. an older maya apiserver without the versioned API. The
+// versioned API responds the not found error of its resources in
+// the error envelope.
+func IsUnknownPath(code int, body []byte) bool {
+	if code != http.StatusNotFound {
+		return false
+	}
+	e := &Error{}
+	return json.Unmarshal(body, e) != nil || e.Code == 0
+}
+
+// LegacyPath returns the path of the alias e.g. /latest/volumes/
+// of the given path of the versioned API e.g. /v1/volumes/. It
+// returns false if the path is not of the versioned API.
+func LegacyPath(path string) (string, bool) {
+	if !strings.HasPrefix(path, APIPrefix) {
+		return "", false
+	}
+	return LatestPrefix + strings.TrimPrefix(path, APIPrefix), true
+}
+
+// IsNotFound returns true if the given error or its cause is an
+// API error having reason NotFound
+func IsNotFound(err error) bool {
+	return HasReason(err, ReasonNotFound)
+}
+
+// HasReason returns true if the given error or its cause is an
+// API error having the given reason
+func HasReason(err error, reason Reason) bool {
+	e, ok := errors.Cause(err).(*Error)
+	return ok && e.Reason == reason
+}
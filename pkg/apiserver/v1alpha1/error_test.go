@@ -0,0 +1,138 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+)
+
+func TestDecodeError(t *testing.T) {
+	tests := map[string]struct {
+		code     int
+		body     string
+		expected *Error
+	}{
+		"error envelope": {
+			code:     404,
+			body:     `{"code":404,"reason":"NotFound","message":"volume not found","details":["pvc-1"]}`,
+			expected: &Error{Code: 404, Reason: ReasonNotFound, Message: "volume not found", Details: []string{"pvc-1"}},
+		},
+		"plain text": {
+			code:     500,
+			body:     "failed to read volume\n",
+			expected: &Error{Code: 500, Reason: ReasonInternalError, Message: "failed to read volume"},
+		},
+		"empty body": {
+			code:     403,
+			expected: &Error{Code: 403, Reason: ReasonForbidden, Message: "Forbidden"},
+		},
+		"json that is not an envelope": {
+			code:     400,
+			body:     `{"kind":"CASVolume"}`,
+			expected: &Error{Code: 400, Reason: ReasonBadRequest, Message: `{"kind":"CASVolume"}`},
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			got := DecodeError(mock.code, []byte(mock.body))
+			if !reflect.DeepEqual(got, mock.expected) {
+				t.Fatalf("Test %q failed: expected '%+v': actual '%+v'", name, mock.expected, got)
+			}
+		})
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"not found":         {err: NewError(404, "volume not found"), expected: true},
+		"wrapped not found": {err: errors.Wrap(NewError(404, "volume not found"), "failed to delete"), expected: true},
+		"other reason":      {err: NewError(500, "volume not found")},
+		"other error":       {err: errors.New("volume not found")},
+		"nil error":         {},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			if got := IsNotFound(mock.err); got != mock.expected {
+				t.Fatalf("Test %q failed: expected '%t': actual '%t'", name, mock.expected, got)
+			}
+		})
+	}
+}
+
+func TestIsUnknownPath(t *testing.T) {
+	tests := map[string]struct {
+		code     int
+		body     string
+		expected bool
+	}{
+		"not found of unknown path": {
+			code:     404,
+			body:     "404 page not found\n",
+			expected: true,
+		},
+		"not found of resource": {
+			code: 404,
+			body: `{"code":404,"reason":"NotFound","message":"volume not found"}`,
+		},
+		"other error": {
+			code: 500,
+			body: "failed to read volume",
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			if got := IsUnknownPath(mock.code, []byte(mock.body)); got != mock.expected {
+				t.Fatalf("Test %q failed: expected '%t': actual '%t'", name, mock.expected, got)
+			}
+		})
+	}
+}
+
+func TestLegacyPath(t *testing.T) {
+	tests := map[string]struct {
+		path       string
+		expected   string
+		isVersioned bool
+	}{
+		"versioned path": {
+			path:       "/v1/volumes/pvc-1",
+			expected:   "/latest/volumes/pvc-1",
+			isVersioned: true,
+		},
+		"legacy path": {
+			path: "/latest/volumes/pvc-1",
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			got, ok := LegacyPath(mock.path)
+			if got != mock.expected || ok != mock.isVersioned {
+				t.Fatalf("Test %q failed: expected '%s' '%t': actual '%s' '%t'", name, mock.expected, mock.isVersioned, got, ok)
+			}
+		})
+	}
+}
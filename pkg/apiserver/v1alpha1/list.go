@@ -0,0 +1,177 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strconv"
+
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// LabelSelectorParam is the query parameter of list
+	// request to filter the items by labels
+	LabelSelectorParam = "labelSelector"
+
+	// FieldSelectorParam is the query parameter of list
+	// request to filter the items by fields e.g.
+	// metadata.namespace=default
+	FieldSelectorParam = "fieldSelector"
+
+	// LimitParam is the query parameter of list request to
+	// set the maximum number of items in the response
+	LimitParam = "limit"
+
+	// ContinueParam is the query parameter of list request
+	// to get the next page of items. Its value is the continue
+	// token of the previous response.
+	ContinueParam = "continue"
+
+	// WatchParam is the query parameter of list request to
+	// stream the changes to the items instead of listing them
+	WatchParam = "watch"
+
+	// EventStreamContentType is the content type of watch
+	// response that is streamed as server sent events
+	EventStreamContentType = "text/event-stream"
+)
+
+// ListOptions are the options of a list request
+type ListOptions struct {
+	LabelSelector string
+	FieldSelector string
+	Limit         int64
+	Continue      string
+	Watch         bool
+
+	labels labels.Selector
+	fields fields.Selector
+}
+
+// ParseListOptions returns the list options set as query
+// parameters of a list request
+func ParseListOptions(query url.Values) (*ListOptions, error) {
+	var err error
+	opts := &ListOptions{
+		LabelSelector: query.Get(LabelSelectorParam),
+		FieldSelector: query.Get(FieldSelectorParam),
+		Continue:      query.Get(ContinueParam),
+	}
+	opts.labels, err = labels.Parse(opts.LabelSelector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s {%s}", LabelSelectorParam, opts.LabelSelector)
+	}
+	opts.fields, err = fields.ParseSelector(opts.FieldSelector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s {%s}", FieldSelectorParam, opts.FieldSelector)
+	}
+	if limit := query.Get(LimitParam); limit != "" {
+		opts.Limit, err = strconv.ParseInt(limit, 10, 64)
+		if err != nil || opts.Limit < 0 {
+			return nil, errors.Errorf("invalid %s {%s}: expected a non negative integer", LimitParam, limit)
+		}
+	}
+	if watch := query.Get(WatchParam); watch != "" {
+		opts.Watch, err = strconv.ParseBool(watch)
+		if err != nil {
+			return nil, errors.Errorf("invalid %s {%s}: expected a boolean", WatchParam, watch)
+		}
+	}
+	if opts.Watch && (opts.Limit != 0 || opts.Continue != "") {
+		return nil, errors.Errorf("invalid list options: %s can not be used with %s or %s",
+			WatchParam, LimitParam, ContinueParam)
+	}
+	return opts, nil
+}
+
+// Query returns the list options as query parameters of a
+// list request
+func (o *ListOptions) Query() url.Values {
+	query := url.Values{}
+	if o.LabelSelector != "" {
+		query.Set(LabelSelectorParam, o.LabelSelector)
+	}
+	if o.FieldSelector != "" {
+		query.Set(FieldSelectorParam, o.FieldSelector)
+	}
+	if o.Limit != 0 {
+		query.Set(LimitParam, strconv.FormatInt(o.Limit, 10))
+	}
+	if o.Continue != "" {
+		query.Set(ContinueParam, o.Continue)
+	}
+	if o.Watch {
+		query.Set(WatchParam, "true")
+	}
+	return query
+}
+
+// Matches returns true if the item having the given labels
+// & fields is selected by the list options
+func (o *ListOptions) Matches(l map[string]string, f fields.Set) bool {
+	if o.labels != nil && !o.labels.Matches(labels.Set(l)) {
+		return false
+	}
+	if o.fields != nil && !o.fields.Matches(f) {
+		return false
+	}
+	return true
+}
+
+// EncodeContinue returns the continue token of a page whose
+// last item has the given key
+func EncodeContinue(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+// DecodeContinue returns the key of the last item of the page
+// that the given continue token was returned with
+func DecodeContinue(token string) (string, error) {
+	key, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid %s token {%s}", ContinueParam, token)
+	}
+	return string(key), nil
+}
+
+// EventType is the type of a watch event
+type EventType string
+
+const (
+	// EventAdded is the type of event sent for an item that
+	// exists when the watch starts or is added later
+	EventAdded EventType = "ADDED"
+	// EventModified is the type of event sent for an item
+	// that is modified
+	EventModified EventType = "MODIFIED"
+	// EventDeleted is the type of event sent for an item that
+	// is deleted
+	EventDeleted EventType = "DELETED"
+	// EventError is the type of event sent if the watch fails
+	// to list the items. Its object is the error envelope.
+	EventError EventType = "ERROR"
+)
+
+// WatchEvent is a change to an item of a watched list
+type WatchEvent struct {
+	Type   EventType   `json:"type"`
+	Object interface{} `json:"object"`
+}
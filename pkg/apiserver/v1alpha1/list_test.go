@@ -0,0 +1,107 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"net/url"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+func TestParseListOptions(t *testing.T) {
+	tests := map[string]struct {
+		query   string
+		isErr   bool
+		limit   int64
+		isWatch bool
+	}{
+		"no options":             {query: ""},
+		"selectors & limit":      {query: "labelSelector=app%3Dmysql&fieldSelector=spec.casType%3Dcstor&limit=10", limit: 10},
+		"watch":                  {query: "watch=true", isWatch: true},
+		"invalid label selector": {query: "labelSelector=app%3D%3D%3D", isErr: true},
+		"invalid field selector": {query: "fieldSelector=spec.casType", isErr: true},
+		"negative limit":         {query: "limit=-1", isErr: true},
+		"invalid watch":          {query: "watch=yes-please", isErr: true},
+		"watch with limit":       {query: "watch=true&limit=10", isErr: true},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			query, _ := url.ParseQuery(mock.query)
+			opts, err := ParseListOptions(query)
+			if mock.isErr != (err != nil) {
+				t.Fatalf("Test %q failed: expected error '%t': actual '%v'", name, mock.isErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if opts.Limit != mock.limit || opts.Watch != mock.isWatch {
+				t.Fatalf("Test %q failed: unexpected options '%+v'", name, opts)
+			}
+			if got := opts.Query().Encode(); got != query.Encode() {
+				t.Fatalf("Test %q failed: expected query '%s': actual '%s'", name, query.Encode(), got)
+			}
+		})
+	}
+}
+
+func TestListOptionsMatches(t *testing.T) {
+	query, _ := url.ParseQuery("labelSelector=app%3Dmysql&fieldSelector=spec.casType%3Dcstor")
+	opts, err := ParseListOptions(query)
+	if err != nil {
+		t.Fatalf("Test failed: unexpected error: %v", err)
+	}
+	tests := map[string]struct {
+		labels   map[string]string
+		fields   fields.Set
+		expected bool
+	}{
+		"matching labels & fields": {
+			labels:   map[string]string{"app": "mysql"},
+			fields:   fields.Set{"spec.casType": "cstor"},
+			expected: true,
+		},
+		"other labels": {
+			labels: map[string]string{"app": "nginx"},
+			fields: fields.Set{"spec.casType": "cstor"},
+		},
+		"other fields": {
+			labels: map[string]string{"app": "mysql"},
+			fields: fields.Set{"spec.casType": "jiva"},
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			if got := opts.Matches(mock.labels, mock.fields); got != mock.expected {
+				t.Fatalf("Test %q failed: expected '%t': actual '%t'", name, mock.expected, got)
+			}
+		})
+	}
+}
+
+func TestContinueToken(t *testing.T) {
+	key := "default/pvc-1"
+	got, err := DecodeContinue(EncodeContinue(key))
+	if err != nil || got != key {
+		t.Fatalf("Test failed: expected key '%s': actual '%s': %v", key, got, err)
+	}
+	if _, err := DecodeContinue("%%%"); err == nil {
+		t.Fatalf("Test failed: expected error for invalid token: actual nil")
+	}
+}
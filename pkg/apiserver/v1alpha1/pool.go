@@ -0,0 +1,165 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	cstorpool "github.com/openebs/maya/pkg/cstor/pool/v1alpha1"
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+)
+
+// raidGroupBlockDeviceCount is the number of block devices that
+// a raid group of given type is made of or a multiple of
+var raidGroupBlockDeviceCount = map[string]int{
+	string(v1alpha1.PoolStriped):  int(v1alpha1.StripedBlockDeviceCountCPV),
+	string(v1alpha1.PoolMirrored): int(v1alpha1.MirroredBlockDeviceCountCPV),
+	string(v1alpha1.PoolRaidz):    int(v1alpha1.RaidzBlockDeviceCountCPV),
+	string(v1alpha1.PoolRaidz2):   int(v1alpha1.Raidz2BlockDeviceCountCPV),
+}
+
+// PoolCreateRequest is the request to create a cstor pool
+// cluster i.e. a cstor pool on each of the given nodes
+type PoolCreateRequest struct {
+	// Name of the cstor pool cluster
+	Name string `json:"name"`
+
+	// RaidGroupType is the default type of raid groups
+	// e.g. stripe, mirror, raidz or raidz2
+	RaidGroupType string `json:"raidGroupType"`
+
+	// Compression is the compression algorithm of pools
+	// e.g. lz4 or off
+	Compression string `json:"compression,omitempty"`
+
+	// OverProvisioning allows volumes to be provisioned
+	// beyond the capacity of pools
+	OverProvisioning bool `json:"overProvisioning,omitempty"`
+
+	// OverCommitRatio is the ratio of the capacity that can
+	// be provisioned on pools to the size of pools e.g. 1.5
+	OverCommitRatio string `json:"overCommitRatio,omitempty"`
+
+	// Nodes are the pools to be created per node
+	Nodes []PoolNode `json:"nodes"`
+}
+
+// PoolExpandRequest is the request to add raid groups to the
+// pool of a node of cstor pool cluster
+type PoolExpandRequest struct {
+	PoolNode `json:",inline"`
+}
+
+// PoolNode is the pool of a node
+type PoolNode struct {
+	// NodeName is the hostname of the node
+	NodeName string `json:"nodeName"`
+
+	// RaidGroups of the pool
+	RaidGroups []PoolRaidGroup `json:"raidGroups"`
+}
+
+// PoolRaidGroup is a raid group of a pool
+type PoolRaidGroup struct {
+	// Type of the raid group. The raid group type of pool
+	// is used if not set.
+	Type string `json:"type,omitempty"`
+
+	// BlockDevices are the names of block devices of the
+	// raid group
+	BlockDevices []string `json:"blockDevices"`
+}
+
+// Validate returns error if the create request is invalid
+func (r *PoolCreateRequest) Validate() error {
+	if r.Name == "" {
+		return errors.New("invalid pool create request: missing name")
+	}
+	if _, ok := raidGroupBlockDeviceCount[r.RaidGroupType]; !ok {
+		return errors.Errorf("invalid pool create request {%s}: invalid raid group type {%s}", r.Name, r.RaidGroupType)
+	}
+	if len(r.Nodes) == 0 {
+		return errors.Errorf("invalid pool create request {%s}: missing nodes", r.Name)
+	}
+	if _, err := cstorpool.ParseOverCommitRatio(r.OverCommitRatio); err != nil {
+		return errors.Wrapf(err, "invalid pool create request {%s}", r.Name)
+	}
+	nodes := map[string]bool{}
+	devices := map[string]bool{}
+	for _, node := range r.Nodes {
+		if nodes[node.NodeName] {
+			return errors.Errorf("invalid pool create request {%s}: duplicate node {%s}", r.Name, node.NodeName)
+		}
+		nodes[node.NodeName] = true
+		if err := node.validate(r.RaidGroupType, devices); err != nil {
+			return errors.Wrapf(err, "invalid pool create request {%s}", r.Name)
+		}
+	}
+	return nil
+}
+
+// Validate returns error if the expand request is invalid.
+// The raid groups are expected to be of given type if their
+// type is not set.
+func (r *PoolExpandRequest) Validate(raidGroupType string) error {
+	if err := r.validate(raidGroupType, map[string]bool{}); err != nil {
+		return errors.Wrap(err, "invalid pool expand request")
+	}
+	return nil
+}
+
+// validate returns error if the pool of node is invalid. The
+// block devices of pool are added to the given devices so that
+// a block device is used only once.
+func (n *PoolNode) validate(raidGroupType string, devices map[string]bool) error {
+	if n.NodeName == "" {
+		return errors.New("missing node name")
+	}
+	if len(n.RaidGroups) == 0 {
+		return errors.Errorf("missing raid groups of node {%s}", n.NodeName)
+	}
+	for i, rg := range n.RaidGroups {
+		rgType := rg.TypeOrDefault(raidGroupType)
+		count, ok := raidGroupBlockDeviceCount[rgType]
+		if !ok {
+			return errors.Errorf("invalid type {%s} of raid group {%d} of node {%s}", rgType, i, n.NodeName)
+		}
+		if len(rg.BlockDevices) == 0 || len(rg.BlockDevices)%count != 0 {
+			return errors.Errorf(
+				"invalid raid group {%d} of node {%s}: {%s} raid group expects a multiple of {%d} block devices: got {%d}",
+				i, n.NodeName, rgType, count, len(rg.BlockDevices))
+		}
+		for _, bd := range rg.BlockDevices {
+			if bd == "" {
+				return errors.Errorf("invalid raid group {%d} of node {%s}: missing block device name", i, n.NodeName)
+			}
+			if devices[bd] {
+				return errors.Errorf("invalid raid group {%d} of node {%s}: duplicate block device {%s}", i, n.NodeName, bd)
+			}
+			devices[bd] = true
+		}
+	}
+	return nil
+}
+
+// TypeOrDefault returns the type of raid group or the given
+// default type if it is not set
+func (rg PoolRaidGroup) TypeOrDefault(defaultType string) string {
+	if rg.Type == "" {
+		return defaultType
+	}
+	return rg.Type
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+)
+
+func fakePoolNode(name string, rgs ...PoolRaidGroup) PoolNode {
+	return PoolNode{NodeName: name, RaidGroups: rgs}
+}
+
+func fakeRaidGroup(rgType string, bds ...string) PoolRaidGroup {
+	return PoolRaidGroup{Type: rgType, BlockDevices: bds}
+}
+
+func TestPoolCreateRequestValidate(t *testing.T) {
+	tests := map[string]struct {
+		req   PoolCreateRequest
+		isErr bool
+	}{
+		"valid mirror pool": {
+			req: PoolCreateRequest{Name: "pool", RaidGroupType: "mirror", Nodes: []PoolNode{
+				fakePoolNode("node-1", fakeRaidGroup("", "bd-1", "bd-2"), fakeRaidGroup("", "bd-3", "bd-4")),
+				fakePoolNode("node-2", fakeRaidGroup("stripe", "bd-5")),
+			}},
+		},
+		"missing name": {
+			req:   PoolCreateRequest{RaidGroupType: "stripe", Nodes: []PoolNode{fakePoolNode("node-1", fakeRaidGroup("", "bd-1"))}},
+			isErr: true,
+		},
+		"invalid raid group type": {
+			req:   PoolCreateRequest{Name: "pool", RaidGroupType: "striped", Nodes: []PoolNode{fakePoolNode("node-1", fakeRaidGroup("", "bd-1"))}},
+			isErr: true,
+		},
+		"missing nodes": {
+			req:   PoolCreateRequest{Name: "pool", RaidGroupType: "stripe"},
+			isErr: true,
+		},
+		"duplicate node": {
+			req: PoolCreateRequest{Name: "pool", RaidGroupType: "stripe", Nodes: []PoolNode{
+				fakePoolNode("node-1", fakeRaidGroup("", "bd-1")),
+				fakePoolNode("node-1", fakeRaidGroup("", "bd-2")),
+			}},
+			isErr: true,
+		},
+		"invalid block device count": {
+			req:   PoolCreateRequest{Name: "pool", RaidGroupType: "raidz", Nodes: []PoolNode{fakePoolNode("node-1", fakeRaidGroup("", "bd-1", "bd-2"))}},
+			isErr: true,
+		},
+		"valid over commit ratio": {
+			req: PoolCreateRequest{Name: "pool", RaidGroupType: "stripe", OverCommitRatio: "1.5", Nodes: []PoolNode{
+				fakePoolNode("node-1", fakeRaidGroup("", "bd-1")),
+			}},
+		},
+		"invalid over commit ratio": {
+			req: PoolCreateRequest{Name: "pool", RaidGroupType: "stripe", OverCommitRatio: "twice", Nodes: []PoolNode{
+				fakePoolNode("node-1", fakeRaidGroup("", "bd-1")),
+			}},
+			isErr: true,
+		},
+		"block device of two nodes": {
+			req: PoolCreateRequest{Name: "pool", RaidGroupType: "stripe", Nodes: []PoolNode{
+				fakePoolNode("node-1", fakeRaidGroup("", "bd-1")),
+				fakePoolNode("node-2", fakeRaidGroup("", "bd-1")),
+			}},
+			isErr: true,
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			err := mock.req.Validate()
+			if mock.isErr != (err != nil) {
+				t.Fatalf("Test %q failed: expected error '%t': actual '%v'", name, mock.isErr, err)
+			}
+		})
+	}
+}
+
+func TestPoolExpandRequestValidate(t *testing.T) {
+	tests := map[string]struct {
+		req           PoolExpandRequest
+		raidGroupType string
+		isErr         bool
+	}{
+		"default raid group type": {
+			req:           PoolExpandRequest{fakePoolNode("node-1", fakeRaidGroup("", "bd-1", "bd-2"))},
+			raidGroupType: "mirror",
+		},
+		"raid group type of request": {
+			req:           PoolExpandRequest{fakePoolNode("node-1", fakeRaidGroup("raidz", "bd-1", "bd-2", "bd-3"))},
+			raidGroupType: "mirror",
+		},
+		"missing node name": {
+			req:           PoolExpandRequest{fakePoolNode("", fakeRaidGroup("", "bd-1"))},
+			raidGroupType: "stripe",
+			isErr:         true,
+		},
+		"missing raid groups": {
+			req:           PoolExpandRequest{fakePoolNode("node-1")},
+			raidGroupType: "stripe",
+			isErr:         true,
+		},
+		"duplicate block device": {
+			req:           PoolExpandRequest{fakePoolNode("node-1", fakeRaidGroup("", "bd-1", "bd-1"))},
+			raidGroupType: "mirror",
+			isErr:         true,
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			err := mock.req.Validate(mock.raidGroupType)
+			if mock.isErr != (err != nil) {
+				t.Fatalf("Test %q failed: expected error '%t': actual '%v'", name, mock.isErr, err)
+			}
+		})
+	}
+}
@@ -17,12 +17,14 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
 	"strings"
 
 	"github.com/ghodss/yaml"
 	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
 	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
 	"github.com/openebs/maya/pkg/task"
+	trace "github.com/openebs/maya/pkg/trace/v1alpha1"
 	"github.com/openebs/maya/pkg/util"
 )
 
@@ -85,6 +87,7 @@ func ConfigToMap(all []v1alpha1.Config) (m map[string]interface{}, err error) {
 type Interface interface {
 	Configurer
 	Runner
+	DryRunner
 }
 
 // Configurer abstracts configuring
@@ -92,6 +95,7 @@ type Interface interface {
 type Configurer interface {
 	SetConfig(values map[string]interface{})
 	SetValues(key string, values map[string]interface{})
+	SetContext(ctx context.Context)
 }
 
 // Runner abstracts execution of
@@ -100,6 +104,12 @@ type Runner interface {
 	Run() (output []byte, err error)
 }
 
+// DryRunner abstracts rendering of cas
+// template without executing it
+type DryRunner interface {
+	DryRun() (status *v1alpha1.CASTemplateDryRunStatus, err error)
+}
+
 // engine implements various cas template
 // related operations
 type engine struct {
@@ -108,6 +118,7 @@ type engine struct {
 	key             string                 // a path to store template values
 	taskSpecFetcher task.TaskSpecFetcher   // to fetch runtask specification
 	taskGroupRunner *task.TaskGroupRunner  // runs the tasks in the sequence specified in cas template
+	ctx             context.Context        // has the span of the caller to trace the execution
 }
 
 // initValues provides engine specific
@@ -214,6 +225,12 @@ func (c *engine) SetValues(k string, v map[string]interface{}) {
 	util.SetNestedField(c.values, v, c.key)
 }
 
+// SetContext sets the context whose span traces
+// the cas template execution
+func (c *engine) SetContext(ctx context.Context) {
+	c.ctx = ctx
+}
+
 // prepareTasksForExec prepares the taskGroupRunner
 // instance with the info needed to run the tasks
 func (c *engine) prepareTasksForExec() error {
@@ -260,29 +277,69 @@ func (c *engine) prepareFallback() {
 	c.taskGroupRunner.SetFallback(f)
 }
 
-// Run executes the cas engine based on the tasks
-// specified in the cas template
-func (c *engine) Run() (output []byte, err error) {
+// prepare sets the template values and prepares
+// the tasks specified in cas template for execution
+func (c *engine) prepare() (err error) {
 	c.setLabels()
 
 	err = c.setDefaultsIfEmptyConfig()
 	if err != nil {
-		err = errors.Wrap(err, "failed to run cas template engine")
 		return
 	}
 
 	err = c.prepareTasksForExec()
 	if err != nil {
-		err = errors.Wrap(err, "failed to run cas template engine")
 		return
 	}
 
 	err = c.prepareOutputTask()
 	if err != nil {
-		err = errors.Wrap(err, "failed to run cas template engine")
 		return
 	}
 
 	c.prepareFallback()
+	return
+}
+
+// Run executes the cas engine based on the tasks
+// specified in cas template
+func (c *engine) Run() (output []byte, err error) {
+	ctx, span := trace.Start(c.ctx, "castemplate.Engine.Run", trace.String("castemplate", c.cast.Name))
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = c.prepare()
+	if err != nil {
+		err = errors.Wrap(err, "failed to run cas template engine")
+		return
+	}
+
+	c.taskGroupRunner.SetContext(ctx)
 	return c.taskGroupRunner.Run(c.values)
 }
+
+// DryRun renders the tasks specified in cas template
+// without creating, updating or deleting any resource
+//
+// NOTE:
+//  Errors while rendering the tasks are reported in
+// the returned status
+func (c *engine) DryRun() (status *v1alpha1.CASTemplateDryRunStatus, err error) {
+	err = c.prepare()
+	if err != nil {
+		err = errors.Wrap(err, "failed to dry run cas template engine")
+		return
+	}
+
+	tasks, output, err := c.taskGroupRunner.DryRun(c.values)
+	status = &v1alpha1.CASTemplateDryRunStatus{
+		Tasks:  tasks,
+		Output: string(output),
+	}
+	if err != nil {
+		status.Error = err.Error()
+	}
+	return status, nil
+}
@@ -0,0 +1,542 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	"github.com/openebs/maya/pkg/task"
+	templatefuncs "github.com/openebs/maya/pkg/templatefuncs/v1alpha1"
+	"github.com/openebs/maya/pkg/util"
+)
+
+// LintSeverity represents the severity of a lint issue
+type LintSeverity string
+
+const (
+	// LintError flags an issue that will result in a failure when the
+	// cas template or runtask is run
+	LintError LintSeverity = "error"
+
+	// LintWarning flags an issue that may result in a failure when the
+	// cas template or runtask is run
+	LintWarning LintSeverity = "warning"
+)
+
+const (
+	// casTemplateLintKind is the kind reported for cas template issues
+	casTemplateLintKind = "CASTemplate"
+
+	// runTaskLintKind is the kind reported for runtask issues
+	runTaskLintKind = "RunTask"
+)
+
+var (
+	// supportedActions is the list of runtask actions understood by the
+	// task executor
+	supportedActions = []string{
+		string(task.GetTA),
+		string(task.ListTA),
+		string(task.PutTA),
+		string(task.DeleteTA),
+		string(task.PatchTA),
+		string(task.ExecTA),
+		string(task.RolloutstatusTA),
+		string(task.OutputTA),
+	}
+
+	// saveFuncs is the list of template functions that save a value against
+	// a path of template values
+	saveFuncs = []string{"saveAs", "saveas", "saveIf", "saveif", "addTo"}
+
+	// taskResultErrKeys is the list of task result properties that are set
+	// by the task executor itself
+	taskResultErrKeys = []string{
+		string(apis.TaskResultVerifyErrTRTP),
+		string(apis.TaskResultNotFoundErrTRTP),
+		string(apis.TaskResultVersionMismatchErrTRTP),
+	}
+
+	// staticMetaRegex matches a top level meta property that is not
+	// templated e.g. 'kind: Service'
+	staticMetaRegex = regexp.MustCompile(`^(id|apiVersion|kind|action)\s*:\s*(\S.*)$`)
+)
+
+// LintIssue represents a problem found while linting a cas template or a
+// runtask
+type LintIssue struct {
+	// Severity of this issue
+	Severity LintSeverity `json:"severity"`
+
+	// Kind of the resource having this issue i.e. CASTemplate or RunTask
+	Kind string `json:"kind"`
+
+	// Name of the resource having this issue
+	Name string `json:"name"`
+
+	// Field of the resource having this issue e.g. spec.meta
+	Field string `json:"field"`
+
+	// Message describes this issue
+	Message string `json:"message"`
+}
+
+// String implements Stringer interface
+func (i LintIssue) String() string {
+	return fmt.Sprintf("%s: %s {%s}: %s: %s", i.Severity, i.Kind, i.Name, i.Field, i.Message)
+}
+
+// LintIssueList represents a list of lint issues
+type LintIssueList []LintIssue
+
+// HasErrors flags if any of the issues is an error
+func (l LintIssueList) HasErrors() bool {
+	for _, i := range l {
+		if i.Severity == LintError {
+			return true
+		}
+	}
+	return false
+}
+
+// Errors returns the issues that are errors
+func (l LintIssueList) Errors() (errs LintIssueList) {
+	for _, i := range l {
+		if i.Severity == LintError {
+			errs = append(errs, i)
+		}
+	}
+	return
+}
+
+// String implements Stringer interface
+func (l LintIssueList) String() string {
+	var issues []string
+	for _, i := range l {
+		issues = append(issues, i.String())
+	}
+	return strings.Join(issues, "\n")
+}
+
+// runTaskIssue returns a lint issue for the given runtask
+func runTaskIssue(sev LintSeverity, name, field, format string, args ...interface{}) LintIssue {
+	return LintIssue{
+		Severity: sev,
+		Kind:     runTaskLintKind,
+		Name:     name,
+		Field:    field,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+// casTemplateIssue returns a lint issue for the given cas template
+func casTemplateIssue(sev LintSeverity, name, field, format string, args ...interface{}) LintIssue {
+	return LintIssue{
+		Severity: sev,
+		Kind:     casTemplateLintKind,
+		Name:     name,
+		Field:    field,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+// parseTaskTemplate parses the given runtask template against the
+// registered template functions
+func parseTaskTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(templatefuncs.AllCustomFuncs()).Parse(text)
+}
+
+// runTaskTemplates returns the templates of the given runtask mapped to
+// their field names
+func runTaskTemplates(rt *apis.RunTask) [][2]string {
+	return [][2]string{
+		{"spec.meta", rt.Spec.Meta},
+		{"spec.task", rt.Spec.Task},
+		{"spec.post", rt.Spec.PostRun},
+	}
+}
+
+// staticMeta returns the top level meta properties of the given meta
+// template that are not templated. A property may have more than one value
+// if it is set in different branches of the template.
+func staticMeta(meta string) map[string][]string {
+	props := map[string][]string{}
+	for _, line := range strings.Split(meta, "\n") {
+		matches := staticMetaRegex.FindStringSubmatch(strings.TrimRight(line, " \t\r"))
+		if len(matches) != 3 || strings.Contains(matches[2], "{{") {
+			continue
+		}
+		val := strings.Trim(matches[2], `"'`)
+		if !util.ContainsString(props[matches[1]], val) {
+			props[matches[1]] = append(props[matches[1]], val)
+		}
+	}
+	return props
+}
+
+// LintRunTask verifies the given runtask for issues that would otherwise
+// surface only when the runtask is executed. It checks:
+//
+//  1/ meta, task & post templates against the registered template functions
+//  2/ action as well as apiVersion & kind against the ones supported by the
+// task executor
+//
+// NOTE:
+//  Only the meta properties that are not templated are verified
+func LintRunTask(rt *apis.RunTask) (issues LintIssueList) {
+	if rt == nil {
+		return
+	}
+
+	for _, tpl := range runTaskTemplates(rt) {
+		_, err := parseTaskTemplate(tpl[0], tpl[1])
+		if err != nil {
+			issues = append(issues, runTaskIssue(LintError, rt.Name, tpl[0], "invalid template: %s", err))
+		}
+	}
+
+	if len(strings.TrimSpace(rt.Spec.Meta)) == 0 {
+		issues = append(issues, runTaskIssue(LintError, rt.Name, "spec.meta", "missing meta"))
+		return
+	}
+
+	meta := staticMeta(rt.Spec.Meta)
+	for _, action := range meta["action"] {
+		if !util.ContainsString(supportedActions, action) {
+			issues = append(issues, runTaskIssue(LintError, rt.Name, "spec.meta", "unsupported action {%s}: supported actions %v", action, supportedActions))
+		}
+	}
+
+	// verify the combinations of apiVersion, kind & action that can be
+	// determined without running the meta template
+	for _, kind := range meta["kind"] {
+		if kind == string(task.CommandKind) {
+			continue
+		}
+		for _, apiVersion := range meta["apiVersion"] {
+			for _, action := range meta["action"] {
+				if action == string(task.OutputTA) || !util.ContainsString(supportedActions, action) {
+					continue
+				}
+				m := task.MetaTaskSpec{
+					MetaTaskIdentity: task.MetaTaskIdentity{
+						Identity:   "lint",
+						APIVersion: apiVersion,
+						Kind:       kind,
+					},
+					Action: task.MetaTaskAction(action),
+				}
+				if !task.IsSupportedMetaTask(m) {
+					issues = append(issues, runTaskIssue(LintError, rt.Name, "spec.meta", "unsupported task: action {%s} is not supported for apiVersion {%s} kind {%s}", action, apiVersion, kind))
+				}
+			}
+		}
+	}
+	return
+}
+
+// taskResultRefs holds the task result paths that are referred to & saved
+// by a runtask
+type taskResultRefs struct {
+	// refs are the paths read from .TaskResult
+	refs [][]string
+
+	// saves are the paths saved to .TaskResult
+	saves [][]string
+
+	// isDynamic flags if .TaskResult is saved to at paths that can be
+	// determined only at runtime
+	isDynamic bool
+}
+
+// isTaskResult flags if the given node refers to .TaskResult itself
+func isTaskResult(node parse.Node) bool {
+	switch n := node.(type) {
+	case *parse.FieldNode:
+		return len(n.Ident) == 1 && n.Ident[0] == string(apis.TaskResultTLP)
+	case *parse.VariableNode:
+		return len(n.Ident) == 2 && n.Ident[0] == "$" && n.Ident[1] == string(apis.TaskResultTLP)
+	}
+	return false
+}
+
+// hasDefault flags if the given pipeline makes use of default template
+// function after its first command
+func hasDefault(pipe *parse.PipeNode) bool {
+	for _, cmd := range pipe.Cmds[1:] {
+		if len(cmd.Args) == 0 {
+			continue
+		}
+		fn, ok := cmd.Args[0].(*parse.IdentifierNode)
+		if ok && fn.Ident == "default" {
+			return true
+		}
+	}
+	return false
+}
+
+// addCommand extracts the .TaskResult path saved by the given command
+func (r *taskResultRefs) addCommand(cmd *parse.CommandNode) {
+	if len(cmd.Args) == 0 {
+		return
+	}
+	fn, ok := cmd.Args[0].(*parse.IdentifierNode)
+	if !ok {
+		return
+	}
+	if fn.Ident == "storeAt" {
+		for _, arg := range cmd.Args[1:] {
+			if isTaskResult(arg) {
+				r.isDynamic = true
+			}
+		}
+		return
+	}
+	if !util.ContainsString(saveFuncs, fn.Ident) || len(cmd.Args) < 3 || !isTaskResult(cmd.Args[2]) {
+		return
+	}
+	path, ok := cmd.Args[1].(*parse.StringNode)
+	if !ok {
+		r.isDynamic = true
+		return
+	}
+	r.saves = append(r.saves, strings.Split(path.Text, "."))
+}
+
+// walk extracts the .TaskResult paths referred to & saved by the given node
+// & its children. isDotRoot flags if dot refers to the template values.
+func (r *taskResultRefs) walk(node parse.Node, isDotRoot bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			r.walk(child, isDotRoot)
+		}
+	case *parse.ActionNode:
+		r.walk(n.Pipe, isDotRoot)
+	case *parse.IfNode:
+		r.walk(n.Pipe, isDotRoot)
+		r.walk(n.List, isDotRoot)
+		r.walk(n.ElseList, isDotRoot)
+	case *parse.RangeNode:
+		// dot is set to the current element within range
+		r.walk(n.Pipe, isDotRoot)
+		r.walk(n.List, false)
+		r.walk(n.ElseList, isDotRoot)
+	case *parse.WithNode:
+		// dot is set to the value of pipeline within with
+		r.walk(n.Pipe, isDotRoot)
+		r.walk(n.List, false)
+		r.walk(n.ElseList, isDotRoot)
+	case *parse.TemplateNode:
+		r.walk(n.Pipe, isDotRoot)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for idx, cmd := range n.Cmds {
+			count := len(r.refs)
+			r.walk(cmd, isDotRoot)
+			// a reference that is piped to default is optional
+			// e.g. {{ .TaskResult.id.ns | default "openebs" }}
+			if idx == 0 && hasDefault(n) {
+				r.refs = r.refs[:count]
+			}
+		}
+	case *parse.CommandNode:
+		if isDotRoot {
+			r.addCommand(n)
+		}
+		for _, arg := range n.Args {
+			r.walk(arg, isDotRoot)
+		}
+	case *parse.ChainNode:
+		r.walk(n.Node, isDotRoot)
+	case *parse.FieldNode:
+		if isDotRoot && len(n.Ident) > 1 && n.Ident[0] == string(apis.TaskResultTLP) {
+			r.refs = append(r.refs, n.Ident[1:])
+		}
+	case *parse.VariableNode:
+		if len(n.Ident) > 2 && n.Ident[0] == "$" && n.Ident[1] == string(apis.TaskResultTLP) {
+			r.refs = append(r.refs, n.Ident[2:])
+		}
+	}
+}
+
+// newTaskResultRefs returns the .TaskResult paths referred to & saved by
+// the given runtask. Templates that fail to parse are ignored.
+func newTaskResultRefs(rt *apis.RunTask) *taskResultRefs {
+	r := &taskResultRefs{}
+	for _, tpl := range runTaskTemplates(rt) {
+		t, err := parseTaskTemplate(tpl[0], tpl[1])
+		if err != nil {
+			continue
+		}
+		for _, tmpl := range t.Templates() {
+			if tmpl.Tree != nil {
+				r.walk(tmpl.Tree.Root, true)
+			}
+		}
+	}
+	return r
+}
+
+// isPathMatch flags if the referred path is available via the saved path
+// i.e. if either of the paths is a prefix of the other
+func isPathMatch(ref, saved []string) bool {
+	l := len(ref)
+	if len(saved) < l {
+		l = len(saved)
+	}
+	for i := 0; i < l; i++ {
+		if ref[i] != saved[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// isPathSaved flags if the referred path is available via any of the
+// saved paths
+func isPathSaved(ref []string, saves [][]string) bool {
+	for _, saved := range saves {
+		if isPathMatch(ref, saved) {
+			return true
+		}
+	}
+	return false
+}
+
+// LintCASTemplate verifies the given cas template along with its runtasks
+// for issues that would otherwise surface only when the cas template is
+// run. It checks:
+//
+//  1/ runtasks & output task referred to by the cas template are present
+//  2/ runtask ids are unique
+//  3/ runtasks refer to only those .TaskResult paths that are saved by the
+// runtasks that run before them
+//
+// NOTE:
+//  A reference to a .TaskResult path that is not saved renders as
+// '<no value>' & hence is reported as a warning. References that are piped
+// to default template function are not reported.
+//
+// NOTE:
+//  runtasks is a map of runtask name to runtask. Individual runtasks are
+// not linted; use LintRunTask for the same.
+func LintCASTemplate(cast *apis.CASTemplate, runtasks map[string]*apis.RunTask) (issues LintIssueList) {
+	if cast == nil {
+		return
+	}
+
+	var ordered []*apis.RunTask
+	var fields []string
+	isMissing := false
+	ids := map[string]string{}
+	for idx, name := range cast.Spec.RunTasks.Tasks {
+		field := fmt.Sprintf("spec.run.tasks[%d]", idx)
+		name = strings.TrimSpace(name)
+		if len(name) == 0 {
+			issues = append(issues, casTemplateIssue(LintError, cast.Name, field, "missing runtask name"))
+			continue
+		}
+		rt := runtasks[name]
+		if rt == nil {
+			isMissing = true
+			issues = append(issues, casTemplateIssue(LintWarning, cast.Name, field, "runtask {%s} not found", name))
+			continue
+		}
+		for _, id := range staticMeta(rt.Spec.Meta)["id"] {
+			if other, ok := ids[id]; ok {
+				issues = append(issues, casTemplateIssue(LintError, cast.Name, field, "runtask {%s} has the same id {%s} as runtask {%s}", name, id, other))
+				continue
+			}
+			ids[id] = name
+		}
+		ordered = append(ordered, rt)
+		fields = append(fields, field)
+	}
+
+	output := strings.TrimSpace(cast.Spec.OutputTask)
+	if len(output) != 0 {
+		rt := runtasks[output]
+		if rt == nil {
+			isMissing = true
+			issues = append(issues, casTemplateIssue(LintWarning, cast.Name, "spec.output", "output runtask {%s} not found", output))
+		} else {
+			ordered = append(ordered, rt)
+			fields = append(fields, "spec.output")
+		}
+	}
+
+	// a dangling reference can not be confirmed if some of the runtasks are
+	// not available or if results are saved at paths known only at runtime
+	isUnsure := isMissing
+	refs := make([]*taskResultRefs, len(ordered))
+	var allSaves [][]string
+	for idx, rt := range ordered {
+		refs[idx] = newTaskResultRefs(rt)
+		allSaves = append(allSaves, refs[idx].saves...)
+		isUnsure = isUnsure || refs[idx].isDynamic
+	}
+
+	var saves [][]string
+	for idx, rt := range ordered {
+		saves = append(saves, refs[idx].saves...)
+		reported := map[string]bool{}
+		for _, ref := range refs[idx].refs {
+			path := strings.Join(ref, ".")
+			if reported[path] || util.ContainsString(taskResultErrKeys, ref[len(ref)-1]) || isPathSaved(ref, saves) {
+				continue
+			}
+			reported[path] = true
+			if isPathSaved(ref, allSaves) {
+				issues = append(issues, casTemplateIssue(LintWarning, cast.Name, fields[idx], "runtask {%s} refers to {.TaskResult.%s} which is saved only by a runtask that runs later", rt.Name, path))
+				continue
+			}
+			if isUnsure {
+				continue
+			}
+			issues = append(issues, casTemplateIssue(LintWarning, cast.Name, fields[idx], "runtask {%s} refers to {.TaskResult.%s} which is not saved by any runtask", rt.Name, path))
+		}
+	}
+	return
+}
+
+// LintAll verifies all the given runtasks as well as cas templates. The
+// cas templates are verified against the given runtasks.
+func LintAll(casts []*apis.CASTemplate, runtasks []*apis.RunTask) (issues LintIssueList) {
+	rtMap := map[string]*apis.RunTask{}
+	for _, rt := range runtasks {
+		if rt == nil {
+			continue
+		}
+		rtMap[rt.Name] = rt
+		issues = append(issues, LintRunTask(rt)...)
+	}
+	for _, cast := range casts {
+		issues = append(issues, LintCASTemplate(cast, rtMap)...)
+	}
+	return
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func fakeRunTask(name, meta, task, post string) *apis.RunTask {
+	return &apis.RunTask{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: apis.RunTaskSpec{
+			Meta:    meta,
+			Task:    task,
+			PostRun: post,
+		},
+	}
+}
+
+func TestLintRunTask(t *testing.T) {
+	tests := map[string]struct {
+		runtask        *apis.RunTask
+		expectedErrors int
+		expectedIssues int
+	}{
+		"valid runtask": {
+			runtask: fakeRunTask("rt1", `
+id: getsvc
+runNamespace: {{ .Volume.runNamespace }}
+apiVersion: v1
+kind: Service
+action: get
+objectName: {{ .Volume.owner }}`, "", `{{- jsonpath .JsonResult "{.spec.clusterIP}" | trim | saveAs "getsvc.ip" .TaskResult | noop -}}`),
+		},
+		"templated kind is not verified": {
+			runtask: fakeRunTask("rt1", `
+id: getres
+apiVersion: v1
+kind: {{ .Config.Kind.value }}
+action: get`, "", ""),
+		},
+		"command runtask": {
+			runtask: fakeRunTask("rt1", `
+id: cmd
+kind: Command`, "", `{{- create cstor snapshot | run | saveas "cmd" .TaskResult -}}`),
+		},
+		"output runtask": {
+			runtask: fakeRunTask("rt1", `action: output`, `kind: CASVolume`, ""),
+		},
+		"missing meta": {
+			runtask:        fakeRunTask("rt1", ``, `kind: Service`, ""),
+			expectedErrors: 1,
+			expectedIssues: 1,
+		},
+		"invalid template syntax": {
+			runtask: fakeRunTask("rt1", `
+id: getsvc
+apiVersion: v1
+kind: Service
+action: get`, "", `{{- .JsonResult `),
+			expectedErrors: 1,
+			expectedIssues: 1,
+		},
+		"unknown template function": {
+			runtask: fakeRunTask("rt1", `
+id: getsvc
+apiVersion: v1
+kind: Service
+action: get`, `name: {{ .Volume.owner | toUpperCase }}`, ""),
+			expectedErrors: 1,
+			expectedIssues: 1,
+		},
+		"unsupported action": {
+			runtask: fakeRunTask("rt1", `
+id: getsvc
+apiVersion: v1
+kind: Service
+action: fetch`, "", ""),
+			expectedErrors: 1,
+			expectedIssues: 1,
+		},
+		"any kind with generic action": {
+			runtask: fakeRunTask("rt1", `
+id: putsc
+apiVersion: storage.k8s.io/v1
+kind: StorageClass
+action: put`, "", ""),
+		},
+		"unsupported kind": {
+			runtask: fakeRunTask("rt1", `
+id: execsvc
+apiVersion: v1
+kind: Service
+action: exec`, "", ""),
+			expectedErrors: 1,
+			expectedIssues: 1,
+		},
+		"unsupported action in one of the branches": {
+			runtask: fakeRunTask("rt1", `
+id: svc
+apiVersion: v1
+kind: Service
+{{- if eq .Volume.isPatch "true" }}
+action: patch
+{{- else }}
+action: rolloutstatus
+{{- end }}`, "", ""),
+			expectedErrors: 1,
+			expectedIssues: 1,
+		},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			issues := LintRunTask(mock.runtask)
+			if len(issues) != mock.expectedIssues {
+				t.Fatalf("failed to lint runtask: expected issues '%d': actual issues '%d': %s", mock.expectedIssues, len(issues), issues)
+			}
+			if len(issues.Errors()) != mock.expectedErrors {
+				t.Fatalf("failed to lint runtask: expected errors '%d': actual errors '%d': %s", mock.expectedErrors, len(issues.Errors()), issues)
+			}
+		})
+	}
+}
+
+func TestLintCASTemplate(t *testing.T) {
+	getsvc := fakeRunTask("getsvc", `
+id: getsvc
+apiVersion: v1
+kind: Service
+action: get`, "", `{{- jsonpath .JsonResult "{.spec.clusterIP}" | trim | saveAs "getsvc.ip" .TaskResult | noop -}}`)
+	putdeploy := fakeRunTask("putdeploy", `
+id: putdeploy
+apiVersion: apps/v1beta1
+kind: Deployment
+action: put`, `
+metadata:
+  annotations:
+    ip: {{ .TaskResult.getsvc.ip }}
+    ns: {{ .TaskResult.getns.ns | default "openebs" }}
+{{- range $k, $v := .Volume.labels }}
+    {{ $k }}: {{ $v.TaskResult.name }}
+{{- end }}`, `{{- jsonpath .JsonResult "{.metadata.name}" | trim | saveAs "putdeploy.objectName" .TaskResult | noop -}}`)
+	dupdeploy := fakeRunTask("dupdeploy", `
+id: putdeploy
+apiVersion: apps/v1beta1
+kind: Deployment
+action: put`, "", "")
+	output := fakeRunTask("output", `action: output`, `
+name: {{ .TaskResult.putdeploy.objectName }}
+ip: {{ .TaskResult.getsvc.ip }}
+port: {{ .TaskResult.getsvc.port }}
+{{- if .TaskResult.putdeploy.verifyErr }}
+error: true
+{{- end }}`, "")
+	storeat := fakeRunTask("storeat", `
+id: invoke
+kind: Command`, "", `
+{{- $store := storeAt .TaskResult -}}
+{{- $runner := storeRunner $store -}}`)
+
+	tests := map[string]struct {
+		tasks          []string
+		output         string
+		runtasks       []*apis.RunTask
+		expectedErrors int
+		expectedIssues int
+	}{
+		"valid cas template": {
+			tasks:    []string{"getsvc", "putdeploy"},
+			runtasks: []*apis.RunTask{getsvc, putdeploy},
+		},
+		"missing runtask": {
+			tasks:          []string{"getsvc", "putdeploy", "deletedeploy"},
+			runtasks:       []*apis.RunTask{getsvc, putdeploy},
+			expectedIssues: 1,
+		},
+		"missing runtask name": {
+			tasks:          []string{"getsvc", " "},
+			runtasks:       []*apis.RunTask{getsvc},
+			expectedErrors: 1,
+			expectedIssues: 1,
+		},
+		"duplicate runtask id": {
+			tasks:          []string{"getsvc", "putdeploy", "dupdeploy"},
+			runtasks:       []*apis.RunTask{getsvc, putdeploy, dupdeploy},
+			expectedErrors: 1,
+			expectedIssues: 1,
+		},
+		"result saved by a later runtask": {
+			tasks:          []string{"putdeploy", "getsvc"},
+			runtasks:       []*apis.RunTask{getsvc, putdeploy},
+			expectedIssues: 1,
+		},
+		"result not saved by any runtask": {
+			tasks:          []string{"getsvc", "putdeploy"},
+			output:         "output",
+			runtasks:       []*apis.RunTask{getsvc, putdeploy, output},
+			expectedIssues: 1,
+		},
+		"result not saved by any runtask with dynamic store": {
+			tasks:    []string{"getsvc", "putdeploy", "storeat"},
+			output:   "output",
+			runtasks: []*apis.RunTask{getsvc, putdeploy, output, storeat},
+		},
+		"result not saved by any runtask with missing runtask": {
+			tasks:          []string{"getsvc", "putdeploy"},
+			output:         "missingoutput",
+			runtasks:       []*apis.RunTask{getsvc},
+			expectedIssues: 2,
+		},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			cast := &apis.CASTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "cast"},
+				Spec: apis.CASTemplateSpec{
+					RunTasks:   apis.RunTasks{Tasks: mock.tasks},
+					OutputTask: mock.output,
+				},
+			}
+			runtasks := map[string]*apis.RunTask{}
+			for _, rt := range mock.runtasks {
+				runtasks[rt.Name] = rt
+			}
+			issues := LintCASTemplate(cast, runtasks)
+			if len(issues) != mock.expectedIssues {
+				t.Fatalf("failed to lint cas template: expected issues '%d': actual issues '%d': %s", mock.expectedIssues, len(issues), issues)
+			}
+			if len(issues.Errors()) != mock.expectedErrors {
+				t.Fatalf("failed to lint cas template: expected errors '%d': actual errors '%d': %s", mock.expectedErrors, len(issues.Errors()), issues)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+)
+
+// CreateBackup creates the given backup of a cstor volume
+func (c *Client) CreateBackup(bkp *v1alpha1.CStorBackup) error {
+	return c.do(request{method: "POST", path: "backups/", body: bkp}, nil)
+}
+
+// ReadLastBackup returns the backup of the given backup name, volume
+// and namespace
+func (c *Client) ReadLastBackup(bkp *v1alpha1.CStorBackup) (*v1alpha1.CStorBackup, error) {
+	last := &v1alpha1.CStorBackup{}
+	err := c.do(request{method: "GET", path: "backups/", body: bkp}, last)
+	if err != nil {
+		return nil, err
+	}
+	return last, nil
+}
+
+// CreateRestore restores a backup to a cstor volume as per the given
+// restore
+func (c *Client) CreateRestore(rst *v1alpha1.CStorRestore) error {
+	return c.do(request{method: "POST", path: "restore/", body: rst}, nil)
+}
+
+// ReadRestore returns the status of the given restore
+func (c *Client) ReadRestore(rst *v1alpha1.CStorRestore) (v1alpha1.CStorRestoreStatus, error) {
+	var status v1alpha1.CStorRestoreStatus
+	err := c.do(request{method: "GET", path: "restore/", body: rst}, &status)
+	return status, err
+}
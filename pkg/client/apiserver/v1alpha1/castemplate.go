@@ -0,0 +1,32 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+)
+
+// DryRunCASTemplate renders the cas template of the given dry run
+// without provisioning the volume
+func (c *Client) DryRunCASTemplate(dryRun *v1alpha1.CASTemplateDryRun) (*v1alpha1.CASTemplateDryRun, error) {
+	result := &v1alpha1.CASTemplateDryRun{}
+	err := c.do(request{method: "POST", path: "castemplates/dryrun", namespace: dryRun.Namespace, body: dryRun}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
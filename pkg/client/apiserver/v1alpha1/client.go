@@ -0,0 +1,154 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha1 is the typed client of the versioned REST API of
+// maya apiserver. Its operations are the operations of the OpenAPI
+// document served at /v1/openapi.json. Requests fall back to the
+// legacy path e.g. /latest/volumes/ if the versioned path is not
+// served, so that the client works with older maya apiserver too.
+package v1alpha1
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+)
+
+const (
+	// defaultTimeout is the timeout of requests if the http client
+	// is not set
+	defaultTimeout = 60 * time.Second
+
+	// namespaceHeader is the request header that has the namespace
+	// of namespaced resources e.g. volumes
+	namespaceHeader = "namespace"
+)
+
+// Client is the typed client of maya apiserver REST API
+type Client struct {
+	url  string
+	http *http.Client
+}
+
+// NewClient returns a new instance of client of the maya apiserver
+// at the given url e.g. http://10.0.0.1:5656
+func NewClient(url string) *Client {
+	return &Client{
+		url:  strings.TrimSuffix(url, "/"),
+		http: &http.Client{Timeout: defaultTimeout},
+	}
+}
+
+// WithHTTPClient sets the http client that sends the requests
+func (c *Client) WithHTTPClient(h *http.Client) *Client {
+	c.http = h
+	return c
+}
+
+// request is a request of the REST API
+type request struct {
+	method string
+
+	// path is the path of the resource relative to the API prefix
+	// e.g. volumes/pvc-1
+	path string
+
+	namespace string
+	query     url.Values
+	body      interface{}
+}
+
+// do sends the request and decodes the response into out. Failed
+// responses are returned as *apis.Error.
+func (c *Client) do(r request, out interface{}) error {
+	var body []byte
+	if r.body != nil {
+		var err error
+		body, err = json.Marshal(r.body)
+		if err != nil {
+			return errors.Wrapf(err, "failed to encode request {%s %s}", r.method, r.path)
+		}
+	}
+
+	path := apis.APIPrefix + r.path
+	code, resp, err := c.send(r, path, body)
+	if err != nil {
+		return err
+	}
+	if apis.IsUnknownPath(code, resp) {
+		// older maya apiserver serves the legacy path only
+		path, _ = apis.LegacyPath(path)
+		code, resp, err = c.send(r, path, body)
+		if err != nil {
+			return err
+		}
+	}
+	if code != http.StatusOK {
+		return apis.DecodeError(code, resp)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(resp, out); err != nil {
+		return errors.Wrapf(err, "failed to decode response of {%s %s}", r.method, path)
+	}
+	return nil
+}
+
+// send sends the request to the given path and returns the status
+// code and the body of the response
+func (c *Client) send(r request, path string, body []byte) (int, []byte, error) {
+	u := c.url + path
+	if len(r.query) != 0 {
+		u += "?" + r.query.Encode()
+	}
+	req, err := http.NewRequest(r.method, u, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, errors.Wrapf(err, "failed to build request {%s %s}", r.method, path)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if r.namespace != "" {
+		req.Header.Set(namespaceHeader, r.namespace)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, nil, errors.Wrapf(err, "failed to send request {%s %s}", r.method, path)
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, errors.Wrapf(err, "failed to read response of {%s %s}", r.method, path)
+	}
+	return resp.StatusCode, respBody, nil
+}
+
+// listQuery returns the query parameters of the given list options
+func listQuery(opts *apis.ListOptions) url.Values {
+	if opts == nil {
+		return nil
+	}
+	return opts.Query()
+}
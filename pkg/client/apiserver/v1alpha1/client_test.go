@@ -0,0 +1,151 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
+)
+
+// fakeServer returns a server that serves the volume pvc-1 of
+// namespace default at the given prefix; other paths of the prefix
+// are errors as per the envelope & paths out of the prefix are
+// unknown
+func fakeServer(prefix string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, prefix) {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Path != prefix+"volumes/pvc-1" || r.Header.Get(namespaceHeader) != "default" {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(apis.NewError(http.StatusNotFound, "volume not found"))
+			return
+		}
+		vol := v1alpha1.CASVolume{}
+		vol.Name = "pvc-1"
+		vol.Namespace = "default"
+		json.NewEncoder(w).Encode(vol)
+	}))
+}
+
+func TestReadVolume(t *testing.T) {
+	tests := map[string]struct {
+		prefix    string
+		name      string
+		namespace string
+		isErr     bool
+		notFound  bool
+	}{
+		"versioned server": {
+			prefix:    "/v1/",
+			name:      "pvc-1",
+			namespace: "default",
+		},
+		"older server falls back to legacy path": {
+			prefix:    "/latest/",
+			name:      "pvc-1",
+			namespace: "default",
+		},
+		"volume not found": {
+			prefix:    "/v1/",
+			name:      "pvc-2",
+			namespace: "default",
+			isErr:     true,
+			notFound:  true,
+		},
+		"volume of other namespace": {
+			prefix:    "/latest/",
+			name:      "pvc-1",
+			namespace: "openebs",
+			isErr:     true,
+			notFound:  true,
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			s := fakeServer(mock.prefix)
+			defer s.Close()
+
+			vol, err := NewClient(s.URL).ReadVolume(mock.name, mock.namespace)
+			if mock.isErr != (err != nil) {
+				t.Fatalf("test %q failed: expected error %t got %v", name, mock.isErr, err)
+			}
+			if mock.notFound != apis.IsNotFound(err) {
+				t.Fatalf("test %q failed: expected not found %t got %v", name, mock.notFound, err)
+			}
+			if err == nil && vol.Name != mock.name {
+				t.Fatalf("test %q failed: expected volume %q got %q", name, mock.name, vol.Name)
+			}
+		})
+	}
+}
+
+func TestReadInstanceID(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/meta-data/instance-id" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`"any-compute"`))
+	}))
+	defer s.Close()
+
+	id, err := NewClient(s.URL).ReadInstanceID()
+	if err != nil {
+		t.Fatalf("failed to read instance id: %v", err)
+	}
+	if id != "any-compute" {
+		t.Fatalf("expected instance id %q got %q", "any-compute", id)
+	}
+}
+
+// TestOperations verifies that the client has a method for each
+// operation of the OpenAPI document of maya apiserver
+func TestOperations(t *testing.T) {
+	b, err := ioutil.ReadFile("openapi.json")
+	if err != nil {
+		t.Fatalf("failed to read openapi document: %v", err)
+	}
+	doc := struct {
+		Paths map[string]map[string]struct {
+			OperationID string `json:"operationId"`
+		} `json:"paths"`
+	}{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		t.Fatalf("failed to decode openapi document: %v", err)
+	}
+
+	client := reflect.TypeOf(&Client{})
+	for path, ops := range doc.Paths {
+		for method, op := range ops {
+			name := strings.ToUpper(op.OperationID[:1]) + op.OperationID[1:]
+			if _, ok := client.MethodByName(name); !ok {
+				t.Errorf("missing client method %q of operation {%s %s}", name, method, path)
+			}
+		}
+	}
+}
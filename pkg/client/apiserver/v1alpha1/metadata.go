@@ -0,0 +1,31 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// ReadInstanceID returns the instance id of maya apiserver
+func (c *Client) ReadInstanceID() (string, error) {
+	var id string
+	err := c.do(request{method: "GET", path: "meta-data/instance-id"}, &id)
+	return id, err
+}
+
+// ReadAvailabilityZone returns the availability zone of maya apiserver
+func (c *Client) ReadAvailabilityZone() (string, error) {
+	var zone string
+	err := c.do(request{method: "GET", path: "meta-data/placement/availability-zone"}, &zone)
+	return zone, err
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
+)
+
+// ListPools returns the cstor pools that match the given list options
+func (c *Client) ListPools(opts *apis.ListOptions) (*v1alpha1.CStorPoolList, error) {
+	pools := &v1alpha1.CStorPoolList{}
+	err := c.do(request{method: "GET", path: "pools/", query: listQuery(opts)}, pools)
+	if err != nil {
+		return nil, err
+	}
+	return pools, nil
+}
+
+// ReadPool returns the cstor pool of the given name
+func (c *Client) ReadPool(name string) (*v1alpha1.CStorPool, error) {
+	pool := &v1alpha1.CStorPool{}
+	err := c.do(request{method: "GET", path: "pools/" + name}, pool)
+	if err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
+
+// ListPoolClusters returns the cstor pool clusters
+func (c *Client) ListPoolClusters() (*v1alpha1.CStorPoolClusterList, error) {
+	clusters := &v1alpha1.CStorPoolClusterList{}
+	err := c.do(request{method: "GET", path: "poolclusters/"}, clusters)
+	if err != nil {
+		return nil, err
+	}
+	return clusters, nil
+}
+
+// ReadPoolCluster returns the cstor pool cluster of the given name
+func (c *Client) ReadPoolCluster(name string) (*v1alpha1.CStorPoolCluster, error) {
+	cluster := &v1alpha1.CStorPoolCluster{}
+	err := c.do(request{method: "GET", path: "poolclusters/" + name}, cluster)
+	if err != nil {
+		return nil, err
+	}
+	return cluster, nil
+}
+
+// CreatePoolCluster creates a cstor pool cluster as per the given
+// request
+func (c *Client) CreatePoolCluster(req *apis.PoolCreateRequest) (*v1alpha1.CStorPoolCluster, error) {
+	cluster := &v1alpha1.CStorPoolCluster{}
+	err := c.do(request{method: "POST", path: "poolclusters/", body: req}, cluster)
+	if err != nil {
+		return nil, err
+	}
+	return cluster, nil
+}
+
+// ExpandPoolCluster adds the raid groups of the given request to the
+// cstor pool cluster of the given name
+func (c *Client) ExpandPoolCluster(name string, req *apis.PoolExpandRequest) (*v1alpha1.CStorPoolCluster, error) {
+	cluster := &v1alpha1.CStorPoolCluster{}
+	err := c.do(request{method: "PATCH", path: "poolclusters/" + name, body: req}, cluster)
+	if err != nil {
+		return nil, err
+	}
+	return cluster, nil
+}
+
+// DeletePoolCluster deletes the cstor pool cluster of the given name
+func (c *Client) DeletePoolCluster(name string) (*v1alpha1.CStorPoolCluster, error) {
+	cluster := &v1alpha1.CStorPoolCluster{}
+	err := c.do(request{method: "DELETE", path: "poolclusters/" + name}, cluster)
+	if err != nil {
+		return nil, err
+	}
+	return cluster, nil
+}
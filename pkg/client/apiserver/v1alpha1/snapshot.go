@@ -0,0 +1,72 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"net/url"
+
+	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+)
+
+// snapshotQuery returns the query parameters that select the volume
+// of snapshots
+func snapshotQuery(volume, namespace, casType string) url.Values {
+	q := url.Values{}
+	q.Set("volume", volume)
+	q.Set("namespace", namespace)
+	if casType != "" {
+		q.Set("casType", casType)
+	}
+	return q
+}
+
+// ListSnapshots returns the snapshots of the given volume
+func (c *Client) ListSnapshots(volume, namespace, casType string) (*v1alpha1.CASSnapshotList, error) {
+	snaps := &v1alpha1.CASSnapshotList{}
+	err := c.do(request{method: "GET", path: "snapshots/", query: snapshotQuery(volume, namespace, casType)}, snaps)
+	if err != nil {
+		return nil, err
+	}
+	return snaps, nil
+}
+
+// CreateSnapshot creates the given snapshot
+func (c *Client) CreateSnapshot(snap *v1alpha1.CASSnapshot) (*v1alpha1.CASSnapshot, error) {
+	created := &v1alpha1.CASSnapshot{}
+	err := c.do(request{method: "POST", path: "snapshots/", body: snap}, created)
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// ReadSnapshot returns the snapshot of the given name of the given
+// volume
+func (c *Client) ReadSnapshot(name, volume, namespace, casType string) (*v1alpha1.CASSnapshot, error) {
+	snap := &v1alpha1.CASSnapshot{}
+	err := c.do(request{method: "GET", path: "snapshots/" + name, query: snapshotQuery(volume, namespace, casType)}, snap)
+	if err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// DeleteSnapshot deletes the snapshot of the given name of the given
+// volume
+func (c *Client) DeleteSnapshot(name, volume, namespace, casType string) error {
+	return c.do(request{method: "DELETE", path: "snapshots/" + name, query: snapshotQuery(volume, namespace, casType)}, nil)
+}
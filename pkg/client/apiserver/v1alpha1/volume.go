@@ -0,0 +1,74 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
+)
+
+// ListVolumes returns the volumes of the given namespace that match
+// the given list options
+func (c *Client) ListVolumes(namespace string, opts *apis.ListOptions) (*v1alpha1.CASVolumeList, error) {
+	vols := &v1alpha1.CASVolumeList{}
+	err := c.do(request{method: "GET", path: "volumes/", namespace: namespace, query: listQuery(opts)}, vols)
+	if err != nil {
+		return nil, err
+	}
+	return vols, nil
+}
+
+// CreateVolume creates the given volume
+func (c *Client) CreateVolume(vol *v1alpha1.CASVolume) (*v1alpha1.CASVolume, error) {
+	created := &v1alpha1.CASVolume{}
+	err := c.do(request{method: "POST", path: "volumes/", namespace: vol.Namespace, body: vol}, created)
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// ReadVolume returns the volume of the given name and namespace
+func (c *Client) ReadVolume(name, namespace string) (*v1alpha1.CASVolume, error) {
+	vol := &v1alpha1.CASVolume{}
+	err := c.do(request{method: "GET", path: "volumes/" + name, namespace: namespace}, vol)
+	if err != nil {
+		return nil, err
+	}
+	return vol, nil
+}
+
+// DeleteVolume deletes the volume of the given name and namespace
+func (c *Client) DeleteVolume(name, namespace string) (*v1alpha1.CASVolume, error) {
+	vol := &v1alpha1.CASVolume{}
+	err := c.do(request{method: "DELETE", path: "volumes/" + name, namespace: namespace}, vol)
+	if err != nil {
+		return nil, err
+	}
+	return vol, nil
+}
+
+// ReadVolumeStats returns the metrics of the volume of the given name
+// and namespace
+func (c *Client) ReadVolumeStats(name, namespace string) (*v1alpha1.VolumeMetricsList, error) {
+	stats := &v1alpha1.VolumeMetricsList{}
+	err := c.do(request{method: "GET", path: "volumes/stats/" + name, namespace: namespace}, stats)
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
@@ -48,6 +48,10 @@ func (c *FakeOpenebsV1alpha1) CStorPoolClusters(namespace string) v1alpha1.CStor
 	return &FakeCStorPoolClusters{c, namespace}
 }
 
+func (c *FakeOpenebsV1alpha1) CStorReplicaMigrations(namespace string) v1alpha1.CStorReplicaMigrationInterface {
+	return &FakeCStorReplicaMigrations{c, namespace}
+}
+
 func (c *FakeOpenebsV1alpha1) CStorRestores(namespace string) v1alpha1.CStorRestoreInterface {
 	return &FakeCStorRestores{c, namespace}
 }
@@ -64,6 +68,10 @@ func (c *FakeOpenebsV1alpha1) CStorVolumeReplicas(namespace string) v1alpha1.CSt
 	return &FakeCStorVolumeReplicas{c, namespace}
 }
 
+func (c *FakeOpenebsV1alpha1) JivaVolumeHealths(namespace string) v1alpha1.JivaVolumeHealthInterface {
+	return &FakeJivaVolumeHealths{c, namespace}
+}
+
 func (c *FakeOpenebsV1alpha1) NewTestCStorPools(namespace string) v1alpha1.NewTestCStorPoolInterface {
 	return &FakeNewTestCStorPools{c, namespace}
 }
@@ -28,6 +28,8 @@ type CStorPoolExpansion interface{}
 
 type CStorPoolClusterExpansion interface{}
 
+type CStorReplicaMigrationExpansion interface{}
+
 type CStorRestoreExpansion interface{}
 
 type CStorVolumeExpansion interface{}
@@ -36,6 +38,8 @@ type CStorVolumeClaimExpansion interface{}
 
 type CStorVolumeReplicaExpansion interface{}
 
+type JivaVolumeHealthExpansion interface{}
+
 type NewTestCStorPoolExpansion interface{}
 
 type RunTaskExpansion interface{}
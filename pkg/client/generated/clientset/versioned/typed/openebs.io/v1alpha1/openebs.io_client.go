@@ -32,10 +32,12 @@ type OpenebsV1alpha1Interface interface {
 	CStorCompletedBackupsGetter
 	CStorPoolsGetter
 	CStorPoolClustersGetter
+	CStorReplicaMigrationsGetter
 	CStorRestoresGetter
 	CStorVolumesGetter
 	CStorVolumeClaimsGetter
 	CStorVolumeReplicasGetter
+	JivaVolumeHealthsGetter
 	NewTestCStorPoolsGetter
 	RunTasksGetter
 	StoragePoolsGetter
@@ -67,6 +69,10 @@ func (c *OpenebsV1alpha1Client) CStorPoolClusters(namespace string) CStorPoolClu
 	return newCStorPoolClusters(c, namespace)
 }
 
+func (c *OpenebsV1alpha1Client) CStorReplicaMigrations(namespace string) CStorReplicaMigrationInterface {
+	return newCStorReplicaMigrations(c, namespace)
+}
+
 func (c *OpenebsV1alpha1Client) CStorRestores(namespace string) CStorRestoreInterface {
 	return newCStorRestores(c, namespace)
 }
@@ -83,6 +89,10 @@ func (c *OpenebsV1alpha1Client) CStorVolumeReplicas(namespace string) CStorVolum
 	return newCStorVolumeReplicas(c, namespace)
 }
 
+func (c *OpenebsV1alpha1Client) JivaVolumeHealths(namespace string) JivaVolumeHealthInterface {
+	return newJivaVolumeHealths(c, namespace)
+}
+
 func (c *OpenebsV1alpha1Client) NewTestCStorPools(namespace string) NewTestCStorPoolInterface {
 	return newNewTestCStorPools(c, namespace)
 }
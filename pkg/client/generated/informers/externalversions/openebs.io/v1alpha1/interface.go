@@ -34,6 +34,8 @@ type Interface interface {
 	CStorPools() CStorPoolInformer
 	// CStorPoolClusters returns a CStorPoolClusterInformer.
 	CStorPoolClusters() CStorPoolClusterInformer
+	// CStorReplicaMigrations returns a CStorReplicaMigrationInformer.
+	CStorReplicaMigrations() CStorReplicaMigrationInformer
 	// CStorRestores returns a CStorRestoreInformer.
 	CStorRestores() CStorRestoreInformer
 	// CStorVolumes returns a CStorVolumeInformer.
@@ -42,6 +44,8 @@ type Interface interface {
 	CStorVolumeClaims() CStorVolumeClaimInformer
 	// CStorVolumeReplicas returns a CStorVolumeReplicaInformer.
 	CStorVolumeReplicas() CStorVolumeReplicaInformer
+	// JivaVolumeHealths returns a JivaVolumeHealthInformer.
+	JivaVolumeHealths() JivaVolumeHealthInformer
 	// NewTestCStorPools returns a NewTestCStorPoolInformer.
 	NewTestCStorPools() NewTestCStorPoolInformer
 	// RunTasks returns a RunTaskInformer.
@@ -88,6 +92,11 @@ func (v *version) CStorPoolClusters() CStorPoolClusterInformer {
 	return &cStorPoolClusterInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// CStorReplicaMigrations returns a CStorReplicaMigrationInformer.
+func (v *version) CStorReplicaMigrations() CStorReplicaMigrationInformer {
+	return &cStorReplicaMigrationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // CStorRestores returns a CStorRestoreInformer.
 func (v *version) CStorRestores() CStorRestoreInformer {
 	return &cStorRestoreInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -108,6 +117,11 @@ func (v *version) CStorVolumeReplicas() CStorVolumeReplicaInformer {
 	return &cStorVolumeReplicaInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// JivaVolumeHealths returns a JivaVolumeHealthInformer.
+func (v *version) JivaVolumeHealths() JivaVolumeHealthInformer {
+	return &jivaVolumeHealthInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // NewTestCStorPools returns a NewTestCStorPoolInformer.
 func (v *version) NewTestCStorPools() NewTestCStorPoolInformer {
 	return &newTestCStorPoolInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -50,6 +50,14 @@ type CStorPoolClusterListerExpansion interface{}
 // CStorPoolClusterNamespaceLister.
 type CStorPoolClusterNamespaceListerExpansion interface{}
 
+// CStorReplicaMigrationListerExpansion allows custom methods to be added to
+// CStorReplicaMigrationLister.
+type CStorReplicaMigrationListerExpansion interface{}
+
+// CStorReplicaMigrationNamespaceListerExpansion allows custom methods to be added to
+// CStorReplicaMigrationNamespaceLister.
+type CStorReplicaMigrationNamespaceListerExpansion interface{}
+
 // CStorRestoreListerExpansion allows custom methods to be added to
 // CStorRestoreLister.
 type CStorRestoreListerExpansion interface{}
@@ -82,6 +90,14 @@ type CStorVolumeReplicaListerExpansion interface{}
 // CStorVolumeReplicaNamespaceLister.
 type CStorVolumeReplicaNamespaceListerExpansion interface{}
 
+// JivaVolumeHealthListerExpansion allows custom methods to be added to
+// JivaVolumeHealthLister.
+type JivaVolumeHealthListerExpansion interface{}
+
+// JivaVolumeHealthNamespaceListerExpansion allows custom methods to be added to
+// JivaVolumeHealthNamespaceLister.
+type JivaVolumeHealthNamespaceListerExpansion interface{}
+
 // NewTestCStorPoolListerExpansion allows custom methods to be added to
 // NewTestCStorPoolLister.
 type NewTestCStorPoolListerExpansion interface{}
@@ -88,6 +88,63 @@ type MarkDiskAsRemovedInput struct {
 	Name string `json:"name"`
 }
 
+// Operations returned by the replica to remove a disk from its chain
+const (
+	// OpCoalesce folds the data of the source disk into the target disk
+	OpCoalesce = "coalesce"
+	// OpRemove removes the source disk
+	OpRemove = "remove"
+	// OpReplace replaces the target disk with the source disk
+	OpReplace = "replace"
+)
+
+// PrepareRemoveDiskInput is the input to get the operations that
+// remove a disk
+type PrepareRemoveDiskInput struct {
+	Resource
+	Name string `json:"name"`
+}
+
+// PrepareRemoveAction is an operation that is executed on a replica
+// to remove a disk
+type PrepareRemoveAction struct {
+	Action string `json:"action"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// PrepareRemoveDiskOutput is the output having the operations that
+// remove a disk
+type PrepareRemoveDiskOutput struct {
+	Resource
+	Operations []PrepareRemoveAction `json:"operations"`
+}
+
+// RemoveDiskInput is the input to remove a disk
+type RemoveDiskInput struct {
+	Resource
+	Name string `json:"name"`
+}
+
+// ReplaceDiskInput is the input to replace the target disk with
+// the source disk
+type ReplaceDiskInput struct {
+	Resource
+	Target string `json:"target"`
+	Source string `json:"source"`
+}
+
+// Process is a process run by the sync agent of a replica e.g.
+// to fold a disk into another
+type Process struct {
+	Resource
+	ProcessType string `json:"processType"`
+	SrcFile     string `json:"srcFile"`
+	DestFile    string `json:"destFile"`
+	ExitCode    int    `json:"exitCode"`
+	Output      string `json:"output"`
+}
+
 // ReplicaClient is Client structure
 type ReplicaClient struct {
 	Address    string
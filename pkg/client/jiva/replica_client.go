@@ -127,14 +127,74 @@ func (c *ReplicaClient) MarkDiskAsRemoved(disk string) error {
 	if err != nil {
 		return err
 	}
-	//url := "/replicas/1?action=markdiskasremoved"
-	url := "/replicas/1?action=removedisk"
+	url := "/replicas/1?action=markdiskasremoved"
 
 	return c.Post(url, &MarkDiskAsRemovedInput{
 		Name: disk,
 	}, nil)
 }
 
+// PrepareRemoveDisk returns the operations to be executed on the
+// replica to remove the given disk which is marked as removed
+func (c *ReplicaClient) PrepareRemoveDisk(disk string) ([]PrepareRemoveAction, error) {
+	var output PrepareRemoveDiskOutput
+	err := c.Post("/replicas/1?action=prepareremovedisk", &PrepareRemoveDiskInput{
+		Name: disk,
+	}, &output)
+	return output.Operations, err
+}
+
+// RemoveDisk removes the given disk of the replica
+func (c *ReplicaClient) RemoveDisk(disk string) error {
+	return c.Post("/replicas/1?action=removedisk", &RemoveDiskInput{
+		Name: disk,
+	}, nil)
+}
+
+// ReplaceDisk replaces the target disk of the replica with the
+// source disk
+func (c *ReplicaClient) ReplaceDisk(target, source string) error {
+	return c.Post("/replicas/1?action=replacedisk", &ReplaceDiskInput{
+		Target: target,
+		Source: source,
+	}, nil)
+}
+
+// Coalesce folds the data of disk from into the disk to using the
+// sync agent of the replica and waits for it to complete
+func (c *ReplicaClient) Coalesce(from, to string) error {
+	var running Process
+	err := c.Post(c.SyncAgent+"/processes", &Process{
+		ProcessType: "fold",
+		SrcFile:     from,
+		DestFile:    to,
+	}, &running)
+	if err != nil {
+		return err
+	}
+
+	wait := 250 * time.Millisecond
+	for {
+		err := c.Get(running.Links["self"], &running)
+		if err != nil {
+			return err
+		}
+		switch running.ExitCode {
+		case -2:
+			// process is still running
+			time.Sleep(wait)
+			if wait < time.Second {
+				wait *= 2
+			}
+		case 0:
+			return nil
+		default:
+			return fmt.Errorf("failed to coalesce %s into %s: exit code %d: %s",
+				from, to, running.ExitCode, running.Output)
+		}
+	}
+}
+
 // GetVolumeStats is the helper function for mayactl.It is used to get the response of
 // the replica created in json format and then the response is then decoded to
 // the desired structure.
@@ -17,12 +17,18 @@ limitations under the License.
 package k8s
 
 import (
+	"context"
 	"encoding/json"
+	"net/http"
+	"sync"
 
+	"github.com/golang/glog"
 	openebs "github.com/openebs/maya/pkg/client/generated/clientset/versioned"
 	ndm "github.com/openebs/maya/pkg/client/generated/openebs.io/ndm/v1alpha1/clientset/internalclientset"
 	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+	trace "github.com/openebs/maya/pkg/trace/v1alpha1"
 
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
@@ -169,6 +175,11 @@ type K8sClient struct {
 	// within the current K8s cluster for NDM objects
 	ndmcs *ndm.Clientset
 
+	// dyn refers to the dynamic client capable of
+	// communicating within the current K8s cluster
+	// for objects of any kind
+	dyn dynamic.Interface
+
 	// PV refers to a K8s PersistentVolume object
 	PV *api_core_v1.PersistentVolume
 
@@ -245,34 +256,121 @@ type K8sClient struct {
 	insecure   bool
 }
 
-// NewK8sClient creates a new K8sClient
+// NewK8sClient creates a new K8sClient. The clientsets of the
+// returned client are shared with all the other instances.
 func NewK8sClient(ns string) (*K8sClient, error) {
-	// get the appropriate clientset
-	cs, err := getInClusterCS()
+	shared, err := getSharedClient()
 	if err != nil {
 		return nil, err
 	}
 
-	// get the appropriate openebs clientset
-	oecs, err := getInClusterOECS()
+	c := *shared
+	c.ns = ns
+	return &c, nil
+}
+
+var (
+	sharedOnce      sync.Once
+	sharedClient    *K8sClient
+	sharedTransport http.RoundTripper
+	sharedConfig    *rest.Config
+	sharedErr       error
+)
+
+// getSharedClient returns the client whose clientsets are shared by
+// all the K8sClient instances since these do not depend on the
+// namespace. The clientsets are built only once.
+func getSharedClient() (*K8sClient, error) {
+	sharedOnce.Do(func() {
+		config, err := getK8sConfig()
+		if err != nil {
+			sharedErr = err
+			return
+		}
+		// the transport is built once so that the shared clientsets
+		// & the traced clientsets make use of the same connections
+		sharedTransport, err = rest.TransportFor(config)
+		if err != nil {
+			sharedErr = err
+			return
+		}
+		sharedConfig = configWithTransport(config, sharedTransport)
+		sharedClient, sharedErr = newClientsets(sharedConfig)
+	})
+	return sharedClient, sharedErr
+}
+
+// configWithTransport returns a copy of the given config whose API
+// calls are sent via the given transport. The transport has the
+// authentication & tls settings of the config & hence these are
+// not copied.
+func configWithTransport(config *rest.Config, transport http.RoundTripper) *rest.Config {
+	return &rest.Config{
+		Host:          config.Host,
+		APIPath:       config.APIPath,
+		ContentConfig: config.ContentConfig,
+		UserAgent:     config.UserAgent,
+		Transport:     transport,
+		QPS:           config.QPS,
+		Burst:         config.Burst,
+		RateLimiter:   config.RateLimiter,
+		Timeout:       config.Timeout,
+	}
+}
+
+// newClientsets returns a new K8sClient with the clientsets built
+// from the given config
+func newClientsets(config *rest.Config) (*K8sClient, error) {
+	cs, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, err
 	}
-
-	// get the appropriate ndm clientset
-	ndmcs, err := getInClusterNDMCS()
+	oecs, err := openebs.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	ndmcs, err := ndm.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	dyn, err := dynamic.NewForConfig(config)
 	if err != nil {
 		return nil, err
 	}
-
 	return &K8sClient{
-		ns:    ns,
 		cs:    cs,
 		oecs:  oecs,
 		ndmcs: ndmcs,
+		dyn:   dyn,
 	}, nil
 }
 
+// WithContext returns a copy of this client whose API calls are
+// traced as children of the span in the given context. The copy
+// sends its API calls via the shared transport wrapped by the
+// tracing transport. This client is returned as is if tracing is
+// not enabled.
+func (k *K8sClient) WithContext(ctx context.Context) *K8sClient {
+	if k == nil || !trace.Enabled() || !trace.SpanContextFromContext(ctx).IsValid() {
+		return k
+	}
+	if _, err := getSharedClient(); err != nil {
+		glog.Warningf("failed to trace k8s client: %v", err)
+		return k
+	}
+
+	traced, err := newClientsets(
+		configWithTransport(sharedConfig, trace.NewTransport(ctx, sharedTransport)),
+	)
+	if err != nil {
+		glog.Warningf("failed to trace k8s client: %v", err)
+		return k
+	}
+	c := *k
+	c.cs, c.oecs, c.ndmcs, c.dyn = traced.cs, traced.oecs, traced.ndmcs, traced.dyn
+	return &c
+}
+
 // GetOECS is a getter method for fetching openebs clientset as
 // the openebs clientset is not exported.
 func (k *K8sClient) GetOECS() *openebs.Clientset {
@@ -285,6 +383,15 @@ func (k *K8sClient) GetNDMCS() *ndm.Clientset {
 	return k.ndmcs
 }
 
+// GetDynamic is a getter method for fetching the dynamic client
+// that operates on objects of any kind
+func (k *K8sClient) GetDynamic() (dynamic.Interface, error) {
+	if k.dyn == nil {
+		return nil, errors.New("dynamic client is not initialized")
+	}
+	return k.dyn, nil
+}
+
 // GetKCS is a getter method for fetching kubernetes clientset as
 // the kubernetes clientset is not exported.
 func (k *K8sClient) GetKCS() *kubernetes.Clientset {
@@ -1225,54 +1332,3 @@ func getK8sConfig() (config *rest.Config, err error) {
 	// creates the in-cluster config making use of the Pod's ENV & secrets
 	return rest.InClusterConfig()
 }
-
-// getInClusterCS is used to initialize and return a new http client capable
-// of invoking K8s APIs within the cluster
-func getInClusterCS() (clientset *kubernetes.Clientset, err error) {
-	config, err := getK8sConfig()
-	if err != nil {
-		return nil, err
-	}
-
-	// creates the in-cluster kubernetes clientset
-	clientset, err = kubernetes.NewForConfig(config)
-	if err != nil {
-		return nil, err
-	}
-
-	return clientset, nil
-}
-
-// getInClusterOECS is used to initialize and return a new http client capable
-// of invoking OpenEBS CRD APIs within the cluster
-func getInClusterOECS() (clientset *openebs.Clientset, err error) {
-	config, err := getK8sConfig()
-	if err != nil {
-		return nil, err
-	}
-
-	// creates the in-cluster openebs clientset
-	clientset, err = openebs.NewForConfig(config)
-	if err != nil {
-		return nil, err
-	}
-
-	return clientset, nil
-}
-
-// getInClusterNDMCS is used to initialize and return a new http client capable
-// of invoking NDM CRD APIs within the cluster
-func getInClusterNDMCS() (clientset *ndm.Clientset, err error) {
-	config, err := getK8sConfig()
-	if err != nil {
-		return nil, err
-	}
-
-	// creates the in-cluster openebs clientset
-	clientset, err = ndm.NewForConfig(config)
-	if err != nil {
-		return nil, err
-	}
-
-	return clientset, nil
-}
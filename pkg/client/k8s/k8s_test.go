@@ -17,11 +17,19 @@ limitations under the License.
 package k8s
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
 	"testing"
 
+	env "github.com/openebs/maya/pkg/env/v1alpha1"
+	trace "github.com/openebs/maya/pkg/trace/v1alpha1"
 	api_core_v1 "k8s.io/api/core/v1"
 	api_extn_v1beta1 "k8s.io/api/extensions/v1beta1"
 	mach_apis_meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestGetConfigMap(t *testing.T) {
@@ -150,3 +158,72 @@ func TestGetDeployment(t *testing.T) {
 		})
 	}
 }
+
+type fakeExporter struct{}
+
+func (fakeExporter) Export(service string, spans []trace.SpanData) error { return nil }
+
+func TestWithContext(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		parents []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		parents = append(parents, r.Header.Get(trace.TraceParentHeader))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"kind":"ConfigMap","apiVersion":"v1","metadata":{"name":"cm-1","namespace":"openebs"}}`))
+	}))
+	defer server.Close()
+	os.Setenv(string(env.KubeMaster), server.URL)
+	defer os.Unsetenv(string(env.KubeMaster))
+
+	first, err := NewK8sClient("openebs")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := NewK8sClient("default")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.GetKCS() != second.GetKCS() || first.ns == second.ns {
+		t.Fatalf("expected clientsets to be shared by clients of different namespaces")
+	}
+
+	shutdown := trace.InitWithExporter("test", fakeExporter{})
+	defer shutdown()
+	ctx, span := trace.Start(context.Background(), "runtask.Execute")
+	defer span.End()
+
+	traced := first.WithContext(ctx)
+	if traced.GetKCS() == first.GetKCS() || traced.ns != first.ns {
+		t.Fatalf("expected traced clientsets in the namespace of the client")
+	}
+	if _, err := first.GetKCS().CoreV1().ConfigMaps("openebs").Get("cm-1", mach_apis_meta_v1.GetOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := traced.GetKCS().CoreV1().ConfigMaps("openebs").Get("cm-1", mach_apis_meta_v1.GetOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dyn, err := traced.GetDynamic()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	if _, err := dyn.Resource(gvr).Namespace("openebs").Get("cm-1", mach_apis_meta_v1.GetOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(parents) != 3 || parents[0] != "" {
+		t.Fatalf("expected only the calls of traced client to propagate the span: actual '%v'", parents)
+	}
+	for _, parent := range parents[1:] {
+		sc, err := trace.ParseTraceParent(parent)
+		if err != nil || sc.TraceID != span.Context().TraceID {
+			t.Fatalf("expected trace of runtask span: actual traceparent '%s'", parent)
+		}
+	}
+}
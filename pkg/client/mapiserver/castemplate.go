@@ -0,0 +1,42 @@
+// Copyright © 2019 The OpenEBS Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mapiserver
+
+import (
+	"encoding/json"
+
+	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
+)
+
+const castemplateDryRunPath = apis.APIPrefix + "castemplates/dryrun"
+
+// DryRunCASTemplate renders a cas template for the given volume by invoking
+// the API call to m-apiserver. Nothing is created in the cluster.
+func DryRunCASTemplate(dryRun *v1alpha1.CASTemplateDryRun) (*v1alpha1.CASTemplateDryRun, error) {
+	jsonValue, err := json.Marshal(dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := postRequest(GetURL()+castemplateDryRunPath, jsonValue, dryRun.Namespace, true)
+	if err != nil {
+		return nil, err
+	}
+
+	result := v1alpha1.CASTemplateDryRun{}
+	err = json.Unmarshal(body, &result)
+	return &result, err
+}
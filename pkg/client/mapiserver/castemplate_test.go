@@ -0,0 +1,83 @@
+// Copyright © 2019 The OpenEBS Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mapiserver
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utiltesting "k8s.io/client-go/util/testing"
+)
+
+func TestDryRunCASTemplate(t *testing.T) {
+	tests := map[string]*struct {
+		fakeHandler   utiltesting.FakeHandler
+		err           error
+		addr          string
+		expectedTasks int
+	}{
+		"StatusOK": {
+			fakeHandler: utiltesting.FakeHandler{
+				StatusCode:   200,
+				ResponseBody: `{"metadata":{"name":"pvc-1"},"spec":{"casTemplate":"cast-1"},"status":{"tasks":[{"name":"rt-1","skipped":true},{"name":"rt-2","rendered":["kind: Service"]}],"output":"kind: CASVolume"}}`,
+				T:            t,
+			},
+			err:           nil,
+			addr:          "MAPI_ADDR",
+			expectedTasks: 2,
+		},
+		"NotFound": {
+			fakeHandler: utiltesting.FakeHandler{
+				StatusCode:   404,
+				ResponseBody: "castemplate not found",
+				T:            t,
+			},
+			err:  fmt.Errorf("castemplate not found"),
+			addr: "MAPI_ADDR",
+		},
+		"EmptyResponse": {
+			fakeHandler: utiltesting.FakeHandler{
+				StatusCode: 200,
+				T:          t,
+			},
+			err:  fmt.Errorf("unexpected end of JSON input"),
+			addr: "MAPI_ADDR",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(&tt.fakeHandler)
+			os.Setenv(tt.addr, server.URL)
+			defer os.Unsetenv(tt.addr)
+			defer server.Close()
+			got, err := DryRunCASTemplate(&v1alpha1.CASTemplateDryRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "pvc-1", Namespace: "default"},
+				Spec:       v1alpha1.CASTemplateDryRunSpec{CASTemplate: "cast-1"},
+			})
+
+			if !checkErr(err, tt.err) {
+				t.Fatalf("TestName: %v | DryRunCASTemplate() => Got: %v | Want: %v ", name, err, tt.err)
+			}
+			if err == nil && len(got.Status.Tasks) != tt.expectedTasks {
+				t.Fatalf("TestName: %v | DryRunCASTemplate() => Got tasks: %d | Want tasks: %d ", name, len(got.Status.Tasks), tt.expectedTasks)
+			}
+		})
+	}
+}
@@ -18,9 +18,15 @@ import (
 	"encoding/json"
 
 	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
 )
 
-const poolPath = "/latest/pools/"
+const (
+	poolPath = apis.APIPrefix + "pools/"
+	// poolClusterPath is the path of the cstor pool clusters that
+	// create, expand & delete the pools
+	poolClusterPath = apis.APIPrefix + "poolclusters/"
+)
 
 // ListPools returns a obj StoragePoolList from api-server
 func ListPools() (*v1alpha1.CStorPoolList, error) {
@@ -44,3 +50,41 @@ func ReadPool(poolName string) (*v1alpha1.CStorPool, error) {
 	err = json.Unmarshal(body, &pool)
 	return &pool, err
 }
+
+// CreatePool requests maya-apiserver to create a cstor pool
+// cluster as per the given request
+func CreatePool(req *apis.PoolCreateRequest) (*v1alpha1.CStorPoolCluster, error) {
+	jsonValue, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := postRequest(GetURL()+poolClusterPath, jsonValue, "", false)
+	if err != nil {
+		return nil, err
+	}
+	cspc := v1alpha1.CStorPoolCluster{}
+	err = json.Unmarshal(body, &cspc)
+	return &cspc, err
+}
+
+// ExpandPool requests maya-apiserver to add the raid groups of
+// the given request to the cstor pool cluster
+func ExpandPool(poolName string, req *apis.PoolExpandRequest) (*v1alpha1.CStorPoolCluster, error) {
+	jsonValue, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := patchRequest(GetURL()+poolClusterPath+poolName, jsonValue, "")
+	if err != nil {
+		return nil, err
+	}
+	cspc := v1alpha1.CStorPoolCluster{}
+	err = json.Unmarshal(body, &cspc)
+	return &cspc, err
+}
+
+// DeletePool requests maya-apiserver to delete the cstor pool
+// cluster. It fails if any of the pools has volume replicas.
+func DeletePool(poolName string) error {
+	return deleteRequest(GetURL()+poolClusterPath+poolName, "")
+}
@@ -20,6 +20,7 @@ import (
 	"os"
 	"testing"
 
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
 	utiltesting "k8s.io/client-go/util/testing"
 )
 
@@ -132,3 +133,125 @@ func TestReadPool(t *testing.T) {
 		})
 	}
 }
+
+func TestCreatePool(t *testing.T) {
+	tests := map[string]*struct {
+		fakeHandler utiltesting.FakeHandler
+		err         error
+		addr        string
+	}{
+		"StatusOK": {
+			fakeHandler: utiltesting.FakeHandler{
+				StatusCode:   200,
+				ResponseBody: `{"apiVersion":"openebs.io/v1alpha1","kind":"CStorPoolCluster","metadata":{"name":"cstor-pool","namespace":"openebs"},"spec":{"pools":[{"nodeSelector":{"kubernetes.io/hostname":"node-1"},"raidGroups":[{"type":"stripe","blockDevices":[{"blockDeviceName":"bd-1"}]}],"poolConfig":{"defaultRaidGroupType":"stripe"}}]}}`,
+				T:            t,
+			},
+			err:  nil,
+			addr: "MAPI_ADDR",
+		},
+		"BadRequest": {
+			fakeHandler: utiltesting.FakeHandler{
+				StatusCode:   400,
+				ResponseBody: `{"code":400,"reason":"BadRequest","message":"failed to create pool"}`,
+				T:            t,
+			},
+			err:  fmt.Errorf("failed to create pool"),
+			addr: "MAPI_ADDR",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(&tt.fakeHandler)
+			os.Setenv(tt.addr, server.URL)
+			defer os.Unsetenv(tt.addr)
+			defer server.Close()
+			_, got := CreatePool(&apis.PoolCreateRequest{Name: "cstor-pool"})
+
+			if !checkErr(got, tt.err) {
+				t.Fatalf("TestName: %v | CreatePool() => Got: %v | Want: %v ", name, got, tt.err)
+			}
+		})
+	}
+}
+
+func TestExpandPool(t *testing.T) {
+	tests := map[string]*struct {
+		fakeHandler utiltesting.FakeHandler
+		err         error
+		addr        string
+	}{
+		"StatusOK": {
+			fakeHandler: utiltesting.FakeHandler{
+				StatusCode:   200,
+				ResponseBody: `{"apiVersion":"openebs.io/v1alpha1","kind":"CStorPoolCluster","metadata":{"name":"cstor-pool","namespace":"openebs"}}`,
+				T:            t,
+			},
+			err:  nil,
+			addr: "MAPI_ADDR",
+		},
+		"NotFound": {
+			fakeHandler: utiltesting.FakeHandler{
+				StatusCode:   404,
+				ResponseBody: "HTTP Error 404 : Not Found",
+				T:            t,
+			},
+			err:  fmt.Errorf("Server status error: Not Found"),
+			addr: "MAPI_ADDR",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(&tt.fakeHandler)
+			os.Setenv(tt.addr, server.URL)
+			defer os.Unsetenv(tt.addr)
+			defer server.Close()
+			_, got := ExpandPool("cstor-pool", &apis.PoolExpandRequest{})
+
+			if !checkErr(got, tt.err) {
+				t.Fatalf("TestName: %v | ExpandPool() => Got: %v | Want: %v ", name, got, tt.err)
+			}
+		})
+	}
+}
+
+func TestDeletePool(t *testing.T) {
+	tests := map[string]*struct {
+		fakeHandler utiltesting.FakeHandler
+		err         error
+		addr        string
+	}{
+		"StatusOK": {
+			fakeHandler: utiltesting.FakeHandler{
+				StatusCode: 200,
+				T:          t,
+			},
+			err:  nil,
+			addr: "MAPI_ADDR",
+		},
+		"Conflict": {
+			fakeHandler: utiltesting.FakeHandler{
+				StatusCode:   409,
+				ResponseBody: `{"code":409,"reason":"Conflict","message":"failed to delete storage pool 'cstor-pool': pool has volume replicas {pvc-1-cstor-pool-a1b2}"}`,
+				T:            t,
+			},
+			err:  fmt.Errorf("failed to delete storage pool 'cstor-pool': pool has volume replicas {pvc-1-cstor-pool-a1b2}"),
+			addr: "MAPI_ADDR",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(&tt.fakeHandler)
+			os.Setenv(tt.addr, server.URL)
+			defer os.Unsetenv(tt.addr)
+			defer server.Close()
+			got := DeletePool("cstor-pool")
+
+			if !checkErr(got, tt.err) {
+				t.Fatalf("TestName: %v | DeletePool() => Got: %v | Want: %v ", name, got, tt.err)
+			}
+		})
+	}
+}
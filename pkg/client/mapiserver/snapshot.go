@@ -23,15 +23,16 @@ import (
 	"text/tabwriter"
 	"time"
 
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
 	client "github.com/openebs/maya/pkg/client/jiva"
 	"github.com/openebs/maya/types/v1"
 )
 
 const (
 	httpTimeout        = 5 * time.Second
-	snapshotCreatePath = "/latest/snapshots/create/"
-	snapshotRevertPath = "/latest/snapshots/revert/"
-	snapshotListPath   = "/latest/snapshots/list/"
+	snapshotCreatePath = apis.APIPrefix + "snapshots/create/"
+	snapshotRevertPath = apis.APIPrefix + "snapshots/revert/"
+	snapshotListPath   = apis.APIPrefix + "snapshots/list/"
 	snapshotTemplate   = `
 Snapshot Details:
 ------------------
@@ -20,7 +20,6 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
-	"reflect"
 	"testing"
 
 	utiltesting "k8s.io/client-go/util/testing"
@@ -124,7 +123,7 @@ func TestCreateSnapshot(t *testing.T) {
 			defer os.Unsetenv(tt.addr)
 			defer server.Close()
 			got := CreateSnapshot(tt.volumeName, tt.snapName, tt.namespace)
-			if !reflect.DeepEqual(got, tt.err) {
+			if !checkErr(got, tt.err) {
 				t.Fatalf("CreateSnapshot(%v, %v) => got %v, want %v ", tt.volumeName, tt.snapName, got, tt.err)
 			}
 		})
@@ -216,7 +215,7 @@ func TestRevertSnapshot(t *testing.T) {
 			defer server.Close()
 			got := RevertSnapshot(tt.volumeName, tt.snapName, tt.namespace)
 
-			if !reflect.DeepEqual(got, tt.err) {
+			if !checkErr(got, tt.err) {
 				t.Fatalf("RevertSnapshot(%v, %v) => got %v, want %v ", tt.volumeName, tt.snapName, got, tt.err)
 			}
 		})
@@ -342,7 +341,7 @@ func TestListSnapshot(t *testing.T) {
 			defer os.Unsetenv(tt.addr)
 			defer server.Close()
 			got := ListSnapshot(tt.volumeName, tt.namespace)
-			if !reflect.DeepEqual(got, tt.err) {
+			if !checkErr(got, tt.err) {
 				t.Fatalf("ListSnapshot(%v) => got %v, want %v ", tt.volumeName, got, tt.err)
 			}
 		})
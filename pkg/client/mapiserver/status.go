@@ -14,10 +14,13 @@
 
 package mapiserver
 
-import "github.com/openebs/maya/pkg/util"
+import (
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
+	"github.com/openebs/maya/pkg/util"
+)
 
 const (
-	getStatusPath = "/latest/meta-data/instance-id"
+	getStatusPath = apis.APIPrefix + "meta-data/instance-id"
 )
 
 // GetStatus returns the status of maya-apiserver via http
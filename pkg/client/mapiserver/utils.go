@@ -16,17 +16,20 @@ package mapiserver
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"html/template"
 	"io/ioutil"
 	"net"
 	"net/http"
+	neturl "net/url"
 	"os"
 	"sort"
 	"text/tabwriter"
 	"time"
 
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
 	"github.com/openebs/maya/types/v1"
 )
 
@@ -141,12 +144,58 @@ func postRequest(url string, values []byte, namespace string, chkbody bool) ([]b
 
 	code := resp.StatusCode
 
-	if chkbody && err == nil && code != http.StatusOK {
-		return nil, fmt.Errorf(string(body))
+	if legacy, ok := legacyURL(url, code, body); ok {
+		return postRequest(legacy, values, namespace, chkbody)
 	}
 
 	if code != http.StatusOK {
-		return nil, fmt.Errorf("Server status error: %v", http.StatusText(code))
+		return nil, responseError(code, body, chkbody)
+	}
+
+	return body, nil
+}
+
+// patchRequest sends json patch request to a url with payload
+// of values and returns the response
+func patchRequest(url string, values []byte, namespace string) ([]byte, error) {
+	if len(url) == 0 {
+		return nil, errors.New("Invalid URL")
+	}
+
+	req, err := http.NewRequest("PATCH", url, bytes.NewBuffer(values))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+
+	if len(namespace) > 0 {
+		req.Header.Set("namespace", namespace)
+	}
+
+	c := &http.Client{
+		Timeout: defaultTimeOut,
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	code := resp.StatusCode
+
+	if legacy, ok := legacyURL(url, code, body); ok {
+		return patchRequest(legacy, values, namespace)
+	}
+
+	if code != http.StatusOK {
+		return nil, responseError(code, body, false)
 	}
 
 	return body, nil
@@ -185,12 +234,16 @@ func getRequest(url string, namespace string, chkbody bool) ([]byte, error) {
 
 	body, err := ioutil.ReadAll(resp.Body)
 
-	if chkbody && err == nil && code != http.StatusOK {
-		return nil, fmt.Errorf(string(body))
+	if err != nil {
+		return nil, err
+	}
+
+	if legacy, ok := legacyURL(url, code, body); ok {
+		return getRequest(legacy, namespace, chkbody)
 	}
 
 	if code != http.StatusOK {
-		return nil, fmt.Errorf("Server status error: %v", http.StatusText(code))
+		return nil, responseError(code, body, chkbody)
 	}
 
 	return body, nil
@@ -226,12 +279,50 @@ func deleteRequest(url string, namespace string) error {
 	code := resp.StatusCode
 
 	if code != http.StatusOK {
-		return fmt.Errorf("Server status error: %v", http.StatusText(code))
+		body, _ := ioutil.ReadAll(resp.Body)
+		if legacy, ok := legacyURL(url, code, body); ok {
+			return deleteRequest(legacy, namespace)
+		}
+		return responseError(code, body, false)
 	}
 
 	return nil
 }
 
+// legacyURL returns the url of the legacy API i.e. the /latest/
+// alias of the given url if its versioned path is not served
+// e.g. by an older maya-apiserver
+func legacyURL(url string, code int, body []byte) (string, bool) {
+	if !apis.IsUnknownPath(code, body) {
+		return "", false
+	}
+	u, err := neturl.Parse(url)
+	if err != nil {
+		return "", false
+	}
+	path, ok := apis.LegacyPath(u.Path)
+	if !ok {
+		return "", false
+	}
+	u.Path = path
+	return u.String(), true
+}
+
+// responseError returns the error of a failed response. The error
+// envelope of versioned API is returned as is, so that the caller
+// can check its reason e.g. via apis.IsNotFound. The plain text
+// body of legacy API is used as the message if chkbody is set.
+func responseError(code int, body []byte, chkbody bool) error {
+	apiErr := &apis.Error{}
+	if err := json.Unmarshal(body, apiErr); err == nil && apiErr.Code != 0 {
+		return apiErr
+	}
+	if chkbody {
+		return apis.NewError(code, string(body))
+	}
+	return apis.NewError(code, fmt.Sprintf("Server status error: %v", http.StatusText(code)))
+}
+
 // Print binds the object with go template and executes it
 func Print(format string, obj interface{}) error {
 	// New Instance of tabwriter
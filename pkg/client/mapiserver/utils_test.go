@@ -16,6 +16,7 @@ package mapiserver
 
 import (
 	"errors"
+	"net/http"
 	"net/http/httptest"
 	"os"
 	"reflect"
@@ -275,3 +276,48 @@ func TestPostRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestLegacyPathFallback(t *testing.T) {
+	tests := map[string]struct {
+		versioned    http.HandlerFunc
+		expectedBody string
+		isErr        bool
+	}{
+		"older server without versioned path": {
+			expectedBody: "legacy",
+		},
+		"versioned path not found error": {
+			versioned: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"code":404,"reason":"NotFound","message":"pool not found"}`))
+			},
+			isErr: true,
+		},
+	}
+	for name, tt := range tests {
+		name, tt := name, tt
+		t.Run(name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/latest/pools/", func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("legacy"))
+			})
+			if tt.versioned != nil {
+				mux.HandleFunc("/v1/pools/", tt.versioned)
+			}
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			body, err := getRequest(server.URL+"/v1/pools/pool-1", "", false)
+			if tt.isErr != (err != nil) {
+				t.Fatalf("TestName: %v | getRequest() => Got error: %v | Want error: %t", name, err, tt.isErr)
+			}
+			if string(body) != tt.expectedBody {
+				t.Fatalf("TestName: %v | getRequest() => Got: %s | Want: %s", name, body, tt.expectedBody)
+			}
+			err = deleteRequest(server.URL+"/v1/pools/pool-1", "")
+			if tt.isErr != (err != nil) {
+				t.Fatalf("TestName: %v | deleteRequest() => Got error: %v | Want error: %t", name, err, tt.isErr)
+			}
+		})
+	}
+}
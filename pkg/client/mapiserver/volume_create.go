@@ -19,12 +19,13 @@ import (
 	"time"
 
 	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
 	volumeCreateTimeout = 60 * time.Second
-	volumePath          = "/latest/volumes/"
+	volumePath          = apis.APIPrefix + "volumes/"
 )
 
 // CreateVolume creates a volume by invoking the API call to m-apiserver
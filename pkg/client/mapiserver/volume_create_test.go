@@ -19,7 +19,6 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
-	"reflect"
 	"testing"
 
 	utiltesting "k8s.io/client-go/util/testing"
@@ -79,7 +78,7 @@ func TestCreateVolume(t *testing.T) {
 			defer server.Close()
 			got := CreateVolume(tt.volumeName, tt.size, tt.namespace)
 
-			if !reflect.DeepEqual(got, tt.err) {
+			if !checkErr(got, tt.err) {
 				t.Fatalf("CreateVolume(%v, %v) => got %v, want %v ", tt.volumeName, tt.size, got, tt.err)
 			}
 		})
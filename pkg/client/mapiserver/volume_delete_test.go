@@ -19,7 +19,6 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
-	"reflect"
 	"testing"
 
 	utiltesting "k8s.io/client-go/util/testing"
@@ -95,7 +94,7 @@ func TestDeleteVolume(t *testing.T) {
 			defer server.Close()
 			got := DeleteVolume(tt.volumeName, tt.namespace)
 
-			if !reflect.DeepEqual(got, tt.err) {
+			if !checkErr(got, tt.err) {
 				t.Fatalf("DeleteVolume(%v) => got %v, want %v ", tt.volumeName, got, tt.err)
 			}
 		})
@@ -17,11 +17,13 @@ package mapiserver
 import (
 	"encoding/json"
 	"time"
+
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
 )
 
 const (
 	timeoutVolumesList = 5 * time.Second
-	listVolumePath     = "/latest/volumes/"
+	listVolumePath     = apis.APIPrefix + "volumes/"
 )
 
 // ListVolumes and return them as obj
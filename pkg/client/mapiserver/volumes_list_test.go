@@ -18,7 +18,6 @@ import (
 	"fmt"
 	"net/http/httptest"
 	"os"
-	"reflect"
 	"testing"
 
 	"github.com/openebs/maya/types/v1"
@@ -74,7 +73,7 @@ func TestListVolumes(t *testing.T) {
 			defer server.Close()
 			got := ListVolumes(&vsm)
 
-			if !reflect.DeepEqual(got, tt.err) {
+			if !checkErr(got, tt.err) {
 				t.Fatalf("ListVolumes(%v) => got %v, want %v ", vsm, got, tt.err)
 			}
 		})
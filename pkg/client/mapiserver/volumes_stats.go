@@ -18,10 +18,11 @@ import (
 	"encoding/json"
 
 	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	apis "github.com/openebs/maya/pkg/apiserver/v1alpha1"
 )
 
 const (
-	statsVolumePath = "/latest/volumes/stats/"
+	statsVolumePath = apis.APIPrefix + "volumes/stats/"
 )
 
 // VolumeStats returns the VolumeMetrics fetched from apisever endpoint
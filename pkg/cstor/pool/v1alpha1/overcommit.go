@@ -0,0 +1,59 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strconv"
+	"strings"
+
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	"github.com/pkg/errors"
+)
+
+// ParseOverCommitRatio parses the over commit ratio of a pool e.g.
+// 1.5. Zero is returned if the ratio is empty i.e. there is no limit.
+func ParseOverCommitRatio(ratio string) (float64, error) {
+	ratio = strings.TrimSpace(ratio)
+	if ratio == "" {
+		return 0, nil
+	}
+	r, err := strconv.ParseFloat(ratio, 64)
+	if err != nil || r <= 0 {
+		return 0, errors.Errorf("invalid overCommitRatio '%s': expected a positive number e.g. 1.5", ratio)
+	}
+	return r, nil
+}
+
+// OverCommitRatio returns the ratio of the capacity that can be
+// provisioned on the pool to the size of the pool. Zero is returned
+// if the ratio is not set on the pool i.e. there is no limit. The
+// ratio does not depend on OverProvisioning since it is set to false
+// on the pools that never specified it.
+func OverCommitRatio(attr apis.CStorPoolAttr) (float64, error) {
+	return ParseOverCommitRatio(attr.OverCommitRatio)
+}
+
+// OverCommitLimit returns the capacity in bytes that can be
+// provisioned on a pool of the given size. Zero is returned if there
+// is no limit.
+func OverCommitLimit(attr apis.CStorPoolAttr, size int64) (int64, error) {
+	ratio, err := OverCommitRatio(attr)
+	if err != nil {
+		return 0, err
+	}
+	return int64(ratio * float64(size)), nil
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+)
+
+func TestOverCommitLimit(t *testing.T) {
+	tests := map[string]struct {
+		attr          apis.CStorPoolAttr
+		expectedLimit int64
+		expectedErr   bool
+	}{
+		"over commit ratio not set": {
+			attr:          apis.CStorPoolAttr{},
+			expectedLimit: 0,
+		},
+		"over commit ratio without over provisioning": {
+			attr:          apis.CStorPoolAttr{OverCommitRatio: "2"},
+			expectedLimit: 200,
+		},
+		"over provisioning without limit": {
+			attr:          apis.CStorPoolAttr{OverProvisioning: true},
+			expectedLimit: 0,
+		},
+		"over provisioning with ratio": {
+			attr:          apis.CStorPoolAttr{OverProvisioning: true, OverCommitRatio: "1.5"},
+			expectedLimit: 150,
+		},
+		"invalid ratio": {
+			attr:        apis.CStorPoolAttr{OverProvisioning: true, OverCommitRatio: "twice"},
+			expectedErr: true,
+		},
+		"negative ratio": {
+			attr:        apis.CStorPoolAttr{OverProvisioning: true, OverCommitRatio: "-1"},
+			expectedErr: true,
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			limit, err := OverCommitLimit(mock.attr, 100)
+			if mock.expectedErr != (err != nil) {
+				t.Fatalf("Test %q failed: expected error %t: actual %v", name, mock.expectedErr, err)
+			}
+			if limit != mock.expectedLimit {
+				t.Fatalf("Test %q failed: expected limit %d: actual %d", name, mock.expectedLimit, limit)
+			}
+		})
+	}
+}
@@ -43,6 +43,16 @@ func (b *Builder) WithName(name string) *Builder {
 	return b
 }
 
+// WithNamespace sets the Namespace field of CSPC with provided value.
+func (b *Builder) WithNamespace(namespace string) *Builder {
+	if len(namespace) == 0 {
+		b.errs = append(b.errs, errors.New("failed to build CSPC object: missing CSPC namespace"))
+		return b
+	}
+	b.cspc.object.Namespace = namespace
+	return b
+}
+
 // WithPoolSpecBuilder adds a pool to this cspc object.
 //
 // NOTE:
@@ -68,3 +78,8 @@ func (b *Builder) Build() (*CSPC, error) {
 	}
 	return b.cspc, nil
 }
+
+// ToAPI returns the cspc api object from the CSPC object.
+func (c *CSPC) ToAPI() *apisv1alpha1.CStorPoolCluster {
+	return c.object
+}
@@ -59,13 +59,13 @@ func (b *Builder) WithCacheFilePath(cacheFile string) *Builder {
 	return b
 }
 
-// WithDefaultRaidGroupType sets the cacheFile field of pool spec with provided value.
-func (b *Builder) WithDefaultRaidGroupType(cacheFile string) *Builder {
-	if len(cacheFile) == 0 {
-		b.errs = append(b.errs, errors.New("failed to build pool spec object: missing cache file path"))
+// WithDefaultRaidGroupType sets the defaultRaidGroupType field of pool spec with provided value.
+func (b *Builder) WithDefaultRaidGroupType(raidGroupType string) *Builder {
+	if len(raidGroupType) == 0 {
+		b.errs = append(b.errs, errors.New("failed to build pool spec object: missing default raid group type"))
 		return b
 	}
-	b.ps.object.PoolConfig.CacheFile = cacheFile
+	b.ps.object.PoolConfig.DefaultRaidGroupType = raidGroupType
 	return b
 }
 
@@ -75,6 +75,16 @@ func (b *Builder) WithOverProvisioning() *Builder {
 	return b
 }
 
+// WithOverCommitRatio sets the OverCommitRatio field of pool spec with provided value.
+func (b *Builder) WithOverCommitRatio(ratio string) *Builder {
+	if len(ratio) == 0 {
+		b.errs = append(b.errs, errors.New("failed to build pool spec object: missing over commit ratio"))
+		return b
+	}
+	b.ps.object.PoolConfig.OverCommitRatio = ratio
+	return b
+}
+
 // WithCompression sets the Compression field of pool spec with provided value.
 func (b *Builder) WithCompression(compressionType string) *Builder {
 	if len(compressionType) == 0 {
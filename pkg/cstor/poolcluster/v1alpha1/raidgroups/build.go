@@ -52,6 +52,25 @@ func (b *Builder) WithName(name string) *Builder {
 	return b
 }
 
+// WithBlockDevices adds the block devices of provided names to the raid group.
+func (b *Builder) WithBlockDevices(names ...string) *Builder {
+	if len(names) == 0 {
+		b.errs = append(b.errs, errors.New("failed to build raid group object: missing block devices"))
+		return b
+	}
+	for _, name := range names {
+		if len(name) == 0 {
+			b.errs = append(b.errs, errors.New("failed to build raid group object: missing block device name"))
+			return b
+		}
+		b.rg.object.BlockDevices = append(
+			b.rg.object.BlockDevices,
+			apisv1alpha1.CStorPoolClusterBlockDevice{BlockDeviceName: name},
+		)
+	}
+	return b
+}
+
 // WithWriteCache flags the IsWriteCache field of raid group.
 func (b *Builder) WithWriteCache(cacheFile string) *Builder {
 	b.rg.object.IsWriteCache = true
@@ -157,6 +157,36 @@ const (
 	// CASTemplateToReadStoragePoolENVK is the ENV key that specifies the CAS Template
 	// to read storagepool
 	CASTemplateToReadStoragePoolENVK ENVKey = "OPENEBS_IO_CAS_TEMPLATE_TO_READ_STORAGE_POOL"
+
+	// TraceExporter is the ENV key that specifies the exporter of
+	// the traces i.e. otlp or stdout. Tracing is disabled if this
+	// is not set.
+	TraceExporter ENVKey = "OPENEBS_IO_TRACE_EXPORTER"
+
+	// TraceOTLPEndpoint is the ENV key that specifies the OTLP/HTTP
+	// endpoint of the trace collector e.g. http://otel-collector:4318
+	TraceOTLPEndpoint ENVKey = "OPENEBS_IO_TRACE_OTLP_ENDPOINT"
+
+	// JivaSnapshotCompactionThreshold is the ENV key that specifies the
+	// number of snapshots of a jiva replica beyond which its system
+	// created snapshots are removed. Compaction is disabled if this
+	// is set to 0.
+	JivaSnapshotCompactionThreshold ENVKey = "OPENEBS_IO_JIVA_SNAPSHOT_COMPACTION_THRESHOLD"
+
+	// JivaSnapshotCompactionInterval is the ENV key that specifies the
+	// interval at which the snapshots of jiva replicas are inspected
+	// e.g. 10m
+	JivaSnapshotCompactionInterval ENVKey = "OPENEBS_IO_JIVA_SNAPSHOT_COMPACTION_INTERVAL"
+
+	// JivaHealthCheckInterval is the ENV key that specifies the interval
+	// at which the health of jiva replicas is reconciled e.g. 30s.
+	// Health reconciliation is disabled if this is set to 0.
+	JivaHealthCheckInterval ENVKey = "OPENEBS_IO_JIVA_HEALTH_CHECK_INTERVAL"
+
+	// JivaReplicaRecoveryGracePeriod is the ENV key that specifies the
+	// time a jiva replica may stay in write only mode without rebuilding
+	// before it is restarted e.g. 5m
+	JivaReplicaRecoveryGracePeriod ENVKey = "OPENEBS_IO_JIVA_REPLICA_RECOVERY_GRACE_PERIOD"
 )
 
 // EnvironmentSetter abstracts setting of environment variable
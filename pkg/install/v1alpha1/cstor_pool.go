@@ -108,6 +108,9 @@ spec:
     objectName: {{.Storagepool.owner}}
   post: |
     {{- jsonpath .JsonResult "{.metadata.uid}" | trim | addTo "getspc.objectUID" .TaskResult | noop -}}
+    {{- jsonpath .JsonResult "{.spec.poolSpec.scrubInterval}" | trim | addTo "getspc.scrubInterval" .TaskResult | noop -}}
+    {{- jsonpath .JsonResult "{.spec.poolSpec.overProvisioning}" | trim | addTo "getspc.overProvisioning" .TaskResult | noop -}}
+    {{- jsonpath .JsonResult "{.spec.poolSpec.overCommitRatio}" | trim | addTo "getspc.overCommitRatio" .TaskResult | noop -}}
 ---
 apiVersion: openebs.io/v1alpha1
 kind: RunTask
@@ -154,7 +157,13 @@ spec:
         {{- end }}
       poolSpec:
         poolType: {{$blockDeviceIdList.poolType}}
-        overProvisioning: false
+        overProvisioning: {{ .TaskResult.getspc.overProvisioning | default "false" }}
+        {{- with .TaskResult.getspc.scrubInterval }}
+        scrubInterval: {{ . }}
+        {{- end }}
+        {{- with .TaskResult.getspc.overCommitRatio }}
+        overCommitRatio: {{ . | quote }}
+        {{- end }}
     status:
       phase: Init
 ---
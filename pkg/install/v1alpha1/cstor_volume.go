@@ -696,6 +696,11 @@ spec:
     {{- $preferredReplicaAntiAffinity := .TaskResult.creategetpvc.preferredReplicaAntiAffinity }}
     {{- $isClone := .Volume.isCloneEnable | default "false" -}}
     {{- $zvolWorkers := .Config.ZvolWorkers.value | default "" -}}
+    {{- $blockSize := .Config.BlockSize.value | default "" -}}
+    {{- $compression := .Config.Compression.value | default "" -}}
+    {{- $sync := .Config.Sync.value | default "" -}}
+    {{- $checksum := .Config.Checksum.value | default "" -}}
+    {{- $logBias := .Config.LogBias.value | default "" -}}
     kind: CStorVolumeReplica
     apiVersion: openebs.io/v1alpha1
     metadata:
@@ -739,6 +744,21 @@ spec:
       {{- if ne $zvolWorkers  "" }}
       zvolWorkers: {{ .Config.ZvolWorkers.value }}
       {{- end }}
+      {{- if ne $blockSize "" }}
+      blockSize: {{ $blockSize }}
+      {{- end }}
+      {{- if ne $compression "" }}
+      compression: {{ $compression }}
+      {{- end }}
+      {{- if ne $sync "" }}
+      sync: {{ $sync }}
+      {{- end }}
+      {{- if ne $checksum "" }}
+      checksum: {{ $checksum }}
+      {{- end }}
+      {{- if ne $logBias "" }}
+      logBias: {{ $logBias }}
+      {{- end }}
     status:
       # phase would be update by appropriate target
       phase: ""
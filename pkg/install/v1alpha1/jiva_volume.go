@@ -1225,7 +1225,7 @@ spec:
       targetPortal: {{ .TaskResult.createputsvc.clusterIP }}:3260
       iqn: iqn.2016-09.com.openebs.jiva:{{ .Volume.owner }}
       replicas: {{ .Config.ReplicaCount.value }}
-      targetIP: {{ .TaskResult.readlistsvc.clusterIP }}
+      targetIP: {{ .TaskResult.createputsvc.clusterIP }}
       targetPort: 3260
       casType: jiva
 ---
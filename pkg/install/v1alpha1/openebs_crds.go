@@ -279,6 +279,90 @@ spec:
 ---
 apiVersion: apiextensions.k8s.io/v1beta1
 kind: CustomResourceDefinition
+metadata:
+  # name must match the spec fields below, and be in the form: <plural>.<group>
+  name: cstorreplicamigrations.openebs.io
+spec:
+  # group name to use for REST API: /apis/<group>/<version>
+  group: openebs.io
+  # version name to use for REST API: /apis/<group>/<version>
+  version: v1alpha1
+  # either Namespaced or Cluster
+  scope: Namespaced
+  names:
+    # kind is normally the CamelCased singular type. Your resource manifests use this.
+    kind: CStorReplicaMigration
+    # plural name to be used in the URL: /apis/<group>/<version>/<plural>
+    plural: cstorreplicamigrations
+    # singular name to be used as an alias on the CLI and for display
+    singular: cstorreplicamigration
+    # shortNames allow shorter string to match your resource on the CLI
+    shortNames:
+    - cstorreplicamigration
+    - crm
+  additionalPrinterColumns:
+  - JSONPath: .spec.volumeName
+    name: Volume
+    description: Volume whose replica is migrated
+    type: string
+  - JSONPath: .spec.sourcePool
+    name: Source
+    description: Pool the replica is migrated from
+    type: string
+  - JSONPath: .spec.targetPool
+    name: Target
+    description: Pool the replica is migrated to
+    type: string
+  - JSONPath: .status.phase
+    name: Status
+    description: Identifies the progress of the migration
+    type: string
+  - JSONPath: .metadata.creationTimestamp
+    name: Age
+    type: date
+---
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  # name must match the spec fields below, and be in the form: <plural>.<group>
+  name: jivavolumehealths.openebs.io
+spec:
+  # group name to use for REST API: /apis/<group>/<version>
+  group: openebs.io
+  # version name to use for REST API: /apis/<group>/<version>
+  version: v1alpha1
+  # either Namespaced or Cluster
+  scope: Namespaced
+  names:
+    # kind is normally the CamelCased singular type. Your resource manifests use this.
+    kind: JivaVolumeHealth
+    # plural name to be used in the URL: /apis/<group>/<version>/<plural>
+    plural: jivavolumehealths
+    # singular name to be used as an alias on the CLI and for display
+    singular: jivavolumehealth
+    # shortNames allow shorter string to match your resource on the CLI
+    shortNames:
+    - jivavolumehealth
+    - jvh
+  additionalPrinterColumns:
+  - JSONPath: .spec.volumeName
+    name: Volume
+    description: Jiva volume whose replicas are reconciled
+    type: string
+  - JSONPath: .status.healthyReplicas
+    name: Healthy
+    description: Number of replicas in read write mode
+    type: integer
+  - JSONPath: .status.phase
+    name: Status
+    description: Identifies the health of the volume
+    type: string
+  - JSONPath: .metadata.creationTimestamp
+    name: Age
+    type: date
+---
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
 metadata:
   # name must match the spec fields below, and be in the form: <plural>.<group>
   name: cstorvolumereplicas.openebs.io
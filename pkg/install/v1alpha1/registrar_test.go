@@ -0,0 +1,70 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	castemplate "github.com/openebs/maya/pkg/castemplate/v1alpha1"
+	template "github.com/openebs/maya/pkg/template/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestLintRegisteredArtifacts verifies that the cas templates and the
+// runtasks installed by maya-apiserver do not have lint issues. Warnings
+// are treated as errors since a reference to a result that is not saved
+// renders as '<no value>' in the installed templates.
+func TestLintRegisteredArtifacts(t *testing.T) {
+	list, errs := RegisteredArtifacts().
+		MapIf(ArtifactTemplater(map[string]interface{}{}, template.TextTemplate), IsNotRunTask)
+	if len(errs) != 0 {
+		t.Fatalf("Test failed: expected no error while templating artifacts: actual %v", errs)
+	}
+
+	var casts []*apis.CASTemplate
+	var runtasks []*apis.RunTask
+	for _, artifact := range list.Items {
+		var tm metav1.TypeMeta
+		err := yaml.Unmarshal([]byte(artifact.Doc), &tm)
+		if err != nil {
+			t.Fatalf("Test failed: expected valid yaml: actual error %v", err)
+		}
+		switch tm.Kind {
+		case "CASTemplate":
+			cast := &apis.CASTemplate{}
+			err = yaml.Unmarshal([]byte(artifact.Doc), cast)
+			casts = append(casts, cast)
+		case "RunTask":
+			rt := &apis.RunTask{}
+			err = yaml.Unmarshal([]byte(artifact.Doc), rt)
+			runtasks = append(runtasks, rt)
+		}
+		if err != nil {
+			t.Fatalf("Test failed: expected valid %s: actual error %v", tm.Kind, err)
+		}
+	}
+	if len(casts) == 0 || len(runtasks) == 0 {
+		t.Fatalf("Test failed: expected cas templates and runtasks: actual %d cas templates %d runtasks", len(casts), len(runtasks))
+	}
+
+	issues := castemplate.LintAll(casts, runtasks)
+	if len(issues) != 0 {
+		t.Fatalf("Test failed: expected no lint issues: actual\n%s", issues)
+	}
+}
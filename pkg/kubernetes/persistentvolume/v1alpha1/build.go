@@ -129,6 +129,15 @@ func (b *Builder) WithLocalHostPathFormat(path, fstype string) *Builder {
 	return b
 }
 
+// WithMountOptions sets the MountOptions field of PV with provided arguments
+func (b *Builder) WithMountOptions(mountOptions []string) *Builder {
+	if len(mountOptions) == 0 {
+		return b
+	}
+	b.pv.object.Spec.MountOptions = mountOptions
+	return b
+}
+
 // WithNodeAffinity sets the NodeAffinity field of PV with provided node name
 func (b *Builder) WithNodeAffinity(nodeName string) *Builder {
 	if len(nodeName) == 0 {
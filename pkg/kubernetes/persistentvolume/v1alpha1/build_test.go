@@ -236,6 +236,42 @@ func TestBuildWithLocalHostDirectory(t *testing.T) {
 	}
 }
 
+func TestBuildWithMountOptions(t *testing.T) {
+	tests := map[string]struct {
+		mountOptions []string
+		builder      *Builder
+		expectCount  int
+	}{
+		"Test Builderwith mount options": {
+			mountOptions: []string{"noatime", "discard"},
+			builder: &Builder{pv: &PV{
+				object: &corev1.PersistentVolume{},
+			}},
+			expectCount: 2,
+		},
+		"Test Builderwithout mount options": {
+			mountOptions: nil,
+			builder: &Builder{pv: &PV{
+				object: &corev1.PersistentVolume{},
+			}},
+			expectCount: 0,
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			b := mock.builder.WithMountOptions(mock.mountOptions)
+			if len(b.errs) > 0 {
+				t.Fatalf("Test %q failed: expected error to be nil", name)
+			}
+			if len(b.pv.object.Spec.MountOptions) != mock.expectCount {
+				t.Fatalf("Test %q failed: expected %d mount options got %d",
+					name, mock.expectCount, len(b.pv.object.Spec.MountOptions))
+			}
+		})
+	}
+}
+
 func TestBuildWithNodeAffinity(t *testing.T) {
 	tests := map[string]struct {
 		nodeName  string
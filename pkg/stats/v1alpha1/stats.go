@@ -14,7 +14,11 @@
 
 package stats
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	apis "github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+)
 
 // ReplicaMode is the mode of replica.In jiva it can be either RO
 // or RW and HEALTHY or DEGRADED for cstor respectively
@@ -95,6 +99,10 @@ type VolumeStats struct {
 	Replicas []Replica `json:"Replicas"`
 	// Target status is the status of the target (RW/RO)
 	TargetStatus TargetMode `json:"Status"`
+	// ReplicaStatuses keeps the io details of the replicas
+	// connected to the cstor target. These are not part of
+	// the stats response & are fetched separately.
+	ReplicaStatuses []apis.ReplicaStatus `json:"-"`
 }
 
 // Replica is used to store the info about the replicas
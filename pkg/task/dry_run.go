@@ -0,0 +1,192 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package task
+
+import (
+	"github.com/ghodss/yaml"
+	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+	"github.com/openebs/maya/pkg/template"
+	"github.com/openebs/maya/pkg/util"
+)
+
+// dryRun renders the task specifications of this task without modifying any
+// resource. It returns the rendered specifications; one per repeat of this
+// task.
+//
+// NOTE:
+//  Read only tasks i.e. get & list are executed as is, so that the tasks
+// that follow get rendered with the actual values.
+//
+// NOTE:
+//  Put based tasks are never executed. Instead their rendered specifications
+// are set as the task's result and the post operations are run against it.
+// This lets the tasks that follow refer to the result of a put based task
+// e.g. the name of the object that would have been created.
+func (m *executor) dryRun() (rendered []string, executed bool, err error) {
+	if m.MetaExec.isReadOnly() {
+		return nil, true, m.Execute()
+	}
+
+	rptExec := m.MetaExec.getRepeatExecutor()
+	if !rptExec.isRepeat() {
+		r, err := m.dryRunIt()
+		if err != nil {
+			return nil, false, err
+		}
+		return []string{r}, false, nil
+	}
+
+	for idx := 0; idx < rptExec.len(); idx++ {
+		rptMetaExec, err := m.MetaExec.asRepeatInstance(idx)
+		if err != nil {
+			return nil, false, err
+		}
+		m.MetaExec = rptMetaExec
+
+		current, err := m.MetaExec.repeater.getItem(idx)
+		if err != nil {
+			return nil, false, err
+		}
+		util.SetNestedField(
+			m.Values,
+			current,
+			string(v1alpha1.ListItemsTLP),
+			string(v1alpha1.CurrentRepeatResourceLITP),
+		)
+
+		r, err := m.dryRunIt()
+		if err != nil {
+			return nil, false, err
+		}
+		rendered = append(rendered, r)
+	}
+	return rendered, false, nil
+}
+
+// dryRunIt renders the task specifications of this task against the current
+// template values
+func (m *executor) dryRunIt() (string, error) {
+	b, err := template.AsTemplatedBytes("DryRun", m.Runtask.Spec.Task, m.Values)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to render task: %s", m)
+	}
+
+	if !m.MetaExec.isPut() || m.MetaExec.isCommand() || len(b) == 0 {
+		return string(b), nil
+	}
+
+	// simulate the result of a put based task
+	j, err := yaml.YAMLToJSON(b)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to simulate task result: %s", m)
+	}
+	util.SetNestedField(m.Values, j, string(v1alpha1.CurrentJSONResultTLP))
+
+	err = m.postExecuteIt()
+	redactJsonResult(m.Values)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to simulate task result: %s", m)
+	}
+	return string(b), nil
+}
+
+// dryRunATask renders a task based on the task specs & template values
+func (m *TaskGroupRunner) dryRunATask(
+	runtask *v1alpha1.RunTask,
+	values map[string]interface{},
+) (result v1alpha1.RunTaskDryRunResult, err error) {
+	result.Name = runtask.Name
+
+	v, err := yaml.Marshal(values)
+	if err != nil {
+		return result, errors.Wrapf(err, "failed to dry run runtask {%s}: invalid template values", runtask.Name)
+	}
+	result.Values = string(v)
+
+	te, err := newExecutor(runtask, values)
+	if err != nil {
+		return result, errors.Wrap(err, "failed to dry run runtask: failed to init executor")
+	}
+
+	meta := te.MetaExec.getMetaInfo()
+	result.ID = meta.Identity
+	result.APIVersion = meta.APIVersion
+	result.Kind = meta.Kind
+	result.Action = string(meta.Action)
+	result.ObjectName = meta.ObjectName
+	result.RunNamespace = meta.RunNamespace
+
+	if !m.isTaskIDUnique(te.getTaskIdentity()) {
+		return result, errors.Errorf("failed to dry run runtask {%s}: multiple tasks having same identity is not allowed in a group run duplicate id {%s}", runtask.Name, te.getTaskIdentity())
+	}
+
+	if te.MetaExec.isDisabled() {
+		result.Skipped = true
+		result.Message = "runtask is disabled"
+		return result, nil
+	}
+
+	rendered, executed, err := te.dryRun()
+	redactJsonResult(values)
+	if err != nil {
+		return result, errors.Wrapf(err, "failed to dry run runtask {%s}", runtask.Name)
+	}
+	result.Rendered = rendered
+	result.Executed = executed
+	if !executed && len(rendered) == 0 {
+		result.Skipped = true
+		result.Message = "no resources to repeat runtask with"
+	}
+	return result, nil
+}
+
+// DryRun renders all the defined tasks in sequence & the output task without
+// creating, updating or deleting any resource. Rendering stops at the first
+// task that fails; the tasks that follow are reported as skipped.
+//
+// NOTE: values is mutated (i.e. gets modified after each task render) to
+// let the task result be made available to the next task
+func (m *TaskGroupRunner) DryRun(values map[string]interface{}) (
+	results []v1alpha1.RunTaskDryRunResult,
+	output []byte,
+	err error,
+) {
+	for _, runtask := range m.allTasks {
+		if err != nil {
+			results = append(results, v1alpha1.RunTaskDryRunResult{
+				Name:    runtask.Name,
+				Skipped: true,
+				Message: "a previous runtask failed",
+			})
+			continue
+		}
+
+		var result v1alpha1.RunTaskDryRunResult
+		result, err = m.dryRunATask(runtask, values)
+		if err != nil {
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+	if err != nil {
+		return
+	}
+
+	output, err = m.runOutput(values)
+	return
+}
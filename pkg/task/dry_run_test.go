@@ -0,0 +1,125 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package task
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	"github.com/openebs/maya/pkg/util"
+)
+
+func TestExecutorDryRun(t *testing.T) {
+	tests := map[string]struct {
+		meta             MetaTaskSpec
+		task             string
+		post             string
+		expectedRendered []string
+		expectedSaved    string
+		isErr            bool
+	}{
+		"put task is rendered and its result is simulated": {
+			meta: MetaTaskSpec{
+				MetaTaskIdentity: MetaTaskIdentity{Identity: "svc", Kind: "Service", APIVersion: "v1"},
+				Action:           PutTA,
+			},
+			task: `
+kind: Service
+metadata:
+  name: {{ .Volume.owner }}-svc`,
+			post:             `{{- jsonpath .JsonResult "{.metadata.name}" | trim | saveAs "svc.objectName" .TaskResult | noop -}}`,
+			expectedRendered: []string{"\nkind: Service\nmetadata:\n  name: pvc-1-svc"},
+			expectedSaved:    "pvc-1-svc",
+		},
+		"patch task is rendered but not simulated": {
+			meta: MetaTaskSpec{
+				MetaTaskIdentity: MetaTaskIdentity{Identity: "svc", Kind: "Service", APIVersion: "v1"},
+				Action:           PatchTA,
+			},
+			task:             `type: merge`,
+			post:             `{{- "patched" | saveAs "svc.objectName" .TaskResult | noop -}}`,
+			expectedRendered: []string{"type: merge"},
+			expectedSaved:    "",
+		},
+		"put task is rendered once per repeat": {
+			meta: MetaTaskSpec{
+				MetaTaskIdentity: MetaTaskIdentity{Identity: "cvr", Kind: "CStorVolumeReplica", APIVersion: "openebs.io/v1alpha1"},
+				Action:           PutTA,
+				RepeatWith: RepeatWithResource{
+					Resources: []string{"pool-1", "pool-2"},
+				},
+			},
+			task:             `name: {{ .Volume.owner }}-{{ .ListItems.currentRepeatResource }}`,
+			expectedRendered: []string{"name: pvc-1-pool-1", "name: pvc-1-pool-2"},
+		},
+		"invalid task template": {
+			meta: MetaTaskSpec{
+				MetaTaskIdentity: MetaTaskIdentity{Identity: "svc", Kind: "Service", APIVersion: "v1"},
+				Action:           PutTA,
+			},
+			task:  `name: {{ .Volume.owner`,
+			isErr: true,
+		},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			i, _ := newTaskIdentifier(mock.meta.MetaTaskIdentity)
+			r, _ := newRepeatExecutor(mock.meta.RepeatWith)
+			values := map[string]interface{}{
+				string(v1alpha1.VolumeTLP): map[string]interface{}{
+					"owner": "pvc-1",
+				},
+				string(v1alpha1.ListItemsTLP):  map[string]interface{}{},
+				string(v1alpha1.TaskResultTLP): map[string]interface{}{},
+			}
+			te := &executor{
+				Values: values,
+				MetaExec: &MetaExecutor{
+					metaTask:   mock.meta,
+					identifier: i,
+					repeater:   r,
+				},
+				Runtask: &v1alpha1.RunTask{
+					Spec: v1alpha1.RunTaskSpec{
+						Task:    mock.task,
+						PostRun: mock.post,
+					},
+				},
+			}
+
+			rendered, executed, err := te.dryRun()
+			if mock.isErr != (err != nil) {
+				t.Fatalf("failed to dry run task: expected error '%t': actual error '%v'", mock.isErr, err)
+			}
+			if executed {
+				t.Fatalf("failed to dry run task: expected task not to be executed")
+			}
+			if mock.isErr {
+				return
+			}
+			if !reflect.DeepEqual(rendered, mock.expectedRendered) {
+				t.Fatalf("failed to dry run task: expected rendered '%#v': actual rendered '%#v'", mock.expectedRendered, rendered)
+			}
+			saved := util.GetNestedString(values, string(v1alpha1.TaskResultTLP), "svc", "objectName")
+			if saved != mock.expectedSaved {
+				t.Fatalf("failed to dry run task: expected saved result '%s': actual saved result '%s'", mock.expectedSaved, saved)
+			}
+		})
+	}
+}
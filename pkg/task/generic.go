@@ -0,0 +1,263 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package task
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+	"github.com/openebs/maya/pkg/template"
+	unstruct "github.com/openebs/maya/pkg/unstruct/v1alpha2"
+	"github.com/openebs/maya/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// resourceCache caches the kubernetes resources that
+// are discovered from the cluster while executing the
+// tasks. It is shared by the tasks since the resources
+// do not depend on the task.
+var resourceCache = unstruct.NewResourceCache()
+
+// genericClient returns the kubernetes client to execute
+// this task against any kubernetes resource. It is built
+// from the task's K8sClient & hence makes use of its
+// config & the context it is traced with.
+func (m *executor) genericClient() (*unstruct.Kubeclient, error) {
+	if m.generic != nil {
+		return m.generic, nil
+	}
+	k := m.getK8sClient()
+	dyn, err := k.GetDynamic()
+	if err != nil {
+		return nil, err
+	}
+	m.generic = unstruct.NewKubeClient(
+		unstruct.WithClient(dyn),
+		unstruct.WithDiscoveryClient(k.GetKCS().Discovery()),
+		unstruct.WithResourceCache(resourceCache),
+	)
+	return m.generic, nil
+}
+
+// groupVersionKind returns the kind of the
+// resource this task operates on
+func (m *executor) groupVersionKind() schema.GroupVersionKind {
+	id := m.MetaExec.getTaskIdentity()
+	return schema.FromAPIVersionAndKind(id.APIVersion, id.Kind)
+}
+
+// resource returns the kubernetes resource this task
+// operates on as discovered from the cluster
+func (m *executor) resource() (*metav1.APIResource, error) {
+	return m.generic.ResourceFor(m.groupVersionKind())
+}
+
+// namespaceFor returns the namespace to be used for
+// the resource; cluster scoped resources do not have
+// any namespace
+func (m *executor) namespaceFor(r *metav1.APIResource) string {
+	if !r.Namespaced {
+		return ""
+	}
+	return m.getTaskRunNamespace()
+}
+
+// setResult sets the provided object as the json
+// result of this task
+func (m *executor) setResult(obj interface{}) error {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	util.SetNestedField(m.Values, raw, string(v1alpha1.CurrentJSONResultTLP))
+	return nil
+}
+
+// executeGeneric executes the task against the
+// resource of any apiVersion & kind by making use
+// of kubernetes dynamic client
+func (m *executor) executeGeneric() (err error) {
+	if _, err = m.genericClient(); err != nil {
+		return err
+	}
+	r, err := m.resource()
+	if err != nil {
+		return err
+	}
+
+	if m.MetaExec.isGet() {
+		err = m.getGeneric(r)
+	} else if m.MetaExec.isList() {
+		err = m.listGeneric(r)
+	} else if m.MetaExec.isPut() {
+		err = m.putGeneric(r)
+	} else if m.MetaExec.isPatch() {
+		err = m.patchGeneric(r)
+	} else if m.MetaExec.isDelete() {
+		err = m.deleteGeneric(r)
+	} else {
+		err = ErrorUnSupportedTask
+	}
+	return
+}
+
+// getGeneric will get the resource as specified
+// in the RunTask
+func (m *executor) getGeneric(r *metav1.APIResource) error {
+	obj, err := m.generic.Get(
+		m.getTaskObjectName(),
+		unstruct.WithGetNamespace(m.namespaceFor(r)),
+		unstruct.WithGroupVersionResource(unstruct.GroupVersionResource(r)),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get %s {%s}", r.Kind, m.getTaskObjectName())
+	}
+
+	return m.setResult(obj)
+}
+
+// listGeneric will list the resources based on the
+// list options specified in the RunTask
+func (m *executor) listGeneric(r *metav1.APIResource) error {
+	opts, err := m.MetaExec.getListOptions()
+	if err != nil {
+		return errors.Wrapf(err, "failed to list %s", r.Kind)
+	}
+
+	list, err := m.generic.List(
+		unstruct.WithListNamespace(m.namespaceFor(r)),
+		unstruct.WithListOption(opts),
+		unstruct.WithListGroupVersionResource(unstruct.GroupVersionResource(r)),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list %s", r.Kind)
+	}
+
+	return m.setResult(list)
+}
+
+// asUnstructured generates the resource out of the
+// RunTask's embedded yaml
+func (m *executor) asUnstructured(r *metav1.APIResource) (*unstructured.Unstructured, error) {
+	raw, err := template.AsTemplatedBytes(m.getTaskIdentity(), m.Runtask.Spec.Task, m.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := &unstructured.Unstructured{}
+	err = yaml.Unmarshal(raw, &obj.Object)
+	if err != nil {
+		return nil, err
+	}
+	if len(obj.Object) == 0 {
+		return nil, errors.New("missing resource yaml")
+	}
+
+	// apiVersion & kind of the task are used if these
+	// are not specified in the resource's yaml
+	gvk := m.groupVersionKind()
+	if obj.GetAPIVersion() == "" {
+		obj.SetAPIVersion(gvk.GroupVersion().String())
+	}
+	if obj.GetKind() == "" {
+		obj.SetKind(gvk.Kind)
+	}
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(m.namespaceFor(r))
+	}
+	return obj, nil
+}
+
+// putGeneric will create the resource whose
+// specifications are defined in the RunTask
+func (m *executor) putGeneric(r *metav1.APIResource) error {
+	obj, err := m.asUnstructured(r)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create %s", r.Kind)
+	}
+
+	created, err := m.generic.CreateAndGet(
+		obj,
+		unstruct.WithCreateGroupVersionResource(unstruct.GroupVersionResource(r)),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create %s {%s}", r.Kind, obj.GetName())
+	}
+
+	return m.setResult(created)
+}
+
+// patchGeneric will patch the resource where patch
+// specifications are configured in the RunTask
+func (m *executor) patchGeneric(r *metav1.APIResource) error {
+	patch, err := asTaskPatch(m.getTaskIdentity(), m.Runtask.Spec.Task, m.Values)
+	if err != nil {
+		return errors.Wrapf(err, "failed to patch %s", r.Kind)
+	}
+
+	pe, err := newTaskPatchExecutor(patch)
+	if err != nil {
+		return errors.Wrapf(err, "failed to patch %s", r.Kind)
+	}
+
+	raw, err := pe.toJson()
+	if err != nil {
+		return errors.Wrapf(err, "failed to patch %s", r.Kind)
+	}
+
+	patched, err := m.generic.Patch(
+		m.getTaskObjectName(),
+		pe.patchType(),
+		raw,
+		unstruct.WithPatchNamespace(m.namespaceFor(r)),
+		unstruct.WithPatchGroupVersionResource(unstruct.GroupVersionResource(r)),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to patch %s {%s}", r.Kind, m.getTaskObjectName())
+	}
+
+	return m.setResult(patched)
+}
+
+// deleteGeneric will delete one or more resources
+// as specified in the RunTask
+func (m *executor) deleteGeneric(r *metav1.APIResource) error {
+	objectNames := strings.Split(strings.TrimSpace(m.getTaskObjectName()), ",")
+	deletePropagation := metav1.DeletePropagationForeground
+
+	for _, name := range objectNames {
+		obj := &unstructured.Unstructured{}
+		obj.SetName(strings.TrimSpace(name))
+		obj.SetNamespace(m.namespaceFor(r))
+
+		err := m.generic.Delete(
+			obj,
+			unstruct.WithDeleteOption(&metav1.DeleteOptions{PropagationPolicy: &deletePropagation}),
+			unstruct.WithDeleteGroupVersionResource(unstruct.GroupVersionResource(r)),
+		)
+		if err != nil {
+			return errors.Wrapf(err, "failed to delete %s {%s}", r.Kind, name)
+		}
+	}
+
+	return nil
+}
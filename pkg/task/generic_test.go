@@ -0,0 +1,319 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package task
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+	unstruct "github.com/openebs/maya/pkg/unstruct/v1alpha2"
+	"github.com/openebs/maya/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/dynamic"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+// fakeDynamicClient is an in-memory dynamic client that
+// stores the objects against their resource, namespace
+// & name
+type fakeDynamicClient struct {
+	objects map[string]*unstructured.Unstructured
+}
+
+type fakeResourceClient struct {
+	client    *fakeDynamicClient
+	resource  schema.GroupVersionResource
+	namespace string
+}
+
+func (f *fakeDynamicClient) Resource(r schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return &fakeResourceClient{client: f, resource: r}
+}
+
+func (f *fakeResourceClient) Namespace(ns string) dynamic.ResourceInterface {
+	return &fakeResourceClient{client: f.client, resource: f.resource, namespace: ns}
+}
+
+func (f *fakeResourceClient) key(name string) string {
+	return f.resource.String() + "/" + f.namespace + "/" + name
+}
+
+func (f *fakeResourceClient) Create(obj *unstructured.Unstructured, options metav1.CreateOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	if _, ok := f.client.objects[f.key(obj.GetName())]; ok {
+		return nil, errors.Errorf("%s already exists", obj.GetName())
+	}
+	f.client.objects[f.key(obj.GetName())] = obj.DeepCopy()
+	return obj, nil
+}
+
+func (f *fakeResourceClient) Update(obj *unstructured.Unstructured, options metav1.UpdateOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeResourceClient) UpdateStatus(obj *unstructured.Unstructured, options metav1.UpdateOptions) (*unstructured.Unstructured, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeResourceClient) Delete(name string, options *metav1.DeleteOptions, subresources ...string) error {
+	if _, ok := f.client.objects[f.key(name)]; !ok {
+		return errors.Errorf("%s not found", name)
+	}
+	delete(f.client.objects, f.key(name))
+	return nil
+}
+
+func (f *fakeResourceClient) DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error {
+	return errors.New("not implemented")
+}
+
+func (f *fakeResourceClient) Get(name string, options metav1.GetOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	obj, ok := f.client.objects[f.key(name)]
+	if !ok {
+		return nil, errors.Errorf("%s not found", name)
+	}
+	return obj, nil
+}
+
+func (f *fakeResourceClient) List(opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	list := &unstructured.UnstructuredList{}
+	for k, obj := range f.client.objects {
+		if k == f.key(obj.GetName()) {
+			list.Items = append(list.Items, *obj)
+		}
+	}
+	return list, nil
+}
+
+func (f *fakeResourceClient) Watch(opts metav1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeResourceClient) Patch(name string, pt types.PatchType, data []byte, options metav1.UpdateOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	obj, ok := f.client.objects[f.key(name)]
+	if !ok {
+		return nil, errors.Errorf("%s not found", name)
+	}
+	patch := map[string]interface{}{}
+	err := json.Unmarshal(data, &patch)
+	if err != nil {
+		return nil, err
+	}
+	// only top level labels are merged by this fake
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	l, _, _ := unstructured.NestedStringMap(patch, "metadata", "labels")
+	for k, v := range l {
+		labels[k] = v
+	}
+	obj.SetLabels(labels)
+	return obj, nil
+}
+
+func fakeGenericClient(objs ...*unstructured.Unstructured) *fakeDynamicClient {
+	f := &fakeDynamicClient{objects: map[string]*unstructured.Unstructured{}}
+	for _, obj := range objs {
+		gvr := schema.GroupVersionResource{Group: "policy", Version: "v1beta1", Resource: "poddisruptionbudgets"}
+		if obj.GetKind() == "PriorityClass" {
+			gvr = schema.GroupVersionResource{Group: "scheduling.k8s.io", Version: "v1", Resource: "priorityclasses"}
+		}
+		f.objects[gvr.String()+"/"+obj.GetNamespace()+"/"+obj.GetName()] = obj
+	}
+	return f
+}
+
+func fakeGenericDiscovery() *fakediscovery.FakeDiscovery {
+	return &fakediscovery.FakeDiscovery{
+		Fake: &clienttesting.Fake{
+			Resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "policy/v1beta1",
+					APIResources: []metav1.APIResource{
+						{Name: "poddisruptionbudgets", Kind: "PodDisruptionBudget", Namespaced: true},
+						{Name: "poddisruptionbudgets/status", Kind: "PodDisruptionBudget", Namespaced: true},
+					},
+				},
+				{
+					GroupVersion: "scheduling.k8s.io/v1",
+					APIResources: []metav1.APIResource{
+						{Name: "priorityclasses", Kind: "PriorityClass"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func fakeUnstructured(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(apiVersion)
+	u.SetKind(kind)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	return u
+}
+
+func TestExecuteGeneric(t *testing.T) {
+	tests := map[string]struct {
+		objects      []*unstructured.Unstructured
+		meta         MetaTaskSpec
+		task         string
+		expectedName string
+		expectedKey  string
+		isDeleted    bool
+		isErr        bool
+	}{
+		"get namespaced resource": {
+			objects: []*unstructured.Unstructured{fakeUnstructured("policy/v1beta1", "PodDisruptionBudget", "openebs", "pdb1")},
+			meta: MetaTaskSpec{
+				MetaTaskIdentity: MetaTaskIdentity{Identity: "pdb", Kind: "PodDisruptionBudget", APIVersion: "policy/v1beta1"},
+				MetaTaskProps:    MetaTaskProps{RunNamespace: "openebs", ObjectName: "pdb1"},
+				Action:           GetTA,
+			},
+			expectedName: "pdb1",
+		},
+		"get cluster scoped resource ignores run namespace": {
+			objects: []*unstructured.Unstructured{fakeUnstructured("scheduling.k8s.io/v1", "PriorityClass", "", "pc1")},
+			meta: MetaTaskSpec{
+				MetaTaskIdentity: MetaTaskIdentity{Identity: "pc", Kind: "PriorityClass", APIVersion: "scheduling.k8s.io/v1"},
+				MetaTaskProps:    MetaTaskProps{RunNamespace: "openebs", ObjectName: "pc1"},
+				Action:           GetTA,
+			},
+			expectedName: "pc1",
+		},
+		"put namespaced resource in run namespace": {
+			meta: MetaTaskSpec{
+				MetaTaskIdentity: MetaTaskIdentity{Identity: "pdb", Kind: "PodDisruptionBudget", APIVersion: "policy/v1beta1"},
+				MetaTaskProps:    MetaTaskProps{RunNamespace: "openebs"},
+				Action:           PutTA,
+			},
+			task: `
+metadata:
+  name: {{ .Volume.owner }}-pdb`,
+			expectedName: "pvc-1-pdb",
+			expectedKey:  "policy/v1beta1, Resource=poddisruptionbudgets/openebs/pvc-1-pdb",
+		},
+		"patch namespaced resource": {
+			objects: []*unstructured.Unstructured{fakeUnstructured("policy/v1beta1", "PodDisruptionBudget", "openebs", "pdb1")},
+			meta: MetaTaskSpec{
+				MetaTaskIdentity: MetaTaskIdentity{Identity: "pdb", Kind: "PodDisruptionBudget", APIVersion: "policy/v1beta1"},
+				MetaTaskProps:    MetaTaskProps{RunNamespace: "openebs", ObjectName: "pdb1"},
+				Action:           PatchTA,
+			},
+			task: `
+type: merge
+pspec: |-
+  metadata:
+    labels:
+      owner: {{ .Volume.owner }}`,
+			expectedName: "pdb1",
+		},
+		"delete multiple resources": {
+			objects: []*unstructured.Unstructured{
+				fakeUnstructured("policy/v1beta1", "PodDisruptionBudget", "openebs", "pdb1"),
+				fakeUnstructured("policy/v1beta1", "PodDisruptionBudget", "openebs", "pdb2"),
+			},
+			meta: MetaTaskSpec{
+				MetaTaskIdentity: MetaTaskIdentity{Identity: "pdb", Kind: "PodDisruptionBudget", APIVersion: "policy/v1beta1"},
+				MetaTaskProps:    MetaTaskProps{RunNamespace: "openebs", ObjectName: "pdb1, pdb2"},
+				Action:           DeleteTA,
+			},
+			isDeleted: true,
+		},
+		"get missing resource": {
+			meta: MetaTaskSpec{
+				MetaTaskIdentity: MetaTaskIdentity{Identity: "pdb", Kind: "PodDisruptionBudget", APIVersion: "policy/v1beta1"},
+				MetaTaskProps:    MetaTaskProps{RunNamespace: "openebs", ObjectName: "pdb1"},
+				Action:           GetTA,
+			},
+			isErr: true,
+		},
+		"undiscovered kind": {
+			meta: MetaTaskSpec{
+				MetaTaskIdentity: MetaTaskIdentity{Identity: "np", Kind: "NetworkPolicy", APIVersion: "networking.k8s.io/v1"},
+				MetaTaskProps:    MetaTaskProps{RunNamespace: "openebs", ObjectName: "np1"},
+				Action:           GetTA,
+			},
+			isErr: true,
+		},
+	}
+
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			fc := fakeGenericClient(mock.objects...)
+			generic := unstruct.NewKubeClient(
+				unstruct.WithClient(fc),
+				unstruct.WithDiscoveryClient(fakeGenericDiscovery()),
+			)
+			i, _ := newTaskIdentifier(mock.meta.MetaTaskIdentity)
+			values := map[string]interface{}{
+				string(v1alpha1.VolumeTLP): map[string]interface{}{
+					"owner": "pvc-1",
+				},
+			}
+			te := &executor{
+				Values: values,
+				MetaExec: &MetaExecutor{
+					metaTask:   mock.meta,
+					identifier: i,
+				},
+				Runtask: &v1alpha1.RunTask{
+					Spec: v1alpha1.RunTaskSpec{Task: mock.task},
+				},
+				generic: generic,
+			}
+
+			err := te.executeGeneric()
+			if mock.isErr != (err != nil) {
+				t.Fatalf("failed to execute generic task %q: expected error '%t': actual error '%v'", name, mock.isErr, err)
+			}
+			if mock.isErr {
+				return
+			}
+			if mock.isDeleted && len(fc.objects) != 0 {
+				t.Fatalf("failed to execute generic task %q: expected objects to be deleted: actual '%v'", name, fc.objects)
+			}
+			if mock.expectedKey != "" && fc.objects[mock.expectedKey] == nil {
+				t.Fatalf("failed to execute generic task %q: expected object '%s': actual '%v'", name, mock.expectedKey, fc.objects)
+			}
+			if mock.expectedName == "" {
+				return
+			}
+			raw, _ := util.GetNestedField(values, string(v1alpha1.CurrentJSONResultTLP)).([]byte)
+			result := &unstructured.Unstructured{}
+			err = result.UnmarshalJSON(raw)
+			if err != nil {
+				t.Fatalf("failed to execute generic task %q: invalid json result: %v", name, err)
+			}
+			if result.GetName() != mock.expectedName {
+				t.Fatalf("failed to execute generic task %q: expected name '%s': actual name '%s'", name, mock.expectedName, result.GetName())
+			}
+			if mock.meta.Action == PatchTA && result.GetLabels()["owner"] != "pvc-1" {
+				t.Fatalf("failed to execute generic task %q: expected patched labels: actual '%v'", name, result.GetLabels())
+			}
+		})
+	}
+}
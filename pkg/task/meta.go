@@ -17,6 +17,7 @@ limitations under the License.
 package task
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -180,6 +181,8 @@ type MetaExecutor struct {
 	repeater repeatExecutor
 	// k8sClient will be used to make K8s API calls
 	k8sClient *m_k8s_client.K8sClient
+	// ctx has the span that traces the K8s API calls
+	ctx context.Context
 }
 
 // getMetaInstances is a utility function that provides required objects
@@ -264,6 +267,13 @@ func (m *MetaExecutor) getK8sClient() *m_k8s_client.K8sClient {
 	return m.k8sClient
 }
 
+// withContext traces the K8s API calls of this executor as children
+// of the span in the given context
+func (m *MetaExecutor) withContext(ctx context.Context) {
+	m.ctx = ctx
+	m.k8sClient = m.k8sClient.WithContext(ctx)
+}
+
 func (m *MetaExecutor) getRetry() (attempts int, interval time.Duration) {
 	retry := m.metaTask.Retry
 	// "attempts,interval" format
@@ -326,6 +336,17 @@ func (m *MetaExecutor) isRolloutstatus() bool {
 	return m.metaTask.Action == RolloutstatusTA
 }
 
+// isGeneric flags if the task's action can be
+// executed against any kubernetes resource
+func (m *MetaExecutor) isGeneric() bool {
+	return m.isGet() || m.isList() || m.isPut() || m.isPatch() || m.isDelete()
+}
+
+// isReadOnly flags if executing this task does not modify any resource
+func (m *MetaExecutor) isReadOnly() bool {
+	return !m.isCommand() && (m.isGet() || m.isList())
+}
+
 func (m *MetaExecutor) isPutExtnV1B1Deploy() bool {
 	return m.identifier.isExtnV1B1Deploy() && m.isPut()
 }
@@ -592,6 +613,33 @@ func (m *MetaExecutor) isRolloutstatusAppsV1Deploy() bool {
 	return m.identifier.isAppsV1Deploy() && m.isRolloutstatus()
 }
 
+// isSupported flags if this task can be executed by the task executor
+//
+// NOTE:
+//  This needs to be in sync with the kinds & actions handled by the
+// executor's ExecuteIt & rolloutStatus
+func (m *MetaExecutor) isSupported() bool {
+	return m.isCommand() ||
+		m.isGeneric() ||
+		m.isExecCoreV1Pod() ||
+		m.isRolloutstatusExtnV1B1Deploy() ||
+		m.isRolloutstatusAppsV1Deploy()
+}
+
+// IsSupportedMetaTask flags if a task with the given meta task
+// specifications can be executed by the task executor
+func IsSupportedMetaTask(meta MetaTaskSpec) bool {
+	i, err := newTaskIdentifier(meta.MetaTaskIdentity)
+	if err != nil {
+		return false
+	}
+	m := &MetaExecutor{
+		metaTask:   meta,
+		identifier: i,
+	}
+	return m.isSupported()
+}
+
 // getRollbackMetaInstances is a utility function that provides objects
 // required to build a rollback based meta task executor
 func getRollbackMetaInstances(given MetaTaskSpec, objectName string) (m MetaTaskSpec, i taskIdentifier, err error) {
@@ -647,7 +695,8 @@ func (m *MetaExecutor) asRollbackInstance(objectName string) (*MetaExecutor, boo
 	return &MetaExecutor{
 		metaTask:   rbSpec,
 		identifier: i,
-		k8sClient:  k,
+		k8sClient:  k.WithContext(m.ctx),
+		ctx:        m.ctx,
 	}, true, nil
 }
 
@@ -706,6 +755,7 @@ func (m *MetaExecutor) asRepeatInstance(repeatIndex int) (*MetaExecutor, error)
 		metaTask:   rSpec,
 		identifier: i,
 		repeater:   r,
-		k8sClient:  k,
+		k8sClient:  k.WithContext(m.ctx),
+		ctx:        m.ctx,
 	}, nil
 }
@@ -1539,3 +1539,74 @@ action: {{ .action }}
 		})
 	}
 }
+
+func TestIsSupportedMetaTask(t *testing.T) {
+	tests := map[string]struct {
+		meta        MetaTaskSpec
+		isSupported bool
+	}{
+		"get core v1 pod": {
+			meta: MetaTaskSpec{
+				MetaTaskIdentity: MetaTaskIdentity{Identity: "pod", Kind: "Pod", APIVersion: "v1"},
+				Action:           GetTA,
+			},
+			isSupported: true,
+		},
+		"list openebs cstor volume replica": {
+			meta: MetaTaskSpec{
+				MetaTaskIdentity: MetaTaskIdentity{Identity: "cvr", Kind: "CStorVolumeReplica", APIVersion: "openebs.io/v1alpha1"},
+				Action:           ListTA,
+			},
+			isSupported: true,
+		},
+		"command without apiversion": {
+			meta: MetaTaskSpec{
+				MetaTaskIdentity: MetaTaskIdentity{Identity: "cmd", Kind: "Command"},
+			},
+			isSupported: true,
+		},
+		"put storage v1 storageclass": {
+			meta: MetaTaskSpec{
+				MetaTaskIdentity: MetaTaskIdentity{Identity: "sc", Kind: "StorageClass", APIVersion: "storage.k8s.io/v1"},
+				Action:           PutTA,
+			},
+			isSupported: true,
+		},
+		"patch policy v1beta1 poddisruptionbudget": {
+			meta: MetaTaskSpec{
+				MetaTaskIdentity: MetaTaskIdentity{Identity: "pdb", Kind: "PodDisruptionBudget", APIVersion: "policy/v1beta1"},
+				Action:           PatchTA,
+			},
+			isSupported: true,
+		},
+		"exec core v1 service": {
+			meta: MetaTaskSpec{
+				MetaTaskIdentity: MetaTaskIdentity{Identity: "svc", Kind: "Service", APIVersion: "v1"},
+				Action:           ExecTA,
+			},
+			isSupported: false,
+		},
+		"rolloutstatus apps v1 statefulset": {
+			meta: MetaTaskSpec{
+				MetaTaskIdentity: MetaTaskIdentity{Identity: "sts", Kind: "StatefulSet", APIVersion: "apps/v1"},
+				Action:           RolloutstatusTA,
+			},
+			isSupported: false,
+		},
+		"missing id": {
+			meta: MetaTaskSpec{
+				MetaTaskIdentity: MetaTaskIdentity{Kind: "Pod", APIVersion: "v1"},
+				Action:           GetTA,
+			},
+			isSupported: false,
+		},
+	}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			if IsSupportedMetaTask(mock.meta) != mock.isSupported {
+				t.Fatalf("failed to check supported meta task: expected '%t': actual '%t'", mock.isSupported, !mock.isSupported)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package task
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -25,6 +26,7 @@ import (
 	stringer "github.com/openebs/maya/pkg/apis/stringer/v1alpha1"
 	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
 	templatefuncs "github.com/openebs/maya/pkg/templatefuncs/v1alpha1"
+	trace "github.com/openebs/maya/pkg/trace/v1alpha1"
 	"github.com/openebs/maya/pkg/util"
 )
 
@@ -58,6 +60,9 @@ type TaskGroupRunner struct {
 	// rollbacks is an array of task executor that need to be run in
 	// sequence in the event of any error
 	rollbacks []*executor
+	// ctx has the span of the caller e.g. cas template engine under
+	// which the tasks are traced
+	ctx context.Context
 }
 
 // NewTaskGroupRunner returns a new task group.
@@ -65,6 +70,12 @@ func NewTaskGroupRunner() *TaskGroupRunner {
 	return &TaskGroupRunner{}
 }
 
+// SetContext sets the context whose span traces the tasks run by
+// this group runner
+func (m *TaskGroupRunner) SetContext(ctx context.Context) {
+	m.ctx = ctx
+}
+
 // AddRunTask adds a task to the list of tasks to be run by this group runner.
 func (m *TaskGroupRunner) AddRunTask(runtask *v1alpha1.RunTask) (err error) {
 	if runtask == nil {
@@ -192,11 +203,19 @@ func (m *TaskGroupRunner) fallback(values map[string]interface{}) (output []byte
 
 // runATask will run a task based on the task specs & template values
 func (m *TaskGroupRunner) runATask(runtask *v1alpha1.RunTask, values map[string]interface{}) (err error) {
+	ctx, span := trace.Start(m.ctx, "runtask.Execute", trace.String("runtask", runtask.Name))
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
 	te, err := newExecutor(runtask, values)
 	if err != nil {
 		// log with verbose details
 		return errors.Wrap(err, "failed to execute runtask: failed to init executor")
 	}
+	span.SetAttributes(trace.String("runtask.identity", te.getTaskIdentity()))
+	te.MetaExec.withContext(ctx)
 
 	// check if the task ID is unique in this group
 	if !m.isTaskIDUnique(te.getTaskIdentity()) {
@@ -0,0 +1,347 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	env "github.com/openebs/maya/pkg/env/v1alpha1"
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+)
+
+const (
+	// ExporterOTLP exports the spans to an OTLP/HTTP endpoint
+	ExporterOTLP = "otlp"
+	// ExporterStdout writes the spans to stdout
+	ExporterStdout = "stdout"
+
+	// otlpTracesPath is the path of OTLP/HTTP endpoint that
+	// receives the spans
+	otlpTracesPath = "/v1/traces"
+
+	queueSize     = 2048
+	batchSize     = 512
+	batchInterval = 5 * time.Second
+	exportTimeout = 10 * time.Second
+
+	// otlp status code of a span that recorded an error
+	otlpStatusError = 2
+)
+
+// Exporter exports a batch of ended spans of the given service
+type Exporter interface {
+	Export(service string, spans []SpanData) error
+}
+
+type tracer struct {
+	processor *processor
+}
+
+var (
+	tracerLock   sync.RWMutex
+	globalTracer *tracer
+)
+
+func getTracer() *tracer {
+	tracerLock.RLock()
+	defer tracerLock.RUnlock()
+	return globalTracer
+}
+
+// Enabled returns true if tracing is initialized
+func Enabled() bool {
+	return getTracer() != nil
+}
+
+// Init initializes tracing of the given service with the exporter
+// set in the environment. Tracing stays disabled if no exporter
+// is set. The returned function flushes the pending spans and
+// disables tracing.
+func Init(service string) (func(), error) {
+	exporter, err := exporterFromEnv()
+	if err != nil {
+		return func() {}, err
+	}
+	if exporter == nil {
+		return func() {}, nil
+	}
+	glog.Infof("tracing of %s is enabled: exporter {%s}", service, env.Get(env.TraceExporter))
+	return InitWithExporter(service, exporter), nil
+}
+
+// InitWithExporter initializes tracing of the given service with
+// the given exporter, see Init
+func InitWithExporter(service string, exporter Exporter) func() {
+	p := newProcessor(service, exporter)
+	tracerLock.Lock()
+	globalTracer = &tracer{processor: p}
+	tracerLock.Unlock()
+	return func() {
+		tracerLock.Lock()
+		if globalTracer != nil && globalTracer.processor == p {
+			globalTracer = nil
+		}
+		tracerLock.Unlock()
+		p.shutdown()
+	}
+}
+
+func exporterFromEnv() (Exporter, error) {
+	switch name := strings.ToLower(strings.TrimSpace(env.Get(env.TraceExporter))); name {
+	case "":
+		return nil, nil
+	case ExporterStdout:
+		return NewStdoutExporter(os.Stdout), nil
+	case ExporterOTLP:
+		endpoint := env.Get(env.TraceOTLPEndpoint)
+		if endpoint == "" {
+			return nil, errors.Errorf("failed to init otlp trace exporter: %s is not set", env.TraceOTLPEndpoint)
+		}
+		return NewOTLPExporter(endpoint), nil
+	default:
+		return nil, errors.Errorf("invalid trace exporter {%s}: supported exporters are {%s, %s}",
+			name, ExporterOTLP, ExporterStdout)
+	}
+}
+
+// processor batches the ended spans and exports them in the
+// background
+type processor struct {
+	service  string
+	exporter Exporter
+	queue    chan SpanData
+	flush    chan chan struct{}
+	done     chan struct{}
+	once     sync.Once
+}
+
+func newProcessor(service string, exporter Exporter) *processor {
+	p := &processor{
+		service:  service,
+		exporter: exporter,
+		queue:    make(chan SpanData, queueSize),
+		flush:    make(chan chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+// enqueue queues the span for export. The span is dropped if the
+// queue is full so that tracing never blocks the traced operation.
+func (p *processor) enqueue(s SpanData) {
+	select {
+	case <-p.done:
+	case p.queue <- s:
+	default:
+		glog.V(4).Infof("dropped span {%s}: trace queue is full", s.Name)
+	}
+}
+
+func (p *processor) run() {
+	ticker := time.NewTicker(batchInterval)
+	defer ticker.Stop()
+	batch := make([]SpanData, 0, batchSize)
+	export := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := p.exporter.Export(p.service, batch); err != nil {
+			glog.Errorf("failed to export %d spans: %v", len(batch), err)
+		}
+		batch = make([]SpanData, 0, batchSize)
+	}
+	for {
+		select {
+		case s := <-p.queue:
+			batch = append(batch, s)
+			if len(batch) >= batchSize {
+				export()
+			}
+		case <-ticker.C:
+			export()
+		case flushed := <-p.flush:
+			for n := len(p.queue); n > 0; n-- {
+				batch = append(batch, <-p.queue)
+			}
+			export()
+			close(flushed)
+			return
+		}
+	}
+}
+
+// shutdown exports the queued spans and stops the processor
+func (p *processor) shutdown() {
+	p.once.Do(func() {
+		close(p.done)
+		flushed := make(chan struct{})
+		p.flush <- flushed
+		<-flushed
+	})
+}
+
+// otlp json encoding of the spans as defined by
+// opentelemetry-proto collector/trace/v1 ExportTraceServiceRequest
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              SpanKind        `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            *otlpStatus     `json:"status,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+func newOTLPRequest(service string, spans []SpanData) otlpRequest {
+	scope := otlpScopeSpans{Scope: otlpScope{Name: "github.com/openebs/maya"}}
+	for _, s := range spans {
+		span := otlpSpan{
+			TraceID:           s.Context.TraceID.String(),
+			SpanID:            s.Context.SpanID.String(),
+			Name:              s.Name,
+			Kind:              s.Kind,
+			StartTimeUnixNano: strconv.FormatInt(s.Start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.End.UnixNano(), 10),
+		}
+		if s.Parent.IsValid() {
+			span.ParentSpanID = s.Parent.String()
+		}
+		for _, a := range s.Attributes {
+			span.Attributes = append(span.Attributes, otlpAttribute{Key: a.Key, Value: otlpValue{a.Value}})
+		}
+		if s.Error != "" {
+			span.Status = &otlpStatus{Code: otlpStatusError, Message: s.Error}
+		}
+		scope.Spans = append(scope.Spans, span)
+	}
+	return otlpRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: []otlpAttribute{{Key: "service.name", Value: otlpValue{service}}},
+			},
+			ScopeSpans: []otlpScopeSpans{scope},
+		}},
+	}
+}
+
+// OTLPExporter exports the spans to an OTLP/HTTP endpoint in
+// json encoding
+type OTLPExporter struct {
+	url    string
+	client *http.Client
+}
+
+// NewOTLPExporter returns a new instance of OTLPExporter that
+// exports to the given endpoint e.g. http://otel-collector:4318
+func NewOTLPExporter(endpoint string) *OTLPExporter {
+	return &OTLPExporter{
+		url:    strings.TrimSuffix(endpoint, "/") + otlpTracesPath,
+		client: &http.Client{Timeout: exportTimeout},
+	}
+}
+
+// Export posts the given spans to the OTLP/HTTP endpoint
+func (e *OTLPExporter) Export(service string, spans []SpanData) error {
+	body, err := json.Marshal(newOTLPRequest(service, spans))
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal spans")
+	}
+	resp, err := e.client.Post(e.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "failed to post spans to {%s}", e.url)
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("failed to post spans to {%s}: status {%s}", e.url, resp.Status)
+	}
+	return nil
+}
+
+// StdoutExporter writes the spans in OTLP json encoding, one
+// batch per line
+type StdoutExporter struct {
+	sync.Mutex
+	w io.Writer
+}
+
+// NewStdoutExporter returns a new instance of StdoutExporter
+// that writes to the given writer
+func NewStdoutExporter(w io.Writer) *StdoutExporter {
+	return &StdoutExporter{w: w}
+}
+
+// Export writes the given spans
+func (e *StdoutExporter) Export(service string, spans []SpanData) error {
+	body, err := json.Marshal(newOTLPRequest(service, spans))
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal spans")
+	}
+	e.Lock()
+	defer e.Unlock()
+	_, err = e.w.Write(append(body, '\n'))
+	return err
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+)
+
+const (
+	// TraceParentHeader is the w3c trace context header that
+	// propagates the span of the caller
+	TraceParentHeader = "traceparent"
+
+	// traceParentVersion is the only supported version of
+	// traceparent header
+	traceParentVersion = "00"
+	// sampledFlag is set as all the spans are exported
+	sampledFlag = "01"
+)
+
+// TraceParent returns the traceparent header value of the given
+// span context e.g.
+// 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+func TraceParent(sc SpanContext) string {
+	return fmt.Sprintf("%s-%s-%s-%s",
+		traceParentVersion, sc.TraceID, sc.SpanID, sampledFlag)
+}
+
+// ParseTraceParent returns the span context of the given
+// traceparent header value
+func ParseTraceParent(value string) (SpanContext, error) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) != 4 || parts[0] != traceParentVersion {
+		return SpanContext{}, errors.Errorf("invalid traceparent {%s}", value)
+	}
+	sc := SpanContext{}
+	if err := decodeHex(parts[1], sc.TraceID[:]); err != nil {
+		return SpanContext{}, errors.Wrapf(err, "invalid trace id of traceparent {%s}", value)
+	}
+	if err := decodeHex(parts[2], sc.SpanID[:]); err != nil {
+		return SpanContext{}, errors.Wrapf(err, "invalid span id of traceparent {%s}", value)
+	}
+	if !sc.IsValid() {
+		return SpanContext{}, errors.Errorf("invalid traceparent {%s}: ids are zero", value)
+	}
+	return sc, nil
+}
+
+func decodeHex(s string, dst []byte) error {
+	if len(s) != 2*len(dst) {
+		return errors.Errorf("expected %d hex digits: got %d", 2*len(dst), len(s))
+	}
+	_, err := hex.Decode(dst, []byte(s))
+	return err
+}
+
+// inject sets the traceparent header of the span in the given
+// context on the given headers
+func inject(ctx context.Context, header http.Header) {
+	if sc := SpanContextFromContext(ctx); sc.IsValid() {
+		header.Set(TraceParentHeader, TraceParent(sc))
+	}
+}
+
+// Extract returns the context containing the span of the caller
+// propagated via the traceparent header. The given context is
+// returned if the header is missing or invalid.
+func Extract(ctx context.Context, header http.Header) context.Context {
+	traceParent := header.Get(TraceParentHeader)
+	if traceParent == "" {
+		return ctx
+	}
+	sc, err := ParseTraceParent(traceParent)
+	if err != nil {
+		return ctx
+	}
+	return ContextWithRemoteSpanContext(ctx, sc)
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha1 provides spans that are compatible with
+// opentelemetry. Spans are propagated across processes via the
+// w3c traceparent header and are exported via OTLP/HTTP or to
+// stdout.
+package v1alpha1
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// SpanKind is the kind of span as defined by opentelemetry
+type SpanKind int
+
+const (
+	// SpanKindInternal is the kind of span of an operation
+	// within the process
+	SpanKindInternal SpanKind = 1
+	// SpanKindServer is the kind of span of a request served
+	// by the process
+	SpanKindServer SpanKind = 2
+	// SpanKindClient is the kind of span of a request sent by
+	// the process e.g. kubernetes api calls
+	SpanKindClient SpanKind = 3
+)
+
+// TraceID identifies a trace
+type TraceID [16]byte
+
+// SpanID identifies a span of a trace
+type SpanID [8]byte
+
+// String returns the hex encoding of trace id
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+// IsValid returns true if trace id is not all zeros
+func (t TraceID) IsValid() bool {
+	return t != TraceID{}
+}
+
+// String returns the hex encoding of span id
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+// IsValid returns true if span id is not all zeros
+func (s SpanID) IsValid() bool {
+	return s != SpanID{}
+}
+
+// SpanContext identifies a span across processes
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+}
+
+// IsValid returns true if both trace & span ids are valid
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// Attribute is a key value pair set on a span
+type Attribute struct {
+	Key   string
+	Value string
+}
+
+// String returns an attribute with the given key & value
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Span is an operation of a trace. A nil span is valid and
+// is returned if tracing is not enabled, hence all methods of
+// span are nil safe.
+type Span struct {
+	sync.Mutex
+	tracer     *tracer
+	name       string
+	kind       SpanKind
+	context    SpanContext
+	parent     SpanID
+	start      time.Time
+	end        time.Time
+	attributes []Attribute
+	err        string
+	ended      bool
+}
+
+// SpanData is the snapshot of an ended span that is exported
+type SpanData struct {
+	Name       string
+	Kind       SpanKind
+	Context    SpanContext
+	Parent     SpanID
+	Start      time.Time
+	End        time.Time
+	Attributes []Attribute
+	Error      string
+}
+
+type spanKey struct{}
+type remoteKey struct{}
+
+// Start starts a span of the given name as a child of the span
+// or the remote span in the given context. It returns the
+// context containing the started span.
+func Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, *Span) {
+	return StartWithKind(ctx, SpanKindInternal, name, attrs...)
+}
+
+// StartWithKind starts a span of the given kind, see Start
+func StartWithKind(
+	ctx context.Context,
+	kind SpanKind,
+	name string,
+	attrs ...Attribute,
+) (context.Context, *Span) {
+	t := getTracer()
+	if t == nil {
+		return ctx, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	s := &Span{
+		tracer:     t,
+		name:       name,
+		kind:       kind,
+		start:      time.Now(),
+		attributes: attrs,
+	}
+	if parent := SpanContextFromContext(ctx); parent.IsValid() {
+		s.context.TraceID = parent.TraceID
+		s.parent = parent.SpanID
+	} else {
+		rand.Read(s.context.TraceID[:])
+	}
+	rand.Read(s.context.SpanID[:])
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+// FromContext returns the span in the given context
+func FromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	s, _ := ctx.Value(spanKey{}).(*Span)
+	return s
+}
+
+// SpanContextFromContext returns the span context of the span
+// in the given context or else of the remote span extracted
+// into the given context
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	if s := FromContext(ctx); s != nil {
+		return s.Context()
+	}
+	if ctx == nil {
+		return SpanContext{}
+	}
+	sc, _ := ctx.Value(remoteKey{}).(SpanContext)
+	return sc
+}
+
+// ContextWithRemoteSpanContext returns a context containing the
+// span context of a span of another process
+func ContextWithRemoteSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	if !sc.IsValid() {
+		return ctx
+	}
+	return context.WithValue(ctx, remoteKey{}, sc)
+}
+
+// Context returns the span context of the span
+func (s *Span) Context() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.context
+}
+
+// SetAttributes sets the given attributes on the span
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.attributes = append(s.attributes, attrs...)
+}
+
+// RecordError sets the status of the span to error if the
+// given error is not nil
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.err = err.Error()
+}
+
+// End ends the span & queues it for export. Only the first
+// call to end is considered.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	if s.ended {
+		s.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	data := SpanData{
+		Name:       s.name,
+		Kind:       s.kind,
+		Context:    s.context,
+		Parent:     s.parent,
+		Start:      s.start,
+		End:        s.end,
+		Attributes: append([]Attribute(nil), s.attributes...),
+		Error:      s.err,
+	}
+	s.Unlock()
+	s.tracer.processor.enqueue(data)
+}
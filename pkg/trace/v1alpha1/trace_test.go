@@ -0,0 +1,197 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+type fakeExporter struct {
+	sync.Mutex
+	spans []SpanData
+}
+
+func (f *fakeExporter) Export(service string, spans []SpanData) error {
+	f.Lock()
+	defer f.Unlock()
+	f.spans = append(f.spans, spans...)
+	return nil
+}
+
+func TestParseTraceParent(t *testing.T) {
+	cases := map[string]struct {
+		value string
+		isErr bool
+	}{
+		"valid traceparent": {value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		"invalid version":   {value: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", isErr: true},
+		"invalid trace id":  {value: "00-4bf92f3577b34da6-00f067aa0ba902b7-01", isErr: true},
+		"invalid span id":   {value: "00-4bf92f3577b34da6a3ce929d0e0e4736-zzf067aa0ba902b7-01", isErr: true},
+		"zero trace id":     {value: "00-00000000000000000000000000000000-00f067aa0ba902b7-01", isErr: true},
+		"missing flags":     {value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7", isErr: true},
+		"empty traceparent": {value: "", isErr: true},
+	}
+	for name, tt := range cases {
+		name, tt := name, tt
+		t.Run(name, func(t *testing.T) {
+			sc, err := ParseTraceParent(tt.value)
+			if tt.isErr != (err != nil) {
+				t.Fatalf("Test %q failed: expected error '%t': actual error '%v'", name, tt.isErr, err)
+			}
+			if !tt.isErr && TraceParent(sc) != tt.value {
+				t.Fatalf("Test %q failed: expected '%s': actual '%s'", name, tt.value, TraceParent(sc))
+			}
+		})
+	}
+}
+
+func TestStartDisabled(t *testing.T) {
+	ctx, span := Start(context.Background(), "disabled")
+	if span != nil {
+		t.Fatalf("expected nil span when tracing is disabled: actual '%#v'", span)
+	}
+	// nil span must be safe to use
+	span.SetAttributes(String("key", "value"))
+	span.RecordError(errors.New("error"))
+	span.End()
+	if FromContext(ctx) != nil {
+		t.Fatalf("expected no span in context when tracing is disabled")
+	}
+}
+
+func TestPropagation(t *testing.T) {
+	exporter := &fakeExporter{}
+	shutdown := InitWithExporter("test", exporter)
+
+	// caller process
+	ctx, caller := Start(context.Background(), "caller")
+	header := http.Header{}
+	inject(ctx, header)
+	caller.End()
+
+	// server process
+	ctx = Extract(context.Background(), header)
+	ctx, server := StartWithKind(ctx, SpanKindServer, "server")
+	_, child := Start(ctx, "child", String("key", "value"))
+	child.RecordError(errors.New("failed"))
+	child.End()
+	server.End()
+	shutdown()
+
+	if Enabled() {
+		t.Fatalf("expected tracing to be disabled after shutdown")
+	}
+	if len(exporter.spans) != 3 {
+		t.Fatalf("expected 3 spans to be exported: actual '%d'", len(exporter.spans))
+	}
+	// spans are exported in the order they ended
+	c, ch, s := exporter.spans[0], exporter.spans[1], exporter.spans[2]
+	if c.Context.TraceID != s.Context.TraceID || s.Context.TraceID != ch.Context.TraceID {
+		t.Fatalf("expected spans of same trace: actual '%s', '%s', '%s'",
+			c.Context.TraceID, s.Context.TraceID, ch.Context.TraceID)
+	}
+	if s.Parent != c.Context.SpanID {
+		t.Fatalf("expected parent of server span '%s': actual '%s'", c.Context.SpanID, s.Parent)
+	}
+	if ch.Parent != s.Context.SpanID {
+		t.Fatalf("expected parent of child span '%s': actual '%s'", s.Context.SpanID, ch.Parent)
+	}
+	if ch.Error != "failed" || len(ch.Attributes) != 1 {
+		t.Fatalf("expected error & attribute of child span: actual '%#v'", ch)
+	}
+}
+
+func TestOTLPExporter(t *testing.T) {
+	var (
+		path string
+		req  otlpRequest
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		body, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	shutdown := InitWithExporter("maya-apiserver", NewOTLPExporter(server.URL))
+	ctx, parent := Start(context.Background(), "parent")
+	_, child := StartWithKind(ctx, SpanKindClient, "child")
+	child.RecordError(errors.New("failed"))
+	child.End()
+	parent.End()
+	shutdown()
+
+	if path != otlpTracesPath {
+		t.Fatalf("expected spans to be posted to '%s': actual '%s'", otlpTracesPath, path)
+	}
+	if len(req.ResourceSpans) != 1 || len(req.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Fatalf("expected one resource & scope: actual '%#v'", req)
+	}
+	if got := req.ResourceSpans[0].Resource.Attributes[0].Value.StringValue; got != "maya-apiserver" {
+		t.Fatalf("expected service name 'maya-apiserver': actual '%s'", got)
+	}
+	spans := req.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans: actual '%d'", len(spans))
+	}
+	if spans[0].Kind != SpanKindClient || spans[0].Status == nil || spans[0].Status.Code != otlpStatusError {
+		t.Fatalf("expected client span with error status: actual '%#v'", spans[0])
+	}
+	if spans[0].ParentSpanID != spans[1].SpanID || spans[1].ParentSpanID != "" {
+		t.Fatalf("expected child of root span: actual '%#v'", spans)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(TraceParentHeader)
+	}))
+	defer server.Close()
+
+	exporter := &fakeExporter{}
+	shutdown := InitWithExporter("test", exporter)
+	ctx, span := Start(context.Background(), "parent")
+	client := &http.Client{Transport: NewTransport(ctx, nil)}
+	resp, err := client.Get(server.URL + "/api/v1/pods")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	span.End()
+	shutdown()
+
+	if len(exporter.spans) != 2 {
+		t.Fatalf("expected 2 spans to be exported: actual '%d'", len(exporter.spans))
+	}
+	sc, err := ParseTraceParent(got)
+	if err != nil {
+		t.Fatalf("expected traceparent header to be propagated: %v", err)
+	}
+	if sc != exporter.spans[0].Context || exporter.spans[0].Parent != span.Context().SpanID {
+		t.Fatalf("expected traceparent of client span: actual '%s'", got)
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2019 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
+)
+
+// transport starts a client span for every request e.g.
+// kubernetes api calls and propagates the span to the server
+type transport struct {
+	ctx  context.Context
+	base http.RoundTripper
+}
+
+// NewTransport returns a round tripper that traces the requests
+// sent via the given round tripper as children of the span in
+// the given context
+func NewTransport(ctx context.Context, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{ctx: ctx, base: base}
+}
+
+// WrapTransport returns a function that wraps a round tripper
+// via NewTransport. It is meant to be set as WrapTransport of
+// kubernetes rest config.
+func WrapTransport(ctx context.Context) func(http.RoundTripper) http.RoundTripper {
+	return func(base http.RoundTripper) http.RoundTripper {
+		return NewTransport(ctx, base)
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := StartWithKind(t.ctx, SpanKindClient, req.Method+" "+req.URL.Path,
+		String("http.method", req.Method),
+		String("http.url", req.URL.String()),
+	)
+	defer span.End()
+
+	// request must not be modified by round tripper
+	req = req.WithContext(req.Context())
+	req.Header = cloneHeader(req.Header)
+	inject(ctx, req.Header)
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		return resp, err
+	}
+	span.SetAttributes(String("http.status_code", strconv.Itoa(resp.StatusCode)))
+	if resp.StatusCode >= http.StatusInternalServerError {
+		span.RecordError(errors.Errorf("%s", resp.Status))
+	}
+	return resp, nil
+}
+
+func cloneHeader(h http.Header) http.Header {
+	c := make(http.Header, len(h))
+	for k, v := range h {
+		c[k] = append([]string(nil), v...)
+	}
+	return c
+}
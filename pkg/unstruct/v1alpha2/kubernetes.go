@@ -18,6 +18,7 @@ package v1alpha2
 
 import (
 	"strings"
+	"sync"
 
 	k8s "github.com/openebs/maya/pkg/client/k8s/v1alpha1"
 	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
@@ -25,6 +26,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 )
 
@@ -36,6 +39,10 @@ type getClientsetFn func() (clientset dynamic.Interface, err error)
 // abstracts fetching of clientset from kubeConfigPath
 type getClientsetForPathFn func(kubeConfigPath string) (clientset dynamic.Interface, err error)
 
+// getDiscoveryFn is a typed function that
+// abstracts fetching of discovery client
+type getDiscoveryFn func() (discovery.DiscoveryInterface, error)
+
 // CreateFn is a typed function that abstracts
 // creating of unstructured object
 type CreateFn func(
@@ -60,6 +67,25 @@ type DeleteFn func(
 	opt *DeleteOption,
 ) error
 
+// ListFn is a typed function that abstracts
+// listing of unstructured objects
+type ListFn func(
+	cli dynamic.Interface,
+	namespace string,
+	opt *ListOption,
+) (*unstructured.UnstructuredList, error)
+
+// PatchFn is a typed function that abstracts
+// patching of unstructured object
+type PatchFn func(
+	cli dynamic.Interface,
+	name string,
+	namespace string,
+	pt types.PatchType,
+	data []byte,
+	opt *PatchOption,
+) (*unstructured.Unstructured, error)
+
 // Kubeclient enables kubernetes API operations on catalog
 // instance
 type Kubeclient struct {
@@ -68,23 +94,58 @@ type Kubeclient struct {
 	// make kubernetes API calls
 	clientset dynamic.Interface
 
+	// discovery refers to the client that
+	// will be responsible to discover the
+	// resources supported by kubernetes
+	discovery discovery.DiscoveryInterface
+
 	// Kubeconfig path to get kubernetes clientset
 	kubeConfigPath string
 
+	// resources caches the discovered
+	// resources against their kind
+	resources *ResourceCache
+
+	// mutex guards the lazy init of
+	// clientset & discovery client
+	mutex sync.Mutex
+
 	// functions useful during mocking
 	getClientset        getClientsetFn
 	getClientsetForPath getClientsetForPathFn
+	getDiscovery        getDiscoveryFn
 	create              CreateFn
 	get                 GetFn
+	list                ListFn
+	patch               PatchFn
 	delete              DeleteFn
 }
 
+// ResourceCache caches the resources discovered from
+// kubernetes cluster against their kind. It is safe for
+// concurrent use & can be shared by the Kubeclient
+// instances of the same cluster.
+type ResourceCache struct {
+	sync.Mutex
+	resources map[schema.GroupVersionKind]metav1.APIResource
+}
+
+// NewResourceCache returns a new instance of ResourceCache
+func NewResourceCache() *ResourceCache {
+	return &ResourceCache{
+		resources: map[schema.GroupVersionKind]metav1.APIResource{},
+	}
+}
+
 // KubeclientBuildOption defines the abstraction to build
 // a Kubeclient instance
 type KubeclientBuildOption func(*Kubeclient)
 
 // withDefaults sets default options for Kubeclient
 func withDefaults(k *Kubeclient) {
+	if k.resources == nil {
+		k.resources = NewResourceCache()
+	}
 	if k.clientset == nil {
 		k.getClientset = func() (dynamic.Interface, error) {
 			return client.New().Dynamic()
@@ -95,6 +156,40 @@ func withDefaults(k *Kubeclient) {
 			return client.New(client.WithKubeConfigPath(k.kubeConfigPath)).Dynamic()
 		}
 	}
+	if k.getDiscovery == nil {
+		k.getDiscovery = func() (discovery.DiscoveryInterface, error) {
+			cs, err := client.New(client.WithKubeConfigPath(k.kubeConfigPath)).Clientset()
+			if err != nil {
+				return nil, err
+			}
+			return cs.Discovery(), nil
+		}
+	}
+	if k.list == nil {
+		k.list = func(
+			cli dynamic.Interface,
+			namespace string,
+			opt *ListOption) (*unstructured.UnstructuredList, error) {
+			return cli.
+				Resource(opt.gvr).
+				Namespace(namespace).
+				List(*opt.ListOptions)
+		}
+	}
+	if k.patch == nil {
+		k.patch = func(
+			cli dynamic.Interface,
+			name string,
+			namespace string,
+			pt types.PatchType,
+			data []byte,
+			opt *PatchOption) (*unstructured.Unstructured, error) {
+			return cli.
+				Resource(opt.gvr).
+				Namespace(namespace).
+				Patch(name, pt, data, *opt.UpdateOptions, opt.subresources...)
+		}
+	}
 	if k.get == nil {
 		k.get = func(
 			cli dynamic.Interface,
@@ -111,8 +206,12 @@ func withDefaults(k *Kubeclient) {
 			cli dynamic.Interface,
 			obj *unstructured.Unstructured,
 			opt *CreateOption) (*unstructured.Unstructured, error) {
+			gvr := opt.gvr
+			if gvr.Empty() {
+				gvr = k8s.GroupVersionResourceFromGVK(obj)
+			}
 			return cli.
-				Resource(k8s.GroupVersionResourceFromGVK(obj)).
+				Resource(gvr).
 				Namespace(obj.GetNamespace()).
 				Create(obj, *opt.CreateOptions, opt.subresources...)
 		}
@@ -121,8 +220,12 @@ func withDefaults(k *Kubeclient) {
 		k.delete = func(
 			cli dynamic.Interface,
 			obj *unstructured.Unstructured, opt *DeleteOption) error {
+			gvr := opt.gvr
+			if gvr.Empty() {
+				gvr = k8s.GroupVersionResourceFromGVK(obj)
+			}
 			return cli.
-				Resource(k8s.GroupVersionResourceFromGVK(obj)).
+				Resource(gvr).
 				Namespace(obj.GetNamespace()).
 				Delete(obj.GetName(), opt.DeleteOptions, opt.subresources...)
 		}
@@ -137,6 +240,22 @@ func WithClient(c dynamic.Interface) KubeclientBuildOption {
 	}
 }
 
+// WithDiscoveryClient sets the kubernetes discovery
+// client against the Kubeclient instance
+func WithDiscoveryClient(d discovery.DiscoveryInterface) KubeclientBuildOption {
+	return func(k *Kubeclient) {
+		k.discovery = d
+	}
+}
+
+// WithResourceCache sets the cache of discovered
+// resources against the Kubeclient instance
+func WithResourceCache(c *ResourceCache) KubeclientBuildOption {
+	return func(k *Kubeclient) {
+		k.resources = c
+	}
+}
+
 // WithKubeConfigPath sets kubeconfig path
 // against this client instance
 func WithKubeConfigPath(kubeConfigPath string) KubeclientBuildOption {
@@ -167,6 +286,9 @@ func (k *Kubeclient) getClientsetForPathOrDirect() (dynamic.Interface, error) {
 // getClientsetOrCached returns either a new instance
 // of kubernetes client or its cached copy
 func (k *Kubeclient) getClientsetOrCached() (dynamic.Interface, error) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
 	if k.clientset != nil {
 		return k.clientset, nil
 	}
@@ -179,6 +301,69 @@ func (k *Kubeclient) getClientsetOrCached() (dynamic.Interface, error) {
 	return k.clientset, nil
 }
 
+// getDiscoveryOrCached returns either a new instance
+// of discovery client or its cached copy
+func (k *Kubeclient) getDiscoveryOrCached() (discovery.DiscoveryInterface, error) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	if k.discovery != nil {
+		return k.discovery, nil
+	}
+	d, err := k.getDiscovery()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get discovery client")
+	}
+
+	k.discovery = d
+	return k.discovery, nil
+}
+
+// ResourceFor returns the kubernetes resource of the
+// given kind as discovered from kubernetes cluster. The
+// discovered resources are cached.
+func (k *Kubeclient) ResourceFor(gvk schema.GroupVersionKind) (*metav1.APIResource, error) {
+	if gvk.Kind == "" || gvk.Version == "" {
+		return nil, errors.Errorf("failed to get resource: invalid kind {%s}", gvk)
+	}
+
+	k.resources.Lock()
+	defer k.resources.Unlock()
+
+	if r, ok := k.resources.resources[gvk]; ok {
+		return &r, nil
+	}
+	d, err := k.getDiscoveryOrCached()
+	if err != nil {
+		return nil, err
+	}
+	list, err := d.ServerResourcesForGroupVersion(gvk.GroupVersion().String())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get resource for kind {%s}", gvk)
+	}
+	for _, r := range list.APIResources {
+		// skip subresources e.g. deployments/status
+		if r.Kind != gvk.Kind || strings.Contains(r.Name, "/") {
+			continue
+		}
+		r.Group = gvk.Group
+		r.Version = gvk.Version
+		k.resources.resources[gvk] = r
+		return &r, nil
+	}
+	return nil, errors.Errorf("failed to get resource for kind {%s}: resource not found", gvk)
+}
+
+// GroupVersionResource returns the group version resource
+// of the given kubernetes resource
+func GroupVersionResource(r *metav1.APIResource) schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group:    r.Group,
+		Version:  r.Version,
+		Resource: r.Name,
+	}
+}
+
 // Get returns an unstructured instance from kubernetes
 // cluster
 func (k *Kubeclient) Get(name string, opts ...GetOptionFn) (*unstructured.Unstructured, error) {
@@ -236,16 +421,56 @@ func (k *Kubeclient) DeleteAll(u ...*unstructured.Unstructured) []error {
 // Create creates an unstructured instance at
 // kubernetes cluster
 func (k *Kubeclient) Create(u *unstructured.Unstructured, opts ...CreateOptionFn) error {
+	_, err := k.CreateAndGet(u, opts...)
+	return err
+}
+
+// CreateAndGet creates an unstructured instance at
+// kubernetes cluster and returns the created instance
+func (k *Kubeclient) CreateAndGet(
+	u *unstructured.Unstructured,
+	opts ...CreateOptionFn,
+) (*unstructured.Unstructured, error) {
 	if u == nil {
-		return errors.Errorf("create failed: nil unstruct instance was provided")
+		return nil, errors.Errorf("create failed: nil unstruct instance was provided")
 	}
 	cli, err := k.getClientsetOrCached()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	cOptions := NewCreateOption(opts...)
-	_, err = k.create(cli, u, cOptions)
-	return err
+	return k.create(cli, u, cOptions)
+}
+
+// List returns a list of unstructured instances
+// from kubernetes cluster
+func (k *Kubeclient) List(opts ...ListOptionFn) (*unstructured.UnstructuredList, error) {
+	cli, err := k.getClientsetOrCached()
+	if err != nil {
+		return nil, err
+	}
+	lOptions := NewListOption(opts...)
+	return k.list(cli, lOptions.namespace, lOptions)
+}
+
+// Patch patches the unstructured instance at
+// kubernetes cluster and returns the patched
+// instance
+func (k *Kubeclient) Patch(
+	name string,
+	pt types.PatchType,
+	data []byte,
+	opts ...PatchOptionFn,
+) (*unstructured.Unstructured, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, errors.New("failed to patch unstructured instance: missing name")
+	}
+	cli, err := k.getClientsetOrCached()
+	if err != nil {
+		return nil, err
+	}
+	pOptions := NewPatchOption(opts...)
+	return k.patch(cli, name, pOptions.namespace, pt, data, pOptions)
 }
 
 // Delete deletes the unstructured instance from
@@ -315,10 +540,100 @@ func NewGetOption(gOpts ...GetOptionFn) *GetOption {
 	return opts
 }
 
+// ListOption holds the kubernetes options
+// to list a resource
+type ListOption struct {
+	namespace string
+	*metav1.ListOptions
+	gvr schema.GroupVersionResource
+}
+
+// ListOptionFn abstracts the construction of ListOption
+type ListOptionFn func(*ListOption)
+
+// WithListNamespace is a ListOptionFn to provide
+// namespace
+func WithListNamespace(namespace string) ListOptionFn {
+	return func(opt *ListOption) {
+		opt.namespace = namespace
+	}
+}
+
+// WithListOption is a ListOptionFn to provide
+// kubernetes listoption
+func WithListOption(listOption metav1.ListOptions) ListOptionFn {
+	return func(opt *ListOption) {
+		opt.ListOptions = &listOption
+	}
+}
+
+// WithListGroupVersionResource is a ListOptionFn to
+// provide GroupVersionResource
+func WithListGroupVersionResource(r schema.GroupVersionResource) ListOptionFn {
+	return func(opt *ListOption) {
+		opt.gvr = r
+	}
+}
+
+// NewListOption returns a new instance of ListOption
+func NewListOption(lOpts ...ListOptionFn) *ListOption {
+	opts := &ListOption{ListOptions: &metav1.ListOptions{}, gvr: schema.GroupVersionResource{}}
+	for _, o := range lOpts {
+		o(opts)
+	}
+	return opts
+}
+
+// PatchOption holds the kubernetes options
+// to patch a resource
+type PatchOption struct {
+	namespace string
+	*metav1.UpdateOptions
+	gvr          schema.GroupVersionResource
+	subresources []string
+}
+
+// PatchOptionFn abstracts the construction of PatchOption
+type PatchOptionFn func(*PatchOption)
+
+// WithPatchNamespace is a PatchOptionFn to provide
+// namespace
+func WithPatchNamespace(namespace string) PatchOptionFn {
+	return func(opt *PatchOption) {
+		opt.namespace = namespace
+	}
+}
+
+// WithPatchGroupVersionResource is a PatchOptionFn to
+// provide GroupVersionResource
+func WithPatchGroupVersionResource(r schema.GroupVersionResource) PatchOptionFn {
+	return func(opt *PatchOption) {
+		opt.gvr = r
+	}
+}
+
+// WithPatchSubResources is a PatchOptionFn to provide
+// subresources
+func WithPatchSubResources(r ...string) PatchOptionFn {
+	return func(opt *PatchOption) {
+		opt.subresources = r
+	}
+}
+
+// NewPatchOption returns a new instance of PatchOption
+func NewPatchOption(pOpts ...PatchOptionFn) *PatchOption {
+	opts := &PatchOption{UpdateOptions: &metav1.UpdateOptions{}, gvr: schema.GroupVersionResource{}}
+	for _, o := range pOpts {
+		o(opts)
+	}
+	return opts
+}
+
 // DeleteOption holds kubernetes options to delete a
 // resource
 type DeleteOption struct {
 	*metav1.DeleteOptions
+	gvr          schema.GroupVersionResource
 	subresources []string
 }
 
@@ -344,6 +659,14 @@ func WithDeleteOption(deleteOpt *metav1.DeleteOptions) DeleteOptionFn {
 	}
 }
 
+// WithDeleteGroupVersionResource is a DeleteOptionFn to
+// provide GroupVersionResource
+func WithDeleteGroupVersionResource(r schema.GroupVersionResource) DeleteOptionFn {
+	return func(opt *DeleteOption) {
+		opt.gvr = r
+	}
+}
+
 // WithDeleteSubResources is a DeleteOptionFn to provide
 // subresources during delete
 func WithDeleteSubResources(r ...string) DeleteOptionFn {
@@ -355,12 +678,13 @@ func WithDeleteSubResources(r ...string) DeleteOptionFn {
 // CreateOption holds the kubernetes option to create a resource
 type CreateOption struct {
 	*metav1.CreateOptions
+	gvr          schema.GroupVersionResource
 	subresources []string
 }
 
 // NewCreateOption returns a new instance of CreateOption
 func NewCreateOption(cOpts ...CreateOptionFn) *CreateOption {
-	opts := &CreateOption{&metav1.CreateOptions{}, []string{}}
+	opts := &CreateOption{CreateOptions: &metav1.CreateOptions{}, subresources: []string{}}
 	for _, o := range cOpts {
 		o(opts)
 	}
@@ -378,6 +702,14 @@ func WithCreateOption(opt metav1.CreateOptions) CreateOptionFn {
 	}
 }
 
+// WithCreateGroupVersionResource is CreateOptionFn to provide
+// GroupVersionResource
+func WithCreateGroupVersionResource(r schema.GroupVersionResource) CreateOptionFn {
+	return func(createOpt *CreateOption) {
+		createOpt.gvr = r
+	}
+}
+
 // WithCreateSubResources is CreateOptionFn to kubernetes
 // subresources during resource creation
 func WithCreateSubResources(r ...string) CreateOptionFn {
@@ -20,8 +20,13 @@ import (
 	errors "github.com/openebs/maya/pkg/errors/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	fakediscovery "k8s.io/client-go/discovery/fake"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
+	clienttesting "k8s.io/client-go/testing"
 )
 
 func fakeGetErr(cli dynamic.Interface, name, namespace string, opts *GetOption) (*unstructured.Unstructured, error) {
@@ -48,6 +53,48 @@ func fakeDeleteOk(cli dynamic.Interface, obj *unstructured.Unstructured, opts *D
 	return nil
 }
 
+func fakeListOk(cli dynamic.Interface, namespace string, opts *ListOption) (*unstructured.UnstructuredList, error) {
+	return &unstructured.UnstructuredList{}, nil
+}
+
+func fakeListErr(cli dynamic.Interface, namespace string, opts *ListOption) (*unstructured.UnstructuredList, error) {
+	return nil, errors.New("some error")
+}
+
+func fakePatchOk(cli dynamic.Interface, name, namespace string, pt types.PatchType, data []byte, opts *PatchOption) (*unstructured.Unstructured, error) {
+	return &unstructured.Unstructured{}, nil
+}
+
+func fakePatchErr(cli dynamic.Interface, name, namespace string, pt types.PatchType, data []byte, opts *PatchOption) (*unstructured.Unstructured, error) {
+	return nil, errors.New("some error")
+}
+
+func fakeGetDiscoveryErr() (discovery.DiscoveryInterface, error) {
+	return nil, errors.New("fake error")
+}
+
+func fakeDiscovery() *fakediscovery.FakeDiscovery {
+	return &fakediscovery.FakeDiscovery{
+		Fake: &clienttesting.Fake{
+			Resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "apps/v1",
+					APIResources: []metav1.APIResource{
+						{Name: "deployments/status", Kind: "Deployment", Namespaced: true},
+						{Name: "deployments", Kind: "Deployment", Namespaced: true},
+					},
+				},
+				{
+					GroupVersion: "storage.k8s.io/v1",
+					APIResources: []metav1.APIResource{
+						{Name: "storageclasses", Kind: "StorageClass"},
+					},
+				},
+			},
+		},
+	}
+}
+
 func fakeGetClientSetOk() (dynamic.Interface, error) {
 	return dynamic.NewForConfig(&rest.Config{})
 }
@@ -371,3 +418,159 @@ func TestKubernetesDeleteAll(t *testing.T) {
 		})
 	}
 }
+
+func TestKubernetesList(t *testing.T) {
+	tests := map[string]struct {
+		getClientSetFn getClientsetFn
+		list           ListFn
+		ExpectErr      bool
+	}{
+		"T1": {fakeGetClientSetOk, fakeListOk, false},
+		// Negative casses
+		"T2": {fakeGetClientSetErr, fakeListOk, true},
+		"T3": {fakeGetClientSetOk, fakeListErr, true},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			fc := &Kubeclient{
+				getClientset: mock.getClientSetFn,
+				list:         mock.list,
+			}
+			_, err := fc.List(WithListNamespace("default"), WithListOption(metav1.ListOptions{LabelSelector: "app=fake"}))
+			if mock.ExpectErr && err == nil {
+				t.Fatalf("Test %q failed: expected error not to be nil", name)
+			}
+			if !mock.ExpectErr && err != nil {
+				t.Fatalf("Test %q failed: expected error to be nil", name)
+			}
+		})
+	}
+}
+
+func TestKubernetesPatch(t *testing.T) {
+	tests := map[string]struct {
+		getClientSetFn getClientsetFn
+		patch          PatchFn
+		name           string
+		ExpectErr      bool
+	}{
+		"T1": {fakeGetClientSetOk, fakePatchOk, "fake-name", false},
+		// Negative casses
+		"T2": {fakeGetClientSetOk, fakePatchOk, "", true},
+		"T3": {fakeGetClientSetErr, fakePatchOk, "fake-name", true},
+		"T4": {fakeGetClientSetOk, fakePatchErr, "fake-name", true},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			fc := &Kubeclient{
+				getClientset: mock.getClientSetFn,
+				patch:        mock.patch,
+			}
+			_, err := fc.Patch(mock.name, types.MergePatchType, []byte("{}"), WithPatchNamespace("default"))
+			if mock.ExpectErr && err == nil {
+				t.Fatalf("Test %q failed: expected error not to be nil", name)
+			}
+			if !mock.ExpectErr && err != nil {
+				t.Fatalf("Test %q failed: expected error to be nil", name)
+			}
+		})
+	}
+}
+
+func TestKubernetesResourceFor(t *testing.T) {
+	tests := map[string]struct {
+		discovery          discovery.DiscoveryInterface
+		getDiscovery       getDiscoveryFn
+		gvk                schema.GroupVersionKind
+		expectedResource   string
+		expectedNamespaced bool
+		ExpectErr          bool
+	}{
+		"namespaced resource": {
+			discovery:          fakeDiscovery(),
+			gvk:                schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+			expectedResource:   "deployments",
+			expectedNamespaced: true,
+		},
+		"cluster scoped resource": {
+			discovery:        fakeDiscovery(),
+			gvk:              schema.GroupVersionKind{Group: "storage.k8s.io", Version: "v1", Kind: "StorageClass"},
+			expectedResource: "storageclasses",
+		},
+		"unknown kind": {
+			discovery: fakeDiscovery(),
+			gvk:       schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "StatefulSet"},
+			ExpectErr: true,
+		},
+		"unknown group version": {
+			discovery: fakeDiscovery(),
+			gvk:       schema.GroupVersionKind{Group: "apps", Version: "v1beta1", Kind: "Deployment"},
+			ExpectErr: true,
+		},
+		"missing kind": {
+			discovery: fakeDiscovery(),
+			gvk:       schema.GroupVersionKind{Version: "v1"},
+			ExpectErr: true,
+		},
+		"discovery error": {
+			getDiscovery: fakeGetDiscoveryErr,
+			gvk:          schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+			ExpectErr:    true,
+		},
+	}
+	for name, mock := range tests {
+		name, mock := name, mock
+		t.Run(name, func(t *testing.T) {
+			fc := &Kubeclient{
+				discovery:    mock.discovery,
+				getDiscovery: mock.getDiscovery,
+				resources:    NewResourceCache(),
+			}
+			r, err := fc.ResourceFor(mock.gvk)
+			if mock.ExpectErr && err == nil {
+				t.Fatalf("Test %q failed: expected error not to be nil", name)
+			}
+			if !mock.ExpectErr && err != nil {
+				t.Fatalf("Test %q failed: expected error to be nil: %v", name, err)
+			}
+			if mock.ExpectErr {
+				return
+			}
+			gvr := GroupVersionResource(r)
+			if gvr.Resource != mock.expectedResource || gvr.Group != mock.gvk.Group || gvr.Version != mock.gvk.Version {
+				t.Fatalf("Test %q failed: expected resource '%s': actual '%s'", name, mock.expectedResource, gvr)
+			}
+			if r.Namespaced != mock.expectedNamespaced {
+				t.Fatalf("Test %q failed: expected namespaced '%t': actual '%t'", name, mock.expectedNamespaced, r.Namespaced)
+			}
+			// cached resource is returned without discovery
+			fc.discovery = nil
+			fc.getDiscovery = fakeGetDiscoveryErr
+			if _, err := fc.ResourceFor(mock.gvk); err != nil {
+				t.Fatalf("Test %q failed: expected cached resource: %v", name, err)
+			}
+		})
+	}
+}
+
+func TestKubernetesResourceForSharedCache(t *testing.T) {
+	cache := NewResourceCache()
+	gvk := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	first := NewKubeClient(WithDiscoveryClient(fakeDiscovery()), WithResourceCache(cache))
+	if _, err := first.ResourceFor(gvk); err != nil {
+		t.Fatalf("expected resource to be discovered: %v", err)
+	}
+	// resource discovered by first client is returned by the
+	// second client without discovery
+	second := NewKubeClient(WithResourceCache(cache))
+	second.getDiscovery = fakeGetDiscoveryErr
+	r, err := second.ResourceFor(gvk)
+	if err != nil {
+		t.Fatalf("expected cached resource: %v", err)
+	}
+	if r.Name != "deployments" {
+		t.Fatalf("expected resource 'deployments': actual '%s'", r.Name)
+	}
+}
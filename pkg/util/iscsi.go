@@ -26,6 +26,7 @@ const (
 	IstgtStatusCmd       = "STATUS"
 	IstgtRefreshCmd      = "REFRESH"
 	IstgtReplicaCmd      = "REPLICA"
+	IstgtResizeCmd       = "RESIZE"
 	IstgtExecuteQuietCmd = "-q"
 	ReplicaStatus        = "Replica status"
 	WaitTimeForIscsi     = 3 * time.Second
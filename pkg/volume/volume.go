@@ -17,6 +17,7 @@ limitations under the License.
 package volume
 
 import (
+	"context"
 	"strings"
 
 	"github.com/ghodss/yaml"
@@ -36,6 +37,9 @@ import (
 type OperationOptions struct {
 	// k8sClient will make K8s API calls
 	k8sClient *m_k8s_client.K8sClient
+	// ctx has the span of the caller e.g. http request
+	// under which the operation is traced
+	ctx context.Context
 }
 
 // Operation exposes methods with respect to volume related operations
@@ -71,6 +75,14 @@ func NewOperation(volume *v1alpha1.CASVolume) (*Operation, error) {
 	}, nil
 }
 
+// WithContext traces the operation including its K8s API
+// calls as children of the span in the given context
+func (v *Operation) WithContext(ctx context.Context) *Operation {
+	v.ctx = ctx
+	v.k8sClient = v.k8sClient.WithContext(ctx)
+	return v
+}
+
 // getCloneLabels returns a map of clone specific configuration
 func (v *Operation) getCloneLabels() (map[string]interface{}, error) {
 	// Initially all the values are set to their defaults
@@ -190,6 +202,7 @@ func (v *Operation) Create() (*v1alpha1.CASVolume, error) {
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create volume: %s", v.volume)
 	}
+	engine.SetContext(v.ctx)
 
 	// create the volume
 	data, err := engine.Run()
@@ -207,6 +220,79 @@ func (v *Operation) Create() (*v1alpha1.CASVolume, error) {
 	return vol, nil
 }
 
+// DryRun renders the cas template that creates this volume without creating
+// any resource in the cluster. The cas template used to create volumes of the
+// storage class is rendered if castName is empty.
+//
+// NOTE:
+//  casConfigPVC is the cas config that would otherwise be fetched from the
+// volume's persistent volume claim
+func (v *Operation) DryRun(castName, casConfigPVC string) (*v1alpha1.CASTemplateDryRunStatus, error) {
+	if v.k8sClient == nil {
+		return nil, errors.Errorf("failed to dry run volume: nil k8s client: %s", v.volume)
+	}
+
+	capacity := v.volume.Spec.Capacity
+	if len(capacity) == 0 {
+		return nil, errors.Errorf("failed to dry run volume: missing volume capacity: %s", v.volume)
+	}
+
+	scName := v.volume.Labels[string(v1alpha1.StorageClassKey)]
+	if len(scName) == 0 {
+		return nil, errors.Errorf("failed to dry run volume: missing storage class label {%s}: %s", string(v1alpha1.StorageClassKey), v.volume)
+	}
+
+	cloneLabels, err := v.getCloneLabels()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dry run volume")
+	}
+	cloneLabels[string(v1alpha1.StorageClassVTP)] = scName
+
+	// fetch the storage class specifications
+	sc, err := v.k8sClient.GetStorageV1SC(scName, mach_apis_meta_v1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(errors.WithStack(err), "failed to dry run volume: %s", v.volume)
+	}
+	casConfigSC := sc.Annotations[string(v1alpha1.CASConfigKey)]
+
+	if len(castName) == 0 {
+		castName = getCreateCASTemplate("", sc)
+	}
+	if len(castName) == 0 {
+		return nil, errors.Errorf("failed to dry run volume: missing cas template: %s", v.volume)
+	}
+
+	cast, err := v.k8sClient.GetOEV1alpha1CAST(castName, mach_apis_meta_v1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(errors.WithStack(err), "failed to dry run volume: %s", v.volume)
+	}
+
+	volumeLabels := map[string]interface{}{
+		string(v1alpha1.OwnerVTP):                   v.volume.Name,
+		string(v1alpha1.CapacityVTP):                capacity,
+		string(v1alpha1.RunNamespaceVTP):            v.volume.Namespace,
+		string(v1alpha1.PersistentVolumeClaimVTP):   v.volume.Labels[string(v1alpha1.PersistentVolumeClaimKey)],
+		string(v1alpha1.IsRestoreVolumePropertyVTP): "false",
+	}
+
+	engine, err := NewVolumeEngine(
+		casConfigPVC,
+		casConfigSC,
+		cast,
+		string(v1alpha1.VolumeTLP),
+		util.MergeMaps(volumeLabels, cloneLabels),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dry run volume: %s", v.volume)
+	}
+
+	status, err := engine.DryRun()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dry run volume: %s", v.volume)
+	}
+	return status, nil
+}
+
 // Delete removes a CASVolume
 func (v *Operation) Delete() (*v1alpha1.CASVolume, error) {
 	if len(v.volume.Name) == 0 {
@@ -262,6 +348,7 @@ func (v *Operation) Delete() (*v1alpha1.CASVolume, error) {
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to delete volume: %s", v.volume)
 	}
+	engine.SetContext(v.ctx)
 
 	// delete volume
 	data, err := engine.Run()
@@ -349,6 +436,7 @@ func (v *Operation) Read() (*v1alpha1.CASVolume, error) {
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to read volume {%s}", v.volume.Name)
 	}
+	engine.SetContext(v.ctx)
 
 	// read volume details by executing engine
 	data, err := engine.Run()
@@ -18,6 +18,7 @@ limitations under the License.
 package volume
 
 import (
+	"context"
 	"strings"
 
 	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
@@ -131,3 +132,25 @@ func (c *volumeEngine) Run() (op []byte, err error) {
 	// delegate to generic cas template engine
 	return c.engine.Run()
 }
+
+// SetContext sets the context whose span traces
+// the cas template execution
+func (c *volumeEngine) SetContext(ctx context.Context) {
+	c.engine.SetContext(ctx)
+}
+
+// DryRun renders a CAS volume related operation
+// without executing it
+func (c *volumeEngine) DryRun() (status *v1alpha1.CASTemplateDryRunStatus, err error) {
+	m, err := cast.ConfigToMap(c.prepareFinalConfig())
+	if err != nil {
+		err = errors.Wrapf(err, "failed to dry run volume engine")
+		return
+	}
+
+	// set final config
+	c.engine.SetConfig(m)
+
+	// delegate to generic cas template engine
+	return c.engine.DryRun()
+}
@@ -25,8 +25,10 @@ import (
 
 	"github.com/golang/glog"
 	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	castemplate "github.com/openebs/maya/pkg/castemplate/v1alpha1"
 	clientset "github.com/openebs/maya/pkg/client/generated/clientset/versioned"
 	snapclient "github.com/openebs/maya/pkg/client/generated/openebs.io/snapshot/v1alpha1/clientset/internalclientset"
+	menv "github.com/openebs/maya/pkg/env/v1alpha1"
 	"k8s.io/api/admission/v1beta1"
 	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
 	v1 "k8s.io/api/apps/v1"
@@ -298,21 +300,110 @@ func (wh *webhook) validatePVCCreateRequest(req *v1beta1.AdmissionRequest) *v1be
 	return response
 }
 
+// lintResponse returns the admission response based on the lint issues
+// found in the requested object
+func lintResponse(req *v1beta1.AdmissionRequest, issues castemplate.LintIssueList) *v1beta1.AdmissionResponse {
+	response := &v1beta1.AdmissionResponse{}
+	response.Allowed = true
+
+	for _, issue := range issues {
+		glog.Infof("Lint issue for Kind=%v Name=%v: %s", req.Kind.Kind, req.Name, issue)
+	}
+	if !issues.HasErrors() {
+		return response
+	}
+	response.Allowed = false
+	response.Result = &metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    http.StatusBadRequest,
+		Reason:  metav1.StatusReasonBadRequest,
+		Message: fmt.Sprintf("%s '%s' is invalid:\n%s", req.Kind.Kind, req.Name, issues.Errors()),
+	}
+	return response
+}
+
+// badRequestResponse returns the admission response for a request whose
+// object could not be decoded
+func badRequestResponse(req *v1beta1.AdmissionRequest, err error) *v1beta1.AdmissionResponse {
+	glog.Errorf("Could not unmarshal raw object: %v, %v", err, req.Object.Raw)
+	return &v1beta1.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Code:    http.StatusBadRequest,
+			Reason:  metav1.StatusReasonBadRequest,
+			Message: err.Error(),
+		},
+	}
+}
+
+// validateRunTaskRequest validates runtask create & update request
+func (wh *webhook) validateRunTaskRequest(req *v1beta1.AdmissionRequest) *v1beta1.AdmissionResponse {
+	var rt v1alpha1.RunTask
+	err := json.Unmarshal(req.Object.Raw, &rt)
+	if err != nil {
+		return badRequestResponse(req, err)
+	}
+	return lintResponse(req, castemplate.LintRunTask(&rt))
+}
+
+// validateCASTemplateRequest validates castemplate create & update request
+//
+// NOTE:
+//  RunTasks referred to by the castemplate are fetched from the
+// castemplate's task namespace. RunTasks that are not found are reported as
+// warnings since these may get created after the castemplate.
+func (wh *webhook) validateCASTemplateRequest(req *v1beta1.AdmissionRequest) *v1beta1.AdmissionResponse {
+	var cast v1alpha1.CASTemplate
+	err := json.Unmarshal(req.Object.Raw, &cast)
+	if err != nil {
+		return badRequestResponse(req, err)
+	}
+
+	namespace := cast.Spec.TaskNamespace
+	if len(namespace) == 0 {
+		namespace = menv.Get(menv.OpenEBSNamespace)
+	}
+	names := append([]string{cast.Spec.OutputTask}, cast.Spec.RunTasks.Tasks...)
+	runtasks := map[string]*v1alpha1.RunTask{}
+	for _, name := range names {
+		if len(name) == 0 || len(namespace) == 0 || wh.clientset == nil {
+			continue
+		}
+		rt, err := wh.clientset.OpenebsV1alpha1().RunTasks(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			glog.V(4).Infof("Could not get runtask '%s' in namespace '%s' for castemplate '%s': %v",
+				name, namespace, cast.Name, err)
+			continue
+		}
+		runtasks[name] = rt
+	}
+	return lintResponse(req, castemplate.LintCASTemplate(&cast, runtasks))
+}
+
 // validate validates the persistentvolumeclaim(PVC) create, delete request
+// as well as castemplate & runtask create, update request
 func (wh *webhook) validate(ar *v1beta1.AdmissionReview) *v1beta1.AdmissionResponse {
 	req := ar.Request
 	response := &v1beta1.AdmissionResponse{}
 	response.Allowed = true
 
-	// validates only if request kind is pvc
-	if req.Kind.Kind != "PersistentVolumeClaim" {
-		return response
-	}
-	// validates only if requested operation is CREATE or DELETE
-	if req.Operation == v1beta1.Create {
-		return wh.validatePVCCreateRequest(req)
-	} else if req.Operation == v1beta1.Delete {
-		return wh.validatePVCDeleteRequest(req)
+	switch req.Kind.Kind {
+	case "PersistentVolumeClaim":
+		// validates only if requested operation is CREATE or DELETE
+		if req.Operation == v1beta1.Create {
+			return wh.validatePVCCreateRequest(req)
+		} else if req.Operation == v1beta1.Delete {
+			return wh.validatePVCDeleteRequest(req)
+		}
+	case "CASTemplate":
+		if req.Operation == v1beta1.Create || req.Operation == v1beta1.Update {
+			return wh.validateCASTemplateRequest(req)
+		}
+	case "RunTask":
+		if req.Operation == v1beta1.Create || req.Operation == v1beta1.Update {
+			return wh.validateRunTaskRequest(req)
+		}
 	}
 	return response
 }
@@ -18,6 +18,8 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/openebs/maya/pkg/apis/openebs.io/v1alpha1"
+	openebsFakeClientset "github.com/openebs/maya/pkg/client/generated/clientset/versioned/fake"
 	"k8s.io/api/admission/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -140,3 +142,114 @@ func TestValidatePVCCreateRequest(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateRunTaskRequest(t *testing.T) {
+	wh := webhook{}
+	cases := map[string]struct {
+		fakeRunTask      v1alpha1.RunTask
+		expectedResponse bool
+	}{
+		"Valid RunTask": {
+			fakeRunTask: v1alpha1.RunTask{
+				ObjectMeta: metav1.ObjectMeta{Name: "rt-1"},
+				Spec: v1alpha1.RunTaskSpec{
+					Meta: "id: getsvc\napiVersion: v1\nkind: Service\naction: get",
+				},
+			},
+			expectedResponse: true,
+		},
+		"RunTask with unknown template function": {
+			fakeRunTask: v1alpha1.RunTask{
+				ObjectMeta: metav1.ObjectMeta{Name: "rt-1"},
+				Spec: v1alpha1.RunTaskSpec{
+					Meta:    "id: getsvc\napiVersion: v1\nkind: Service\naction: get",
+					PostRun: "{{ .JsonResult | unknownFunc }}",
+				},
+			},
+			expectedResponse: false,
+		},
+		"RunTask with unsupported kind": {
+			fakeRunTask: v1alpha1.RunTask{
+				ObjectMeta: metav1.ObjectMeta{Name: "rt-1"},
+				Spec: v1alpha1.RunTaskSpec{
+					Meta: "id: execsvc\napiVersion: v1\nkind: Service\naction: exec",
+				},
+			},
+			expectedResponse: false,
+		},
+	}
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			webhookReq := &v1beta1.AdmissionRequest{
+				Operation: v1beta1.Create,
+				Kind:      metav1.GroupVersionKind{Kind: "RunTask"},
+				Object: runtime.RawExtension{
+					Raw: serialize(test.fakeRunTask),
+				},
+			}
+			resp := wh.validate(&v1beta1.AdmissionReview{Request: webhookReq})
+			if resp.Allowed != test.expectedResponse {
+				t.Errorf("validate request failed got: '%v' expected: '%v'", resp.Allowed, test.expectedResponse)
+			}
+		})
+	}
+}
+
+func TestValidateCASTemplateRequest(t *testing.T) {
+	wh := webhook{
+		clientset: openebsFakeClientset.NewSimpleClientset(
+			&v1alpha1.RunTask{
+				ObjectMeta: metav1.ObjectMeta{Name: "rt-1", Namespace: "openebs"},
+				Spec: v1alpha1.RunTaskSpec{
+					Meta: "id: getsvc\napiVersion: v1\nkind: Service\naction: get",
+				},
+			},
+			&v1alpha1.RunTask{
+				ObjectMeta: metav1.ObjectMeta{Name: "rt-2", Namespace: "openebs"},
+				Spec: v1alpha1.RunTaskSpec{
+					Meta: "id: getsvc\napiVersion: v1\nkind: Service\naction: get",
+				},
+			},
+		),
+	}
+	cases := map[string]struct {
+		fakeCAST         v1alpha1.CASTemplate
+		expectedResponse bool
+	}{
+		"Valid CASTemplate": {
+			fakeCAST: v1alpha1.CASTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "cast-1"},
+				Spec: v1alpha1.CASTemplateSpec{
+					TaskNamespace: "openebs",
+					RunTasks:      v1alpha1.RunTasks{Tasks: []string{"rt-1", "rt-3"}},
+				},
+			},
+			expectedResponse: true,
+		},
+		"CASTemplate with duplicate runtask ids": {
+			fakeCAST: v1alpha1.CASTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "cast-1"},
+				Spec: v1alpha1.CASTemplateSpec{
+					TaskNamespace: "openebs",
+					RunTasks:      v1alpha1.RunTasks{Tasks: []string{"rt-1", "rt-2"}},
+				},
+			},
+			expectedResponse: false,
+		},
+	}
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			webhookReq := &v1beta1.AdmissionRequest{
+				Operation: v1beta1.Update,
+				Kind:      metav1.GroupVersionKind{Kind: "CASTemplate"},
+				Object: runtime.RawExtension{
+					Raw: serialize(test.fakeCAST),
+				},
+			}
+			resp := wh.validate(&v1beta1.AdmissionReview{Request: webhookReq})
+			if resp.Allowed != test.expectedResponse {
+				t.Errorf("validate request failed got: '%v' expected: '%v'", resp.Allowed, test.expectedResponse)
+			}
+		})
+	}
+}
@@ -74,7 +74,7 @@ func (p *PoolStatus) WithCommand(Command string) *PoolStatus {
 func (p *PoolStatus) Validate() *PoolStatus {
 	for _, check := range p.checks {
 		if !check(p) {
-			p.err = errors.Wrapf(p.err, "validation failed {%v}", runtime.FuncForPC(reflect.ValueOf(check).Pointer()).Name())
+			p.err = wrapErr(p.err, "validation failed {%v}", runtime.FuncForPC(reflect.ValueOf(check).Pointer()).Name())
 		}
 	}
 	return p
@@ -87,25 +87,34 @@ func (p *PoolStatus) Execute() ([]byte, error) {
 		return nil, err
 	}
 	// execute command here
-	return exec.Command(bin.ZPOOL, p.Command).CombinedOutput()
+	return exec.Command(bin.ZPOOL, strings.Fields(p.Command)...).CombinedOutput()
 }
 
 // Build returns the PoolStatus object generated by builder
 func (p *PoolStatus) Build() (*PoolStatus, error) {
 	var c strings.Builder
 	p = p.Validate()
-	p.appendCommand(c, fmt.Sprintf(" %s ", Operation))
+	p.appendCommand(&c, fmt.Sprintf(" %s ", Operation))
 
-	p.appendCommand(c, p.Pool)
+	p.appendCommand(&c, p.Pool)
 
 	p.Command = c.String()
 	return p, p.err
 }
 
 // appendCommand append string to given string builder
-func (p *PoolStatus) appendCommand(c strings.Builder, cmd string) {
+func (p *PoolStatus) appendCommand(c *strings.Builder, cmd string) {
 	_, err := c.WriteString(cmd)
 	if err != nil {
-		p.err = errors.Wrapf(p.err, "Failed to append cmd{%s} : %s", cmd, err.Error())
+		p.err = wrapErr(p.err, "Failed to append cmd{%s} : %s", cmd, err.Error())
 	}
 }
+
+// wrapErr wraps the given error with the message or returns a new
+// error with the message if there is no error to wrap
+func wrapErr(err error, format string, args ...interface{}) error {
+	if err == nil {
+		return errors.Errorf(format, args...)
+	}
+	return errors.Wrapf(err, format, args...)
+}
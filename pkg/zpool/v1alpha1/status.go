@@ -0,0 +1,371 @@
+// Copyright © 2019 The OpenEBS Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ScanScrub is the scan function of a scrub of the pool
+	ScanScrub = "scrub"
+	// ScanResilver is the scan function of a resilver of the pool
+	ScanResilver = "resilver"
+	// InCompleteStatusErr is err msg when the pool or its vdevs
+	// are missing in the output of zpool status command
+	InCompleteStatusErr = "Couldn't find pool or vdevs in output"
+	// vdevResilvering is the note against a vdev that is resilvering
+	vdevResilvering = "(resilvering)"
+)
+
+// ScanState is the state of the last or ongoing scrub/resilver
+type ScanState string
+
+const (
+	// ScanStateNone signifies that the pool was never scanned
+	ScanStateNone ScanState = "None"
+	// ScanStateInProgress signifies that the scan is running
+	ScanStateInProgress ScanState = "InProgress"
+	// ScanStatePaused signifies that the scan is paused
+	ScanStatePaused ScanState = "Paused"
+	// ScanStateFinished signifies that the scan is complete
+	ScanStateFinished ScanState = "Finished"
+	// ScanStateCanceled signifies that the scan was stopped
+	ScanStateCanceled ScanState = "Canceled"
+)
+
+var (
+	scanInProgressRegex = regexp.MustCompile(`^(scrub|resilver) in progress since (.+)$`)
+	scanPausedRegex     = regexp.MustCompile(`^(scrub|resilver) paused since (.+)$`)
+	scanStartedRegex    = regexp.MustCompile(`^(scrub|resilver) started on (.+)$`)
+	scanCanceledRegex   = regexp.MustCompile(`^(scrub|resilver) canceled on (.+)$`)
+	scrubFinishedRegex  = regexp.MustCompile(`^scrub repaired (\S+) in .* with (\d+) errors on (.+)$`)
+	resilverDoneRegex   = regexp.MustCompile(`^resilvered (\S+) in .* with (\d+) errors on (.+)$`)
+	scanRepairedRegex   = regexp.MustCompile(`(\S+) (repaired|resilvered),`)
+	percentDoneRegex    = regexp.MustCompile(`([0-9.]+)% done`)
+	dataErrorsRegex     = regexp.MustCompile(`^(\d+) data errors`)
+	// e.g. 0h12m to go
+	etaHourMinRegex = regexp.MustCompile(`(\d+)h(\d+)m to go`)
+	// e.g. 0 days 00:12:30 to go
+	etaDaysRegex = regexp.MustCompile(`(\d+) days (\d+):(\d+):(\d+) to go`)
+)
+
+// VdevStats is used to store the values of parsed stats of
+// a vdev from the config section of zpool status command
+type VdevStats struct {
+	Name           string
+	Status         ZpoolStatus
+	ReadErrors     int64 // Read errors reported by vdev
+	WriteErrors    int64 // Write errors reported by vdev
+	ChecksumErrors int64 // Checksum errors reported by vdev
+	// HasErrorCounts is false for spares & the vdevs grouping
+	// logs, cache & spares as no error counts are reported for them
+	HasErrorCounts bool
+	Resilvering    bool        // Resilvering is true if the vdev is resilvering
+	Message        string      // Message reported against the vdev if any
+	Children       []VdevStats // Children of the vdev in the vdev tree
+}
+
+// ScanStats is used to store the values of parsed stats of
+// the last or ongoing scrub/resilver of the pool
+type ScanStats struct {
+	Function    string        // Function is either scrub or resilver
+	State       ScanState     // State of the scan
+	InProgress  bool          // InProgress is true if the scan is ongoing
+	PercentDone string        // PercentDone of the scan
+	ETA         time.Duration // ETA of the ongoing scan
+	Repaired    string        // Repaired is the size of the repaired data e.g. 12K
+	Errors      int64         // Errors found by the finished scan
+	StartTime   time.Time     // StartTime of the scan if reported
+	EndTime     time.Time     // EndTime of the finished or canceled scan
+}
+
+// PoolStatus is used to store the values of parsed stats
+// of zpool status command
+type PoolStatus struct {
+	Name       string
+	Status     ZpoolStatus
+	Scan       ScanStats
+	Vdevs      []VdevStats // Vdevs is the vdev tree of the pool
+	DataErrors int64       // DataErrors is the no of permanent data errors
+}
+
+// vdevLine is a vdev parsed from a line of the config section
+// along with its depth in the vdev tree
+type vdevLine struct {
+	depth int
+	vdev  VdevStats
+}
+
+// StatusParser parses output of zpool status command which is
+// in the following form:
+/*
+  pool: cstor-5ce4639a-2dc1-11e9-bbe3-42010a80017a
+ state: DEGRADED
+  scan: scrub in progress since Mon Jun 10 10:00:00 2019
+	1.23G scanned out of 10.0G at 100M/s, 0h1m to go
+	0 repaired, 12.30% done
+config:
+
+	NAME                                        STATE     READ WRITE CKSUM
+	cstor-5ce4639a-2dc1-11e9-bbe3-42010a80017a  DEGRADED     0     0     0
+	  mirror-0                                  DEGRADED     0     0     0
+	    scsi-0Google_PersistentDisk_disk1       ONLINE       0     0     0
+	    scsi-0Google_PersistentDisk_disk2       FAULTED      3  1.5K     0  too many errors
+	spares
+	  scsi-0Google_PersistentDisk_disk3         AVAIL
+
+errors: No known data errors
+*/
+// Every level of the vdev tree is indented by two spaces.
+func StatusParser(output []byte) (PoolStatus, error) {
+	var (
+		status   PoolStatus
+		scan     []string
+		lines    []vdevLine
+		inScan   bool
+		inConfig bool
+		inVdevs  bool
+	)
+	for _, line := range strings.Split(string(output), "\n") {
+		trimmed := strings.TrimSpace(line)
+		key, value := splitStatusLine(trimmed)
+		if key != "" {
+			inScan, inConfig = false, false
+		}
+		switch {
+		case key == "pool":
+			status.Name = value
+		case key == "state":
+			status.Status = ZpoolStatus(value)
+		case key == "scan":
+			inScan = true
+			scan = append(scan, value)
+		case key == "config":
+			inConfig, inVdevs = true, false
+		case key == "errors":
+			if m := dataErrorsRegex.FindStringSubmatch(value); m != nil {
+				status.DataErrors, _ = strconv.ParseInt(m[1], 10, 64)
+			}
+		case inScan:
+			scan = append(scan, trimmed)
+		case inConfig && !inVdevs:
+			fields := strings.Fields(trimmed)
+			inVdevs = len(fields) == 5 && fields[0] == "NAME" && fields[1] == "STATE"
+		case inConfig:
+			if trimmed == "" {
+				inConfig = false
+				continue
+			}
+			line = strings.TrimPrefix(line, "\t")
+			depth := (len(line) - len(strings.TrimLeft(line, " "))) / 2
+			lines = append(lines, vdevLine{depth: depth, vdev: parseVdev(strings.Fields(trimmed))})
+		}
+	}
+	status.Vdevs, _ = buildVdevTree(lines, 0, 0)
+	if status.Name == "" || len(status.Vdevs) == 0 {
+		return PoolStatus{}, errors.New(InCompleteStatusErr)
+	}
+	status.Scan = parseScan(scan)
+	return status, nil
+}
+
+// splitStatusLine returns the key & value of the lines of
+// zpool status command that are in <key>: <value> format
+func splitStatusLine(line string) (string, string) {
+	i := strings.Index(line, ":")
+	if i <= 0 {
+		return "", ""
+	}
+	key := line[:i]
+	switch key {
+	case "pool", "state", "status", "action", "see", "scan", "remove", "config", "errors":
+		return key, strings.TrimSpace(line[i+1:])
+	}
+	return "", ""
+}
+
+// parseVdev parses the columns of a vdev line i.e. NAME, STATE, READ,
+// WRITE & CKSUM followed by an optional message. Spares & the vdevs
+// grouping logs, cache & spares do not have all the columns.
+func parseVdev(fields []string) VdevStats {
+	vdev := VdevStats{Name: fields[0]}
+	if len(fields) < 2 {
+		return vdev
+	}
+	vdev.Status = ZpoolStatus(fields[1])
+	message := fields[2:]
+	if len(fields) >= 5 {
+		read, rerr := ParseErrorCount(fields[2])
+		write, werr := ParseErrorCount(fields[3])
+		cksum, cerr := ParseErrorCount(fields[4])
+		if rerr == nil && werr == nil && cerr == nil {
+			vdev.ReadErrors, vdev.WriteErrors, vdev.ChecksumErrors = read, write, cksum
+			vdev.HasErrorCounts = true
+			message = fields[5:]
+		}
+	}
+	vdev.Message = strings.Join(message, " ")
+	if strings.Contains(vdev.Message, vdevResilvering) {
+		vdev.Resilvering = true
+		vdev.Message = strings.TrimSpace(strings.Replace(vdev.Message, vdevResilvering, "", -1))
+	}
+	return vdev
+}
+
+// ParseErrorCount parses an error count of zpool status which is
+// abbreviated with a suffix once it crosses 1000 e.g. 1.50K
+func ParseErrorCount(count string) (int64, error) {
+	multiplier := float64(1)
+	for _, suffix := range []string{"K", "M", "G", "T", "P", "E"} {
+		multiplier *= 1024
+		if strings.HasSuffix(count, suffix) {
+			value, err := strconv.ParseFloat(strings.TrimSuffix(count, suffix), 64)
+			return int64(value * multiplier), err
+		}
+	}
+	return strconv.ParseInt(count, 10, 64)
+}
+
+// buildVdevTree builds the vdevs at the given depth starting from
+// index i of lines. The index of the first line that does not belong
+// to these vdevs is returned.
+func buildVdevTree(lines []vdevLine, i, depth int) ([]VdevStats, int) {
+	var vdevs []VdevStats
+	for i < len(lines) && lines[i].depth >= depth {
+		vdev := lines[i].vdev
+		vdev.Children, i = buildVdevTree(lines, i+1, depth+1)
+		vdevs = append(vdevs, vdev)
+	}
+	return vdevs, i
+}
+
+// AllVdevs returns every vdev of the vdev tree of the pool with
+// a vdev preceding its children
+func (s PoolStatus) AllVdevs() []VdevStats {
+	return flattenVdevs(s.Vdevs)
+}
+
+func flattenVdevs(vdevs []VdevStats) []VdevStats {
+	var all []VdevStats
+	for _, vdev := range vdevs {
+		all = append(all, vdev)
+		all = append(all, flattenVdevs(vdev.Children)...)
+	}
+	return all
+}
+
+// parseScan parses the lines of the scan section which is in
+// one of the following forms:
+/*
+  scan: none requested
+
+  scan: scrub in progress since Mon Jun  3 10:00:00 2019
+	1.50G scanned out of 10.0G at 100M/s, 0h1m to go
+	0B repaired, 15.00% done
+
+  scan: scrub paused since Mon Jun  3 10:05:00 2019
+	scrub started on Mon Jun  3 10:00:00 2019
+	1.50G scanned out of 10.0G, 15.00% done
+
+  scan: scrub repaired 0B in 0h5m with 0 errors on Mon Jun  3 10:05:00 2019
+
+  scan: scrub canceled on Mon Jun  3 10:05:00 2019
+
+  scan: resilver in progress since Mon Jun  3 10:00:00 2019
+	1.23G scanned at 100M/s, 1.00G issued at 90M/s, 10.0G total
+	1.00G resilvered, 50.00% done, 0 days 00:02:30 to go
+
+  scan: resilvered 1.2G in 0h1m with 0 errors on Mon Jun  3 10:05:00 2019
+*/
+func parseScan(lines []string) ScanStats {
+	stats := ScanStats{State: ScanStateNone}
+	if len(lines) == 0 {
+		return stats
+	}
+	header := lines[0]
+	if m := scanInProgressRegex.FindStringSubmatch(header); m != nil {
+		stats.Function, stats.State = m[1], ScanStateInProgress
+		stats.InProgress = true
+		stats.StartTime = parseScanTime(m[2])
+	} else if m := scanPausedRegex.FindStringSubmatch(header); m != nil {
+		stats.Function, stats.State = m[1], ScanStatePaused
+	} else if m := scanCanceledRegex.FindStringSubmatch(header); m != nil {
+		stats.Function, stats.State = m[1], ScanStateCanceled
+		stats.EndTime = parseScanTime(m[2])
+	} else if m := scrubFinishedRegex.FindStringSubmatch(header); m != nil {
+		stats.Function = ScanScrub
+		setScanFinished(&stats, m)
+	} else if m := resilverDoneRegex.FindStringSubmatch(header); m != nil {
+		stats.Function = ScanResilver
+		setScanFinished(&stats, m)
+	} else {
+		// none requested
+		return stats
+	}
+	for _, line := range lines[1:] {
+		if m := scanStartedRegex.FindStringSubmatch(line); m != nil {
+			stats.StartTime = parseScanTime(m[2])
+		}
+		if m := scanRepairedRegex.FindStringSubmatch(line); m != nil {
+			stats.Repaired = m[1]
+		}
+		if m := percentDoneRegex.FindStringSubmatch(line); m != nil {
+			stats.PercentDone = m[1]
+		}
+		if m := etaHourMinRegex.FindStringSubmatch(line); m != nil {
+			stats.ETA = toDuration(m[1], time.Hour) + toDuration(m[2], time.Minute)
+		} else if m := etaDaysRegex.FindStringSubmatch(line); m != nil {
+			stats.ETA = toDuration(m[1], 24*time.Hour) + toDuration(m[2], time.Hour) +
+				toDuration(m[3], time.Minute) + toDuration(m[4], time.Second)
+		}
+	}
+	return stats
+}
+
+// setScanFinished sets the stats of a finished scan from the
+// submatches of the header i.e. repaired size, errors & end time
+func setScanFinished(stats *ScanStats, m []string) {
+	stats.State = ScanStateFinished
+	stats.PercentDone = "100"
+	stats.Repaired = m[1]
+	stats.Errors, _ = strconv.ParseInt(m[2], 10, 64)
+	stats.EndTime = parseScanTime(m[3])
+}
+
+// parseScanTime parses the time printed by zpool status in local
+// time zone e.g. Mon Jun  3 10:00:00 2019. Zero time is returned
+// if it can not be parsed.
+func parseScanTime(value string) time.Time {
+	t, err := time.ParseInLocation(time.ANSIC, strings.TrimSpace(value), time.Local)
+	if err != nil {
+		glog.Warningf("Unable to parse scan time %q: %v", value, err)
+		return time.Time{}
+	}
+	return t
+}
+
+// toDuration returns the duration of the given no of units,
+// the no is always a valid integer as matched by regex
+func toDuration(n string, unit time.Duration) time.Duration {
+	i, _ := strconv.Atoi(n)
+	return time.Duration(i) * unit
+}
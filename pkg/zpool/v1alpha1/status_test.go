@@ -0,0 +1,45 @@
+// Copyright © 2019 The OpenEBS Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"testing"
+)
+
+func TestParseErrorCount(t *testing.T) {
+	testCases := map[string]struct {
+		count    string
+		expected int64
+		isErr    bool
+	}{
+		"no errors":    {count: "0", expected: 0},
+		"few errors":   {count: "120", expected: 120},
+		"kilo errors":  {count: "1.5K", expected: 1536},
+		"mega errors":  {count: "2M", expected: 2 * 1024 * 1024},
+		"not a number": {count: "ONLINE", isErr: true},
+	}
+	for name, test := range testCases {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseErrorCount(test.count)
+			if test.isErr != (err != nil) {
+				t.Fatalf("Test %q failed: expected error %t: got %v", name, test.isErr, err)
+			}
+			if !test.isErr && got != test.expected {
+				t.Fatalf("Test %q failed: expected %d: got %d", name, test.expected, got)
+			}
+		})
+	}
+}
@@ -69,6 +69,8 @@ type Stats struct {
 	Free                string // Free size of Pools
 	Size                string // Size of pool
 	UsedCapacityPercent string // Used size of pools in precent
+	Fragmentation       string // Fragmentation of free space of pool in percent
+	DedupRatio          string // Deduplication ratio of pool
 }
 
 // String returns string
@@ -119,7 +121,9 @@ func ListParser(output []byte) (Stats, error) {
 		Size:                stats[1],
 		Used:                stats[2],
 		Free:                stats[3],
+		Fragmentation:       stats[5],
 		UsedCapacityPercent: stats[6],
+		DedupRatio:          stats[7],
 		Status:              ZpoolStatus(stats[8]),
 	}, nil
 }